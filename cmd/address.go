@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+)
+
+const (
+	// keyRefPrefix marks an address flag value as a reference to a keystore key
+	// (e.g. "@validator-rewards") rather than a literal address.
+	keyRefPrefix = "@"
+	// selfKeyRef refers to the signing wallet's own address.
+	selfKeyRef = keyRefPrefix + "self"
+	// pChainAlias is the chain prefix accepted on bech32 P-Chain addresses.
+	pChainAlias = "P"
+)
+
+// resolveRewardAddress resolves a reward or owner address flag to a short ID.
+// Accepted forms:
+//   - "" or "@self": the signing wallet's own address (own)
+//   - "@<key-name>": the P-Chain address of a key in the local keystore
+//   - "P-avax1..." or "avax1...": a bech32 P-Chain address
+//   - a raw CB58 short ID
+func resolveRewardAddress(flagValue string, own ids.ShortID) (ids.ShortID, error) {
+	value := strings.TrimSpace(flagValue)
+	switch {
+	case value == "" || value == selfKeyRef:
+		return own, nil
+	case strings.HasPrefix(value, keyRefPrefix):
+		return keystoreAddress(strings.TrimPrefix(value, keyRefPrefix))
+	}
+	return parsePChainAddress(value)
+}
+
+// parsePChainAddress parses a bech32 P-Chain address, with or without the
+// "P-" prefix, or a raw CB58 short ID.
+func parsePChainAddress(value string) (ids.ShortID, error) {
+	value = strings.TrimSpace(value)
+	if chainAlias, _, ok := strings.Cut(value, "-"); ok {
+		if chainAlias != pChainAlias {
+			return ids.ShortEmpty, fmt.Errorf("%q is not a P-Chain address", value)
+		}
+		addr, err := address.ParseToID(value)
+		if err != nil {
+			return ids.ShortEmpty, fmt.Errorf("invalid bech32 address %q: %w", value, err)
+		}
+		return addr, nil
+	}
+
+	if addr, err := ids.ShortFromString(value); err == nil {
+		return addr, nil
+	}
+	_, addrBytes, err := address.ParseBech32(value)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%q is neither a bech32 address nor a CB58 short ID", value)
+	}
+	addr, err := ids.ToShortID(addrBytes)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("invalid bech32 address %q: %w", value, err)
+	}
+	return addr, nil
+}
+
+// resolveChangeAddress resolves --change-address like a reward address, and
+// additionally rejects a bech32 address whose HRP belongs to another network,
+// since change sent there would be unspendable on this one.
+func resolveChangeAddress(flagValue string, own ids.ShortID, networkID uint32) (ids.ShortID, error) {
+	if err := checkAddressNetwork(flagValue, networkID); err != nil {
+		return ids.ShortEmpty, err
+	}
+	return resolveRewardAddress(flagValue, own)
+}
+
+// checkAddressNetwork returns an error if value is a bech32 address for a
+// network other than networkID. Other forms (short IDs, key references)
+// carry no network and pass.
+func checkAddressNetwork(value string, networkID uint32) error {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, keyRefPrefix) {
+		return nil
+	}
+	if _, rest, ok := strings.Cut(value, "-"); ok {
+		value = rest
+	}
+	hrp, _, err := address.ParseBech32(value)
+	if err != nil {
+		return nil
+	}
+	if want := constants.GetHRP(networkID); hrp != want {
+		return fmt.Errorf("address %q is for the %q network, but network ID %d uses %q", value, hrp, networkID, want)
+	}
+	return nil
+}
+
+// keystoreAddress returns the P-Chain address recorded for a keystore key.
+// The key itself is not decrypted.
+func keystoreAddress(name string) (ids.ShortID, error) {
+	if err := keystore.ValidateKeyName(name); err != nil {
+		return ids.ShortEmpty, fmt.Errorf("invalid key reference %q: %w", keyRefPrefix+name, err)
+	}
+	ks, err := keystore.Load()
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("failed to load keystore: %w", err)
+	}
+	entry, ok := ks.GetKey(name)
+	if !ok {
+		return ids.ShortEmpty, fmt.Errorf("key %q not found in keystore", name)
+	}
+	addr, err := ids.ShortFromString(entry.PChainAddress)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("keystore entry %q has an invalid P-Chain address: %w", name, err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+)
+
+func TestResolveRewardAddress(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	ks, err := keystore.Load()
+	if err != nil {
+		t.Fatalf("keystore.Load() error = %v", err)
+	}
+	keyCopy := make([]byte, len(ewoqPrivateKey))
+	copy(keyCopy, ewoqPrivateKey)
+	if err := ks.ImportKey("rewards", keyCopy, nil); err != nil {
+		t.Fatalf("ImportKey() error = %v", err)
+	}
+	entry, _ := ks.GetKey("rewards")
+	keyAddr, err := ids.ShortFromString(entry.PChainAddress)
+	if err != nil {
+		t.Fatalf("ShortFromString() error = %v", err)
+	}
+
+	own := ids.GenerateTestShortID()
+	other := ids.GenerateTestShortID()
+	formatted := wallet.FormatPChainAddress(other, constants.FujiID)
+	bare, err := address.FormatBech32(constants.FujiHRP, other[:])
+	if err != nil {
+		t.Fatalf("FormatBech32() error = %v", err)
+	}
+	xChain, err := address.Format("X", constants.FujiHRP, other[:])
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    ids.ShortID
+		wantErr bool
+	}{
+		{name: "empty defaults to own", input: "", want: own},
+		{name: "self reference", input: "@self", want: own},
+		{name: "short ID", input: other.String(), want: other},
+		{name: "bech32 with chain prefix", input: formatted, want: other},
+		{name: "bech32 without chain prefix", input: bare, want: other},
+		{name: "surrounding whitespace", input: "  " + formatted + "\n", want: other},
+		{name: "keystore reference", input: "@rewards", want: keyAddr},
+		{name: "unknown key", input: "@missing", wantErr: true},
+		{name: "invalid key name", input: "@../etc", wantErr: true},
+		{name: "X-Chain address", input: xChain, wantErr: true},
+		{name: "garbage", input: "not-an-address", wantErr: true},
+		{name: "bad checksum", input: formatted[:len(formatted)-1] + "q", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRewardAddress(tt.input, own)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveRewardAddress(%q) = %s, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRewardAddress(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("resolveRewardAddress(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveChangeAddress(t *testing.T) {
+	own := ids.GenerateTestShortID()
+	other := ids.GenerateTestShortID()
+	fuji := wallet.FormatPChainAddress(other, constants.FujiID)
+	mainnet := wallet.FormatPChainAddress(other, constants.MainnetID)
+	bareMainnet, err := address.FormatBech32(constants.MainnetHRP, other[:])
+	if err != nil {
+		t.Fatalf("FormatBech32() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    ids.ShortID
+		wantErr bool
+	}{
+		{name: "self reference", input: "@self", want: own},
+		{name: "short ID", input: other.String(), want: other},
+		{name: "matching network", input: fuji, want: other},
+		{name: "mainnet address on fuji", input: mainnet, wantErr: true},
+		{name: "bare mainnet address on fuji", input: bareMainnet, wantErr: true},
+		{name: "garbage", input: "not-an-address", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveChangeAddress(tt.input, own, constants.FujiID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveChangeAddress(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("resolveChangeAddress(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
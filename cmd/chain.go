@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/platform-cli/pkg/genesis"
+	"github.com/ava-labs/platform-cli/pkg/network"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
 	"github.com/spf13/cobra"
 )
@@ -18,11 +22,17 @@ import (
 // maxGenesisLen is the maximum allowed genesis file size (matches P-Chain limit).
 const maxGenesisLen = units.MiB // 1 MB
 
+// stdinGenesisPath reads the genesis from stdin, e.g. piped from gen-genesis.
+const stdinGenesisPath = "-"
+
 var (
 	chainSubnetID    string
 	chainGenesisFile string
 	chainName        string
 	chainVMID        string
+	// chainGenesisValidate checks a Subnet-EVM genesis before the chain is
+	// created.
+	chainGenesisValidate bool
 )
 
 var chainCmd = &cobra.Command{
@@ -36,61 +46,119 @@ var chainCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new chain (CreateChainTx)",
 	Long:  `Create a new blockchain on a subnet.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := getOperationContext()
-		defer cancel()
+	RunE:  runChainCreate,
+}
 
-		if chainSubnetID == "" {
-			return fmt.Errorf("--subnet-id is required")
-		}
-		if chainGenesisFile == "" {
-			return fmt.Errorf("--genesis is required")
-		}
+// subnetCreateChainCmd is chain create listed with the other subnet commands,
+// since a chain is always created on a subnet. It shares chain create's
+// flags.
+var subnetCreateChainCmd = &cobra.Command{
+	Use:   "create-chain",
+	Short: "Create a new chain on the subnet (same as chain create)",
+	Long:  `Create a new blockchain on a subnet. This is the same command as chain create.`,
+	RunE:  runChainCreate,
+}
 
-		subnetID, err := ids.FromString(chainSubnetID)
-		if err != nil {
-			return fmt.Errorf("invalid subnet ID: %w", err)
-		}
+// runChainCreate issues a CreateChainTx. It resolves the network with
+// getNetworkConfig and signs with the shared wallet loaders, so --rpc-url and
+// --ledger work as they do for other P-Chain commands.
+func runChainCreate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := getOperationContext()
+	defer cancel()
 
-		genesis, err := loadGenesisJSON(chainGenesisFile)
-		if err != nil {
-			return err
-		}
+	if chainSubnetID == "" {
+		return fmt.Errorf("--subnet-id is required")
+	}
+	if chainGenesisFile == "" {
+		return fmt.Errorf("--genesis is required")
+	}
 
-		// Default to Subnet-EVM
-		vmID := constants.SubnetEVMID
-		if chainVMID != "" {
-			vmID, err = ids.FromString(chainVMID)
-			if err != nil {
-				return fmt.Errorf("invalid VM ID: %w", err)
-			}
-		}
+	subnetID, err := parseIDFlag("subnet-id", chainSubnetID)
+	if err != nil {
+		return err
+	}
 
-		netConfig, err := getNetworkConfig(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get network config: %w", err)
-		}
+	genesisJSON, err := loadGenesisJSON(chainGenesisFile)
+	if err != nil {
+		return err
+	}
 
-		w, cleanup, err := loadPChainWalletWithSubnet(ctx, netConfig, subnetID)
+	// Default to Subnet-EVM
+	vmID := constants.SubnetEVMID
+	if chainVMID != "" {
+		vmID, err = parseIDFlag("vm-id", chainVMID)
 		if err != nil {
-			return fmt.Errorf("failed to create wallet: %w", err)
+			return err
 		}
-		defer cleanup()
-
-		txID, err := pchain.CreateChain(ctx, w, pchain.CreateChainConfig{
-			SubnetID:  subnetID,
-			Genesis:   genesis,
-			VMID:      vmID,
-			FxIDs:     nil,
-			ChainName: chainName,
-		})
-		if err != nil {
+	}
+
+	if chainGenesisValidate {
+		if err := validateGenesis(vmID, genesisJSON); err != nil {
 			return err
 		}
+	}
+
+	ctx, netConfig, err := getNetworkConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get network config: %w", err)
+	}
+
+	w, cleanup, err := loadPChainWalletWithSubnet(ctx, netConfig, subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet: %w", err)
+	}
+	defer cleanup()
 
-		fmt.Printf("Chain ID: %s\n", txID)
+	if err := pchain.CheckSubnetAuthWithConfig(ctx, netConfig, subnetID, []ids.ShortID{w.PChainAddress()}); err != nil {
+		return err
+	}
+
+	if err := checkFunds(ctx, w, 0, ""); err != nil {
+		return err
+	}
+
+	warnVMNotRegistered(ctx, netConfig, vmID)
+
+	txID, err := pchain.CreateChain(ctx, w, pchain.CreateChainConfig{
+		SubnetID:  subnetID,
+		Genesis:   genesisJSON,
+		VMID:      vmID,
+		FxIDs:     nil,
+		ChainName: chainName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if printSignedTxs(w) {
 		return nil
-	},
+	}
+
+	fmt.Printf("Chain ID: %s\n", txID)
+	return nil
+}
+
+// warnVMNotRegistered warns when the node at rpcURL lacks the chain's VM.
+// It is only a warning: the node may not validate the subnet, and public API
+// nodes do not expose their VMs. Nodes that do not answer are not reported.
+func warnVMNotRegistered(ctx context.Context, netConfig network.Config, vmID ids.ID) {
+	if err := pchain.CheckVMRegisteredWithConfig(ctx, netConfig, vmID); errors.Is(err, pchain.ErrVMNotRegistered) {
+		printWarning("WARNING: %v. If this node validates the subnet, install the VM before creating the chain.", err)
+	}
+}
+
+// validateGenesis runs --genesis-validate. Only Subnet-EVM genesis files can
+// be checked; for other VMs the flag is a usage error rather than a silent
+// no-op.
+func validateGenesis(vmID ids.ID, genesisJSON []byte) error {
+	if vmID != constants.SubnetEVMID {
+		return withExitCode(exitUsage, fmt.Errorf("--genesis-validate only supports Subnet-EVM (VM ID %s), not %s", constants.SubnetEVMID, vmID))
+	}
+	if err := genesis.ValidateSubnetEVM(genesisJSON); err != nil {
+		return err
+	}
+	fmt.Fprintln(progressWriter(), "Genesis passed Subnet-EVM validation")
+	return nil
 }
 
 func loadGenesisJSON(path string) ([]byte, error) {
@@ -98,6 +166,9 @@ func loadGenesisJSON(path string) ([]byte, error) {
 	if path == "" {
 		return nil, fmt.Errorf("genesis file path cannot be empty")
 	}
+	if path == stdinGenesisPath {
+		return readGenesisJSON(os.Stdin)
+	}
 	if !strings.EqualFold(filepath.Ext(path), ".json") {
 		return nil, fmt.Errorf("genesis file must use a .json extension")
 	}
@@ -119,7 +190,12 @@ func loadGenesisJSON(path string) ([]byte, error) {
 	}
 	defer file.Close()
 
-	genesis, err := io.ReadAll(io.LimitReader(file, maxGenesisLen+1))
+	return readGenesisJSON(file)
+}
+
+// readGenesisJSON reads at most maxGenesisLen bytes of genesis JSON from r.
+func readGenesisJSON(r io.Reader) ([]byte, error) {
+	genesis, err := io.ReadAll(io.LimitReader(r, maxGenesisLen+1))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read genesis file: %w", err)
 	}
@@ -136,9 +212,13 @@ func loadGenesisJSON(path string) ([]byte, error) {
 func init() {
 	rootCmd.AddCommand(chainCmd)
 	chainCmd.AddCommand(chainCreateCmd)
-
-	chainCreateCmd.Flags().StringVar(&chainSubnetID, "subnet-id", "", "Subnet ID to create chain on")
-	chainCreateCmd.Flags().StringVar(&chainGenesisFile, "genesis", "", "Genesis file path")
-	chainCreateCmd.Flags().StringVar(&chainName, "name", "mychain", "Chain name")
-	chainCreateCmd.Flags().StringVar(&chainVMID, "vm-id", "", "VM ID (default: Subnet-EVM)")
+	subnetCmd.AddCommand(subnetCreateChainCmd)
+
+	for _, cmd := range []*cobra.Command{chainCreateCmd, subnetCreateChainCmd} {
+		cmd.Flags().StringVar(&chainSubnetID, "subnet-id", "", "Subnet ID to create chain on")
+		cmd.Flags().StringVar(&chainGenesisFile, "genesis", "", "Genesis file path, or - for stdin")
+		cmd.Flags().StringVar(&chainName, "name", "mychain", "Chain name")
+		cmd.Flags().StringVar(&chainVMID, "vm-id", "", "VM ID (default: Subnet-EVM)")
+		cmd.Flags().BoolVar(&chainGenesisValidate, "genesis-validate", false, "Check the Subnet-EVM genesis for fields the VM rejects before creating the chain")
+	}
 }
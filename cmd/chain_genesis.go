@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/genesis"
+	"github.com/spf13/cobra"
+)
+
+// genesisVMSubnetEVM is the only VM gen-genesis can template.
+const genesisVMSubnetEVM = "subnet-evm"
+
+// maxAllocFileLen caps the size of a gen-genesis allocation file (1 MB).
+const maxAllocFileLen = 1 << 20
+
+var (
+	genGenesisVM          string
+	genGenesisChainID     uint64
+	genGenesisFeeConfig   genesis.FeeConfig
+	genGenesisAllocs      []string
+	genGenesisAllocFile   string
+	genGenesisTimestamp   int64
+	genGenesisPrecompiles []string
+	genGenesisAdmins      []string
+	genGenesisOut         string
+)
+
+var chainGenGenesisCmd = &cobra.Command{
+	Use:   "gen-genesis",
+	Short: "Generate a Subnet-EVM genesis file",
+	Long: `Generate a complete Subnet-EVM genesis JSON for chain create.
+
+The genesis is written to --out, or to stdout for piping:
+
+  platform-cli chain gen-genesis --chain-id 12345 --alloc 0xabc...:1000 \
+    | platform-cli chain create --subnet-id <ID> --genesis -`,
+	RunE: runChainGenGenesis,
+}
+
+func runChainGenGenesis(cmd *cobra.Command, args []string) error {
+	if genGenesisVM != genesisVMSubnetEVM {
+		return withExitCode(exitUsage, fmt.Errorf("unsupported --vm %q (supported: %s)", genGenesisVM, genesisVMSubnetEVM))
+	}
+	cfg, err := genGenesisConfig()
+	if err != nil {
+		return withExitCode(exitUsage, err)
+	}
+	out, err := genesis.NewSubnetEVM(cfg)
+	if err != nil {
+		return withExitCode(exitUsage, err)
+	}
+	if len(out) > maxGenesisLen {
+		return fmt.Errorf("generated genesis too large: %d bytes (max: %d bytes)", len(out), maxGenesisLen)
+	}
+	out = append(out, '\n')
+
+	if genGenesisOut == "" {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	if !strings.EqualFold(filepath.Ext(genGenesisOut), ".json") {
+		return withExitCode(exitUsage, fmt.Errorf("--out must use a .json extension"))
+	}
+	if err := os.WriteFile(genGenesisOut, out, 0o644); err != nil {
+		return fmt.Errorf("failed to write genesis file: %w", err)
+	}
+	fmt.Fprintf(progressWriter(), "Wrote genesis to %s\n", genGenesisOut)
+	return nil
+}
+
+// genGenesisConfig builds the genesis config from the gen-genesis flags.
+func genGenesisConfig() (genesis.SubnetEVMConfig, error) {
+	cfg := genesis.SubnetEVMConfig{
+		ChainID:     genGenesisChainID,
+		FeeConfig:   genGenesisFeeConfig,
+		Precompiles: genGenesisPrecompiles,
+		Timestamp:   time.Now(),
+	}
+	if cfg.ChainID == 0 {
+		return cfg, fmt.Errorf("--chain-id is required")
+	}
+	switch {
+	case genGenesisTimestamp < 0:
+		return cfg, fmt.Errorf("--timestamp must not be negative")
+	case genGenesisTimestamp > 0:
+		cfg.Timestamp = time.Unix(genGenesisTimestamp, 0)
+	}
+	if genGenesisAllocFile != "" {
+		allocs, err := readAllocationsFile(genGenesisAllocFile)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Allocations = allocs
+	}
+	for _, s := range genGenesisAllocs {
+		alloc, err := genesis.ParseAllocation(s)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid --alloc: %w", err)
+		}
+		cfg.Allocations = append(cfg.Allocations, alloc)
+	}
+	cfg.Admins = make([]common.Address, 0, len(genGenesisAdmins))
+	for _, s := range genGenesisAdmins {
+		admin, err := genesis.ParseEVMAddress(s)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid --precompile-admin: %w", err)
+		}
+		cfg.Admins = append(cfg.Admins, admin)
+	}
+	return cfg, nil
+}
+
+// readAllocationsFile reads a gen-genesis allocation CSV file, rejecting
+// anything that is not a regular file of at most maxAllocFileLen bytes.
+func readAllocationsFile(path string) ([]genesis.Allocation, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat allocation file: %w", err)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return nil, fmt.Errorf("allocation file must be a regular file")
+	}
+	if fileInfo.Size() > maxAllocFileLen {
+		return nil, fmt.Errorf("allocation file too large: %d bytes (max: %d bytes / 1 MB)", fileInfo.Size(), maxAllocFileLen)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open allocation file: %w", err)
+	}
+	defer file.Close()
+
+	return parseAllocations(io.LimitReader(file, maxAllocFileLen))
+}
+
+// parseAllocations parses "address,balance" CSV rows in the send-many file
+// format, with EVM addresses and balances in native tokens. An address may
+// appear only once.
+func parseAllocations(r io.Reader) ([]genesis.Allocation, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var allocs []genesis.Allocation
+	seen := make(map[common.Address]int)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid allocation file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		addrField, balanceField := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if first && strings.EqualFold(addrField, paymentsHeaderField) {
+			continue
+		}
+
+		alloc, err := genesis.NewAllocation(addrField, balanceField)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[alloc.Address]; ok {
+			return nil, fmt.Errorf("line %d: %s is already allocated on line %d", line, alloc.Address, prev)
+		}
+		seen[alloc.Address] = line
+		allocs = append(allocs, alloc)
+	}
+	if len(allocs) == 0 {
+		return nil, fmt.Errorf("allocation file has no allocations")
+	}
+	return allocs, nil
+}
+
+func init() {
+	chainCmd.AddCommand(chainGenGenesisCmd)
+
+	f := chainGenGenesisCmd.Flags()
+	f.StringVar(&genGenesisVM, "vm", genesisVMSubnetEVM, "VM to generate a genesis for (subnet-evm)")
+	f.Uint64Var(&genGenesisChainID, "chain-id", 0, "EVM chain ID (required)")
+	f.Uint64Var(&genGenesisFeeConfig.GasLimit, "gas-limit", genesis.DefaultGasLimit, "Block gas limit")
+	f.Uint64Var(&genGenesisFeeConfig.TargetBlockRate, "target-block-rate", genesis.DefaultTargetBlockRate, "Target seconds between blocks")
+	f.Uint64Var(&genGenesisFeeConfig.MinBaseFee, "min-base-fee", genesis.DefaultMinBaseFee, "Minimum base fee in wei")
+	f.Uint64Var(&genGenesisFeeConfig.TargetGas, "target-gas", genesis.DefaultTargetGas, "Target gas consumed per 10 seconds")
+	f.Uint64Var(&genGenesisFeeConfig.BaseFeeChangeDenominator, "base-fee-change-denominator", genesis.DefaultBaseFeeChangeDenominator, "Base fee change denominator")
+	f.Uint64Var(&genGenesisFeeConfig.MinBlockGasCost, "min-block-gas-cost", genesis.DefaultMinBlockGasCost, "Minimum block gas cost")
+	f.Uint64Var(&genGenesisFeeConfig.MaxBlockGasCost, "max-block-gas-cost", genesis.DefaultMaxBlockGasCost, "Maximum block gas cost")
+	f.Uint64Var(&genGenesisFeeConfig.BlockGasCostStep, "block-gas-cost-step", genesis.DefaultBlockGasCostStep, "Block gas cost step")
+	f.StringArrayVar(&genGenesisAllocs, "alloc", nil, "Fund an address at genesis as 0xADDRESS:AMOUNT in native tokens (repeatable)")
+	f.StringVar(&genGenesisAllocFile, "alloc-file", "", "CSV file of address,balance rows to fund at genesis")
+	f.Int64Var(&genGenesisTimestamp, "timestamp", 0, "Genesis Unix timestamp, for reproducible output (default: now)")
+	f.StringSliceVar(&genGenesisPrecompiles, "precompile", nil, "Enable a precompile: "+strings.Join(genesis.Precompiles(), ", ")+" (repeatable)")
+	f.StringSliceVar(&genGenesisAdmins, "precompile-admin", nil, "Admin address for enabled precompiles (repeatable)")
+	f.StringVar(&genGenesisOut, "out", "", "Write the genesis to this .json file instead of stdout")
+}
@@ -6,8 +6,34 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/genesis"
+	"github.com/spf13/pflag"
 )
 
+func TestSubnetCreateChainMatchesChainCreate(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"subnet", "create-chain"})
+	if err != nil || cmd != subnetCreateChainCmd {
+		t.Fatalf("Find(subnet create-chain) = %v, %v", cmd, err)
+	}
+
+	var want []string
+	chainCreateCmd.Flags().VisitAll(func(f *pflag.Flag) { want = append(want, f.Name+"="+f.DefValue) })
+	var got []string
+	subnetCreateChainCmd.Flags().VisitAll(func(f *pflag.Flag) { got = append(got, f.Name+"="+f.DefValue) })
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("subnet create-chain flags = %v, want %v", got, want)
+	}
+
+	for _, name := range []string{"rpc-url", "ledger", "ledger-index"} {
+		if subnetCreateChainCmd.InheritedFlags().Lookup(name) == nil {
+			t.Errorf("subnet create-chain is missing --%s", name)
+		}
+	}
+}
+
 func TestLoadGenesisJSON_Success(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "genesis.json")
@@ -96,3 +122,115 @@ func TestLoadGenesisJSON_RejectsOversizedFile(t *testing.T) {
 		t.Fatalf("error = %q, want mention of too large", err)
 	}
 }
+
+func TestReadGenesisJSON(t *testing.T) {
+	got, err := readGenesisJSON(strings.NewReader(`{"config":{}}`))
+	if err != nil || string(got) != `{"config":{}}` {
+		t.Fatalf("readGenesisJSON() = %q, %v", got, err)
+	}
+	if _, err := readGenesisJSON(strings.NewReader("not json")); err == nil {
+		t.Fatal("readGenesisJSON() expected error for invalid json")
+	}
+	if _, err := readGenesisJSON(strings.NewReader(strings.Repeat(" ", maxGenesisLen+1))); err == nil {
+		t.Fatal("readGenesisJSON() expected error for oversized genesis")
+	}
+}
+
+func TestValidateGenesis(t *testing.T) {
+	valid, err := genesis.NewSubnetEVM(genesis.SubnetEVMConfig{ChainID: 1, FeeConfig: genesis.DefaultFeeConfig()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateGenesis(constants.SubnetEVMID, valid); err != nil {
+		t.Fatalf("validateGenesis(valid) error = %v", err)
+	}
+
+	err = validateGenesis(constants.SubnetEVMID, []byte(`{"config":{"chainId":1}}`))
+	if errorType(err) != "invalid_genesis" || exitCode(err) != exitUsage {
+		t.Errorf("validateGenesis(incomplete) = %v (type %q, exit %d)", err, errorType(err), exitCode(err))
+	}
+
+	err = validateGenesis(ids.GenerateTestID(), valid)
+	if err == nil || exitCode(err) != exitUsage {
+		t.Errorf("validateGenesis(other VM) = %v, want usage error", err)
+	}
+}
+
+func TestGenGenesisConfig(t *testing.T) {
+	origChainID, origAllocs, origAdmins := genGenesisChainID, genGenesisAllocs, genGenesisAdmins
+	defer func() { genGenesisChainID, genGenesisAllocs, genGenesisAdmins = origChainID, origAllocs, origAdmins }()
+
+	addr := "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	tests := []struct {
+		name    string
+		chainID uint64
+		allocs  []string
+		admins  []string
+		wantErr bool
+	}{
+		{name: "valid", chainID: 1, allocs: []string{addr + ":10"}, admins: []string{addr}},
+		{name: "missing chain id", allocs: []string{addr + ":10"}, wantErr: true},
+		{name: "bad alloc", chainID: 1, allocs: []string{addr}, wantErr: true},
+		{name: "bad admin", chainID: 1, admins: []string{"P-fuji1abc"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genGenesisChainID, genGenesisAllocs, genGenesisAdmins = tt.chainID, tt.allocs, tt.admins
+			cfg, err := genGenesisConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("genGenesisConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(cfg.Allocations) != len(tt.allocs) || len(cfg.Admins) != len(tt.admins)) {
+				t.Fatalf("genGenesisConfig() = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestParseAllocations(t *testing.T) {
+	const (
+		addrA = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+		addrB = "0x0100000000000000000000000000000000000000"
+	)
+	tests := []struct {
+		name    string
+		input   string
+		want    []string // wei balances
+		wantErr string
+	}{
+		{
+			name:  "header and comments",
+			input: "address,balance\n# treasury\n" + addrA + ", 1000\n" + addrB + ",0.5\n",
+			want:  []string{"1000000000000000000000", "500000000000000000"},
+		},
+		{name: "no header", input: addrA + ",1\n", want: []string{"1000000000000000000"}},
+		{name: "empty", input: "address,balance\n", wantErr: "no allocations"},
+		{name: "p-chain address", input: "P-fuji1abc,1\n", wantErr: "line 1"},
+		{name: "zero balance", input: addrA + ",0\n", wantErr: "must be positive"},
+		{name: "too precise", input: addrA + ",0.0000000000000000001\n", wantErr: "decimal places"},
+		{name: "duplicate", input: addrA + ",1\n" + strings.ToLower(addrA) + ",2\n", wantErr: "already allocated on line 1"},
+		{name: "wrong field count", input: addrA + ",1,2\n", wantErr: "invalid allocation file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAllocations(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseAllocations() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAllocations() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseAllocations() = %d allocations, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if got[i].Balance.String() != w {
+					t.Errorf("allocation %d balance = %s, want %s", i, got[i].Balance, w)
+				}
+			}
+		})
+	}
+}
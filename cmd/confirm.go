@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// confirmYes is the answer confirmOrAbort expects. It is matched without
+// regard to case; any other expected answer must be typed exactly.
+const confirmYes = "yes"
+
+// errNotInteractive is returned when a confirmation is needed but stdin is
+// not a terminal and neither --yes nor PLATFORM_CLI_ASSUME_YES is set.
+// Callers may wrap it to name their own override flag.
+var errNotInteractive = errors.New("confirmation needed but stdin is not a terminal")
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirmOrAbort prints prompt and asks the user to type "yes". --yes and
+// PLATFORM_CLI_ASSUME_YES answer for them. On a non-interactive stdin it
+// returns errNotInteractive rather than waiting, so scripts fail fast instead
+// of hanging or acting on stray input. A false result means the user declined.
+func confirmOrAbort(prompt string) (bool, error) {
+	return confirmPrompt(os.Stdin, stdinIsTerminal(), os.Stdout, prompt, confirmYes)
+}
+
+// confirmPrompt is the implementation behind confirmOrAbort, with the input,
+// its interactivity, the prompt's destination and the expected answer as
+// parameters.
+func confirmPrompt(in io.Reader, interactive bool, out io.Writer, prompt, want string) (bool, error) {
+	if skipConfirmations() {
+		return true, nil
+	}
+	if !interactive {
+		return false, fmt.Errorf("%w; re-run with --yes (or set %s=1)", errNotInteractive, assumeYesEnvVar)
+	}
+
+	fmt.Fprint(out, prompt)
+	response, err := readResponse(in)
+	if err != nil {
+		return false, err
+	}
+	if want == confirmYes {
+		return strings.EqualFold(response, confirmYes), nil
+	}
+	return response == want, nil
+}
+
+// readResponse reads one line of user input, without surrounding whitespace.
+// End of input yields an empty response.
+func readResponse(in io.Reader) (string, error) {
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return strings.TrimSpace(response), nil
+}
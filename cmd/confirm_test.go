@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirmPrompt(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	tests := []struct {
+		name        string
+		assumeYes   bool
+		env         string
+		interactive bool
+		input       string
+		want        string
+		confirmed   bool
+		wantErr     error
+	}{
+		{name: "--yes", assumeYes: true, want: confirmYes, confirmed: true},
+		{name: "env", env: "true", want: confirmYes, confirmed: true},
+		{name: "non-interactive", want: confirmYes, wantErr: errNotInteractive},
+		{name: "yes", interactive: true, input: "yes\n", want: confirmYes, confirmed: true},
+		{name: "yes any case, no newline", interactive: true, input: " Yes", want: confirmYes, confirmed: true},
+		{name: "y is not yes", interactive: true, input: "y\n", want: confirmYes},
+		{name: "eof declines", interactive: true, want: confirmYes},
+		{name: "typed answer", interactive: true, input: "mainnet\n", want: "mainnet", confirmed: true},
+		{name: "typed answer is case-sensitive", interactive: true, input: "Mainnet\n", want: "mainnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			t.Setenv(assumeYesEnvVar, tt.env)
+			var in io.Reader = strings.NewReader(tt.input)
+			if !tt.interactive {
+				in = blockingReader{t}
+			}
+			var out strings.Builder
+			got, err := confirmPrompt(in, tt.interactive, &out, "Proceed? ", tt.want)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("confirmPrompt() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.confirmed {
+				t.Fatalf("confirmPrompt() = %v, want %v", got, tt.confirmed)
+			}
+			if prompted := out.String() == "Proceed? "; prompted != (tt.interactive && !tt.assumeYes && tt.env == "") {
+				t.Fatalf("prompt output = %q", out.String())
+			}
+		})
+	}
+}
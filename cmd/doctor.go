@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	avaversion "github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/spf13/cobra"
+)
+
+// Outcomes of a doctor check.
+const (
+	checkPass = "pass"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// keystoreDirPermMask flags keystore directory permission bits beyond the
+// owner's. The keystore itself creates the directory 0700.
+const keystoreDirPermMask = 0o077
+
+// minRecommendedNodeVersion is the oldest avalanchego release doctor accepts
+// without a warning; older nodes predate fee and L1 APIs this CLI uses.
+var minRecommendedNodeVersion = &avaversion.Application{Name: "avalanchego", Major: 1, Minor: 14, Patch: 0}
+
+// doctorCheck is one line of the `doctor` checklist.
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+func passCheck(name, detail string) doctorCheck {
+	return doctorCheck{Name: name, Status: checkPass, Detail: detail}
+}
+
+func failCheck(name, detail, hint string) doctorCheck {
+	return doctorCheck{Name: name, Status: checkFail, Detail: detail, Hint: hint}
+}
+
+func skipCheck(name, detail string) doctorCheck {
+	return doctorCheck{Name: name, Status: checkSkip, Detail: detail}
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the local setup and connectivity to the network",
+	Long: `Run a checklist of common setup problems and print how to fix each one:
+
+  - the keystore directory exists with owner-only permissions and its index
+    is readable
+  - the key that commands would sign with resolves
+  - the selected network's node is reachable and its P-Chain is bootstrapped
+  - the node runs a recent avalanchego release
+  - with --rpc-url and --network-id, the node reports that network ID
+
+doctor only reads; it changes nothing. It exits non-zero if any check fails.
+
+Examples:
+  platform-cli doctor
+  platform-cli doctor --network mainnet
+  platform-cli doctor --rpc-url http://127.0.0.1:9650 --network-id 12345`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := flagOptions()
+		ctx, cancel := opts.OperationContext()
+		defer cancel()
+
+		var checks []doctorCheck
+		keystorePath, err := keystore.DefaultPath()
+		if err != nil {
+			checks = append(checks, failCheck("Keystore", err.Error(), "Set HOME to a writable directory."))
+		} else {
+			ksCheck, ks := checkKeystore(keystorePath)
+			checks = append(checks, ksCheck, checkSigningKey(opts, ks))
+		}
+		checks = append(checks, checkNetwork(ctx, opts)...)
+
+		if wantStructured() {
+			if err := printStructured(checks); err != nil {
+				return err
+			}
+		} else {
+			printDoctorChecks(os.Stdout, checks)
+		}
+
+		if failed := countFailedChecks(checks); failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+// checkKeystore verifies the keystore directory and index without modifying
+// them. The keystore is returned only when its index could be read.
+func checkKeystore(path string) (doctorCheck, *keystore.KeyStore) {
+	const name = "Keystore"
+	fileInfo, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return failCheck(name, fmt.Sprintf("%s does not exist", path),
+			"Create a key with 'platform-cli keys generate --name <name>' or 'platform-cli keys import'."), nil
+	}
+	if err != nil {
+		return failCheck(name, err.Error(), "Check that your user can read "+path+"."), nil
+	}
+	if !fileInfo.IsDir() {
+		return failCheck(name, fmt.Sprintf("%s is not a directory", path), "Move the file aside so the keystore can be created."), nil
+	}
+	if perm := fileInfo.Mode().Perm(); perm&keystoreDirPermMask != 0 {
+		return failCheck(name, fmt.Sprintf("%s has permissions %04o; other users may read your keys", path, perm),
+			fmt.Sprintf("Run: chmod 700 %s", path)), nil
+	}
+
+	ks, err := keystore.LoadFrom(path)
+	if err != nil {
+		return failCheck(name, err.Error(), "Restore keys.json from a backup or re-import your keys."), nil
+	}
+	return passCheck(name, fmt.Sprintf("%s (%d keys)", path, ks.KeyCount())), ks
+}
+
+// checkSigningKey reports which key source commands would sign with, in the
+// same order loadKey tries them.
+func checkSigningKey(opts Options, ks *keystore.KeyStore) doctorCheck {
+	const name = "Signing key"
+	switch {
+	case opts.UseLedger:
+		return skipCheck(name, "using a Ledger; connect it and open the Avalanche app before signing")
+	case opts.From != "" || opts.KeyName != "":
+		if ks == nil {
+			return failCheck(name, "the keystore could not be read", "Fix the keystore check above.")
+		}
+		keyName := opts.KeyName
+		if opts.From != "" {
+			resolved, err := resolveFromKeyName(opts.From)
+			if err != nil {
+				return failCheck(name, err.Error(), "Run 'platform-cli keys list' to see stored keys.")
+			}
+			keyName = resolved
+		}
+		if keyName != "ewoq" && !ks.HasKey(keyName) {
+			return failCheck(name, fmt.Sprintf("key %q not found", keyName), "Run 'platform-cli keys list' to see stored keys.")
+		}
+		return passCheck(name, fmt.Sprintf("key %q", keyName))
+	case opts.PrivateKey != "":
+		return passCheck(name, "--private-key (prefer --key-name or --ledger)")
+	case ks != nil && ks.GetDefault() != "":
+		if !ks.HasKey(ks.GetDefault()) {
+			return failCheck(name, fmt.Sprintf("default key %q is not in the keystore", ks.GetDefault()),
+				"Run 'platform-cli keys default --name <name>' with a stored key.")
+		}
+		return passCheck(name, fmt.Sprintf("default key %q", ks.GetDefault()))
+	case os.Getenv(privateKeyEnvVar) != "":
+		return passCheck(name, privateKeyEnvVar)
+	default:
+		return failCheck(name, "no key source configured",
+			"Run 'platform-cli keys default --name <name>', or pass --key-name or --ledger.")
+	}
+}
+
+// doctorNodeClient is the part of the info API that checkNode uses.
+// info.Client satisfies it.
+type doctorNodeClient interface {
+	GetNetworkID(ctx context.Context, options ...rpc.Option) (uint32, error)
+	IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error)
+	GetNodeVersion(ctx context.Context, options ...rpc.Option) (*info.GetNodeVersionReply, error)
+}
+
+// checkNetwork resolves the selected network the way getNetworkConfig does,
+// but reports problems as checks instead of failing on the first one.
+func checkNetwork(ctx context.Context, opts Options) []doctorCheck {
+	const name = "RPC endpoint"
+	if opts.EndpointFromNetwork {
+		config, fallbacks, err := opts.mirrorNetworkConfig(ctx)
+		if err != nil {
+			return []doctorCheck{failCheck(name, err.Error(), "Point --rpc-url at an endpoint for the --network you named.")}
+		}
+		var checks []doctorCheck
+		for _, endpoint := range append([]string{config.RPCURL}, fallbacks...) {
+			checks = append(checks, checkNode(ctx, info.NewClient(endpoint), endpoint, config.NetworkID)...)
+		}
+		return checks
+	}
+	rpcURL, err := opts.resolveRPCURL(os.Getenv(rpcURLEnvVar))
+	if err != nil {
+		return []doctorCheck{failCheck(name, err.Error(), "Drop one of the two flags.")}
+	}
+	if rpcURL == "" {
+		config, err := network.GetConfig(opts.Network)
+		if err != nil {
+			return []doctorCheck{failCheck(name, err.Error(), "Run 'platform-cli network list' to see valid --network values.")}
+		}
+		return checkNode(ctx, info.NewClient(config.RPCURL), config.RPCURL, config.NetworkID)
+	}
+
+	endpoints, err := opts.endpoints(rpcURL)
+	if err != nil {
+		return []doctorCheck{failCheck(name, err.Error(), "Use an https:// URL, or --allow-insecure-http for a trusted plain-HTTP node.")}
+	}
+	// Every endpoint, fallbacks included, is checked on its own.
+	var checks []doctorCheck
+	for _, endpoint := range endpoints {
+		checks = append(checks, checkNode(ctx, info.NewClient(endpoint), endpoint, opts.NetworkID)...)
+	}
+	return checks
+}
+
+// checkNode checks that the node at rpcURL is reachable, on wantNetworkID
+// (0 accepts any), bootstrapped and recent.
+func checkNode(ctx context.Context, client doctorNodeClient, rpcURL string, wantNetworkID uint32) []doctorCheck {
+	networkID, err := client.GetNetworkID(ctx)
+	if err != nil {
+		return []doctorCheck{
+			failCheck("RPC endpoint", fmt.Sprintf("%s is unreachable: %v", rpcURL, err),
+				"Check your connection and the URL, or pick another endpoint with --rpc-url."),
+			skipCheck("Network ID", "node unreachable"),
+			skipCheck("P-Chain bootstrapped", "node unreachable"),
+			skipCheck("Node version", "node unreachable"),
+		}
+	}
+	checks := []doctorCheck{passCheck("RPC endpoint", rpcURL)}
+
+	switch {
+	case wantNetworkID == 0:
+		checks = append(checks, passCheck("Network ID", fmt.Sprintf("%d (HRP %q)", networkID, network.GetHRP(networkID))))
+	case networkID != wantNetworkID:
+		checks = append(checks, failCheck("Network ID",
+			fmt.Sprintf("expected %d (HRP %q) but the node reports %d (HRP %q)", wantNetworkID, network.GetHRP(wantNetworkID), networkID, network.GetHRP(networkID)),
+			"Fix --network-id, or point --rpc-url at a node on the intended network."))
+	default:
+		checks = append(checks, passCheck("Network ID", fmt.Sprintf("%d (HRP %q)", networkID, network.GetHRP(networkID))))
+	}
+
+	bootstrapped, err := client.IsBootstrapped(ctx, pChainAlias)
+	switch {
+	case err != nil:
+		checks = append(checks, failCheck("P-Chain bootstrapped", err.Error(), "The node may not expose the info API; try another endpoint."))
+	case !bootstrapped:
+		checks = append(checks, failCheck("P-Chain bootstrapped", "the node is still bootstrapping",
+			"Wait for the node to finish bootstrapping, or use another endpoint."))
+	default:
+		checks = append(checks, passCheck("P-Chain bootstrapped", "yes"))
+	}
+
+	reply, err := client.GetNodeVersion(ctx)
+	if err != nil {
+		return append(checks, failCheck("Node version", err.Error(), "The node may not expose the info API; try another endpoint."))
+	}
+	return append(checks, checkNodeVersion(reply.Version))
+}
+
+// checkNodeVersion compares an "avalanchego/1.2.3" version string against
+// minRecommendedNodeVersion.
+func checkNodeVersion(reported string) doctorCheck {
+	const name = "Node version"
+	nodeVersion, err := parseNodeVersion(reported)
+	if err != nil {
+		return failCheck(name, err.Error(), "Check that the endpoint is an avalanchego node.")
+	}
+	if nodeVersion.Compare(minRecommendedNodeVersion) < 0 {
+		return failCheck(name, fmt.Sprintf("%s is older than %s", reported, minRecommendedNodeVersion),
+			fmt.Sprintf("Upgrade the node to %s or later.", minRecommendedNodeVersion.Semantic()))
+	}
+	return passCheck(name, reported)
+}
+
+// parseNodeVersion parses the Version field of info.getNodeVersion.
+func parseNodeVersion(s string) (*avaversion.Application, error) {
+	name, semver, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return nil, fmt.Errorf("unrecognized node version %q", s)
+	}
+	v := &avaversion.Application{Name: name}
+	var rest string
+	n, _ := fmt.Sscanf(strings.TrimPrefix(semver, "v"), "%d.%d.%d%s", &v.Major, &v.Minor, &v.Patch, &rest)
+	if n < 3 {
+		return nil, fmt.Errorf("unrecognized node version %q", s)
+	}
+	return v, nil
+}
+
+func countFailedChecks(checks []doctorCheck) int {
+	failed := 0
+	for _, c := range checks {
+		if c.Status == checkFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+func printDoctorChecks(out io.Writer, checks []doctorCheck) {
+	for _, c := range checks {
+		label := "[" + strings.ToUpper(c.Status) + "]"
+		switch c.Status {
+		case checkPass:
+			label = stylize(os.Stdout, ansiGreen, label)
+		case checkFail:
+			label = stylize(os.Stdout, ansiRed, label)
+		}
+		fmt.Fprintf(out, "%s %s", label, c.Name)
+		if c.Detail != "" {
+			fmt.Fprintf(out, ": %s", c.Detail)
+		}
+		fmt.Fprintln(out)
+		if c.Hint != "" {
+			fmt.Fprintf(out, "       %s\n", c.Hint)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+func TestCheckKeystore(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		check, ks := checkKeystore(filepath.Join(t.TempDir(), "keys"))
+		if check.Status != checkFail || ks != nil {
+			t.Fatalf("checkKeystore() = %+v, %v; want fail and no keystore", check, ks)
+		}
+	})
+
+	t.Run("group readable", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "keys")
+		if err := os.Mkdir(dir, 0o750); err != nil {
+			t.Fatalf("Mkdir() error = %v", err)
+		}
+		check, _ := checkKeystore(dir)
+		if check.Status != checkFail {
+			t.Fatalf("checkKeystore() status = %q, want fail", check.Status)
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o750 {
+			t.Fatalf("permissions changed to %04o; doctor must not modify the keystore", perm)
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "keys")
+		if err := os.Mkdir(dir, 0o700); err != nil {
+			t.Fatalf("Mkdir() error = %v", err)
+		}
+		check, ks := checkKeystore(dir)
+		if check.Status != checkPass || ks == nil {
+			t.Fatalf("checkKeystore() = %+v, %v; want pass with keystore", check, ks)
+		}
+	})
+}
+
+// stubNodeClient implements doctorNodeClient.
+type stubNodeClient struct {
+	networkID    uint32
+	networkErr   error
+	bootstrapped bool
+	version      string
+}
+
+func (s *stubNodeClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return s.networkID, s.networkErr
+}
+
+func (s *stubNodeClient) IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error) {
+	return s.bootstrapped, nil
+}
+
+func (s *stubNodeClient) GetNodeVersion(context.Context, ...rpc.Option) (*info.GetNodeVersionReply, error) {
+	return &info.GetNodeVersionReply{Version: s.version}, nil
+}
+
+func TestCheckNode(t *testing.T) {
+	tests := []struct {
+		name          string
+		client        *stubNodeClient
+		wantNetworkID uint32
+		want          []string
+	}{
+		{
+			name:          "healthy",
+			client:        &stubNodeClient{networkID: 5, bootstrapped: true, version: "avalanchego/1.14.1"},
+			wantNetworkID: 5,
+			want:          []string{checkPass, checkPass, checkPass, checkPass},
+		},
+		{
+			name:   "unreachable",
+			client: &stubNodeClient{networkErr: errors.New("connection refused")},
+			want:   []string{checkFail, checkSkip, checkSkip, checkSkip},
+		},
+		{
+			name:          "wrong network, bootstrapping, old",
+			client:        &stubNodeClient{networkID: 1, version: "avalanchego/1.11.13"},
+			wantNetworkID: 5,
+			want:          []string{checkPass, checkFail, checkFail, checkFail},
+		},
+		{
+			name:   "any network ID accepted",
+			client: &stubNodeClient{networkID: 12345, bootstrapped: true, version: "avalanchego/1.14.0"},
+			want:   []string{checkPass, checkPass, checkPass, checkPass},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := checkNode(context.Background(), tt.client, "http://127.0.0.1:9650", tt.wantNetworkID)
+			if len(checks) != len(tt.want) {
+				t.Fatalf("checkNode() returned %d checks, want %d: %+v", len(checks), len(tt.want), checks)
+			}
+			for i, c := range checks {
+				if c.Status != tt.want[i] {
+					t.Errorf("check %q status = %q, want %q (%s)", c.Name, c.Status, tt.want[i], c.Detail)
+				}
+				if c.Status == checkFail && c.Hint == "" {
+					t.Errorf("failed check %q has no remediation hint", c.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNodeVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    [3]int
+		wantErr bool
+	}{
+		{in: "avalanchego/1.14.1", want: [3]int{1, 14, 1}},
+		{in: "avalanchego/v1.13.0-rc.2", want: [3]int{1, 13, 0}},
+		{in: "1.14.1", wantErr: true},
+		{in: "avalanchego/latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseNodeVersion(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodeVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && [3]int{got.Major, got.Minor, got.Patch} != tt.want {
+				t.Fatalf("parseNodeVersion() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
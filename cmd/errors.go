@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	"github.com/ava-labs/platform-cli/pkg/genesis"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/retry"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// errorTypeGeneric is the type of errors not listed in errorTypes.
+const errorTypeGeneric = "error"
+
+// Process exit codes, by failure class. Scripts can retry on exitNetwork but
+// not on exitUsage.
+const (
+	exitGeneric  = 1 // anything not classified below
+	exitUsage    = 2 // bad flags or arguments
+	exitKey      = 3 // the signing key or Ledger is unavailable, or lacks authority
+	exitNetwork  = 4 // the node could not be reached or did not answer in time
+	exitRejected = 5 // the node or chain refused the request or transaction
+)
+
+// errorTypes maps known errors to the "type" reported in structured error
+// output and to an exit code. The first match wins, so more specific errors
+// come first.
+var errorTypes = []struct {
+	target   error
+	name     string
+	exitCode int
+}{
+	{pchain.ErrInsufficientFunds, "insufficient_funds", exitRejected},
+	{builder.ErrInsufficientFunds, "insufficient_funds", exitRejected},
+	{pchain.ErrStakeTooSmall, "stake_too_small", exitRejected},
+	{pchain.ErrStakeTooLarge, "stake_too_large", exitRejected},
+	{pchain.ErrMissingBLSSigner, "missing_bls_signer", exitUsage},
+	{pchain.ErrSubnetAuthInsufficient, "subnet_auth_insufficient", exitKey},
+	{pchain.ErrSubnetNotTracked, "subnet_not_tracked", exitUsage},
+	{pchain.ErrGenesisTooLarge, "genesis_too_large", exitRejected},
+	{pchain.ErrVMNotRegistered, "vm_not_registered", exitRejected},
+	{genesis.ErrInvalidGenesis, "invalid_genesis", exitUsage},
+	{pchain.ErrUnexpectedWarpPayload, "unexpected_warp_payload", exitUsage},
+	{pchain.ErrDynamicFeesUnavailable, "dynamic_fees_unavailable", exitNetwork},
+	{network.ErrNetworkIDMismatch, "network_id_mismatch", exitNetwork},
+	{network.ErrNodeUnreachable, "node_unreachable", exitNetwork},
+	{network.ErrInfoAPIUnavailable, "info_api_unavailable", exitNetwork},
+	{network.ErrUnexpectedFeeAsset, "unexpected_fee_asset", exitNetwork},
+	{wallet.ErrHistoricalStateUnavailable, "historical_state_unavailable", exitNetwork},
+	{wallet.ErrNotContractCreation, "not_contract_creation", exitUsage},
+	{wallet.ErrOwnershipProofInvalid, "ownership_proof_invalid", exitRejected},
+	{errNotInteractive, "confirmation_required", exitUsage},
+	{retry.ErrBudgetExhausted, "retry_budget_exhausted", exitNetwork},
+	{context.DeadlineExceeded, "timeout", exitNetwork},
+	{context.Canceled, "canceled", exitGeneric},
+}
+
+// cobraUsagePrefixes start the messages of the argument and flag-group
+// errors cobra returns after flag parsing, which SetFlagErrorFunc does not
+// see.
+var cobraUsagePrefixes = []string{
+	"required flag(s)",
+	"if any flags in the group",
+	"unknown command",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+	"invalid argument",
+}
+
+// exitCodeError carries the exit code for an error whose class is known where
+// it is created, such as a key that could not be loaded.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+// withExitCode marks err to exit with code. A nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// exitCode picks the process exit code for a command error.
+func exitCode(err error) int {
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	for _, t := range errorTypes {
+		if errors.Is(err, t.target) {
+			return t.exitCode
+		}
+	}
+
+	// A JSON-RPC error means the node answered and refused the request.
+	var rpcErr *json2.Error
+	if errors.As(err, &rpcErr) {
+		return exitRejected
+	}
+	var netErr net.Error
+	var urlErr *url.Error
+	// avalanchego reports HTTP failures as unwrapped "received status code" errors.
+	if errors.As(err, &netErr) || errors.As(err, &urlErr) || strings.Contains(err.Error(), "received status code") {
+		return exitNetwork
+	}
+	for _, prefix := range cobraUsagePrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return exitUsage
+		}
+	}
+	return exitGeneric
+}
+
+// errorType names the kind of err for structured error output.
+func errorType(err error) string {
+	for _, t := range errorTypes {
+		if errors.Is(err, t.target) {
+			return t.name
+		}
+	}
+	return errorTypeGeneric
+}
+
+// txError records the transaction a failure relates to, such as an export
+// whose import then failed, so structured error output can report it.
+type txError struct {
+	txID ids.ID
+	err  error
+}
+
+// withTxID attaches txID to err. A nil err or an empty txID leaves err as is.
+func withTxID(txID ids.ID, err error) error {
+	if err == nil || txID == ids.Empty {
+		return err
+	}
+	return &txError{txID: txID, err: err}
+}
+
+func (e *txError) Error() string { return e.err.Error() }
+
+func (e *txError) Unwrap() error { return e.err }
+
+// pendingTxID is the last transaction a wallet issued that has not yet been
+// seen accepted. A command stopped while waiting for it reports it.
+var pendingTxID ids.ID
+
+// trackIssuedTx and trackConfirmedTx keep pendingTxID; see
+// wallet.Wallet.SetIssueHandlers.
+func trackIssuedTx(txID ids.ID) { pendingTxID = txID }
+
+func trackConfirmedTx(txID ids.ID) {
+	if pendingTxID == txID {
+		pendingTxID = ids.Empty
+	}
+}
+
+// explainInterrupted tells a command stopped by its operation timeout apart
+// from one cancelled with Ctrl-C; both otherwise read like an RPC failure. A
+// transaction already submitted, from err or pending, is named because it may
+// still be accepted. Other errors are returned as is.
+func explainInterrupted(err error, timeout time.Duration, pending ids.ID) error {
+	var reason string
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		reason = fmt.Sprintf("operation timed out after %s; increase it with --timeout or %s", timeout, timeoutEnvVar)
+	case errors.Is(err, context.Canceled):
+		reason = "operation cancelled by user"
+	default:
+		return err
+	}
+	var txErr *txError
+	if errors.As(err, &txErr) {
+		pending = txErr.txID
+	}
+	if pending != ids.Empty {
+		reason += fmt.Sprintf("; transaction %s was already submitted and may still be accepted, so check it before retrying", pending)
+	}
+	return withTxID(pending, fmt.Errorf("%s: %w", reason, err))
+}
+
+// structuredError is the document written to stderr for a failed command
+// when --output is json or yaml.
+type structuredError struct {
+	Error string `json:"error"`
+	Type  string `json:"type"`
+	TxID  string `json:"txID,omitempty"`
+}
+
+// newStructuredError describes err for structured error output.
+func newStructuredError(err error) structuredError {
+	doc := structuredError{Error: err.Error(), Type: errorType(err)}
+	var txErr *txError
+	if errors.As(err, &txErr) {
+		doc.TxID = txErr.txID.String()
+	}
+	return doc
+}
+
+// writeStructuredError writes err to w in the --output format. If encoding
+// fails, the plain message is written instead so the error is never lost.
+func writeStructuredError(w io.Writer, err error) {
+	data, encErr := marshalStructured(newStructuredError(err))
+	if encErr != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("send failed: %w", &pchain.InsufficientFundsError{Amount: 10, Have: 1, FeeUnknown: true}), "insufficient_funds"},
+		{fmt.Errorf("wrapped: %w", network.ErrNetworkIDMismatch), "network_id_mismatch"},
+		{fmt.Errorf("%w; re-run with --yes", errNotInteractive), "confirmation_required"},
+		{fmt.Errorf("failed to fetch: %w", context.DeadlineExceeded), "timeout"},
+		{errors.New("something else"), errorTypeGeneric},
+	}
+	for _, tt := range tests {
+		if got := errorType(tt.err); got != tt.want {
+			t.Errorf("errorType(%q) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unclassified", errors.New("something else"), exitGeneric},
+		{"bad id flag", fmt.Errorf("wrapped: %w", invalidIDFlagError("subnet-id", "x", errors.New("bad"))), exitUsage},
+		{"flag parse", rootCmd.FlagErrorFunc()(rootCmd, errors.New("unknown flag: --nope")), exitUsage},
+		{"required flag", errors.New(`required flag(s) "amount" not set`), exitUsage},
+		{"confirmation", fmt.Errorf("%w; re-run with --yes", errNotInteractive), exitUsage},
+		{"key", withExitCode(exitKey, errors.New("failed to decrypt key")), exitKey},
+		{"subnet auth", fmt.Errorf("wrapped: %w", pchain.ErrSubnetAuthInsufficient), exitKey},
+		{"timeout", fmt.Errorf("failed to fetch: %w", context.DeadlineExceeded), exitNetwork},
+		{"network id", fmt.Errorf("wrapped: %w", network.ErrNetworkIDMismatch), exitNetwork},
+		{"http status", errors.New("received status code: 503"), exitNetwork},
+		{"rpc rejection", fmt.Errorf("failed to issue tx: %w", &json2.Error{Message: "tx rejected"}), exitRejected},
+		{"insufficient funds", &pchain.InsufficientFundsError{Amount: 10, Have: 1}, exitRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%q) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if withExitCode(exitKey, nil) != nil {
+		t.Error("withExitCode(nil) should stay nil")
+	}
+}
+
+func TestWriteStructuredError(t *testing.T) {
+	origFormat := outputFormat
+	defer func() { outputFormat = origFormat }()
+	outputFormat = outputJSON
+
+	exportTxID := ids.GenerateTestID()
+	err := withTxID(exportTxID, fmt.Errorf("transfer failed: %w", &pchain.InsufficientFundsError{Amount: 10, Have: 1, FeeUnknown: true}))
+
+	var buf bytes.Buffer
+	writeStructuredError(&buf, err)
+
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("structured error is not JSON: %v\n%s", err, buf.String())
+	}
+	want := map[string]string{
+		"error": err.Error(),
+		"type":  "insufficient_funds",
+		"txID":  exportTxID.String(),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("structured error = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("structured error[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+
+	buf.Reset()
+	got = nil
+	writeStructuredError(&buf, errors.New("boom"))
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("structured error is not JSON: %v", err)
+	}
+	if _, ok := got["txID"]; ok {
+		t.Errorf("structured error without a transaction has txID: %v", got)
+	}
+}
+
+func TestWithTxIDKeepsChain(t *testing.T) {
+	if withTxID(ids.Empty, errNotInteractive) != errNotInteractive {
+		t.Fatal("withTxID(ids.Empty, err) should return err unchanged")
+	}
+	if err := withTxID(ids.GenerateTestID(), errNotInteractive); !errors.Is(err, errNotInteractive) {
+		t.Fatalf("withTxID() broke errors.Is: %v", err)
+	}
+}
+
+func TestExplainInterrupted(t *testing.T) {
+	pending, exported := ids.GenerateTestID(), ids.GenerateTestID()
+	timedOut := fmt.Errorf("failed to issue tx: %w", context.DeadlineExceeded)
+	tests := []struct {
+		name       string
+		err        error
+		pending    ids.ID
+		wantPrefix string
+		wantTxID   ids.ID
+	}{
+		{name: "timeout", err: timedOut, wantPrefix: "operation timed out after 2m0s; increase it with --timeout"},
+		{name: "cancel", err: fmt.Errorf("wrapped: %w", context.Canceled), wantPrefix: "operation cancelled by user: wrapped"},
+		{
+			name:       "timeout after issue",
+			err:        timedOut,
+			pending:    pending,
+			wantPrefix: "operation timed out after 2m0s",
+			wantTxID:   pending,
+		},
+		{
+			name:       "tx from error wins",
+			err:        withTxID(exported, fmt.Errorf("import failed: %w", context.Canceled)),
+			pending:    pending,
+			wantPrefix: "operation cancelled by user; transaction " + exported.String(),
+			wantTxID:   exported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := explainInterrupted(tt.err, 2*time.Minute, tt.pending)
+			if !strings.HasPrefix(err.Error(), tt.wantPrefix) {
+				t.Errorf("explainInterrupted() = %q, want prefix %q", err, tt.wantPrefix)
+			}
+			if !errors.Is(err, tt.err) || errorType(err) != errorType(tt.err) {
+				t.Errorf("explainInterrupted() broke the error chain: %v", err)
+			}
+			doc := newStructuredError(err)
+			if tt.wantTxID == ids.Empty {
+				if doc.TxID != "" {
+					t.Errorf("txID = %q, want none", doc.TxID)
+				}
+				return
+			}
+			if doc.TxID != tt.wantTxID.String() || !strings.Contains(err.Error(), "may still be accepted") {
+				t.Errorf("explainInterrupted() = %q (txID %q), want transaction %s named", err, doc.TxID, tt.wantTxID)
+			}
+		})
+	}
+
+	other := errors.New("connection refused")
+	if got := explainInterrupted(other, time.Minute, pending); got != other {
+		t.Errorf("explainInterrupted(other) = %v, want it unchanged", got)
+	}
+}
@@ -1,10 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
 )
 
 func TestAvaxToNAVAX(t *testing.T) {
@@ -92,6 +104,26 @@ func TestFeeToShares(t *testing.T) {
 			input:   1.01,
 			wantErr: true,
 		},
+		{
+			name:    "percent instead of fraction",
+			input:   5,
+			wantErr: true,
+		},
+		{
+			name:  "smallest step",
+			input: 0.000001,
+			want:  1,
+		},
+		{
+			name:    "finer than one share",
+			input:   0.0200005,
+			wantErr: true,
+		},
+		{
+			name:    "NaN",
+			input:   math.NaN(),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,14 +153,14 @@ func TestGetTransferAmountNAVAX(t *testing.T) {
 		transferAmountNAVAX = origAmountNAVAX
 	}()
 
-	transferAmount = 1
+	transferAmount = "1"
 	transferAmountNAVAX = 123
 	_, err := getTransferAmountNAVAX()
 	if err == nil {
 		t.Fatal("getTransferAmountNAVAX() expected error when both --amount and --amount-navax are set")
 	}
 
-	transferAmount = 0
+	transferAmount = ""
 	transferAmountNAVAX = 123
 	got, err := getTransferAmountNAVAX()
 	if err != nil {
@@ -138,7 +170,7 @@ func TestGetTransferAmountNAVAX(t *testing.T) {
 		t.Fatalf("getTransferAmountNAVAX() = %d, want 123", got)
 	}
 
-	transferAmount = 1.5
+	transferAmount = "1.5"
 	transferAmountNAVAX = 0
 	got, err = getTransferAmountNAVAX()
 	if err != nil {
@@ -148,11 +180,129 @@ func TestGetTransferAmountNAVAX(t *testing.T) {
 		t.Fatalf("getTransferAmountNAVAX() = %d, want 1500000000", got)
 	}
 
-	transferAmount = 0
-	transferAmountNAVAX = 0
-	_, err = getTransferAmountNAVAX()
-	if err == nil {
-		t.Fatal("getTransferAmountNAVAX() expected error for missing amount")
+	transferAmount = "1500000000navax"
+	got, err = getTransferAmountNAVAX()
+	if err != nil {
+		t.Fatalf("getTransferAmountNAVAX() returned error: %v", err)
+	}
+	if got != 1_500_000_000 {
+		t.Fatalf("getTransferAmountNAVAX() = %d, want 1500000000", got)
+	}
+
+	for _, amount := range []string{"", "0avax", "1.5eth"} {
+		transferAmount = amount
+		if _, err := getTransferAmountNAVAX(); err == nil {
+			t.Fatalf("getTransferAmountNAVAX() expected error for --amount %q", amount)
+		}
+	}
+}
+
+func TestLowBalanceWarning(t *testing.T) {
+	const fee = 1_000_000
+	tests := []struct {
+		name        string
+		balance     uint64
+		amount      uint64
+		wantWarning bool
+	}{
+		{"plenty left", 10_000_000_000, 1_000_000_000, false},
+		{"exactly reserve left", 10_000_000_000, 10_000_000_000 - fee - fee*feeReserveTxCount, false},
+		{"just under reserve", 10_000_000_000, 10_000_000_000 - fee - fee*feeReserveTxCount + 1, true},
+		{"empties wallet", 10_000_000_000, 10_000_000_000 - fee, true},
+		{"cannot cover fee", 10_000_000_000, 10_000_000_000, false},
+		{"amount exceeds balance", 1_000, 2_000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lowBalanceWarning(tt.balance, tt.amount, fee)
+			if (got != "") != tt.wantWarning {
+				t.Fatalf("lowBalanceWarning(%d, %d, %d) = %q, want warning=%v", tt.balance, tt.amount, fee, got, tt.wantWarning)
+			}
+			if got == "" {
+				return
+			}
+			for _, want := range []string{"--subtract-fee", "pass --yes or --quiet to silence this warning"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("lowBalanceWarning() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipSendWarnings(t *testing.T) {
+	origYes, origQuiet := assumeYes, transferQuiet
+	defer func() { assumeYes, transferQuiet = origYes, origQuiet }()
+	t.Setenv(assumeYesEnvVar, "")
+
+	tests := []struct {
+		name  string
+		yes   bool
+		quiet bool
+		want  bool
+	}{
+		{name: "default", want: false},
+		{name: "yes", yes: true, want: true},
+		{name: "quiet", quiet: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes, transferQuiet = tt.yes, tt.quiet
+			if got := skipSendWarnings(); got != tt.want {
+				t.Fatalf("skipSendWarnings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStakeFlagNAVAX(t *testing.T) {
+	origAVAX, origNAVAX := valStakeAmount, valStakeNAVAX
+	defer func() { valStakeAmount, valStakeNAVAX = origAVAX, origNAVAX }()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    uint64
+		wantErr bool
+	}{
+		{name: "avax", args: []string{"--stake", "2000"}, want: 2_000_000_000_000},
+		{name: "fractional avax", args: []string{"--stake", "25.5"}, want: 25_500_000_000},
+		{name: "navax", args: []string{"--stake-navax", "25000000000"}, want: 25_000_000_000},
+		{name: "neither", wantErr: true},
+		{name: "zero navax", args: []string{"--stake-navax", "0"}, wantErr: true},
+		{name: "negative avax", args: []string{"--stake", "-1"}, wantErr: true},
+		{name: "navax typed as avax overflows", args: []string{"--stake", "25000000000000"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Float64Var(&valStakeAmount, "stake", 0, "")
+			cmd.Flags().Uint64Var(&valStakeNAVAX, "stake-navax", 0, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+			}
+			got, err := stakeFlagNAVAX(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("stakeFlagNAVAX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("stakeFlagNAVAX() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStakeUnitWarning(t *testing.T) {
+	if got := stakeUnitWarning(2000, 2_000_000_000_000); got != "" {
+		t.Errorf("stakeUnitWarning(2000) = %q, want no warning", got)
+	}
+	if got := stakeUnitWarning(stakeMagnitudeWarningAVAX, stakeMagnitudeWarningAVAX*1e9); got != "" {
+		t.Errorf("stakeUnitWarning(threshold) = %q, want no warning", got)
+	}
+	got := stakeUnitWarning(2e9, 2e18)
+	if !strings.Contains(got, "--stake-navax") {
+		t.Errorf("stakeUnitWarning(2e9) = %q, want a --stake-navax hint", got)
 	}
 }
 
@@ -213,6 +363,82 @@ func TestFractionToShares(t *testing.T) {
 	}
 }
 
+func TestResolveOperationTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		flag time.Duration
+		env  string
+		want time.Duration
+	}{
+		{"default", 0, "", defaultOperationTimeout},
+		{"env", 0, "5m", 5 * time.Minute},
+		{"flag beats env", 30 * time.Second, "5m", 30 * time.Second},
+		{"flag without env", 10 * time.Minute, "", 10 * time.Minute},
+		{"invalid env ignored", 0, "soon", defaultOperationTimeout},
+		{"non-positive env ignored", 0, "-1m", defaultOperationTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveOperationTimeout(tt.flag, tt.env); got != tt.want {
+				t.Errorf("resolveOperationTimeout(%s, %q) = %s, want %s", tt.flag, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRPCURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		env        string
+		networkSet bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "neither", want: ""},
+		{name: "env", env: "http://127.0.0.1:9650", want: "http://127.0.0.1:9650"},
+		{name: "flag beats env", flag: "https://devnet:9650", env: "http://127.0.0.1:9650", want: "https://devnet:9650"},
+		{name: "explicit --network beats env", env: "http://127.0.0.1:9650", networkSet: true, want: ""},
+		{name: "flag with --network", flag: "https://devnet:9650", networkSet: true, wantErr: true},
+		{name: "blank env ignored", env: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Options{RPCURL: tt.flag, NetworkSet: tt.networkSet}.resolveRPCURL(tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRPCURL(%q, %q, %v) error = %v, wantErr %v", tt.flag, tt.env, tt.networkSet, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRPCURL(%q, %q, %v) = %q, want %q", tt.flag, tt.env, tt.networkSet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimeoutFlag(t *testing.T) {
+	orig := operationTimeout
+	defer func() { operationTimeout = orig }()
+
+	for _, tt := range []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"--timeout", "45s"}, false},
+		{[]string{"--timeout", "0s"}, true},
+		{[]string{"--timeout", "-5s"}, true},
+	} {
+		cmd := &cobra.Command{}
+		cmd.Flags().DurationVar(&operationTimeout, "timeout", 0, "")
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		if err := validateTimeoutFlag(cmd); (err != nil) != tt.wantErr {
+			t.Errorf("validateTimeoutFlag(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
 func TestParseAutoRenewPeriod(t *testing.T) {
 	got, err := parseAutoRenewPeriod("336h")
 	if err != nil {
@@ -313,3 +539,302 @@ func TestIsEwoqKey(t *testing.T) {
 		t.Fatal("isEwoqKey() expected false for wrong length")
 	}
 }
+
+func TestConfirmRewardAddress(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	own := ids.GenerateTestShortID()
+	other := ids.GenerateTestShortID()
+
+	tests := []struct {
+		name        string
+		reward      ids.ShortID
+		assumeYes   bool
+		interactive bool
+		input       string
+		wantErr     bool
+	}{
+		{name: "own address", reward: own},
+		{name: "other with --yes", reward: other, assumeYes: true},
+		{name: "other non-interactive", reward: other, wantErr: true},
+		{name: "other confirmed", reward: other, interactive: true, input: "yes\n"},
+		{name: "other confirmed without newline", reward: other, interactive: true, input: "YES"},
+		{name: "other declined", reward: other, interactive: true, input: "no\n", wantErr: true},
+		{name: "other empty input", reward: other, interactive: true, input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			err := confirmRewardAddress(strings.NewReader(tt.input), tt.interactive, own, tt.reward, 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmRewardAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmFetchedNodeID(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name        string
+		assumeYes   bool
+		interactive bool
+		input       string
+		wantErr     bool
+	}{
+		{name: "--yes", assumeYes: true},
+		{name: "non-interactive", wantErr: true},
+		{name: "confirmed", interactive: true, input: "yes\n"},
+		{name: "declined", interactive: true, input: "n\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			err := confirmFetchedNodeID(strings.NewReader(tt.input), tt.interactive, nodeID, "127.0.0.1:9650")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmFetchedNodeID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmL1Message(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	tests := []struct {
+		name        string
+		assumeYes   bool
+		interactive bool
+		input       string
+		wantErr     bool
+	}{
+		{name: "--yes", assumeYes: true},
+		{name: "non-interactive", wantErr: true},
+		{name: "confirmed", interactive: true, input: "yes\n"},
+		{name: "declined", interactive: true, input: "no\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			var out strings.Builder
+			err := confirmL1Message(strings.NewReader(tt.input), tt.interactive, &out, []string{"Validation ID: test"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmL1Message() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), "Validation ID: test") {
+				t.Fatalf("confirmL1Message() output = %q, want the summary", out.String())
+			}
+		})
+	}
+}
+
+func TestVerifyMessagePoP(t *testing.T) {
+	pop := newTestPoP(t)
+	if err := verifyMessagePoP(pop.PublicKey[:], pop.ProofOfPossession); err != nil {
+		t.Fatalf("verifyMessagePoP() error = %v", err)
+	}
+	other := newTestPoP(t)
+	if err := verifyMessagePoP(other.PublicKey[:], pop.ProofOfPossession); err == nil {
+		t.Fatal("verifyMessagePoP() expected error for a PoP from another key")
+	}
+}
+
+func TestFetchNodePoP(t *testing.T) {
+	ctx := context.Background()
+	nodeID := ids.GenerateTestNodeID()
+	pop := newTestPoP(t)
+	nodePoP, err := json.Marshal(pop)
+	if err != nil {
+		t.Fatalf("json.Marshal(pop) error = %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"nodeID":%q,"nodePOP":%s}}`, nodeID, nodePoP)
+	}))
+	defer srv.Close()
+
+	gotNodeID, gotPoP, err := fetchNodePoP(ctx, srv.URL)
+	if err != nil {
+		t.Fatalf("fetchNodePoP() error = %v", err)
+	}
+	if gotNodeID != nodeID || gotPoP != pop.ProofOfPossession {
+		t.Fatalf("fetchNodePoP() = (%s, %x), want (%s, %x)", gotNodeID, gotPoP, nodeID, pop.ProofOfPossession)
+	}
+
+	noPoP := newNoPoPInfoServer(t, nodeID)
+	if _, _, err := fetchNodePoP(ctx, noPoP.URL); err == nil || !strings.Contains(err.Error(), "--pop") {
+		t.Fatalf("fetchNodePoP() error = %v, want a missing-PoP error pointing at --pop", err)
+	}
+}
+
+// blockingReader fails the test if a prompt tries to read from it; it stands
+// in for a CI stdin that never delivers input.
+type blockingReader struct{ t *testing.T }
+
+func (r blockingReader) Read([]byte) (int, error) {
+	r.t.Fatal("prompt read from non-interactive stdin")
+	return 0, nil
+}
+
+func TestConfirmKeyChange(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	tests := []struct {
+		name        string
+		assumeYes   bool
+		env         string
+		interactive bool
+		input       string
+		want        bool
+		wantErr     bool
+	}{
+		{name: "--yes", assumeYes: true, want: true},
+		{name: "env", env: "1", want: true},
+		{name: "env false", env: "false", wantErr: true},
+		{name: "non-interactive", wantErr: true},
+		{name: "confirmed", interactive: true, input: "yes\n", want: true},
+		{name: "declined", interactive: true, input: "no\n"},
+		{name: "eof", interactive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			t.Setenv(assumeYesEnvVar, tt.env)
+			var in io.Reader = strings.NewReader(tt.input)
+			if !tt.interactive {
+				in = blockingReader{t}
+			}
+			got, err := confirmKeyChange(in, tt.interactive, "deleting key \"k\"")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmKeyChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("confirmKeyChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignalCancelEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		flag bool
+		env  string
+		want bool
+	}{
+		{name: "default", want: true},
+		{name: "flag", flag: true, want: false},
+		{name: "env", env: "1", want: false},
+		{name: "env false", env: "false", want: true},
+		{name: "env garbage", env: "maybe", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(noSignalCancelEnvVar, tt.env)
+			if got := (Options{NoSignalCancel: tt.flag}).signalCancelEnabled(); got != tt.want {
+				t.Fatalf("signalCancelEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmPlaintextExport(t *testing.T) {
+	origAssumeYes := assumeYes
+	defer func() { assumeYes = origAssumeYes }()
+
+	tests := []struct {
+		name        string
+		assumeYes   bool
+		interactive bool
+		input       string
+		want        bool
+		wantErr     bool
+	}{
+		{name: "--yes", assumeYes: true, want: true},
+		{name: "non-interactive", wantErr: true},
+		{name: "key name typed", interactive: true, input: "mykey\n", want: true},
+		{name: "yes is not the key name", interactive: true, input: "yes\n"},
+		{name: "name is case-sensitive", interactive: true, input: "MyKey\n"},
+		{name: "eof", interactive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes = tt.assumeYes
+			t.Setenv(assumeYesEnvVar, "")
+			var in io.Reader = strings.NewReader(tt.input)
+			if !tt.interactive {
+				in = blockingReader{t}
+			}
+			got, err := confirmPlaintextExport(in, tt.interactive, "mykey")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmPlaintextExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("confirmPlaintextExport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFromKeyName(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	ks, err := keystore.Load()
+	if err != nil {
+		t.Fatalf("keystore.Load() error = %v", err)
+	}
+	keyCopy := make([]byte, len(ewoqPrivateKey))
+	copy(keyCopy, ewoqPrivateKey)
+	if err := ks.ImportKey("ops", keyCopy, nil); err != nil {
+		t.Fatalf("ImportKey() error = %v", err)
+	}
+	entry, _ := ks.GetKey("ops")
+	addr, err := ids.ShortFromString(entry.PChainAddress)
+	if err != nil {
+		t.Fatalf("ShortFromString() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		from    string
+		want    string
+		wantErr bool
+	}{
+		{name: "key name", from: "ops", want: "ops"},
+		{name: "built-in ewoq", from: "ewoq", want: "ewoq"},
+		{name: "short ID", from: entry.PChainAddress, want: "ops"},
+		{name: "bech32", from: wallet.FormatPChainAddress(addr, constants.FujiID), want: "ops"},
+		{name: "EVM address", from: strings.ToLower(entry.EVMAddress), want: "ops"},
+		{name: "unknown address", from: ids.GenerateTestShortID().String(), wantErr: true},
+		{name: "unknown name", from: "nope", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveFromKeyName(tt.from)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveFromKeyName(%q) = %q, want error", tt.from, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveFromKeyName(%q) error = %v", tt.from, err)
+			}
+			if got != tt.want {
+				t.Fatalf("resolveFromKeyName(%q) = %q, want %q", tt.from, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// The helpers below parse ID-valued flags so that every command reports a
+// missing or malformed ID the same way: "--<flag> is required" or
+// "invalid --<flag> "<value>": <reason>". [flag] is the flag name without
+// dashes.
+
+// parseIDFlag parses a CB58 ID (subnet, chain, tx, VM, validation ID).
+func parseIDFlag(flag, value string) (ids.ID, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ids.Empty, withExitCode(exitUsage, fmt.Errorf("--%s is required", flag))
+	}
+	id, err := ids.FromString(value)
+	if err != nil {
+		return ids.Empty, invalidIDFlagError(flag, value, err)
+	}
+	return id, nil
+}
+
+// parseNodeIDFlag parses a NodeID-... value.
+func parseNodeIDFlag(flag, value string) (ids.NodeID, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ids.EmptyNodeID, withExitCode(exitUsage, fmt.Errorf("--%s is required", flag))
+	}
+	nodeID, err := ids.NodeIDFromString(value)
+	if err != nil {
+		return ids.EmptyNodeID, invalidIDFlagError(flag, value, err)
+	}
+	return nodeID, nil
+}
+
+// parseShortIDFlag parses a CB58 short ID such as a raw P-Chain address.
+func parseShortIDFlag(flag, value string) (ids.ShortID, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ids.ShortEmpty, withExitCode(exitUsage, fmt.Errorf("--%s is required", flag))
+	}
+	id, err := ids.ShortFromString(value)
+	if err != nil {
+		return ids.ShortEmpty, invalidIDFlagError(flag, value, err)
+	}
+	return id, nil
+}
+
+func invalidIDFlagError(flag, value string, err error) error {
+	return withExitCode(exitUsage, fmt.Errorf("invalid --%s %q: %w", flag, value, err))
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestParseIDFlag(t *testing.T) {
+	id := ids.GenerateTestID()
+
+	got, err := parseIDFlag("subnet-id", " "+id.String()+" ")
+	if err != nil {
+		t.Fatalf("parseIDFlag() error = %v", err)
+	}
+	if got != id {
+		t.Fatalf("parseIDFlag() = %s, want %s", got, id)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "empty", value: "", wantErr: "--subnet-id is required"},
+		{name: "whitespace", value: "  ", wantErr: "--subnet-id is required"},
+		{name: "malformed", value: "abc", wantErr: `invalid --subnet-id "abc": `},
+		{name: "node ID", value: ids.GenerateTestNodeID().String(), wantErr: "invalid --subnet-id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIDFlag("subnet-id", tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseIDFlag(%q) error = %v, want containing %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseNodeIDFlag(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+
+	got, err := parseNodeIDFlag("node-id", nodeID.String())
+	if err != nil {
+		t.Fatalf("parseNodeIDFlag() error = %v", err)
+	}
+	if got != nodeID {
+		t.Fatalf("parseNodeIDFlag() = %s, want %s", got, nodeID)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "empty", value: "", wantErr: "--node-id is required"},
+		{name: "missing prefix", value: nodeID.String()[len(ids.NodeIDPrefix):], wantErr: "invalid --node-id"},
+		{name: "malformed", value: "NodeID-xyz", wantErr: `invalid --node-id "NodeID-xyz": `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseNodeIDFlag("node-id", tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseNodeIDFlag(%q) error = %v, want containing %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseShortIDFlag(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+
+	got, err := parseShortIDFlag("to", addr.String())
+	if err != nil {
+		t.Fatalf("parseShortIDFlag() error = %v", err)
+	}
+	if got != addr {
+		t.Fatalf("parseShortIDFlag() = %s, want %s", got, addr)
+	}
+
+	if _, err := parseShortIDFlag("to", ""); err == nil || err.Error() != "--to is required" {
+		t.Fatalf("parseShortIDFlag(empty) error = %v, want --to is required", err)
+	}
+	if _, err := parseShortIDFlag("to", "P-avax1xyz"); err == nil || !strings.Contains(err.Error(), `invalid --to "P-avax1xyz": `) {
+		t.Fatalf("parseShortIDFlag(malformed) error = %v", err)
+	}
+}
@@ -1,15 +1,22 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"crypto/subtle"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
 	"github.com/ava-labs/platform-cli/pkg/wallet"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -22,15 +29,34 @@ func clearBytes(b []byte) {
 	}
 }
 
+const (
+	// maxKeyFileSize bounds what --key-file will read; a hex key with a 0x
+	// prefix and trailing newline is well under this.
+	maxKeyFileSize = 1024
+
+	// keyFileOtherReadPerm are the permission bits that let users other than
+	// the owner read a key file.
+	keyFileOtherReadPerm = 0o044
+)
+
 var (
 	// keys flags
-	keyName         string
-	keyEncrypt      bool
-	keyFormat       string
-	keyForce        bool
-	showAddrs       bool
-	keyExportUnsafe bool
-	keyExportFile   string
+	keyName          string
+	keyEncrypt       bool
+	keyFormat        string
+	keyForce         bool
+	keyFile          string
+	keyReplace       bool
+	keyAckPlaintext  bool
+	showAddrs        bool
+	showBalances     bool
+	keyListSort      string
+	keyListFilter    string
+	keyListEncOnly   bool
+	keyListPlainOnly bool
+	keyExportUnsafe  bool
+	keyExportFile    string
+	keyDryRun        bool
 )
 
 var keysCmd = &cobra.Command{
@@ -42,11 +68,12 @@ Keys can be stored encrypted (with a password) or unencrypted.
 Encrypted keys use Argon2id for key derivation and AES-256-GCM for encryption.
 
 Subcommands:
-  import    Import a private key
-  generate  Generate a new random key
-  list      List all stored keys
-  export    Export a key (show private key)
-  delete    Remove a stored key`,
+  import             Import a private key
+  generate           Generate a new random key
+  list               List all stored keys
+  export             Export a key (show private key)
+  export-descriptor  Export a watch-only descriptor (public key only)
+  delete             Remove a stored key`,
 	RunE: requireSubcommand,
 }
 
@@ -55,22 +82,62 @@ var keysImportCmd = &cobra.Command{
 	Short: "Import a private key",
 	Long: `Import a private key into the keystore.
 
-If --private-key is not provided, you will be prompted to enter it (hidden input).
-Keys are encrypted by default. Use --encrypt=false to store unencrypted keys (unsafe).
+The key is read from --key-file, --private-key or AVALANCHE_PRIVATE_KEY, in that
+order; otherwise you will be prompted to enter it (hidden input). Prefer
+--key-file over --private-key, which is visible in process listings.
+Keys are encrypted by default. Use --encrypt=false --i-understand-unencrypted to
+store an unencrypted key (unsafe; for test and dev keys only).
 When encryption is enabled, set PLATFORM_CLI_KEY_PASSWORD for non-interactive use
 or follow the password prompt.
 
+Use --replace to overwrite an existing key of the same name. The existing key is
+only replaced after the new key has been parsed and encrypted, and it keeps its
+default status and creation date. Replacing asks for confirmation unless --force
+is set.
+
+Importing the well-known ewoq test key prints a warning and asks for
+confirmation unless --force is set. The key is tagged as a test key: keys list
+marks it "TEST KEY", and it is not made the default key unless set with
+'keys default --force'.
+
+Use --dry-run to check a key before storing it: the key is parsed and its
+P-Chain and EVM addresses printed, then it is discarded. Nothing is written to
+the keystore, so --name and a password are not needed.
+
 Examples:
+  platform-cli keys import --dry-run --key-file ./mykey.txt
   platform-cli keys import --name mykey --private-key "PrivateKey-..."
   platform-cli keys import --name mykey
-  platform-cli keys import --name mykey --encrypt=false`,
+  platform-cli keys import --name mykey --key-file ./mykey.txt
+  platform-cli keys import --name mykey --encrypt=false --i-understand-unencrypted
+  platform-cli keys import --name mykey --replace`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if keyFile != "" && privateKey != "" {
+			return fmt.Errorf("use either --key-file or --private-key, not both")
+		}
+		if keyDryRun {
+			keyBytes, err := readImportKey()
+			if err != nil {
+				return err
+			}
+			defer clearBytes(keyBytes)
+			if err := previewKey(keyBytes); err != nil {
+				return err
+			}
+			if isEwoqKey(keyBytes) {
+				printWarning("%s", ewoqImportWarning)
+			}
+			return nil
+		}
 		if keyName == "" {
 			return fmt.Errorf("--name is required")
 		}
 		if err := keystore.ValidateKeyName(keyName); err != nil {
 			return err
 		}
+		if err := checkUnencryptedKeyPolicy(keyEncrypt, keyAckPlaintext); err != nil {
+			return err
+		}
 
 		ks, err := keystore.Load()
 		if err != nil {
@@ -78,34 +145,45 @@ Examples:
 		}
 
 		// Check if key already exists
-		if ks.HasKey(keyName) {
-			return fmt.Errorf("key %q already exists. Use a different name or delete the existing key first", keyName)
+		replacing := ks.HasKey(keyName)
+		if replacing && !keyReplace {
+			return fmt.Errorf("key %q already exists. Use a different name or --replace to overwrite it", keyName)
 		}
-
-		// Get private key
-		keyStr := privateKey
-		if keyStr == "" {
-			keyStr = os.Getenv("AVALANCHE_PRIVATE_KEY")
-		}
-		if keyStr == "" {
-			// Prompt for key (hidden input)
-			fmt.Print("Enter private key: ")
-			inputBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
-			fmt.Println()
+		if replacing && !keyForce {
+			fmt.Printf("Key %q already exists and will be overwritten. The old key cannot be recovered without a backup.\n", keyName)
+			confirmed, err := confirmKeyChange(os.Stdin, stdinIsTerminal(), "replacing key "+strconv.Quote(keyName))
 			if err != nil {
-				return fmt.Errorf("failed to read private key: %w", err)
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Import cancelled.")
+				return nil
 			}
-			keyStr = string(inputBytes)
-			clearBytes(inputBytes)
 		}
 
-		keyBytes, err := wallet.ParsePrivateKey(keyStr)
+		// Get private key
+		keyBytes, err := readImportKey()
 		if err != nil {
-			return fmt.Errorf("invalid private key: %w", err)
+			return err
 		}
 		// Clear key bytes when done
 		defer clearBytes(keyBytes)
 
+		testKey := isEwoqKey(keyBytes)
+		if testKey {
+			printWarning("%s", ewoqImportWarning)
+			if !keyForce {
+				confirmed, err := confirmKeyChange(os.Stdin, stdinIsTerminal(), "importing the ewoq test key")
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Println("Import cancelled.")
+					return nil
+				}
+			}
+		}
+
 		// Get password if encrypting
 		var password []byte
 		if keyEncrypt {
@@ -123,48 +201,90 @@ Examples:
 			}
 			defer clearBytes(password)
 		} else {
-			fmt.Fprintln(os.Stderr, "WARNING: storing key unencrypted; anyone with access to ~/.platform/keys/ can read it")
+			printWarning("WARNING: storing key unencrypted; anyone with access to ~/.platform/keys/ can read it")
 		}
 
+		// A test key is stored without becoming the default it would
+		// otherwise have been: the first key, or the default it replaces.
+		wouldBeDefault := ks.KeyCount() == 0 || (replacing && ks.GetDefault() == keyName)
+
 		// Import the key
-		if err := ks.ImportKey(keyName, keyBytes, password); err != nil {
+		importKey, replaceKey := ks.ImportKey, ks.ReplaceKey
+		if testKey {
+			importKey, replaceKey = ks.ImportTestKey, ks.ReplaceTestKey
+		}
+		if replacing {
+			if err := replaceKey(keyName, keyBytes, password); err != nil {
+				return err
+			}
+		} else if err := importKey(keyName, keyBytes, password); err != nil {
 			return err
 		}
 
 		entry, _ := ks.GetKey(keyName)
-		fmt.Printf("Key imported successfully!\n")
+		if replacing {
+			fmt.Printf("Key replaced successfully!\n")
+		} else {
+			fmt.Printf("Key imported successfully!\n")
+		}
 		fmt.Printf("  Name:          %s\n", keyName)
 		fmt.Printf("  P-Chain:       %s\n", entry.PChainAddress)
 		fmt.Printf("  EVM:           %s\n", entry.EVMAddress)
 		fmt.Printf("  Encrypted:     %v\n", entry.Encrypted)
+		if entry.TestKey {
+			fmt.Printf("  Test key:      yes (ewoq)\n")
+		}
 
 		if ks.GetDefault() == keyName {
 			fmt.Printf("  Default:       yes\n")
+		} else if testKey && wouldBeDefault {
+			fmt.Printf("Not kept as the default key because it is a test key; use 'platform-cli keys default --name %s --force' to make it the default.\n", keyName)
 		}
 
 		return nil
 	},
 }
 
+// ewoqImportWarning is printed when the ewoq test key is imported.
+const ewoqImportWarning = "WARNING: this is the well-known ewoq test key. Its private key is public, so anyone can " +
+	"spend funds sent to its addresses. Use it only on local test networks."
+
 var keysGenerateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate a new random key",
 	Long: `Generate a new random secp256k1 private key.
 
-Keys are encrypted by default. Use --encrypt=false to store unencrypted keys (unsafe).
+Keys are encrypted by default. Use --encrypt=false --i-understand-unencrypted to
+store an unencrypted key (unsafe; for test and dev keys only).
 When encryption is enabled, set PLATFORM_CLI_KEY_PASSWORD for non-interactive use
 or follow the password prompt.
 
+With --dry-run, a key is generated and its addresses printed, but it is then
+discarded without being stored.
+
 Examples:
   platform-cli keys generate --name mykey
-  platform-cli keys generate --name mykey --encrypt=false`,
+  platform-cli keys generate --name mykey --encrypt=false --i-understand-unencrypted
+  platform-cli keys generate --dry-run`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if keyDryRun {
+			key, err := secp256k1.NewPrivateKey()
+			if err != nil {
+				return fmt.Errorf("failed to generate key: %w", err)
+			}
+			keyBytes := key.Bytes()
+			defer clearBytes(keyBytes)
+			return previewKey(keyBytes)
+		}
 		if keyName == "" {
 			return fmt.Errorf("--name is required")
 		}
 		if err := keystore.ValidateKeyName(keyName); err != nil {
 			return err
 		}
+		if err := checkUnencryptedKeyPolicy(keyEncrypt, keyAckPlaintext); err != nil {
+			return err
+		}
 
 		ks, err := keystore.Load()
 		if err != nil {
@@ -193,7 +313,7 @@ Examples:
 			}
 			defer clearBytes(password)
 		} else {
-			fmt.Fprintln(os.Stderr, "WARNING: storing key unencrypted; anyone with access to ~/.platform/keys/ can read it")
+			printWarning("WARNING: storing key unencrypted; anyone with access to ~/.platform/keys/ can read it")
 		}
 
 		// Generate the key
@@ -224,16 +344,138 @@ Examples:
 	},
 }
 
+// Orders accepted by `keys list --sort`.
+const (
+	keySortName    = "name"
+	keySortCreated = "created"
+	keySortDefault = "default"
+)
+
+// sortKeyEntries orders entries for `keys list`. Every order falls back to
+// the name, so ties (keys created in the same second, every non-default key)
+// list the same way each time.
+func sortKeyEntries(entries []keystore.KeyEntry, order, defaultKey string) error {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	switch order {
+	case keySortName:
+	case keySortCreated:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		})
+	case keySortDefault:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name == defaultKey && entries[j].Name != defaultKey
+		})
+	default:
+		return fmt.Errorf("invalid --sort %q: must be %s, %s or %s", order, keySortName, keySortCreated, keySortDefault)
+	}
+	return nil
+}
+
+// filterKeyEntries keeps the entries whose name, P-Chain address or EVM
+// address contains substr (ignoring case), optionally narrowed to encrypted
+// or unencrypted keys.
+func filterKeyEntries(entries []keystore.KeyEntry, substr string, encryptedOnly, unencryptedOnly bool) []keystore.KeyEntry {
+	substr = strings.ToLower(strings.TrimSpace(substr))
+	kept := entries[:0]
+	for _, e := range entries {
+		if encryptedOnly && !e.Encrypted || unencryptedOnly && e.Encrypted {
+			continue
+		}
+		if substr != "" &&
+			!strings.Contains(strings.ToLower(e.Name), substr) &&
+			!strings.Contains(strings.ToLower(e.PChainAddress), substr) &&
+			!strings.Contains(strings.ToLower(e.EVMAddress), substr) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// checkUnencryptedKeyPolicy gates --encrypt=false. Plaintext keys are meant
+// for throwaway test and dev keys, so they need --i-understand-unencrypted,
+// and PLATFORM_CLI_REQUIRE_ENCRYPTION forbids them outright on machines that
+// hold real funds.
+func checkUnencryptedKeyPolicy(encrypt, acknowledged bool) error {
+	if encrypt {
+		return nil
+	}
+	if v, err := strconv.ParseBool(os.Getenv(requireEncryptionEnvVar)); err == nil && v {
+		return fmt.Errorf("unencrypted keys are disabled by %s; drop --encrypt=false", requireEncryptionEnvVar)
+	}
+	if !acknowledged {
+		return fmt.Errorf("--encrypt=false stores the private key in plaintext; pass --i-understand-unencrypted to confirm (use only for test and dev keys)")
+	}
+	return nil
+}
+
+// maxBalanceWorkers bounds concurrent balance queries for `keys list --balances`.
+const maxBalanceWorkers = 4
+
+// balanceFetcher returns the P-Chain balance, in nAVAX, of an address.
+type balanceFetcher func(ctx context.Context, addr ids.ShortID) (uint64, error)
+
+// fetchKeyBalances queries the balance of every entry concurrently using a
+// bounded worker pool. The result is aligned with entries; keys whose address
+// can't be parsed or queried get "n/a" rather than failing the whole listing.
+func fetchKeyBalances(ctx context.Context, entries []keystore.KeyEntry, fetch balanceFetcher) []string {
+	results := make([]string, len(entries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := min(maxBalanceWorkers, len(entries))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = "n/a"
+				addr, err := ids.ShortFromString(entries[i].PChainAddress)
+				if err != nil {
+					continue
+				}
+				balance, err := fetch(ctx, addr)
+				if err != nil {
+					continue
+				}
+				results[i] = pchain.FormatAVAX(balance)
+			}
+		}()
+	}
+
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
 var keysListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all stored keys",
 	Long: `List all keys stored in the keystore.
 
 Use --show-addresses to display P-Chain and EVM addresses.
+Use --balances to query each key's unlocked P-Chain balance on the selected
+network. Balances are read by address, so encrypted keys are never decrypted.
+Keys whose balance can't be fetched show "n/a".
+
+--sort orders keys by name (the default), by creation time, or with the
+default key first. --filter keeps keys whose name, P-Chain address or EVM
+address contains the given text (case-insensitive). --encrypted-only and
+--unencrypted-only narrow the list to keys with or without a password, e.g.
+to audit which keys are stored in plaintext.
 
 Examples:
   platform-cli keys list
-  platform-cli keys list --show-addresses`,
+  platform-cli keys list --show-addresses
+  platform-cli keys list --sort created --filter validator
+  platform-cli keys list --unencrypted-only
+  platform-cli keys list --balances --network fuji`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ks, err := keystore.Load()
 		if err != nil {
@@ -246,43 +488,62 @@ Examples:
 			return nil
 		}
 
-		// Sort by name
-		sort.Slice(entries, func(i, j int) bool {
-			return entries[i].Name < entries[j].Name
-		})
-
 		defaultKey := ks.GetDefault()
+		if err := sortKeyEntries(entries, keyListSort, defaultKey); err != nil {
+			return err
+		}
+		entries = filterKeyEntries(entries, keyListFilter, keyListEncOnly, keyListPlainOnly)
+		if len(entries) == 0 {
+			fmt.Println("No keys match the given filters.")
+			return nil
+		}
+
+		var balances []string
+		if showBalances {
+			ctx, cancel := getOperationContext()
+			defer cancel()
+
+			ctx, netConfig, err := getNetworkConfig(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get network config: %w", err)
+			}
+			balances = fetchKeyBalances(ctx, entries, func(ctx context.Context, addr ids.ShortID) (uint64, error) {
+				return wallet.GetAddressBalance(ctx, netConfig, addr)
+			})
+		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
+		header := "NAME\tENCRYPTED\tDEFAULT"
 		if showAddrs {
-			fmt.Fprintln(w, "NAME\tENCRYPTED\tDEFAULT\tP-CHAIN\tEVM\tCREATED")
-			for _, e := range entries {
-				isDefault := ""
-				if e.Name == defaultKey {
-					isDefault = "*"
-				}
-				encrypted := "no"
-				if e.Encrypted {
-					encrypted = "yes"
-				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-					e.Name, encrypted, isDefault, e.PChainAddress, e.EVMAddress, e.CreatedAt.Format("2006-01-02"))
+			header += "\tP-CHAIN\tEVM"
+		}
+		if showBalances {
+			header += "\tBALANCE (AVAX)"
+		}
+		fmt.Fprintln(w, header+"\tCREATED")
+
+		for i, e := range entries {
+			isDefault := ""
+			if e.Name == defaultKey {
+				isDefault = "*"
 			}
-		} else {
-			fmt.Fprintln(w, "NAME\tENCRYPTED\tDEFAULT\tCREATED")
-			for _, e := range entries {
-				isDefault := ""
-				if e.Name == defaultKey {
-					isDefault = "*"
-				}
-				encrypted := "no"
-				if e.Encrypted {
-					encrypted = "yes"
-				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-					e.Name, encrypted, isDefault, e.CreatedAt.Format("2006-01-02"))
+			encrypted := "no"
+			if e.Encrypted {
+				encrypted = "yes"
+			}
+			name := e.Name
+			if e.TestKey {
+				name += " (TEST KEY)"
+			}
+			row := fmt.Sprintf("%s\t%s\t%s", name, encrypted, isDefault)
+			if showAddrs {
+				row += fmt.Sprintf("\t%s\t%s", e.PChainAddress, e.EVMAddress)
 			}
+			if showBalances {
+				row += "\t" + balances[i]
+			}
+			fmt.Fprintln(w, row+"\t"+e.CreatedAt.Format("2006-01-02"))
 		}
 
 		w.Flush()
@@ -298,14 +559,17 @@ var keysExportCmd = &cobra.Command{
 	Long: `Export a key.
 
 Secure default: write to --output-file with permissions 0600.
-If you really need stdout output, you must pass --unsafe-stdout.
+If you really need stdout output, you must pass --unsafe-stdout, and then
+type the key name to confirm (after the password prompt, for encrypted keys).
+--yes or PLATFORM_CLI_ASSUME_YES=1 skips the confirmation for scripts.
 
 If the key is encrypted, you will be prompted for the password.
 
 Examples:
   platform-cli keys export --name mykey --output-file ./mykey.txt
   platform-cli keys export --name mykey --format hex --output-file ./mykey.hex
-  platform-cli keys export --name mykey --unsafe-stdout`,
+  platform-cli keys export --name mykey --unsafe-stdout
+  platform-cli keys export --name mykey --unsafe-stdout --yes`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if keyName == "" {
 			return fmt.Errorf("--name is required")
@@ -359,6 +623,15 @@ Examples:
 			return fmt.Errorf("refusing to print private key to stdout without --unsafe-stdout (or use --output-file)")
 		}
 
+		confirmed, err := confirmPlaintextExport(os.Stdin, stdinIsTerminal(), keyName)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, "Export cancelled.")
+			return nil
+		}
+
 		fmt.Println(exported)
 		return nil
 	},
@@ -394,15 +667,14 @@ Examples:
 		// Confirm deletion
 		if !keyForce {
 			fmt.Printf("Are you sure you want to delete key %q? This cannot be undone.\n", keyName)
-			fmt.Print("Type 'yes' to confirm: ")
-
-			reader := bufio.NewReader(os.Stdin)
-			response, err := reader.ReadString('\n')
+			confirmed, err := confirmOrAbort("Type 'yes' to confirm: ")
+			if errors.Is(err, errNotInteractive) {
+				return fmt.Errorf("deleting key %q: %w; or pass --force", keyName, err)
+			}
 			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
+				return err
 			}
-
-			if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			if !confirmed {
 				fmt.Println("Deletion cancelled.")
 				return nil
 			}
@@ -417,13 +689,38 @@ Examples:
 	},
 }
 
+// confirmKeyChange asks the user to type "yes" before a destructive keystore
+// change. --yes and PLATFORM_CLI_ASSUME_YES answer for them (as does --force,
+// checked by the caller).
+func confirmKeyChange(in io.Reader, interactive bool, action string) (bool, error) {
+	confirmed, err := confirmPrompt(in, interactive, os.Stdout, "Type 'yes' to confirm: ", confirmYes)
+	if errors.Is(err, errNotInteractive) {
+		return false, fmt.Errorf("%s needs confirmation but stdin is not a terminal; re-run with --force (or set %s=1)", action, assumeYesEnvVar)
+	}
+	return confirmed, err
+}
+
+// confirmPlaintextExport asks the user to type the key's name before its
+// private key is shown in the terminal, where it may be seen over a shoulder
+// or on a shared screen. The prompt goes to stderr, away from the key on
+// stdout.
+func confirmPlaintextExport(in io.Reader, interactive bool, name string) (bool, error) {
+	confirmed, err := confirmPrompt(in, interactive, os.Stderr,
+		"This will display your PRIVATE KEY in plaintext. Type the key name to confirm: ", name)
+	if errors.Is(err, errNotInteractive) {
+		return false, fmt.Errorf("printing key %q needs confirmation but stdin is not a terminal; re-run with --yes (or set %s=1)", name, assumeYesEnvVar)
+	}
+	return confirmed, err
+}
+
 var keysDefaultCmd = &cobra.Command{
 	Use:   "default",
 	Short: "Set or show the default key",
 	Long: `Set or show the default key.
 
 When no --name is provided, shows the current default key.
-When --name is provided, sets that key as the default.
+When --name is provided, sets that key as the default. A key tagged as a test
+key, such as an imported ewoq key, needs --force.
 
 Examples:
   platform-cli keys default
@@ -450,6 +747,10 @@ Examples:
 			return err
 		}
 
+		if entry, ok := ks.GetKey(keyName); ok && entry.TestKey && !keyForce {
+			return withExitCode(exitUsage, fmt.Errorf("key %q is a well-known test key with no security; pass --force to make it the default anyway", keyName))
+		}
+
 		if err := ks.SetDefault(keyName); err != nil {
 			return err
 		}
@@ -495,6 +796,82 @@ func promptPassword(confirm bool) ([]byte, error) {
 	return password, nil
 }
 
+// readImportKey reads the private key for `keys import` from --key-file,
+// --private-key, AVALANCHE_PRIVATE_KEY or a hidden prompt, in that order.
+// The caller must clear the returned bytes.
+func readImportKey() ([]byte, error) {
+	if keyFile != "" {
+		return readKeyFile(keyFile)
+	}
+	keyStr := privateKey
+	if keyStr == "" {
+		keyStr = os.Getenv(privateKeyEnvVar)
+	}
+	if keyStr == "" {
+		// Prompt for key (hidden input)
+		fmt.Print("Enter private key: ")
+		inputBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		keyStr = string(inputBytes)
+		clearBytes(inputBytes)
+	}
+
+	keyBytes, err := wallet.ParsePrivateKey(keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return keyBytes, nil
+}
+
+// previewKey prints the addresses keyBytes derives for --dry-run. Nothing is
+// written: the caller discards and clears the key afterwards.
+func previewKey(keyBytes []byte) error {
+	if _, err := wallet.ToPrivateKey(keyBytes); err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	pAddr, evmAddr := wallet.DeriveAddresses(keyBytes)
+
+	fmt.Println("Dry run: the key was not stored.")
+	fmt.Printf("  P-Chain:       %s\n", pAddr)
+	fmt.Printf("  EVM:           %s\n", evmAddr)
+	return nil
+}
+
+// readKeyFile reads and parses a private key (CB58, PrivateKey-CB58, WIF or hex)
+// from path, clearing the raw file contents afterwards. A key file readable
+// by group or others draws a warning.
+func readKeyFile(path string) ([]byte, error) {
+	path = strings.TrimSpace(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("key file %q is not a regular file", path)
+	}
+	if info.Size() > maxKeyFileSize {
+		return nil, fmt.Errorf("key file %q is too large to hold a private key (%d bytes)", path, info.Size())
+	}
+	if perm := info.Mode().Perm(); perm&keyFileOtherReadPerm != 0 {
+		printWarning("WARNING: key file %s is readable by other users (mode %04o); restrict it with: chmod 600 %s", path, perm, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	defer clearBytes(data)
+
+	keyBytes, err := wallet.ParsePrivateKey(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in %s: %w", path, err)
+	}
+	return keyBytes, nil
+}
+
 // writeSensitiveExportFile writes exported private key material to disk
 // with restrictive permissions, even when overwriting an existing file.
 func writeSensitiveExportFile(path string, value string) error {
@@ -523,13 +900,26 @@ func init() {
 	// Import flags
 	keysImportCmd.Flags().StringVar(&keyName, "name", "", "Name for the key (required)")
 	keysImportCmd.Flags().BoolVar(&keyEncrypt, "encrypt", true, "Encrypt the key with a password (default true)")
+	keysImportCmd.Flags().BoolVar(&keyAckPlaintext, "i-understand-unencrypted", false, "Acknowledge that --encrypt=false stores the key in plaintext")
+	keysImportCmd.Flags().BoolVar(&keyReplace, "replace", false, "Overwrite an existing key with the same name")
+	keysImportCmd.Flags().BoolVar(&keyForce, "force", false, "Skip confirmation prompts when replacing a key or importing the ewoq test key")
+	keysImportCmd.Flags().StringVar(&keyFile, "key-file", "", "Read the private key from this file (CB58, WIF or hex; should be mode 0600)")
+	keysImportCmd.Flags().BoolVar(&keyDryRun, "dry-run", false, "Print the key's addresses without storing it")
 
 	// Generate flags
 	keysGenerateCmd.Flags().StringVar(&keyName, "name", "", "Name for the key (required)")
 	keysGenerateCmd.Flags().BoolVar(&keyEncrypt, "encrypt", true, "Encrypt the key with a password (default true)")
+	keysGenerateCmd.Flags().BoolVar(&keyAckPlaintext, "i-understand-unencrypted", false, "Acknowledge that --encrypt=false stores the key in plaintext")
+	keysGenerateCmd.Flags().BoolVar(&keyDryRun, "dry-run", false, "Print a new key's addresses and discard it without storing it")
 
 	// List flags
 	keysListCmd.Flags().BoolVar(&showAddrs, "show-addresses", false, "Show P-Chain and EVM addresses")
+	keysListCmd.Flags().BoolVar(&showBalances, "balances", false, "Show each key's P-Chain balance on the selected network")
+	keysListCmd.Flags().StringVar(&keyListSort, "sort", keySortName, "Order keys by name, created or default (default key first)")
+	keysListCmd.Flags().StringVar(&keyListFilter, "filter", "", "Only list keys whose name or address contains this text")
+	keysListCmd.Flags().BoolVar(&keyListEncOnly, "encrypted-only", false, "Only list password-protected keys")
+	keysListCmd.Flags().BoolVar(&keyListPlainOnly, "unencrypted-only", false, "Only list keys stored without a password")
+	keysListCmd.MarkFlagsMutuallyExclusive("encrypted-only", "unencrypted-only")
 
 	// Export flags
 	keysExportCmd.Flags().StringVar(&keyName, "name", "", "Name of the key to export (required)")
@@ -543,4 +933,5 @@ func init() {
 
 	// Default flags
 	keysDefaultCmd.Flags().StringVar(&keyName, "name", "", "Name of the key to set as default")
+	keysDefaultCmd.Flags().BoolVar(&keyForce, "force", false, "Allow a test key, such as ewoq, to be the default")
 }
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+var keyDescriptorFile string
+
+var keysExportDescriptorCmd = &cobra.Command{
+	Use:   "export-descriptor",
+	Short: "Export a watch-only descriptor (public key and addresses)",
+	Long: `Export a watch-only descriptor for a stored key: its public key and the
+P-Chain and EVM addresses derived from it, as JSON. The descriptor holds no
+private key material and can be shared with monitoring systems.
+
+The key is decrypted only to derive its public key, so an encrypted key
+prompts for its password (or reads PLATFORM_CLI_KEY_PASSWORD).
+
+Watch a descriptor's balance with:
+  platform-cli wallet balance --descriptor <file>
+
+Examples:
+  platform-cli keys export-descriptor --name mykey
+  platform-cli keys export-descriptor --name mykey --output-file mykey.descriptor.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if keyName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if err := keystore.ValidateKeyName(keyName); err != nil {
+			return err
+		}
+
+		keyBytes, err := loadFromKeystore(keyName)
+		if err != nil {
+			return err
+		}
+		defer clearBytes(keyBytes)
+		key, err := wallet.ToPrivateKey(keyBytes)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(wallet.NewDescriptor(key.PublicKey(), ""), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode descriptor: %w", err)
+		}
+		data = append(data, '\n')
+
+		out := strings.TrimSpace(keyDescriptorFile)
+		if out == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		// Descriptors are public, so they share the report file mode.
+		if err := os.WriteFile(out, data, reportFilePerm); err != nil {
+			return fmt.Errorf("failed to write descriptor: %w", err)
+		}
+		fmt.Printf("Descriptor for key %q written to %s\n", keyName, out)
+		return nil
+	},
+}
+
+func init() {
+	keysCmd.AddCommand(keysExportDescriptorCmd)
+
+	keysExportDescriptorCmd.Flags().StringVar(&keyName, "name", "", "Name of the key to describe (required)")
+	keysExportDescriptorCmd.Flags().StringVar(&keyDescriptorFile, "output-file", "", "Write the descriptor to this file instead of stdout")
+}
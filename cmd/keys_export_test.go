@@ -17,6 +17,8 @@ func TestKeysExportUsesEnvPassword(t *testing.T) {
 
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("PLATFORM_CLI_KEY_PASSWORD", testPassword)
+	// Printing to stdout asks for the key name; answer it as a script would.
+	t.Setenv(assumeYesEnvVar, "1")
 
 	ks, err := keystore.Load()
 	if err != nil {
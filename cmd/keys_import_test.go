@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+)
+
+func TestKeysImportFromKeyFile(t *testing.T) {
+	const testKeyName = "from-file"
+
+	t.Setenv("HOME", t.TempDir())
+
+	path := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(path, []byte("  PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	origKeyName, origKeyFile, origKeyEncrypt, origAck, origForce := keyName, keyFile, keyEncrypt, keyAckPlaintext, keyForce
+	defer func() {
+		keyName, keyFile, keyEncrypt, keyAckPlaintext, keyForce = origKeyName, origKeyFile, origKeyEncrypt, origAck, origForce
+	}()
+	keyName = testKeyName
+	keyFile = path
+	keyEncrypt = false
+	keyAckPlaintext = true
+	keyForce = false
+	t.Setenv(assumeYesEnvVar, "")
+
+	// The ewoq key needs confirmation, which a test cannot give.
+	if err := keysImportCmd.RunE(keysImportCmd, nil); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("keys import of ewoq without --force error = %v, want confirmation error", err)
+	}
+
+	keyForce = true
+	if err := keysImportCmd.RunE(keysImportCmd, nil); err != nil {
+		t.Fatalf("keys import --key-file error = %v", err)
+	}
+
+	ks, err := keystore.Load()
+	if err != nil {
+		t.Fatalf("keystore.Load() error = %v", err)
+	}
+	entry, ok := ks.GetKey(testKeyName)
+	if !ok {
+		t.Fatalf("key %q not imported", testKeyName)
+	}
+	wantAddr, _ := wallet.DeriveAddresses(ewoqPrivateKey)
+	if entry.PChainAddress != wantAddr {
+		t.Fatalf("imported P-Chain address = %s, want %s", entry.PChainAddress, wantAddr)
+	}
+	if !entry.TestKey {
+		t.Error("imported ewoq key is not tagged as a test key")
+	}
+	if ks.GetDefault() != "" {
+		t.Errorf("default key = %q, want none for a lone test key", ks.GetDefault())
+	}
+
+	// Making it the default takes --force.
+	keyForce = false
+	if err := keysDefaultCmd.RunE(keysDefaultCmd, nil); err == nil || exitCode(err) != exitUsage {
+		t.Fatalf("keys default for a test key without --force error = %v, want usage error", err)
+	}
+	keyForce = true
+	if err := keysDefaultCmd.RunE(keysDefaultCmd, nil); err != nil {
+		t.Fatalf("keys default --force error = %v", err)
+	}
+	if ks, err = keystore.Load(); err != nil {
+		t.Fatalf("keystore.Load() error = %v", err)
+	}
+	if ks.GetDefault() != testKeyName {
+		t.Fatalf("default key after --force = %q, want %q", ks.GetDefault(), testKeyName)
+	}
+}
+
+func TestReadKeyFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	garbage := filepath.Join(dir, "garbage.txt")
+	if err := os.WriteFile(garbage, []byte("not a key\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	huge := filepath.Join(dir, "huge.txt")
+	if err := os.WriteFile(huge, make([]byte, maxKeyFileSize+1), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	for _, path := range []string{garbage, huge, filepath.Join(dir, "missing.txt"), dir} {
+		if _, err := readKeyFile(path); err == nil {
+			t.Errorf("readKeyFile(%q) expected error", path)
+		}
+	}
+}
+
+func TestCheckUnencryptedKeyPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		encrypt      bool
+		acknowledged bool
+		requireEnv   string
+		wantErr      bool
+	}{
+		{name: "encrypted", encrypt: true},
+		{name: "encrypted with policy", encrypt: true, requireEnv: "1"},
+		{name: "unencrypted without acknowledgment", wantErr: true},
+		{name: "unencrypted acknowledged", acknowledged: true},
+		{name: "policy overrides acknowledgment", acknowledged: true, requireEnv: "true", wantErr: true},
+		{name: "policy disabled", acknowledged: true, requireEnv: "false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(requireEncryptionEnvVar, tt.requireEnv)
+			err := checkUnencryptedKeyPolicy(tt.encrypt, tt.acknowledged)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkUnencryptedKeyPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeysImportUnencryptedNeedsAcknowledgment(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	origKeyName, origKeyEncrypt, origAck := keyName, keyEncrypt, keyAckPlaintext
+	defer func() { keyName, keyEncrypt, keyAckPlaintext = origKeyName, origKeyEncrypt, origAck }()
+	keyName = "plaintext"
+	keyEncrypt = false
+	keyAckPlaintext = false
+
+	if err := keysImportCmd.RunE(keysImportCmd, nil); err == nil {
+		t.Fatal("keys import --encrypt=false without --i-understand-unencrypted expected error")
+	}
+	ks, err := keystore.Load()
+	if err != nil {
+		t.Fatalf("keystore.Load() error = %v", err)
+	}
+	if ks.HasKey("plaintext") {
+		t.Fatal("key was imported despite the missing acknowledgment")
+	}
+}
+
+func TestKeysDryRunWritesNothing(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(path, []byte("PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	origKeyName, origKeyFile, origDryRun := keyName, keyFile, keyDryRun
+	defer func() { keyName, keyFile, keyDryRun = origKeyName, origKeyFile, origDryRun }()
+	keyName = ""
+	keyFile = path
+	keyDryRun = true
+
+	if err := keysImportCmd.RunE(keysImportCmd, nil); err != nil {
+		t.Fatalf("keys import --dry-run error = %v", err)
+	}
+	if err := keysGenerateCmd.RunE(keysGenerateCmd, nil); err != nil {
+		t.Fatalf("keys generate --dry-run error = %v", err)
+	}
+
+	entries, err := os.ReadDir(home)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("dry run wrote %d entries to HOME, want none", len(entries))
+	}
+}
+
+func TestKeysImportDryRunInvalidKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	origKeyFile, origDryRun := keyFile, keyDryRun
+	defer func() { keyFile, keyDryRun = origKeyFile, origDryRun }()
+	keyFile = ""
+	keyDryRun = true
+	t.Setenv(privateKeyEnvVar, "0x1234")
+
+	if err := keysImportCmd.RunE(keysImportCmd, nil); err == nil {
+		t.Fatal("keys import --dry-run with a short key expected error")
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+)
+
+func TestFetchKeyBalances(t *testing.T) {
+	funded := ids.GenerateTestShortID()
+	failing := ids.GenerateTestShortID()
+	empty := ids.GenerateTestShortID()
+
+	entries := []keystore.KeyEntry{
+		{Name: "funded", PChainAddress: funded.String()},
+		{Name: "failing", PChainAddress: failing.String()},
+		{Name: "bad-address", PChainAddress: "not-an-address"},
+		{Name: "empty", PChainAddress: empty.String()},
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	fetch := func(_ context.Context, addr ids.ShortID) (uint64, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			cur := maxInFlight.Load()
+			if n <= cur || maxInFlight.CompareAndSwap(cur, n) {
+				break
+			}
+		}
+
+		switch addr {
+		case funded:
+			return 1_500_000_000, nil
+		case failing:
+			return 0, errors.New("rpc unavailable")
+		default:
+			return 0, nil
+		}
+	}
+
+	got := fetchKeyBalances(context.Background(), entries, fetch)
+	want := []string{"1.5", "n/a", "n/a", "0"}
+	if len(got) != len(want) {
+		t.Fatalf("fetchKeyBalances() returned %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("fetchKeyBalances()[%d] (%s) = %q, want %q", i, entries[i].Name, got[i], want[i])
+		}
+	}
+	if maxInFlight.Load() > maxBalanceWorkers {
+		t.Errorf("fetchKeyBalances() ran %d concurrent queries, want at most %d", maxInFlight.Load(), maxBalanceWorkers)
+	}
+}
+
+func keyEntryNames(entries []keystore.KeyEntry) []string {
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name
+	}
+	return names
+}
+
+func TestSortKeyEntries(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEntries := func() []keystore.KeyEntry {
+		return []keystore.KeyEntry{
+			{Name: "carol", CreatedAt: day},
+			{Name: "alice", CreatedAt: day.Add(time.Hour)},
+			{Name: "bob", CreatedAt: day},
+			{Name: "dave", CreatedAt: day.Add(-time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		order   string
+		want    []string
+		wantErr bool
+	}{
+		{order: keySortName, want: []string{"alice", "bob", "carol", "dave"}},
+		{order: keySortCreated, want: []string{"dave", "bob", "carol", "alice"}},
+		{order: keySortDefault, want: []string{"carol", "alice", "bob", "dave"}},
+		{order: "size", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			entries := newEntries()
+			err := sortKeyEntries(entries, tt.order, "carol")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sortKeyEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := keyEntryNames(entries); !slices.Equal(got, tt.want) {
+				t.Fatalf("sortKeyEntries(%q) = %v, want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterKeyEntries(t *testing.T) {
+	newEntries := func() []keystore.KeyEntry {
+		return []keystore.KeyEntry{
+			{Name: "validator-1", Encrypted: true, PChainAddress: "AbC123", EVMAddress: "0xDEAD"},
+			{Name: "validator-2", PChainAddress: "xyz", EVMAddress: "0xbeef"},
+			{Name: "treasury", Encrypted: true, PChainAddress: "qrs", EVMAddress: "0xcafe"},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		substr          string
+		encryptedOnly   bool
+		unencryptedOnly bool
+		want            []string
+	}{
+		{name: "no filter", want: []string{"validator-1", "validator-2", "treasury"}},
+		{name: "name", substr: "VALIDATOR", want: []string{"validator-1", "validator-2"}},
+		{name: "p-chain address", substr: "abc", want: []string{"validator-1"}},
+		{name: "evm address", substr: "0xBEEF", want: []string{"validator-2"}},
+		{name: "encrypted only", encryptedOnly: true, want: []string{"validator-1", "treasury"}},
+		{name: "unencrypted only", unencryptedOnly: true, want: []string{"validator-2"}},
+		{name: "combined", substr: "validator", encryptedOnly: true, want: []string{"validator-1"}},
+		{name: "no match", substr: "nope", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := keyEntryNames(filterKeyEntries(newEntries(), tt.substr, tt.encryptedOnly, tt.unencryptedOnly))
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("filterKeyEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/spf13/cobra"
+)
+
+// Supported values for `keys report --format`.
+const (
+	reportFormatText     = "text"
+	reportFormatMarkdown = "md"
+)
+
+// reportFilePerm is the mode for report files. Reports hold no secrets and
+// are meant to be shared.
+const reportFilePerm = 0o644
+
+var (
+	keyReportOut    string
+	keyReportFormat string
+)
+
+// keyReport is the data rendered by `keys report`. It only carries index
+// metadata; key material is never loaded.
+type keyReport struct {
+	Generated  time.Time           `json:"generated"`
+	Path       string              `json:"keystore"`
+	DefaultKey string              `json:"defaultKey,omitempty"`
+	Entries    []keystore.KeyEntry `json:"keys"`
+}
+
+var keysReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write a shareable inventory of stored keys",
+	Long: `Write a summary of the keystore for audits: key names, addresses,
+encryption status, creation dates and the default key, plus the keystore path
+and total count. No private key material is read or included.
+
+The report is printed to stdout unless --out is given. --output json or
+--output yaml writes the same data in machine-readable form instead of
+--format.
+
+Examples:
+  platform-cli keys report
+  platform-cli keys report --out keys-report.txt
+  platform-cli keys report --format md --out keys-report.md
+  platform-cli keys report --output yaml --out keys.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if keyReportFormat != reportFormatText && keyReportFormat != reportFormatMarkdown {
+			return fmt.Errorf("invalid --format %q: must be %q or %q", keyReportFormat, reportFormatText, reportFormatMarkdown)
+		}
+		if wantStructured() && cmd.Flags().Changed("format") {
+			return fmt.Errorf("use either --format or --output %s, not both", outputFormat)
+		}
+
+		ks, err := keystore.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load keystore: %w", err)
+		}
+
+		entries := ks.ListKeys()
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		report := keyReport{
+			Generated:  time.Now().UTC(),
+			Path:       ks.Path(),
+			DefaultKey: ks.GetDefault(),
+			Entries:    entries,
+		}
+
+		var buf bytes.Buffer
+		switch {
+		case wantStructured():
+			data, err := marshalStructured(report)
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+		case keyReportFormat == reportFormatMarkdown:
+			writeKeyReportMarkdown(&buf, report)
+		default:
+			writeKeyReportText(&buf, report)
+		}
+
+		out := strings.TrimSpace(keyReportOut)
+		if out == "" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(out, buf.Bytes(), reportFilePerm); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Fprintf(progressWriter(), "Report for %d key(s) written to %s\n", len(entries), out)
+		return nil
+	},
+}
+
+func writeKeyReportText(w io.Writer, r keyReport) {
+	fmt.Fprintln(w, "Platform CLI Key Inventory")
+	fmt.Fprintf(w, "Generated:   %s\n", r.Generated.Format(time.RFC3339))
+	fmt.Fprintf(w, "Keystore:    %s\n", r.Path)
+	fmt.Fprintf(w, "Total keys:  %d\n", len(r.Entries))
+	fmt.Fprintf(w, "Default key: %s\n", reportDefaultKey(r.DefaultKey))
+
+	if len(r.Entries) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tENCRYPTED\tDEFAULT\tP-CHAIN (SHORT ID)\tEVM\tCREATED")
+	for _, e := range r.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Name, yesNo(e.Encrypted), reportDefaultMark(e.Name, r.DefaultKey, "*"),
+			e.PChainAddress, e.EVMAddress, e.CreatedAt.Format(time.RFC3339))
+	}
+	tw.Flush()
+}
+
+func writeKeyReportMarkdown(w io.Writer, r keyReport) {
+	fmt.Fprintln(w, "# Platform CLI Key Inventory")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Generated:** %s\n", r.Generated.Format(time.RFC3339))
+	fmt.Fprintf(w, "- **Keystore:** `%s`\n", r.Path)
+	fmt.Fprintf(w, "- **Total keys:** %d\n", len(r.Entries))
+	fmt.Fprintf(w, "- **Default key:** %s\n", reportDefaultKey(r.DefaultKey))
+
+	if len(r.Entries) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Name | Encrypted | Default | P-Chain (short ID) | EVM | Created |")
+	fmt.Fprintln(w, "|------|-----------|---------|--------------------|-----|---------|")
+	for _, e := range r.Entries {
+		fmt.Fprintf(w, "| %s | %s | %s | `%s` | `%s` | %s |\n",
+			e.Name, yesNo(e.Encrypted), reportDefaultMark(e.Name, r.DefaultKey, "yes"),
+			e.PChainAddress, e.EVMAddress, e.CreatedAt.Format(time.RFC3339))
+	}
+}
+
+func reportDefaultKey(name string) string {
+	if name == "" {
+		return "(none)"
+	}
+	return name
+}
+
+func reportDefaultMark(name, defaultKey, mark string) string {
+	if name == defaultKey {
+		return mark
+	}
+	return ""
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func init() {
+	keysCmd.AddCommand(keysReportCmd)
+
+	keysReportCmd.Flags().StringVar(&keyReportOut, "out", "", "Write the report to this file instead of stdout")
+	keysReportCmd.Flags().StringVar(&keyReportFormat, "format", reportFormatText, "Report format: text or md")
+}
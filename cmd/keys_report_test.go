@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+)
+
+func testKeyReport() keyReport {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return keyReport{
+		Generated:  time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+		Path:       "/home/op/.platform/keys",
+		DefaultKey: "validator",
+		Entries: []keystore.KeyEntry{
+			{Name: "treasury", Encrypted: false, PChainAddress: "addrTreasury", EVMAddress: "0xTreasury", CreatedAt: created},
+			{Name: "validator", Encrypted: true, PChainAddress: "addrValidator", EVMAddress: "0xValidator", CreatedAt: created},
+		},
+	}
+}
+
+func TestWriteKeyReportText(t *testing.T) {
+	var buf bytes.Buffer
+	writeKeyReportText(&buf, testKeyReport())
+	out := buf.String()
+
+	for _, want := range []string{
+		"Generated:   2025-06-01T09:30:00Z",
+		"Keystore:    /home/op/.platform/keys",
+		"Total keys:  2",
+		"Default key: validator",
+		"addrTreasury",
+		"0xValidator",
+		"2025-03-01T12:00:00Z",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text report missing %q:\n%s", want, out)
+		}
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "validator" && (fields[1] != "yes" || fields[2] != "*") {
+			t.Errorf("validator row = %q, want encrypted and default", line)
+		}
+	}
+}
+
+func TestWriteKeyReportMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	writeKeyReportMarkdown(&buf, testKeyReport())
+	out := buf.String()
+
+	for _, want := range []string{
+		"# Platform CLI Key Inventory",
+		"- **Keystore:** `/home/op/.platform/keys`",
+		"- **Total keys:** 2",
+		"| treasury | no |  | `addrTreasury` | `0xTreasury` | 2025-03-01T12:00:00Z |",
+		"| validator | yes | yes | `addrValidator` | `0xValidator` | 2025-03-01T12:00:00Z |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteKeyReport_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	writeKeyReportText(&buf, keyReport{Path: "/tmp/keys"})
+	out := buf.String()
+
+	if !strings.Contains(out, "Total keys:  0") || !strings.Contains(out, "Default key: (none)") {
+		t.Errorf("empty report missing summary:\n%s", out)
+	}
+	if strings.Contains(out, "NAME") {
+		t.Errorf("empty report should not print a table:\n%s", out)
+	}
+}
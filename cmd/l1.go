@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/platform-cli/pkg/node"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +21,7 @@ var (
 	l1Balance      float64
 	l1Message      string
 	l1PoP          string
+	l1PoPFromNode  string
 )
 
 var l1Cmd = &cobra.Command{
@@ -26,7 +34,11 @@ var l1Cmd = &cobra.Command{
 var l1RegisterValidatorCmd = &cobra.Command{
 	Use:   "register-validator",
 	Short: "Register a new L1 validator (RegisterL1ValidatorTx)",
-	Long:  `Register a new validator on an L1 blockchain.`,
+	Long: `Register a new validator on an L1 blockchain.
+
+The BLS proof of possession is given with --pop, or fetched from the
+validator node's /ext/info endpoint with --pop-from-node. The node must be the
+one named in --message.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -34,8 +46,8 @@ var l1RegisterValidatorCmd = &cobra.Command{
 		if l1Message == "" {
 			return fmt.Errorf("--message is required (hex-encoded Warp message)")
 		}
-		if l1PoP == "" {
-			return fmt.Errorf("--pop is required (hex-encoded BLS proof of possession)")
+		if l1PoP == "" && l1PoPFromNode == "" {
+			return fmt.Errorf("--pop (hex-encoded BLS proof of possession) or --pop-from-node is required")
 		}
 		if l1Balance <= 0 {
 			return fmt.Errorf("--balance is required and must be positive")
@@ -46,19 +58,48 @@ var l1RegisterValidatorCmd = &cobra.Command{
 			return fmt.Errorf("invalid message: %w", err)
 		}
 
-		popBytes, err := decodeHexExactLength(l1PoP, bls.SignatureLen)
-		if err != nil {
-			return fmt.Errorf("invalid PoP: %w", err)
-		}
-
 		var pop [bls.SignatureLen]byte
-		copy(pop[:], popBytes)
+		if l1PoP != "" {
+			popBytes, err := decodeHexExactLength(l1PoP, bls.SignatureLen)
+			if err != nil {
+				return fmt.Errorf("invalid PoP: %w", err)
+			}
+			copy(pop[:], popBytes)
+		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
 
+		parsed, err := pchain.ParseRegisterL1ValidatorMessage(message, netConfig.NetworkID, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		if l1PoPFromNode != "" {
+			nodeID, nodePoP, err := fetchNodePoP(ctx, l1PoPFromNode)
+			if err != nil {
+				return err
+			}
+			if nodeID != parsed.NodeID {
+				return fmt.Errorf("--pop-from-node %s is %s, but --message registers %s", l1PoPFromNode, nodeID, parsed.NodeID)
+			}
+			fmt.Fprintf(progressWriter(), "Fetched BLS proof of possession for %s from %s\n", nodeID, l1PoPFromNode)
+			pop = nodePoP
+		}
+		if err := verifyMessagePoP(parsed.BLSPublicKey, pop); err != nil {
+			return err
+		}
+		if err := confirmL1Message(os.Stdin, stdinIsTerminal(), os.Stdout, []string{
+			"Subnet ID:     " + parsed.SubnetID.String(),
+			"Node ID:       " + parsed.NodeID.String(),
+			"Validation ID: " + parsed.ValidationID.String(),
+			fmt.Sprintf("Weight:        %d", parsed.Weight),
+			"Expiry:        " + parsed.Expiry.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+
 		w, cleanup, err := loadPChainWallet(ctx, netConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
@@ -70,12 +111,21 @@ var l1RegisterValidatorCmd = &cobra.Command{
 			return fmt.Errorf("invalid balance: %w", err)
 		}
 
+		if err := checkFunds(ctx, w, balanceNAVAX, "as L1 validator balance"); err != nil {
+			return err
+		}
+
 		txID, err := pchain.RegisterL1Validator(ctx, w, balanceNAVAX, pop, message)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Printf("Register L1 Validator TX: %s\n", txID)
+		fmt.Printf("Validation ID: %s\n", parsed.ValidationID)
 		return nil
 	},
 }
@@ -97,22 +147,42 @@ var l1SetWeightCmd = &cobra.Command{
 			return fmt.Errorf("invalid message: %w", err)
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
 
+		parsed, err := pchain.ParseL1ValidatorWeightMessage(message, netConfig.NetworkID)
+		if err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		if err := confirmL1Message(os.Stdin, stdinIsTerminal(), os.Stdout, []string{
+			"Validation ID: " + parsed.ValidationID.String(),
+			fmt.Sprintf("Nonce:         %d", parsed.Nonce),
+			fmt.Sprintf("Weight:        %d", parsed.Weight),
+		}); err != nil {
+			return err
+		}
+
 		w, cleanup, err := loadPChainWallet(ctx, netConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
 		}
 		defer cleanup()
 
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
+			return err
+		}
+
 		txID, err := pchain.SetL1ValidatorWeight(ctx, w, message)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Printf("Set L1 Validator Weight TX: %s\n", txID)
 		return nil
 	},
@@ -133,12 +203,12 @@ var l1AddBalanceCmd = &cobra.Command{
 			return fmt.Errorf("--balance is required and must be positive")
 		}
 
-		validationID, err := ids.FromString(l1ValidationID)
+		validationID, err := parseIDFlag("validation-id", l1ValidationID)
 		if err != nil {
-			return fmt.Errorf("invalid validation ID: %w", err)
+			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -154,11 +224,19 @@ var l1AddBalanceCmd = &cobra.Command{
 			return fmt.Errorf("invalid balance: %w", err)
 		}
 
+		if err := checkFunds(ctx, w, balanceNAVAX, "as L1 validator balance"); err != nil {
+			return err
+		}
+
 		txID, err := pchain.IncreaseL1ValidatorBalance(ctx, w, validationID, balanceNAVAX)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Printf("Increase L1 Validator Balance TX: %s\n", txID)
 		return nil
 	},
@@ -176,12 +254,12 @@ var l1DisableValidatorCmd = &cobra.Command{
 			return fmt.Errorf("--validation-id is required")
 		}
 
-		validationID, err := ids.FromString(l1ValidationID)
+		validationID, err := parseIDFlag("validation-id", l1ValidationID)
 		if err != nil {
-			return fmt.Errorf("invalid validation ID: %w", err)
+			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -192,16 +270,79 @@ var l1DisableValidatorCmd = &cobra.Command{
 		}
 		defer cleanup()
 
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
+			return err
+		}
+
 		txID, err := pchain.DisableL1Validator(ctx, w, validationID)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Printf("Disable L1 Validator TX: %s\n", txID)
 		return nil
 	},
 }
 
+// fetchNodePoP reads a node's ID and BLS proof of possession from its
+// /ext/info endpoint.
+func fetchNodePoP(ctx context.Context, addr string) (ids.NodeID, [bls.SignatureLen]byte, error) {
+	var pop [bls.SignatureLen]byte
+	info, err := node.GetNodeInfoWithInsecureHTTP(ctx, addr, allowInsecureHTTP)
+	if err != nil {
+		return ids.EmptyNodeID, pop, fmt.Errorf("failed to get node info: %w", err)
+	}
+	nodeID, err := ids.NodeIDFromString(info.NodeID)
+	if err != nil {
+		return ids.EmptyNodeID, pop, fmt.Errorf("node %s returned an invalid node ID %q: %w", addr, info.NodeID, err)
+	}
+	if info.BLSProofOfPossession == "" {
+		return ids.EmptyNodeID, pop, fmt.Errorf("node %s (%s) returned no BLS proof of possession; pass it with --pop instead", addr, nodeID)
+	}
+	popBytes, err := decodeHexExactLength(info.BLSProofOfPossession, bls.SignatureLen)
+	if err != nil {
+		return ids.EmptyNodeID, pop, fmt.Errorf("node %s returned an invalid BLS proof of possession: %w", addr, err)
+	}
+	copy(pop[:], popBytes)
+	return nodeID, pop, nil
+}
+
+// verifyMessagePoP checks that pop is a valid proof of possession for the BLS
+// key named in a RegisterL1Validator message, so a mismatched --pop is caught
+// before the transaction is built.
+func verifyMessagePoP(publicKey []byte, pop [bls.SignatureLen]byte) error {
+	p := &signer.ProofOfPossession{ProofOfPossession: pop}
+	copy(p.PublicKey[:], publicKey)
+	if err := p.Verify(); err != nil {
+		return fmt.Errorf("--pop does not match the BLS public key in --message: %w", err)
+	}
+	return nil
+}
+
+// confirmL1Message shows what a Warp message authorizes and asks the user to
+// confirm it before the transaction is issued.
+func confirmL1Message(in io.Reader, interactive bool, out io.Writer, summary []string) error {
+	fmt.Fprintln(out, "Warp message:")
+	for _, line := range summary {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	confirmed, err := confirmPrompt(in, interactive, out, "Type 'yes' to submit this message: ", confirmYes)
+	if errors.Is(err, errNotInteractive) {
+		return fmt.Errorf("warp message must be confirmed; re-run with --yes to submit it")
+	}
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("warp message not confirmed; aborting")
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(l1Cmd)
 
@@ -213,8 +354,10 @@ func init() {
 	// Register validator flags
 	l1RegisterValidatorCmd.Flags().Float64Var(&l1Balance, "balance", 0, "Initial balance in AVAX for continuous fees (required, > 0)")
 	l1RegisterValidatorCmd.Flags().StringVar(&l1PoP, "pop", "", "BLS proof of possession (hex)")
+	l1RegisterValidatorCmd.Flags().StringVar(&l1PoPFromNode, "pop-from-node", "", "Fetch the BLS proof of possession from this node's /ext/info (IP, host:port or URI)")
 	l1RegisterValidatorCmd.Flags().StringVar(&l1Message, "message", "", "Warp message authorizing the validator (hex)")
 	_ = l1RegisterValidatorCmd.MarkFlagRequired("balance")
+	l1RegisterValidatorCmd.MarkFlagsMutuallyExclusive("pop", "pop-from-node")
 
 	// Set weight flags
 	l1SetWeightCmd.Flags().StringVar(&l1Message, "message", "", "Warp message authorizing the weight change (hex)")
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+// defaultRegisterExpiry is how long a built RegisterL1Validator message stays
+// valid when --expiry-in is not given. It leaves time to collect signatures
+// while staying well inside pchain.RegisterExpiryWindow.
+const defaultRegisterExpiry = time.Hour
+
+var (
+	l1MsgSubnetID       string
+	l1MsgNodeID         string
+	l1MsgBLSPublicKey   string
+	l1MsgWeight         uint64
+	l1MsgExpiryIn       time.Duration
+	l1MsgBalanceOwner   string
+	l1MsgDisableOwner   string
+	l1MsgManagerChainID string
+	l1MsgManagerAddress string
+	l1MsgSigners        string
+	l1MsgSignature      string
+)
+
+var l1BuildRegisterMessageCmd = &cobra.Command{
+	Use:   "build-register-message",
+	Short: "Build the Warp message for l1 register-validator",
+	Long: `Build the RegisterL1Validator Warp message that l1 register-validator
+takes as --message.
+
+The P-Chain only accepts this message if it comes from the L1's validator
+manager (the chain and contract address recorded by subnet convert-to-l1) and
+is signed by enough of the L1's validator weight. Normally the manager
+contract emits the message when registration is initiated on the L1, and
+the signatures are gathered by a signature aggregator. This command builds
+the same bytes from the registration parameters, so you can check them
+against what the contract emitted and attach the aggregate signature.
+
+Without --signers/--signature it prints the unsigned message and the
+validation ID the P-Chain will assign. With them it prints the signed
+message, ready for l1 register-validator --message.
+
+The manager chain and address are looked up from the subnet unless
+--manager-chain-id and --manager-address are given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		subnetID, err := parseIDFlag("subnet-id", l1MsgSubnetID)
+		if err != nil {
+			return err
+		}
+		nodeID, err := parseNodeIDFlag("node-id", l1MsgNodeID)
+		if err != nil {
+			return err
+		}
+		blsPublicKey, err := decodeHexExactLength(l1MsgBLSPublicKey, bls.PublicKeyLen)
+		if err != nil {
+			return fmt.Errorf("invalid --bls-public-key: %w", err)
+		}
+		if _, err := bls.PublicKeyFromCompressedBytes(blsPublicKey); err != nil {
+			return fmt.Errorf("invalid --bls-public-key: %w", err)
+		}
+		if l1MsgWeight == 0 {
+			return fmt.Errorf("--weight is required and must be positive")
+		}
+		if l1MsgExpiryIn <= 0 || l1MsgExpiryIn > pchain.RegisterExpiryWindow {
+			return fmt.Errorf("--expiry-in must be positive and at most %s", pchain.RegisterExpiryWindow)
+		}
+		if (l1MsgSigners == "") != (l1MsgSignature == "") {
+			return fmt.Errorf("--signers and --signature must be given together")
+		}
+		if (l1MsgManagerChainID == "") != (l1MsgManagerAddress == "") {
+			return fmt.Errorf("--manager-chain-id and --manager-address must be given together")
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		balanceOwner, disableOwner, err := parseL1MessageOwners(l1MsgBalanceOwner, l1MsgDisableOwner, netConfig.NetworkID)
+		if err != nil {
+			return err
+		}
+
+		var manager pchain.L1Manager
+		if l1MsgManagerChainID != "" {
+			manager.ChainID, err = parseIDFlag("manager-chain-id", l1MsgManagerChainID)
+			if err != nil {
+				return err
+			}
+			manager.Address, err = decodeHex(l1MsgManagerAddress)
+			if err != nil {
+				return fmt.Errorf("invalid --manager-address: %w", err)
+			}
+		} else {
+			manager, err = pchain.GetL1ManagerWithConfig(ctx, netConfig, subnetID)
+			if err != nil {
+				return err
+			}
+		}
+
+		req := pchain.RegisterL1ValidatorRequest{
+			SubnetID:              subnetID,
+			NodeID:                nodeID,
+			Expiry:                time.Now().Add(l1MsgExpiryIn).Truncate(time.Second),
+			RemainingBalanceOwner: balanceOwner,
+			DisableOwner:          disableOwner,
+			Weight:                l1MsgWeight,
+		}
+		copy(req.BLSPublicKey[:], blsPublicKey)
+
+		unsigned, validationID, err := pchain.NewRegisterL1ValidatorMessage(netConfig.NetworkID, manager, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(progressWriter(), "Manager:       %s / 0x%s\n", manager.ChainID, hex.EncodeToString(manager.Address))
+		fmt.Fprintf(progressWriter(), "Expiry:        %s\n", req.Expiry.UTC().Format(time.RFC3339))
+		fmt.Printf("Validation ID: %s\n", validationID)
+
+		if l1MsgSigners == "" {
+			fmt.Printf("Unsigned message: 0x%s\n", hex.EncodeToString(unsigned.Bytes()))
+			return nil
+		}
+
+		signers, err := decodeHex(l1MsgSigners)
+		if err != nil {
+			return fmt.Errorf("invalid --signers: %w", err)
+		}
+		sigBytes, err := decodeHexExactLength(l1MsgSignature, bls.SignatureLen)
+		if err != nil {
+			return fmt.Errorf("invalid --signature: %w", err)
+		}
+		var signature [bls.SignatureLen]byte
+		copy(signature[:], sigBytes)
+
+		signed, err := pchain.SignWarpMessage(unsigned, signers, signature)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Message: 0x%s\n", hex.EncodeToString(signed))
+		return nil
+	},
+}
+
+// parseL1MessageOwners parses --remaining-balance-owner and --disable-owner
+// and checks both against the address policy. No wallet signs the message,
+// so no address is exempt as the caller's own.
+func parseL1MessageOwners(balanceValue, disableValue string, networkID uint32) (message.PChainOwner, message.PChainOwner, error) {
+	balanceOwner, err := parsePChainOwner("remaining-balance-owner", balanceValue, networkID)
+	if err != nil {
+		return message.PChainOwner{}, message.PChainOwner{}, err
+	}
+	disableOwner, err := parsePChainOwner("disable-owner", disableValue, networkID)
+	if err != nil {
+		return message.PChainOwner{}, message.PChainOwner{}, err
+	}
+	if err := checkAddressPolicy("remaining balance owner", balanceOwner.Addresses, ids.ShortEmpty, networkID); err != nil {
+		return message.PChainOwner{}, message.PChainOwner{}, err
+	}
+	if err := checkAddressPolicy("disable owner", disableOwner.Addresses, ids.ShortEmpty, networkID); err != nil {
+		return message.PChainOwner{}, message.PChainOwner{}, err
+	}
+	return balanceOwner, disableOwner, nil
+}
+
+// parsePChainOwner parses a comma-separated list of P-Chain addresses into a
+// 1-of-N owner, sorted as the P-Chain requires. An empty list is rejected:
+// an owner without addresses could never reclaim the balance or disable the
+// validator.
+func parsePChainOwner(flag, value string, networkID uint32) (message.PChainOwner, error) {
+	var addrs []ids.ShortID
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if err := checkAddressNetwork(part, networkID); err != nil {
+			return message.PChainOwner{}, fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		addr, err := parsePChainAddress(part)
+		if err != nil {
+			return message.PChainOwner{}, fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		if slices.Contains(addrs, addr) {
+			return message.PChainOwner{}, fmt.Errorf("invalid --%s: duplicate address %q", flag, part)
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return message.PChainOwner{}, fmt.Errorf("--%s is required", flag)
+	}
+	slices.SortFunc(addrs, ids.ShortID.Compare)
+	return message.PChainOwner{Threshold: 1, Addresses: addrs}, nil
+}
+
+func init() {
+	l1Cmd.AddCommand(l1BuildRegisterMessageCmd)
+
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgSubnetID, "subnet-id", "", "L1 subnet ID")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgNodeID, "node-id", "", "Validator node ID")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgBLSPublicKey, "bls-public-key", "", "Validator BLS public key (hex)")
+	l1BuildRegisterMessageCmd.Flags().Uint64Var(&l1MsgWeight, "weight", 0, "Validator weight (required, > 0)")
+	l1BuildRegisterMessageCmd.Flags().DurationVar(&l1MsgExpiryIn, "expiry-in", defaultRegisterExpiry, "How long the message stays valid")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgBalanceOwner, "remaining-balance-owner", "", "P-Chain address(es) that receive the remaining balance, comma-separated")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgDisableOwner, "disable-owner", "", "P-Chain address(es) that may disable the validator, comma-separated")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgManagerChainID, "manager-chain-id", "", "Validator manager chain ID (default: looked up from the subnet)")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgManagerAddress, "manager-address", "", "Validator manager contract address (hex; default: looked up from the subnet)")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgSigners, "signers", "", "Signer bit set of the aggregate signature (hex)")
+	l1BuildRegisterMessageCmd.Flags().StringVar(&l1MsgSignature, "signature", "", "Aggregate BLS signature over the unsigned message (hex)")
+}
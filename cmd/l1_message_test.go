@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestParsePChainOwner(t *testing.T) {
+	a, b := ids.ShortID{0x02}, ids.ShortID{0x01}
+
+	got, err := parsePChainOwner("disable-owner", " "+a.String()+", "+b.String()+",", constants.FujiID)
+	if err != nil {
+		t.Fatalf("parsePChainOwner() error = %v", err)
+	}
+	if got.Threshold != 1 || len(got.Addresses) != 2 || got.Addresses[0] != b || got.Addresses[1] != a {
+		t.Fatalf("parsePChainOwner() = %+v, want 1-of-[%s %s]", got, b, a)
+	}
+
+	for name, value := range map[string]string{
+		"empty":     " , ",
+		"duplicate": a.String() + "," + a.String(),
+		"garbage":   "not-an-address",
+		"mainnet":   "P-avax1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+	} {
+		if _, err := parsePChainOwner("disable-owner", value, constants.FujiID); err == nil {
+			t.Fatalf("parsePChainOwner(%s) expected error", name)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+// networkInfo is the JSON shape of one entry in `network list --output json`.
+// Stake amounts are in nAVAX.
+type networkInfo struct {
+	Name              string `json:"name"`
+	NetworkID         uint32 `json:"networkID"`
+	HRP               string `json:"hrp"`
+	RPCURL            string `json:"rpcURL"`
+	MinValidatorStake uint64 `json:"minValidatorStake"`
+	MinDelegatorStake uint64 `json:"minDelegatorStake"`
+	MaxValidatorStake uint64 `json:"maxValidatorStake"`
+	MinStakeDuration  string `json:"minStakeDuration"`
+	MaxStakeDuration  string `json:"maxStakeDuration"`
+}
+
+func newNetworkInfo(config network.Config) networkInfo {
+	return networkInfo{
+		Name:              config.Name,
+		NetworkID:         config.NetworkID,
+		HRP:               network.GetHRP(config.NetworkID),
+		RPCURL:            config.RPCURL,
+		MinValidatorStake: config.MinValidatorStake,
+		MinDelegatorStake: config.MinDelegatorStake,
+		MaxValidatorStake: config.MaxValidatorStake,
+		MinStakeDuration:  config.MinStakeDuration.String(),
+		MaxStakeDuration:  config.MaxStakeDuration.String(),
+	}
+}
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Network information",
+	Long:  `Inspect the networks the CLI can connect to.`,
+	RunE:  requireSubcommand,
+}
+
+var networkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List networks selectable with --network",
+	Long: `List the built-in networks accepted by --network, with their RPC URLs,
+network IDs, address HRPs and staking minimums.
+
+Other networks (local, devnets) are reached with --rpc-url, optionally with
+--network-id.
+
+Examples:
+  platform-cli network list
+  platform-cli network list --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		builtin := network.Builtin()
+		infos := make([]networkInfo, 0, len(builtin))
+		for _, config := range builtin {
+			infos = append(infos, newNetworkInfo(config))
+		}
+
+		if wantStructured() {
+			return printStructured(infos)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tNETWORK ID\tHRP\tRPC URL\tMIN VALIDATOR STAKE\tMIN DELEGATOR STAKE\tMIN DURATION")
+		for _, n := range infos {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s AVAX\t%s AVAX\t%s\n",
+				n.Name, n.NetworkID, n.HRP, n.RPCURL,
+				pchain.FormatAVAX(n.MinValidatorStake), pchain.FormatAVAX(n.MinDelegatorStake), n.MinStakeDuration)
+		}
+		w.Flush()
+
+		fmt.Println("\nFor other networks, use --rpc-url <URL> [--network-id <ID>].")
+		return nil
+	},
+}
+
+var networkFeesCmd = &cobra.Command{
+	Use:   "fees",
+	Short: "Show the current P-Chain dynamic fee state",
+	Long: `Show the P-Chain dynamic fee state of the selected network: the current
+gas price, the price floor, gas capacity and excess, and the target gas rate,
+plus an estimate of what a simple send (one input, two outputs) would cost now.
+
+Gas prices are in nAVAX per unit of gas.
+
+Examples:
+  platform-cli network fees
+  platform-cli network fees --network mainnet --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		state, err := pchain.GetFeeState(ctx, network.NewPChainClient(ctx, netConfig))
+		if errors.Is(err, pchain.ErrDynamicFeesUnavailable) {
+			return fmt.Errorf("%s does not report dynamic fees (the node may predate Etna): %w", netConfig.RPCURL, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if wantStructured() {
+			return printStructured(state)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Gas price:\t%d nAVAX/gas\n", state.Price)
+		fmt.Fprintf(w, "Minimum price:\t%d nAVAX/gas\n", state.MinPrice)
+		fmt.Fprintf(w, "Gas capacity:\t%d\n", state.Capacity)
+		fmt.Fprintf(w, "Excess gas:\t%d\n", state.Excess)
+		fmt.Fprintf(w, "Target gas rate:\t%d gas/s (max %d gas/s)\n", state.TargetPerSecond, state.MaxPerSecond)
+		fmt.Fprintf(w, "Base tx fee:\t~%s AVAX (%d nAVAX)\n", pchain.FormatAVAX(state.BaseTxFee), state.BaseTxFee)
+		fmt.Fprintf(w, "As of:\t%s\n", state.Time.UTC().Format(time.RFC3339))
+		return w.Flush()
+	},
+}
+
+// hrpLookup is `network hrp --hrp`: the HRP to map back to a network ID.
+var hrpLookup string
+
+// networkHRP is the JSON shape of `network hrp --output json`.
+type networkHRP struct {
+	NetworkID uint32 `json:"networkID"`
+	Name      string `json:"name"`
+	HRP       string `json:"hrp"`
+	// Fallback is true when the network has no registered HRP and its
+	// addresses use the shared fallback HRP.
+	Fallback bool `json:"fallback"`
+}
+
+var networkHRPCmd = &cobra.Command{
+	Use:   "hrp",
+	Short: "Show the bech32 address HRP of a network, or the network of an HRP",
+	Long: `Show the Human-Readable Part (HRP) that prefixes a network's bech32 addresses,
+such as "fuji" in P-fuji1..., for the network selected with --network-id or
+--network. With --hrp, map an HRP back to its network ID instead.
+
+Networks without a registered HRP, such as most devnets, all use the fallback
+HRP "custom", so "custom" cannot be mapped back to a network. Nothing is sent
+to a node.
+
+Examples:
+  platform-cli network hrp --network-id 1
+  platform-cli network hrp --network fuji
+  platform-cli network hrp --hrp local --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networkID, err := resolveHRPLookup(
+			cmd.Flags().Changed("hrp"),
+			rootCmd.PersistentFlags().Changed("network-id"),
+			rootCmd.PersistentFlags().Changed("network"),
+		)
+		if err != nil {
+			return err
+		}
+
+		hrp := network.GetHRP(networkID)
+		result := networkHRP{
+			NetworkID: networkID,
+			Name:      constants.NetworkName(networkID),
+			HRP:       hrp,
+			Fallback:  hrp == constants.FallbackHRP,
+		}
+		if wantStructured() {
+			return printStructured(result)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Network ID:\t%d\n", result.NetworkID)
+		fmt.Fprintf(w, "Network:\t%s\n", result.Name)
+		fmt.Fprintf(w, "HRP:\t%s\n", result.HRP)
+		fmt.Fprintf(w, "P-Chain addresses:\tP-%s1...\n", result.HRP)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if result.Fallback {
+			fmt.Printf("\nNetwork %d has no registered HRP; its addresses use the fallback HRP %q.\n", networkID, hrp)
+		}
+		return nil
+	},
+}
+
+// resolveHRPLookup returns the network ID `network hrp` describes, from
+// whichever of --hrp, --network-id or --network was given. With none, the
+// default --network is used.
+func resolveHRPLookup(hrpSet, networkIDSet, networkSet bool) (uint32, error) {
+	given := 0
+	for _, set := range []bool{hrpSet, networkIDSet, networkSet} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return 0, withExitCode(exitUsage, fmt.Errorf("use only one of --hrp, --network-id or --network"))
+	}
+
+	switch {
+	case hrpSet:
+		id, ok := network.GetNetworkIDForHRP(hrpLookup)
+		if strings.EqualFold(hrpLookup, constants.FallbackHRP) {
+			return 0, withExitCode(exitUsage, fmt.Errorf("HRP %q is shared by every network without a registered HRP, so it does not identify one", constants.FallbackHRP))
+		}
+		if !ok {
+			return 0, withExitCode(exitUsage, fmt.Errorf("no known network uses HRP %q", hrpLookup))
+		}
+		return id, nil
+	case networkIDSet:
+		return customNetID, nil
+	default:
+		id, err := constants.NetworkID(networkName)
+		if err != nil {
+			return 0, withExitCode(exitUsage, fmt.Errorf("unknown --network %q: use a network name or --network-id", networkName))
+		}
+		return id, nil
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(networkCmd)
+	networkCmd.AddCommand(networkListCmd)
+	networkCmd.AddCommand(networkFeesCmd)
+	networkCmd.AddCommand(networkHRPCmd)
+
+	networkHRPCmd.Flags().StringVar(&hrpLookup, "hrp", "", "Map this HRP (e.g. fuji, avax, local) to its network ID")
+}
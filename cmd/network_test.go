@@ -0,0 +1,46 @@
+package cmd
+
+import "testing"
+
+func TestResolveHRPLookup(t *testing.T) {
+	origHRP, origID, origName := hrpLookup, customNetID, networkName
+	defer func() { hrpLookup, customNetID, networkName = origHRP, origID, origName }()
+
+	tests := []struct {
+		name                          string
+		hrp                           string
+		networkID                     uint32
+		network                       string
+		hrpSet, networkIDSet, nameSet bool
+		wantID                        uint32
+		wantErr                       bool
+	}{
+		{name: "default network", network: "fuji", wantID: 5},
+		{name: "network name", network: "mainnet", nameSet: true, wantID: 1},
+		{name: "numeric network", network: "network-99", nameSet: true, wantID: 99},
+		{name: "network ID", networkID: 12345, networkIDSet: true, wantID: 12345},
+		{name: "hrp", hrp: "Avax", hrpSet: true, wantID: 1},
+		{name: "fallback hrp", hrp: "custom", hrpSet: true, wantErr: true},
+		{name: "unknown hrp", hrp: "nope", hrpSet: true, wantErr: true},
+		{name: "unknown network", network: "bogus", nameSet: true, wantErr: true},
+		{name: "hrp and network ID", hrp: "fuji", networkID: 5, hrpSet: true, networkIDSet: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hrpLookup, customNetID, networkName = tt.hrp, tt.networkID, tt.network
+			got, err := resolveHRPLookup(tt.hrpSet, tt.networkIDSet, tt.nameSet)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveHRPLookup() = %d, want error", got)
+				}
+				if exitCode(err) != exitUsage {
+					t.Fatalf("exitCode() = %d, want %d", exitCode(err), exitUsage)
+				}
+				return
+			}
+			if err != nil || got != tt.wantID {
+				t.Fatalf("resolveHRPLookup() = %d, %v; want %d", got, err, tt.wantID)
+			}
+		})
+	}
+}
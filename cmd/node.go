@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/platform-cli/pkg/node"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var nodeCmd = &cobra.Command{
@@ -14,7 +18,12 @@ var nodeCmd = &cobra.Command{
 	RunE:  requireSubcommand,
 }
 
-var nodeIP string
+var (
+	nodeIP           string
+	nodeVerifyID     string
+	nodeVerifyPubKey string
+	nodeVerifyPoP    string
+)
 
 var nodeInfoCmd = &cobra.Command{
 	Use:   "info",
@@ -40,9 +49,74 @@ var nodeInfoCmd = &cobra.Command{
 	},
 }
 
+var nodeVerifyBLSCmd = &cobra.Command{
+	Use:   "verify-bls",
+	Short: "Check a BLS public key and proof of possession offline",
+	Long: `Verify that a BLS proof of possession was signed by the given BLS public
+key, without contacting any node. Use it to check validator data assembled by
+hand before passing it to 'subnet convert-to-l1' or 'validator add'.
+
+--node-id is optional; when given it is checked for a valid NodeID format. A
+node ID is derived from the node's TLS certificate, not its BLS key, so the
+pairing itself cannot be verified offline.
+
+Examples:
+  platform-cli node verify-bls --bls-public-key 0x... --bls-pop 0x...
+  platform-cli node verify-bls --node-id NodeID-... --bls-public-key 0x... --pop 0x...`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if nodeVerifyPubKey == "" || nodeVerifyPoP == "" {
+			return fmt.Errorf("--bls-public-key and --bls-pop are required")
+		}
+
+		nodeID, pop, err := verifyBLSTriple(nodeVerifyID, nodeVerifyPubKey, nodeVerifyPoP)
+		if err != nil {
+			return err
+		}
+
+		if nodeID != ids.EmptyNodeID {
+			fmt.Printf("Node ID:        %s\n", nodeID)
+		}
+		fmt.Printf("BLS Public Key: 0x%x\n", pop.PublicKey)
+		fmt.Println("Proof of possession is valid.")
+		return nil
+	},
+}
+
+// verifyBLSTriple parses an optional node ID and verifies the BLS proof of
+// possession. An empty nodeIDStr yields ids.EmptyNodeID.
+func verifyBLSTriple(nodeIDStr, pubKeyHex, popHex string) (ids.NodeID, *signer.ProofOfPossession, error) {
+	nodeID := ids.EmptyNodeID
+	if nodeIDStr = strings.TrimSpace(nodeIDStr); nodeIDStr != "" {
+		parsed, err := parseNodeIDFlag("node-id", nodeIDStr)
+		if err != nil {
+			return ids.EmptyNodeID, nil, err
+		}
+		nodeID = parsed
+	}
+	pop, err := parseManualPoP(pubKeyHex, popHex)
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+	return nodeID, pop, nil
+}
+
+// popFlagAlias lets verify-bls accept --pop as a short form of --bls-pop.
+func popFlagAlias(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "pop" {
+		name = "bls-pop"
+	}
+	return pflag.NormalizedName(name)
+}
+
 func init() {
 	rootCmd.AddCommand(nodeCmd)
 	nodeCmd.AddCommand(nodeInfoCmd)
+	nodeCmd.AddCommand(nodeVerifyBLSCmd)
 
 	nodeInfoCmd.Flags().StringVar(&nodeIP, "ip", "", "Node IP address")
+
+	nodeVerifyBLSCmd.Flags().StringVar(&nodeVerifyID, "node-id", "", "Node ID to check alongside the BLS key (optional)")
+	nodeVerifyBLSCmd.Flags().StringVar(&nodeVerifyPubKey, "bls-public-key", "", "BLS public key (hex)")
+	nodeVerifyBLSCmd.Flags().StringVar(&nodeVerifyPoP, "bls-pop", "", "BLS proof of possession signature (hex; alias --pop)")
+	nodeVerifyBLSCmd.Flags().SetNormalizeFunc(popFlagAlias)
 }
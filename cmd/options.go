@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	nodeutil "github.com/ava-labs/platform-cli/pkg/node"
+	"github.com/ava-labs/platform-cli/pkg/retry"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"golang.org/x/term"
+)
+
+// Options selects the network, the signing key and the time limit of an
+// operation. The cobra layer builds one from the global flags with
+// flagOptions; programs embedding this package can fill one in directly.
+// Methods also read the PLATFORM_CLI_* environment variables and the
+// --output flag, and print progress, warnings and password prompts to
+// stdout and stderr.
+type Options struct {
+	// Network is a built-in network name, e.g. "fuji" or "mainnet".
+	Network string
+	// NetworkSet marks Network as chosen explicitly. An explicit network
+	// cannot be combined with RPCURL (unless EndpointFromNetwork is set) and
+	// disables the PLATFORM_CLI_RPC_URL fallback.
+	NetworkSet bool
+	// RPCURL is a custom node endpoint.
+	RPCURL string
+	// FallbackRPCURLs are further endpoints for the same network, tried in
+	// order when RPCURL fails (see NetworkContext). Each must report the
+	// same network ID.
+	FallbackRPCURLs []string
+	// EndpointFromNetwork uses RPCURL as another endpoint for Network,
+	// keeping that network's ID and parameters.
+	EndpointFromNetwork bool
+	// NetworkID is the expected network ID for RPCURL; 0 asks the node.
+	NetworkID uint32
+	// SkipNetworkIDCheck trusts NetworkID without checking it against the
+	// node.
+	SkipNetworkIDCheck bool
+	// AllowInsecureHTTP allows plain HTTP to non-local endpoints.
+	AllowInsecureHTTP bool
+
+	// The signing key comes from the first of From, KeyName, PrivateKey, the
+	// keystore's default key and AVALANCHE_PRIVATE_KEY that is set, unless
+	// UseLedger selects the Ledger account at LedgerIndex.
+	From        string // keystore key name or address
+	KeyName     string // keystore key name
+	PrivateKey  string // private key in any format wallet.ParsePrivateKey reads
+	UseLedger   bool
+	LedgerIndex uint32
+
+	// Timeout bounds the operation; 0 uses PLATFORM_CLI_TIMEOUT or the
+	// default.
+	Timeout time.Duration
+	// NoSignalCancel ignores SIGINT/SIGTERM instead of cancelling the
+	// operation.
+	NoSignalCancel bool
+}
+
+// flagOptions returns the Options set by the global flags.
+func flagOptions() Options {
+	var rpcURL string
+	var fallbackRPCURLs []string
+	if len(customRPCURLs) > 0 {
+		rpcURL, fallbackRPCURLs = customRPCURLs[0], customRPCURLs[1:]
+	}
+	return Options{
+		Network:             networkName,
+		NetworkSet:          rootCmd.PersistentFlags().Changed("network"),
+		RPCURL:              rpcURL,
+		FallbackRPCURLs:     fallbackRPCURLs,
+		EndpointFromNetwork: endpointFromNet,
+		NetworkID:           customNetID,
+		SkipNetworkIDCheck:  skipNetworkIDCheck,
+		AllowInsecureHTTP:   allowInsecureHTTP,
+		From:                keyFrom,
+		KeyName:             keyNameGlobal,
+		PrivateKey:          privateKey,
+		UseLedger:           useLedger,
+		LedgerIndex:         ledgerIndex,
+		Timeout:             operationTimeout,
+		NoSignalCancel:      noSignalCancel,
+	}
+}
+
+// OperationContext returns a context with timeout and signal handling.
+// The context will be cancelled on SIGINT/SIGTERM or when the timeout expires.
+// With signal cancellation disabled, those signals are ignored instead and
+// only the timeout ends the operation; cancel restores their handling.
+// The returned cancel function must be called to release resources.
+func (o Options) OperationContext() (context.Context, context.CancelFunc) {
+	timeout := o.operationTimeout()
+
+	// Create context with timeout. Retry loops share one backoff budget
+	// sized from it, so their combined waits cannot outlast the command.
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx = retry.WithDeadlineBudget(ctx)
+
+	if !o.signalCancelEnabled() {
+		// Catching and dropping the signals rather than leaving the default
+		// handler, which would kill the process mid-submission. Unlike
+		// signal.Ignore, this is undone by cancel and leaves other
+		// subscribers alone.
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		return ctx, func() {
+			signal.Stop(sigChan)
+			cancel()
+		}
+	}
+
+	cancelOnSignal(ctx, cancel, "\nCancelling...")
+	return ctx, cancel
+}
+
+// WatchContext returns a context without a deadline for commands that run
+// until interrupted, such as wallet balance --watch; each poll sets its own
+// timeout from operationTimeout. SIGINT/SIGTERM cancel it quietly.
+func (o Options) WatchContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelOnSignal(ctx, cancel, "")
+	return ctx, cancel
+}
+
+// cancelOnSignal cancels ctx on SIGINT/SIGTERM, printing notice (if any) to
+// stderr first.
+func cancelOnSignal(ctx context.Context, cancel context.CancelFunc, notice string) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigChan:
+			if notice != "" {
+				fmt.Fprintln(os.Stderr, notice)
+			}
+			cancel()
+		case <-ctx.Done():
+			// Context cancelled or timed out, clean up signal handler
+		}
+		signal.Stop(sigChan)
+	}()
+}
+
+// operationTimeout resolves Timeout against PLATFORM_CLI_TIMEOUT.
+func (o Options) operationTimeout() time.Duration {
+	return resolveOperationTimeout(o.Timeout, os.Getenv(timeoutEnvVar))
+}
+
+// signalCancelEnabled reports whether SIGINT/SIGTERM should cancel the
+// operation. NoSignalCancel or PLATFORM_CLI_NO_SIGNAL_CANCEL turn it off.
+func (o Options) signalCancelEnabled() bool {
+	if o.NoSignalCancel {
+		return false
+	}
+	v, err := strconv.ParseBool(os.Getenv(noSignalCancelEnvVar))
+	return err != nil || !v
+}
+
+// resolveRPCURL picks the custom RPC URL: RPCURL, then envValue
+// (PLATFORM_CLI_RPC_URL) unless Network was set explicitly. An empty result
+// means the named network is used. Setting both RPCURL and Network is an
+// error: silently preferring one could send a mainnet-intended transaction to
+// a devnet, or the reverse.
+func (o Options) resolveRPCURL(envValue string) (string, error) {
+	if o.RPCURL != "" {
+		if o.NetworkSet {
+			return "", fmt.Errorf("specify --network or --rpc-url, not both (--network %q, --rpc-url %q); add --endpoint-from-network to use --rpc-url as an endpoint for --network", o.Network, o.RPCURL)
+		}
+		return o.RPCURL, nil
+	}
+	if o.NetworkSet {
+		return "", nil
+	}
+	return strings.TrimSpace(envValue), nil
+}
+
+// NetworkConfig returns the network configuration, handling custom RPC URLs.
+// If RPCURL (or PLATFORM_CLI_RPC_URL) is set, it creates a custom config
+// (querying network ID if needed). Otherwise, it uses the standard named
+// network config. With fallback endpoints, the first one that answers is
+// used; NetworkContext also fails over to the rest.
+func (o Options) NetworkConfig(ctx context.Context) (network.Config, error) {
+	config, _, err := o.resolveNetwork(ctx)
+	return config, err
+}
+
+// NetworkContext is NetworkConfig, and also returns a context derived from
+// ctx under which requests to the network fail over to its fallback
+// endpoints, with a warning each time; see network.WithFailover.
+func (o Options) NetworkContext(ctx context.Context) (context.Context, network.Config, error) {
+	config, fallbacks, err := o.resolveNetwork(ctx)
+	if err != nil {
+		return nil, network.Config{}, err
+	}
+	ctx, err = network.WithFailover(ctx, config, fallbacks, warnRPCFailover)
+	if err != nil {
+		return nil, network.Config{}, err
+	}
+	return ctx, config, nil
+}
+
+// resolveNetwork returns the network configuration and the fallback
+// endpoints that answered for the same network.
+func (o Options) resolveNetwork(ctx context.Context) (network.Config, []string, error) {
+	if o.EndpointFromNetwork {
+		return o.mirrorNetworkConfig(ctx)
+	}
+	rpcURL, err := o.resolveRPCURL(os.Getenv(rpcURLEnvVar))
+	if err != nil {
+		return network.Config{}, nil, err
+	}
+	if rpcURL != "" {
+		endpoints, err := o.endpoints(rpcURL)
+		if err != nil {
+			return network.Config{}, nil, err
+		}
+		config, fallbacks, err := firstAnsweringEndpoint(endpoints, func(endpoint string) (network.Config, error) {
+			return network.NewCustomConfigWithInsecureHTTP(ctx, endpoint, o.NetworkID, o.AllowInsecureHTTP)
+		})
+		if err != nil {
+			return network.Config{}, nil, err
+		}
+		if o.NetworkID != 0 && !o.SkipNetworkIDCheck {
+			if err := network.VerifyNetworkID(ctx, config.RPCURL, o.NetworkID); err != nil {
+				if errors.Is(err, network.ErrNetworkIDMismatch) {
+					return network.Config{}, nil, fmt.Errorf("%w\n\nFix --network-id, or pass --skip-network-id-check to use it anyway", err)
+				}
+				printWarning("WARNING: could not verify --network-id %d against the node: %v", o.NetworkID, err)
+			}
+		}
+		// Best-effort: a node that can't answer just skips the check.
+		if err := network.CheckFeeAsset(ctx, config.RPCURL); errors.Is(err, network.ErrUnexpectedFeeAsset) {
+			printWarning("WARNING: %v. AVAX amounts and fees shown for this network may be wrong.", err)
+		}
+		if fallbacks, err = o.checkFallbacks(ctx, config, fallbacks); err != nil {
+			return network.Config{}, nil, err
+		}
+		hrp := constants.GetHRP(config.NetworkID)
+		fmt.Fprintf(progressWriter(), "Using custom RPC: %s (network ID: %d, HRP: %s)\n", config.RPCURL, config.NetworkID, hrp)
+		printFallbackRPCURLs(fallbacks)
+		return config, fallbacks, nil
+	}
+	config, err := network.GetConfig(o.Network)
+	return config, nil, err
+}
+
+// endpoints returns the normalized custom endpoints in order of preference:
+// rpcURL, which may be a comma-separated PLATFORM_CLI_RPC_URL list, then
+// FallbackRPCURLs. An invalid URL fails before any endpoint is contacted.
+func (o Options) endpoints(rpcURL string) ([]string, error) {
+	var endpoints []string
+	for _, raw := range append(strings.Split(rpcURL, ","), o.FallbackRPCURLs...) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		endpoint, err := nodeutil.NormalizeNodeURIWithInsecureHTTP(raw, o.AllowInsecureHTTP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --rpc-url %q: %w", raw, err)
+		}
+		if !slices.Contains(endpoints, endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("--rpc-url is empty")
+	}
+	return endpoints, nil
+}
+
+// firstAnsweringEndpoint resolves the config through the first endpoint for
+// which resolve succeeds, warning about each one skipped, and returns the
+// endpoints after it as fallbacks. A network ID mismatch is not skipped: the
+// endpoint answered, for the wrong network.
+func firstAnsweringEndpoint(endpoints []string, resolve func(endpoint string) (network.Config, error)) (network.Config, []string, error) {
+	for i, endpoint := range endpoints {
+		config, err := resolve(endpoint)
+		if err == nil {
+			return config, endpoints[i+1:], nil
+		}
+		if i == len(endpoints)-1 || errors.Is(err, network.ErrNetworkIDMismatch) {
+			return network.Config{}, nil, err
+		}
+		printWarning("WARNING: skipping RPC endpoint %s: %v", endpoint, err)
+	}
+	return network.Config{}, nil, fmt.Errorf("no RPC endpoints")
+}
+
+// checkFallbacks returns the fallback endpoints that serve config's
+// network. Fallbacks that do not answer are dropped with a warning.
+func (o Options) checkFallbacks(ctx context.Context, config network.Config, fallbacks []string) ([]string, error) {
+	if len(fallbacks) == 0 {
+		return nil, nil
+	}
+	fallbacks, skipped, err := network.CheckFallbacks(ctx, config, fallbacks, o.AllowInsecureHTTP)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range skipped {
+		printWarning("WARNING: not using fallback RPC endpoint: %v", err)
+	}
+	return fallbacks, nil
+}
+
+// printFallbackRPCURLs lists the fallback endpoints, if any.
+func printFallbackRPCURLs(fallbacks []string) {
+	if len(fallbacks) > 0 {
+		fmt.Fprintf(progressWriter(), "Fallback RPC: %s\n", strings.Join(fallbacks, ", "))
+	}
+}
+
+// mirrorNetworkConfig resolves EndpointFromNetwork: the built-in config for
+// Network, reached through RPCURL instead of its public endpoint, and the
+// fallback endpoints that serve the same network.
+func (o Options) mirrorNetworkConfig(ctx context.Context) (network.Config, []string, error) {
+	switch {
+	case o.RPCURL == "":
+		return network.Config{}, nil, fmt.Errorf("--endpoint-from-network requires --rpc-url")
+	case !o.NetworkSet:
+		return network.Config{}, nil, fmt.Errorf("--endpoint-from-network requires --network to name the network --rpc-url serves")
+	case o.NetworkID != 0:
+		return network.Config{}, nil, fmt.Errorf("--network-id cannot be used with --endpoint-from-network; the network ID comes from --network")
+	}
+	config, err := network.GetConfig(o.Network)
+	if err != nil {
+		return network.Config{}, nil, err
+	}
+	endpoints, err := o.endpoints(o.RPCURL)
+	if err != nil {
+		return network.Config{}, nil, err
+	}
+	config, fallbacks, err := firstAnsweringEndpoint(endpoints, func(endpoint string) (network.Config, error) {
+		return network.WithEndpoint(ctx, config, endpoint, o.AllowInsecureHTTP)
+	})
+	if err != nil {
+		return network.Config{}, nil, err
+	}
+	if fallbacks, err = o.checkFallbacks(ctx, config, fallbacks); err != nil {
+		return network.Config{}, nil, err
+	}
+	fmt.Fprintf(progressWriter(), "Using %s via %s\n", config.Name, config.RPCURL)
+	printFallbackRPCURLs(fallbacks)
+	return config, fallbacks, nil
+}
+
+// LoadKey loads the signing key from the first configured source. Its
+// errors exit with the key/auth exit code. The caller should clear the
+// returned bytes when done with them.
+func (o Options) LoadKey() ([]byte, error) {
+	key, err := o.readKey()
+	if err != nil {
+		return nil, withExitCode(exitKey, err)
+	}
+	return key, nil
+}
+
+// openLedger opens the Ledger account at LedgerIndex. Like LoadKey, its
+// errors exit with the key/auth exit code.
+func (o Options) openLedger() (*wallet.LedgerKeychain, error) {
+	kc, err := wallet.NewLedgerKeychain(o.LedgerIndex)
+	if err != nil {
+		return nil, withExitCode(exitKey, err)
+	}
+	return kc, nil
+}
+
+// readKey picks the key source for LoadKey, in priority order.
+func (o Options) readKey() ([]byte, error) {
+	// Priority 1: Key from keystore by name or address (--from)
+	if o.From != "" {
+		name, err := resolveFromKeyName(o.From)
+		if err != nil {
+			return nil, err
+		}
+		return o.loadFromKeystore(name)
+	}
+	if o.KeyName != "" {
+		if o.PrivateKey != "" {
+			return nil, fmt.Errorf("use either --key-name or --private-key, not both")
+		}
+		return o.loadFromKeystore(o.KeyName)
+	}
+
+	// Priority 2: Direct private key via flag (discouraged; prefer keystore/Ledger)
+	if o.PrivateKey != "" {
+		return wallet.ParsePrivateKey(o.PrivateKey)
+	}
+
+	// Priority 3: Default key from keystore
+	ks, err := keystore.Load()
+	if err == nil && ks.GetDefault() != "" {
+		return o.loadFromKeystore(ks.GetDefault())
+	}
+
+	// Priority 4: Environment variable
+	if envKey := os.Getenv(privateKeyEnvVar); envKey != "" {
+		return wallet.ParsePrivateKey(envKey)
+	}
+
+	return nil, fmt.Errorf("no key source provided. Use --key-name (preferred), --private-key, or set AVALANCHE_PRIVATE_KEY env var")
+}
+
+// loadFromKeystore loads a key from the keystore by name.
+// Special built-in key: "ewoq" returns the well-known test key.
+// Note: The returned key bytes should be cleared by the caller when no longer needed.
+func (o Options) loadFromKeystore(name string) ([]byte, error) {
+	// Built-in: ewoq test key
+	if name == "ewoq" {
+		// SECURITY: Prevent accidental use of ewoq key on mainnet
+		if o.Network == "mainnet" || o.NetworkID == constants.MainnetID {
+			return nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
+		}
+		// Return a copy so caller can safely clear it
+		keyCopy := make([]byte, len(ewoqPrivateKey))
+		copy(keyCopy, ewoqPrivateKey)
+		return keyCopy, nil
+	}
+
+	ks, err := keystore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keystore: %w", err)
+	}
+
+	if !ks.HasKey(name) {
+		return nil, fmt.Errorf("key %q not found in keystore (built-in: ewoq)", name)
+	}
+
+	// Get password if key is encrypted
+	var password []byte
+	if ks.IsEncrypted(name) {
+		// Try environment variable first
+		if envPwd := os.Getenv("PLATFORM_CLI_KEY_PASSWORD"); envPwd != "" {
+			password = []byte(envPwd)
+		} else {
+			// Prompt for password
+			fmt.Fprintf(os.Stderr, "Key %q is encrypted. Enter password: ", name)
+			password, err = term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read password: %w", err)
+			}
+		}
+		// Clear password after use
+		defer clearBytesWallet(password)
+	}
+
+	return ks.LoadKey(name, password)
+}
+
+// LoadPChainWallet creates a P-Chain wallet from either Ledger or private key.
+// Returns the wallet and a cleanup function that must be called when done.
+func (o Options) LoadPChainWallet(ctx context.Context, netConfig network.Config) (*wallet.Wallet, func(), error) {
+	return loadSigningWallet(o, netConfig,
+		func(kc *wallet.LedgerKeychain) (*wallet.Wallet, error) {
+			return wallet.NewWalletFromKeychain(ctx, kc, kc.GetAddress(), netConfig)
+		},
+		func(key *secp256k1.PrivateKey) (*wallet.Wallet, error) {
+			return wallet.NewWallet(ctx, key, netConfig)
+		},
+	)
+}
+
+// LoadPChainWalletWithSubnets creates a P-Chain wallet that tracks several
+// subnets, so one wallet signs for all of them.
+func (o Options) LoadPChainWalletWithSubnets(ctx context.Context, netConfig network.Config, subnetIDs []ids.ID) (*wallet.Wallet, func(), error) {
+	return loadSigningWallet(o, netConfig,
+		func(kc *wallet.LedgerKeychain) (*wallet.Wallet, error) {
+			return wallet.NewWalletFromKeychainWithSubnets(ctx, kc, kc.GetAddress(), netConfig, subnetIDs)
+		},
+		func(key *secp256k1.PrivateKey) (*wallet.Wallet, error) {
+			return wallet.NewWalletWithSubnets(ctx, key, netConfig, subnetIDs)
+		},
+	)
+}
+
+// LoadPChainWalletWithOwner creates a P-Chain wallet whose backend maps
+// ownerID to owner, enabling owner-authorized transactions (e.g.
+// SetAutoRenewedValidatorConfigTx). It fetches P-Chain state once, replacing
+// the standard wallet load rather than adding a second round-trip.
+func (o Options) LoadPChainWalletWithOwner(ctx context.Context, netConfig network.Config, ownerID ids.ID, owner fx.Owner) (*wallet.Wallet, func(), error) {
+	return loadSigningWallet(o, netConfig,
+		func(kc *wallet.LedgerKeychain) (*wallet.Wallet, error) {
+			return wallet.NewWalletFromKeychainWithOwner(ctx, kc, kc.GetAddress(), netConfig, ownerID, owner)
+		},
+		func(key *secp256k1.PrivateKey) (*wallet.Wallet, error) {
+			return wallet.NewWalletFromKeychainWithOwner(ctx, secp256k1fx.NewKeychain(key), key.Address(), netConfig, ownerID, owner)
+		},
+	)
+}
+
+// LoadFullWallet creates a multi-chain wallet (P-Chain + C-Chain).
+func (o Options) LoadFullWallet(ctx context.Context, netConfig network.Config) (*wallet.FullWallet, func(), error) {
+	return loadSigningWallet(o, netConfig,
+		func(kc *wallet.LedgerKeychain) (*wallet.FullWallet, error) {
+			return wallet.NewFullWalletFromKeychain(ctx, kc, kc.GetAddress(), kc.GetEVMPublicKey().EthAddress(), netConfig)
+		},
+		func(key *secp256k1.PrivateKey) (*wallet.FullWallet, error) {
+			return wallet.NewFullWallet(ctx, key, netConfig)
+		},
+	)
+}
+
+// loadSigningWallet builds a wallet with fromLedger when o selects a Ledger,
+// or with fromKey from the loaded private key otherwise. The cleanup function
+// closes the Ledger; the private key bytes are cleared before returning.
+func loadSigningWallet[W any](
+	o Options,
+	netConfig network.Config,
+	fromLedger func(kc *wallet.LedgerKeychain) (W, error),
+	fromKey func(key *secp256k1.PrivateKey) (W, error),
+) (W, func(), error) {
+	var zero W
+	if o.UseLedger {
+		if !wallet.LedgerEnabled {
+			return zero, nil, fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
+		}
+		kc, err := o.openLedger()
+		if err != nil {
+			return zero, nil, err
+		}
+		w, err := fromLedger(kc)
+		if err != nil {
+			kc.Close()
+			return zero, nil, err
+		}
+		return w, kc.Close, nil
+	}
+
+	keyBytes, err := o.LoadKey()
+	if err != nil {
+		return zero, nil, err
+	}
+	// Clear key bytes after wallet creation
+	defer clearBytesWallet(keyBytes)
+	if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
+		return zero, nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
+	}
+
+	key, err := wallet.ToPrivateKey(keyBytes)
+	if err != nil {
+		return zero, nil, err
+	}
+	w, err := fromKey(key)
+	if err != nil {
+		return zero, nil, err
+	}
+	return w, func() {}, nil
+}
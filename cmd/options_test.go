@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+func TestOptionsNetworkConfig(t *testing.T) {
+	t.Setenv(rpcURLEnvVar, "")
+	for _, name := range []string{"fuji", "mainnet"} {
+		config, err := Options{Network: name, NetworkSet: true}.NetworkConfig(context.Background())
+		if err != nil {
+			t.Fatalf("NetworkConfig(%s) error = %v", name, err)
+		}
+		if config.Name != name {
+			t.Errorf("NetworkConfig(%s) = %s", name, config.Name)
+		}
+	}
+
+	_, err := Options{Network: "fuji", NetworkSet: true, RPCURL: "http://127.0.0.1:9650"}.NetworkConfig(context.Background())
+	if err == nil {
+		t.Fatal("NetworkConfig() with both a network and an RPC URL expected error")
+	}
+	_, err = Options{RPCURL: "http://127.0.0.1:9650", EndpointFromNetwork: true}.NetworkConfig(context.Background())
+	if err == nil {
+		t.Fatal("NetworkConfig() with EndpointFromNetwork but no network expected error")
+	}
+}
+
+// newNetworkIDInfoServer answers info.getNetworkID with networkID.
+func newNetworkIDInfoServer(t *testing.T, networkID uint32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"networkID":"%d"}}`, networkID)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOptionsNetworkConfig_Fallbacks(t *testing.T) {
+	t.Setenv(rpcURLEnvVar, "")
+	ctx := context.Background()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	a, b := newNetworkIDInfoServer(t, 12345), newNetworkIDInfoServer(t, 12345)
+
+	// The first endpoint is down, so the next one that answers is used and
+	// the rest become fallbacks.
+	config, fallbacks, err := Options{RPCURL: down.URL, FallbackRPCURLs: []string{a.URL, b.URL}}.resolveNetwork(ctx)
+	if err != nil {
+		t.Fatalf("resolveNetwork() error = %v", err)
+	}
+	if config.RPCURL != a.URL || len(fallbacks) != 1 || fallbacks[0] != b.URL {
+		t.Fatalf("resolveNetwork() = RPC %s, fallbacks %v; want %s with fallback %s", config.RPCURL, fallbacks, a.URL, b.URL)
+	}
+
+	// PLATFORM_CLI_RPC_URL takes a comma-separated list.
+	t.Setenv(rpcURLEnvVar, a.URL+", "+b.URL)
+	config, fallbacks, err = Options{}.resolveNetwork(ctx)
+	if err != nil {
+		t.Fatalf("resolveNetwork() from %s error = %v", rpcURLEnvVar, err)
+	}
+	if config.RPCURL != a.URL || len(fallbacks) != 1 {
+		t.Fatalf("resolveNetwork() from %s = RPC %s, fallbacks %v", rpcURLEnvVar, config.RPCURL, fallbacks)
+	}
+	t.Setenv(rpcURLEnvVar, "")
+
+	// NetworkContext turns failover on only when there are fallbacks.
+	failoverCtx, _, err := Options{RPCURL: a.URL, FallbackRPCURLs: []string{b.URL}}.NetworkContext(ctx)
+	if err != nil {
+		t.Fatalf("NetworkContext() error = %v", err)
+	}
+	if network.HTTPClient(failoverCtx) == http.DefaultClient {
+		t.Fatal("NetworkContext() with a fallback did not turn failover on")
+	}
+	failoverCtx, _, err = Options{RPCURL: a.URL}.NetworkContext(ctx)
+	if err != nil {
+		t.Fatalf("NetworkContext() error = %v", err)
+	}
+	if network.HTTPClient(failoverCtx) != http.DefaultClient {
+		t.Fatal("NetworkContext() without fallbacks turned failover on")
+	}
+
+	other := newNetworkIDInfoServer(t, constants.FujiID)
+	_, err = Options{RPCURL: a.URL, FallbackRPCURLs: []string{other.URL}}.NetworkConfig(ctx)
+	if !errors.Is(err, network.ErrNetworkIDMismatch) {
+		t.Fatalf("NetworkConfig() with a fallback on another network error = %v, want %v", err, network.ErrNetworkIDMismatch)
+	}
+
+	_, err = Options{RPCURL: a.URL, FallbackRPCURLs: []string{"http://127.0.0.1:9650/custom/path"}}.NetworkConfig(ctx)
+	if err == nil {
+		t.Fatal("NetworkConfig() expected error for an invalid fallback URL")
+	}
+}
+
+func TestOptionsLoadKey(t *testing.T) {
+	key, err := Options{Network: "fuji", KeyName: "ewoq"}.LoadKey()
+	if err != nil {
+		t.Fatalf("LoadKey(ewoq on fuji) error = %v", err)
+	}
+	if !bytes.Equal(key, ewoqPrivateKey) {
+		t.Fatal("LoadKey(ewoq on fuji) returned the wrong key")
+	}
+
+	for _, opts := range []Options{
+		{Network: "mainnet", KeyName: "ewoq"},
+		{NetworkID: constants.MainnetID, KeyName: "ewoq"},
+	} {
+		if _, err := opts.LoadKey(); err == nil {
+			t.Errorf("LoadKey(%+v) expected the mainnet ewoq guard", opts)
+		} else if exitCode(err) != exitKey {
+			t.Errorf("LoadKey(%+v) exit code = %d, want %d", opts, exitCode(err), exitKey)
+		}
+	}
+
+	if _, err := (Options{KeyName: "a", PrivateKey: "b"}).LoadKey(); err == nil {
+		t.Fatal("LoadKey() with both KeyName and PrivateKey expected error")
+	}
+}
+
+func TestFlagOptions(t *testing.T) {
+	origNetwork, origLedger, origTimeout := networkName, useLedger, operationTimeout
+	defer func() { networkName, useLedger, operationTimeout = origNetwork, origLedger, origTimeout }()
+
+	networkName, useLedger, operationTimeout = "mainnet", true, 42
+	opts := flagOptions()
+	if opts.Network != "mainnet" || !opts.UseLedger || opts.Timeout != 42 {
+		t.Fatalf("flagOptions() = %+v, want the global flag values", opts)
+	}
+}
+
+func TestOptionsOperationContext_NoSignalCancel(t *testing.T) {
+	t.Setenv(noSignalCancelEnvVar, "")
+
+	ctx, cancel := Options{NoSignalCancel: true}.OperationContext()
+	if signal.Ignored(os.Interrupt) || signal.Ignored(syscall.SIGTERM) {
+		cancel()
+		t.Fatal("OperationContext() ignored SIGINT/SIGTERM process-wide")
+	}
+
+	// The signal is swallowed: neither the process nor the context ends.
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		cancel()
+		t.Fatalf("Kill() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := ctx.Err(); err != nil {
+		cancel()
+		t.Fatalf("context error after SIGINT = %v, want none", err)
+	}
+	cancel()
+}
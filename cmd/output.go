@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Supported values for --output.
+const (
+	outputText  = "text"
+	outputTable = "table" // same as text: the human-readable tables
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputFormat selects how commands that support structured output render
+// their results.
+var outputFormat string
+
+// validateOutputFormat rejects unknown --output values.
+func validateOutputFormat() error {
+	switch outputFormat {
+	case outputText, outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return withExitCode(exitUsage, fmt.Errorf("invalid --output %q: must be %q, %q, %q or %q", outputFormat, outputText, outputTable, outputJSON, outputYAML))
+	}
+}
+
+// wantStructured reports whether JSON or YAML output was requested.
+func wantStructured() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// progressWriter is where commands print progress and informational lines.
+// With structured output these go to stderr, so stdout carries only the
+// document.
+func progressWriter() io.Writer {
+	if wantStructured() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// marshalStructured encodes v in the --output format. YAML is converted from
+// the JSON encoding, so both use the same field names and value formats.
+func marshalStructured(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s output: %w", outputFormat, err)
+	}
+	if outputFormat != outputYAML {
+		return append(data, '\n'), nil
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode yaml output: %w", err)
+	}
+	return data, nil
+}
+
+// printStructured writes v to stdout as JSON or YAML, per --output.
+func printStructured(v any) error {
+	data, err := marshalStructured(v)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "Output format for commands that support it: text (or table), json or yaml")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestMarshalStructured(t *testing.T) {
+	orig := outputFormat
+	defer func() { outputFormat = orig }()
+
+	report := testKeyReport()
+
+	outputFormat = outputJSON
+	jsonData, err := marshalStructured(report)
+	if err != nil {
+		t.Fatalf("marshalStructured(json) error = %v", err)
+	}
+
+	outputFormat = outputYAML
+	yamlData, err := marshalStructured(report)
+	if err != nil {
+		t.Fatalf("marshalStructured(yaml) error = %v", err)
+	}
+	for _, want := range []string{"defaultKey: validator\n", "keystore: /home/op/.platform/keys\n", "  name: treasury\n", "generated: \"2025-06-01T09:30:00Z\"\n"} {
+		if !strings.Contains(string(yamlData), want) {
+			t.Errorf("YAML output missing %q:\n%s", want, yamlData)
+		}
+	}
+
+	// Both formats carry the same document.
+	var fromJSON, fromYAML map[string]any
+	if err := json.Unmarshal(jsonData, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if err := yaml.Unmarshal(yamlData, &fromYAML); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	gotJSON, _ := json.Marshal(fromJSON)
+	gotYAML, _ := json.Marshal(fromYAML)
+	if string(gotJSON) != string(gotYAML) {
+		t.Fatalf("JSON and YAML documents differ:\njson: %s\nyaml: %s", gotJSON, gotYAML)
+	}
+}
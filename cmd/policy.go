@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/platform-cli/pkg/keystore"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// policyFileName is the address allowlist read from the directory that
+	// holds the keystore (~/.platform/policy.yaml).
+	policyFileName = "policy.yaml"
+
+	// maxPolicyFileLen bounds the policy file; an allowlist is a few lines.
+	maxPolicyFileLen = units.MiB
+)
+
+// enforcePolicy turns policy violations from warnings into errors.
+var enforcePolicy bool
+
+// policyFile is the on-disk form of the address policy.
+type policyFile struct {
+	AllowedAddresses []string `json:"allowedAddresses"`
+}
+
+// addressPolicy is the set of P-Chain addresses that may receive funds or
+// rewards, or own subnets.
+type addressPolicy struct {
+	path    string
+	allowed set.Set[ids.ShortID]
+}
+
+// defaultPolicyPath returns ~/.platform/policy.yaml.
+func defaultPolicyPath() (string, error) {
+	keysPath, err := keystore.DefaultPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(keysPath), policyFileName), nil
+}
+
+// loadAddressPolicy reads the policy at path. A missing file is not an
+// error: it returns nil, meaning no policy is configured.
+func loadAddressPolicy(path string) (*addressPolicy, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat policy file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("policy file %s must be a regular file", path)
+	}
+	if info.Size() > maxPolicyFileLen {
+		return nil, fmt.Errorf("policy file too large: %d bytes (max: %d bytes)", info.Size(), maxPolicyFileLen)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var file policyFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+	policy := &addressPolicy{path: path, allowed: set.NewSet[ids.ShortID](len(file.AllowedAddresses))}
+	for _, entry := range file.AllowedAddresses {
+		addr, err := parsePChainAddress(entry)
+		if err != nil {
+			return nil, fmt.Errorf("policy file %s: %w", path, err)
+		}
+		policy.allowed.Add(addr)
+	}
+	return policy, nil
+}
+
+// policyEnforced reports whether violations are errors, via --enforce-policy
+// or PLATFORM_CLI_ENFORCE_POLICY.
+func policyEnforced() bool {
+	if enforcePolicy {
+		return true
+	}
+	v, err := strconv.ParseBool(os.Getenv(enforcePolicyEnvVar))
+	return err == nil && v
+}
+
+// checkAddressPolicy checks addrs, used as role (e.g. "reward address"),
+// against the policy file. The signing wallet's own address is always
+// allowed. Without a policy file nothing is checked, unless enforcement is
+// on, which then fails. A violation is a warning, or an error when enforced.
+func checkAddressPolicy(role string, addrs []ids.ShortID, own ids.ShortID, networkID uint32) error {
+	path, err := defaultPolicyPath()
+	if err != nil {
+		return err
+	}
+	policy, err := loadAddressPolicy(path)
+	if err != nil {
+		return err
+	}
+	enforced := policyEnforced()
+	if policy == nil {
+		if enforced {
+			return fmt.Errorf("--enforce-policy is set but there is no policy file at %s", path)
+		}
+		return nil
+	}
+
+	var denied []string
+	for _, addr := range addrs {
+		if addr != own && !policy.allowed.Contains(addr) {
+			denied = append(denied, wallet.FormatPChainAddress(addr, networkID))
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("%s %s not in the allowlist in %s", role, strings.Join(denied, ", "), policy.path)
+	if enforced {
+		return errors.New(msg)
+	}
+	printWarning("WARNING: %s", msg)
+	return nil
+}
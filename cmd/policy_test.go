@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+)
+
+// writePolicy writes a policy file into a fresh HOME and returns its path.
+func writePolicy(t *testing.T, contents string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".platform", policyFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestLoadAddressPolicy(t *testing.T) {
+	allowed := ids.ShortID{0x01}
+	bech32, err := address.Format(pChainAlias, constants.FujiHRP, allowed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := writePolicy(t, "allowedAddresses:\n  - "+bech32+"\n  - "+ids.ShortID{0x02}.String()+"\n")
+	policy, err := loadAddressPolicy(path)
+	if err != nil {
+		t.Fatalf("loadAddressPolicy() error = %v", err)
+	}
+	if policy.allowed.Len() != 2 || !policy.allowed.Contains(allowed) {
+		t.Fatalf("loadAddressPolicy() allowed = %v", policy.allowed)
+	}
+
+	missing, err := loadAddressPolicy(filepath.Join(t.TempDir(), policyFileName))
+	if err != nil || missing != nil {
+		t.Fatalf("loadAddressPolicy(missing) = %v, %v; want nil, nil", missing, err)
+	}
+
+	for name, contents := range map[string]string{
+		"bad address":   "allowedAddresses: [not-an-address]\n",
+		"unknown field": "allowed: []\n",
+		"not yaml":      "allowedAddresses: [\n",
+	} {
+		if _, err := loadAddressPolicy(writePolicy(t, contents)); err == nil {
+			t.Errorf("loadAddressPolicy(%s) expected error", name)
+		}
+	}
+}
+
+func TestCheckAddressPolicy(t *testing.T) {
+	origEnforce := enforcePolicy
+	defer func() { enforcePolicy = origEnforce }()
+	t.Setenv(enforcePolicyEnvVar, "")
+
+	own, allowed, other := ids.ShortID{0x0a}, ids.ShortID{0x01}, ids.ShortID{0x02}
+
+	// No policy file: nothing to check unless enforcement is requested.
+	t.Setenv("HOME", t.TempDir())
+	enforcePolicy = false
+	if err := checkAddressPolicy("recipient", []ids.ShortID{other}, own, constants.FujiID); err != nil {
+		t.Fatalf("checkAddressPolicy(no policy) error = %v", err)
+	}
+	enforcePolicy = true
+	if err := checkAddressPolicy("recipient", []ids.ShortID{other}, own, constants.FujiID); err == nil {
+		t.Fatal("checkAddressPolicy(no policy, enforced) expected error")
+	}
+
+	writePolicy(t, "allowedAddresses: ["+allowed.String()+"]\n")
+	tests := []struct {
+		name    string
+		addrs   []ids.ShortID
+		enforce bool
+		env     string
+		wantErr bool
+	}{
+		{name: "allowed", addrs: []ids.ShortID{allowed}, enforce: true},
+		{name: "own address", addrs: []ids.ShortID{own, allowed}, enforce: true},
+		{name: "denied warns", addrs: []ids.ShortID{allowed, other}},
+		{name: "denied enforced", addrs: []ids.ShortID{allowed, other}, enforce: true, wantErr: true},
+		{name: "denied enforced by env", addrs: []ids.ShortID{other}, env: "1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enforcePolicy = tt.enforce
+			t.Setenv(enforcePolicyEnvVar, tt.env)
+			err := checkAddressPolicy("recipient", tt.addrs, own, constants.FujiID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkAddressPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// recordingChangeOwner records the change owner applyChangeAddress sets.
+type recordingChangeOwner struct {
+	addr ids.ShortID
+	set  bool
+}
+
+func (r *recordingChangeOwner) SetChangeOwner(addr ids.ShortID) {
+	r.addr, r.set = addr, true
+}
+
+func TestApplyChangeAddress_Policy(t *testing.T) {
+	origEnforce, origChange := enforcePolicy, changeAddress
+	defer func() { enforcePolicy, changeAddress = origEnforce, origChange }()
+	t.Setenv(enforcePolicyEnvVar, "")
+
+	own, allowed, other := ids.ShortID{0x0a}, ids.ShortID{0x01}, ids.ShortID{0x02}
+	writePolicy(t, "allowedAddresses: ["+allowed.String()+"]\n")
+	enforcePolicy = true
+
+	tests := []struct {
+		name    string
+		flag    string
+		want    ids.ShortID
+		wantErr bool
+	}{
+		{name: "allowed", flag: allowed.String(), want: allowed},
+		{name: "self", flag: "@self", want: own},
+		{name: "denied", flag: other.String(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeAddress = tt.flag
+			var w recordingChangeOwner
+			err := applyChangeAddress(&w, own, constants.FujiID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyChangeAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if w.set {
+					t.Fatalf("applyChangeAddress() set change owner %s despite policy error", w.addr)
+				}
+				return
+			}
+			if w.addr != tt.want {
+				t.Fatalf("applyChangeAddress() change owner = %s, want %s", w.addr, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseL1MessageOwners_Policy(t *testing.T) {
+	origEnforce := enforcePolicy
+	defer func() { enforcePolicy = origEnforce }()
+	t.Setenv(enforcePolicyEnvVar, "")
+
+	allowed, other := ids.ShortID{0x01}, ids.ShortID{0x02}
+	writePolicy(t, "allowedAddresses: ["+allowed.String()+"]\n")
+	enforcePolicy = true
+
+	tests := []struct {
+		name    string
+		balance string
+		disable string
+		wantErr bool
+	}{
+		{name: "both allowed", balance: allowed.String(), disable: allowed.String()},
+		{name: "balance owner denied", balance: allowed.String() + "," + other.String(), disable: allowed.String(), wantErr: true},
+		{name: "disable owner denied", balance: allowed.String(), disable: other.String(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseL1MessageOwners(tt.balance, tt.disable, constants.FujiID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseL1MessageOwners() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
 	"time"
 
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -15,8 +14,37 @@ import (
 
 const (
 	// defaultOperationTimeout is the default timeout for network operations.
-	// Can be overridden via PLATFORM_CLI_TIMEOUT environment variable.
+	// Can be overridden via --timeout or the PLATFORM_CLI_TIMEOUT environment variable.
 	defaultOperationTimeout = 2 * time.Minute
+
+	// timeoutEnvVar names the environment variable read when --timeout is unset.
+	timeoutEnvVar = "PLATFORM_CLI_TIMEOUT"
+
+	// rpcURLEnvVar names the environment variable read when neither --rpc-url
+	// nor --network is given.
+	rpcURLEnvVar = "PLATFORM_CLI_RPC_URL"
+
+	// privateKeyEnvVar is the last-resort key source read by loadKey.
+	privateKeyEnvVar = "AVALANCHE_PRIVATE_KEY"
+
+	// assumeYesEnvVar, when set to a true value ("1", "true"), acts like --yes.
+	assumeYesEnvVar = "PLATFORM_CLI_ASSUME_YES"
+
+	// requireEncryptionEnvVar, when set to a true value, makes keys import
+	// and generate refuse --encrypt=false.
+	requireEncryptionEnvVar = "PLATFORM_CLI_REQUIRE_ENCRYPTION"
+
+	// noSignalCancelEnvVar, when set to a true value, acts like
+	// --no-signal-cancel.
+	noSignalCancelEnvVar = "PLATFORM_CLI_NO_SIGNAL_CANCEL"
+
+	// enforcePolicyEnvVar, when set to a true value, acts like
+	// --enforce-policy.
+	enforcePolicyEnvVar = "PLATFORM_CLI_ENFORCE_POLICY"
+
+	// shareRoundingTolerance absorbs float error when checking that a fee maps
+	// to a whole number of reward shares.
+	shareRoundingTolerance = 1e-6
 )
 
 var (
@@ -24,14 +52,25 @@ var (
 	version = "dev"
 
 	// Global flags
-	networkName       string
-	privateKey        string
-	useLedger         bool
-	allowInsecureHTTP bool   // Allow plain HTTP for non-local node endpoint discovery
-	ledgerIndex       uint32 // Ledger address index (BIP44)
-	keyNameGlobal     string // Key name for loading from keystore
-	customRPCURL      string // Custom RPC URL for devnets
-	customNetID       uint32 // Optional network ID for custom RPC (auto-detected if not set)
+	networkName        string
+	privateKey         string
+	useLedger          bool
+	allowInsecureHTTP  bool          // Allow plain HTTP for non-local node endpoint discovery
+	ledgerIndex        uint32        // Ledger address index (BIP44)
+	keyNameGlobal      string        // Key name for loading from keystore
+	keyFrom            string        // Keystore key selected by name or address
+	customRPCURLs      []string      // Custom RPC URLs for devnets; later ones are fallbacks
+	endpointFromNet    bool          // --rpc-url is another endpoint for --network, not a custom network
+	customNetID        uint32        // Optional network ID for custom RPC (auto-detected if not set)
+	skipNetworkIDCheck bool          // Trust --network-id without checking it against the node
+	assumeYes          bool          // Skip confirmation prompts and advisory warnings
+	broadcastTx        bool          // Issue signed transactions (false = sign and print only)
+	outputTxFile       string        // Also write each signed P-Chain transaction to this file
+	changeAddress      string        // Where transaction change goes (default: the signing address)
+	operationTimeout   time.Duration // Operation timeout (0 = use PLATFORM_CLI_TIMEOUT or the default)
+	noSignalCancel     bool          // Ignore SIGINT/SIGTERM instead of cancelling the operation
+	skipBalanceCheck   bool          // Build transactions without first checking the wallet covers them
+	refreshBefore      bool          // Re-sync wallet UTXOs from the node right before each transaction is built
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -41,6 +80,15 @@ var rootCmd = &cobra.Command{
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	RunE:          requireSubcommand,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateTimeoutFlag(cmd); err != nil {
+			return err
+		}
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+		return validateUnits()
+	},
 	Long: `Avalanche P-Chain operations: staking, subnets, transfers, and L1 validators.
 
 Example usage:
@@ -52,14 +100,18 @@ Example usage:
 Environment Variables:
   AVALANCHE_PRIVATE_KEY      Private key fallback (prefer --key-name or --ledger)
   PLATFORM_CLI_KEY_PASSWORD  Password for encrypted keys (safer than prompting in scripts)
-  PLATFORM_CLI_TIMEOUT       Operation timeout duration (e.g., "5m", "30s", default: 2m)`,
+  PLATFORM_CLI_ASSUME_YES    Set to 1/true to answer yes to confirmation prompts (like --yes)
+  PLATFORM_CLI_RPC_URL       Custom RPC URL, or a comma-separated list with fallbacks (when neither --rpc-url nor --network is set)
+  PLATFORM_CLI_TIMEOUT       Operation timeout duration (e.g., "5m", "30s", default: 2m; --timeout wins)
+  NO_COLOR                   Disable colored output when set to any non-empty value`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		err = explainInterrupted(err, flagOptions().operationTimeout(), pendingTxID)
+		printError(err)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -70,9 +122,28 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&allowInsecureHTTP, "allow-insecure-http", false, "Allow plain HTTP for non-local node/custom RPC endpoint discovery (unsafe; use only on trusted networks)")
 	rootCmd.PersistentFlags().Uint32Var(&ledgerIndex, "ledger-index", 0, "Ledger address index (BIP44 path: m/44'/9000'/0'/0/{index})")
 	rootCmd.PersistentFlags().StringVar(&keyNameGlobal, "key-name", "", "Name of key to load from keystore")
-	rootCmd.PersistentFlags().StringVar(&customRPCURL, "rpc-url", "", "Custom RPC URL (overrides --network)")
+	rootCmd.PersistentFlags().StringVar(&keyFrom, "from", "", "Stored key to sign with, by name or by its P-Chain/EVM address")
+	rootCmd.PersistentFlags().StringSliceVar(&customRPCURLs, "rpc-url", nil, "Custom RPC URL (overrides PLATFORM_CLI_RPC_URL; cannot be combined with --network unless --endpoint-from-network is set). Repeat it, or give a comma-separated list, to add fallback endpoints used when the first fails (except for the C-Chain side of cross-chain transfers)")
+	rootCmd.PersistentFlags().BoolVar(&endpointFromNet, "endpoint-from-network", false, "Use --rpc-url as an alternative endpoint for --network, keeping that network's ID and parameters (the endpoint must report the same network ID)")
 	rootCmd.PersistentFlags().Uint32Var(&customNetID, "network-id", 0, "Network ID for custom RPC (1=mainnet, 5=fuji, auto-detected if not set)")
+	rootCmd.PersistentFlags().BoolVar(&skipNetworkIDCheck, "skip-network-id-check", false, "Do not verify --network-id against the node's reported network ID")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Assume yes for confirmation prompts and skip advisory warnings")
+	rootCmd.PersistentFlags().BoolVar(&broadcastTx, "broadcast", true, "Broadcast signed P-Chain transactions (--broadcast=false prints the signed tx hex instead)")
+	rootCmd.PersistentFlags().StringVar(&outputTxFile, "output-tx-file", "", "Also write each signed P-Chain transaction, as hex, to this file (mode 0600) before it is broadcast; re-submit it with 'tx broadcast --in'")
+	rootCmd.PersistentFlags().StringVar(&changeAddress, "change-address", "", "Send transaction change to this P-Chain address: bech32, short ID, or @key-name (default: signing address)")
+	rootCmd.PersistentFlags().DurationVar(&operationTimeout, "timeout", 0, "Operation timeout, e.g. 30s or 10m (overrides PLATFORM_CLI_TIMEOUT; default 2m)")
+	rootCmd.PersistentFlags().BoolVar(&noSignalCancel, "no-signal-cancel", false, "Ignore SIGINT/SIGTERM so an in-flight operation runs to completion or --timeout (also PLATFORM_CLI_NO_SIGNAL_CANCEL; Ctrl-C will not stop the command)")
+	rootCmd.PersistentFlags().BoolVar(&enforcePolicy, "enforce-policy", false, "Refuse recipients, reward addresses and subnet owners not allowlisted in ~/.platform/policy.yaml (also PLATFORM_CLI_ENFORCE_POLICY)")
+	rootCmd.PersistentFlags().BoolVar(&skipBalanceCheck, "skip-balance-check", false, "Do not check that the P-Chain balance covers the amount plus fee before building a transaction")
+	rootCmd.PersistentFlags().BoolVar(&refreshBefore, "refresh-before", false, "Re-fetch the wallet's P-Chain UTXOs right before building each transaction (one extra RPC round per transaction)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors the NO_COLOR environment variable)")
 	_ = rootCmd.PersistentFlags().MarkDeprecated("private-key", "prefer --key-name (keystore) or --ledger to avoid exposing secrets in process arguments")
+	rootCmd.MarkFlagsMutuallyExclusive("from", "key-name")
+	rootCmd.MarkFlagsMutuallyExclusive("from", "private-key")
+	rootCmd.MarkFlagsMutuallyExclusive("from", "ledger")
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return withExitCode(exitUsage, err)
+	})
 
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
@@ -118,40 +189,53 @@ func fractionToShares(name string, value float64) (uint32, error) {
 }
 
 // feeToShares converts a decimal fee (0.02 = 2%) to shares (20,000 out of 1,000,000).
-// Uses rounding to avoid float precision issues.
+// Fees are fractions, not percentages, and may not be finer than one share
+// (0.0001%), since the chain would silently round them.
 func feeToShares(fee float64) (uint32, error) {
-	return fractionToShares("delegation fee", fee)
+	if math.IsNaN(fee) || fee < 0 || fee > 1 {
+		return 0, fmt.Errorf("delegation fee must be a fraction between 0 and 1, e.g. 0.02 for 2%% (got %g)", fee)
+	}
+	scaled := fee * reward.PercentDenominator
+	if math.Abs(scaled-math.Round(scaled)) > shareRoundingTolerance {
+		return 0, fmt.Errorf("delegation fee %g is too precise: the smallest step is 0.000001 (0.0001%%)", fee)
+	}
+	return uint32(math.Round(scaled)), nil
 }
 
-// getOperationContext returns a context with timeout and signal handling.
-// The context will be cancelled on SIGINT/SIGTERM or when the timeout expires.
-// The returned cancel function must be called to release resources.
-func getOperationContext() (context.Context, context.CancelFunc) {
-	// Determine timeout from environment or use default
-	timeout := defaultOperationTimeout
-	if envTimeout := os.Getenv("PLATFORM_CLI_TIMEOUT"); envTimeout != "" {
-		if d, err := time.ParseDuration(envTimeout); err == nil && d > 0 {
-			timeout = d
-		}
+// skipConfirmations reports whether confirmation prompts should be answered
+// yes automatically, via --yes or PLATFORM_CLI_ASSUME_YES.
+func skipConfirmations() bool {
+	if assumeYes {
+		return true
 	}
+	v, err := strconv.ParseBool(os.Getenv(assumeYesEnvVar))
+	return err == nil && v
+}
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-
-	// Set up signal handling for graceful cancellation
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+// validateTimeoutFlag rejects a non-positive --timeout. An unset flag is fine.
+func validateTimeoutFlag(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("timeout") && operationTimeout <= 0 {
+		return withExitCode(exitUsage, fmt.Errorf("--timeout must be positive (got %s)", operationTimeout))
+	}
+	return nil
+}
 
-	go func() {
-		select {
-		case <-sigChan:
-			fmt.Fprintln(os.Stderr, "\nOperation cancelled by user")
-			cancel()
-		case <-ctx.Done():
-			// Context cancelled or timed out, clean up signal handler
+// resolveOperationTimeout picks the operation timeout: a positive --timeout
+// wins, then a valid positive PLATFORM_CLI_TIMEOUT, then the default.
+func resolveOperationTimeout(flagValue time.Duration, envValue string) time.Duration {
+	if flagValue > 0 {
+		return flagValue
+	}
+	if envValue != "" {
+		if d, err := time.ParseDuration(envValue); err == nil && d > 0 {
+			return d
 		}
-		signal.Stop(sigChan)
-	}()
+	}
+	return defaultOperationTimeout
+}
 
-	return ctx, cancel
+// getOperationContext returns the operation context for the global
+// --timeout and --no-signal-cancel flags; see Options.OperationContext.
+func getOperationContext() (context.Context, context.CancelFunc) {
+	return flagOptions().OperationContext()
 }
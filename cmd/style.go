@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI SGR sequences used for styled output.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// noColor disables styled output regardless of terminal detection.
+var noColor bool
+
+// colorEnabled reports whether styled output should be written to w.
+// Styling is only applied when w is a terminal, --no-color is unset, NO_COLOR
+// is empty (https://no-color.org), and TERM is not "dumb". Anything piped or
+// redirected stays plain so scripts parsing stdout see unchanged text.
+func colorEnabled(w io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// stylize wraps s in the given ANSI sequence when w supports color.
+func stylize(w io.Writer, code, s string) string {
+	if !colorEnabled(w) {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// printTxID prints a "<label>: <txID>" result line to stdout, highlighting the
+// label on terminals.
+func printTxID(label string, txID fmt.Stringer) {
+	fmt.Printf("%s %s\n", stylize(os.Stdout, ansiGreen, label+":"), txID)
+}
+
+// printWarning prints a warning line to stderr, highlighted on terminals.
+func printWarning(format string, args ...any) {
+	fmt.Fprintln(os.Stderr, stylize(os.Stderr, ansiYellow, fmt.Sprintf(format, args...)))
+}
+
+// printError prints a top-level command error to stderr, highlighted on
+// terminals. With --output json or yaml it is written as a structured
+// document instead, so scripts can tell failures apart.
+func printError(err error) {
+	if wantStructured() {
+		writeStructuredError(os.Stderr, err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, stylize(os.Stderr, ansiRed, err.Error()))
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestColorEnabled_NonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	if colorEnabled(&buf) {
+		t.Fatal("colorEnabled(bytes.Buffer) = true, want false")
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if colorEnabled(w) {
+		t.Fatal("colorEnabled(pipe) = true, want false")
+	}
+}
+
+func TestColorEnabled_Overrides(t *testing.T) {
+	oldNoColor := noColor
+	defer func() { noColor = oldNoColor }()
+
+	noColor = true
+	if colorEnabled(os.Stdout) {
+		t.Fatal("colorEnabled() = true with --no-color, want false")
+	}
+
+	noColor = false
+	t.Setenv("NO_COLOR", "1")
+	if colorEnabled(os.Stdout) {
+		t.Fatal("colorEnabled() = true with NO_COLOR set, want false")
+	}
+}
+
+func TestStylize_PlainWhenDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	if got := stylize(&buf, ansiRed, "boom"); got != "boom" {
+		t.Fatalf("stylize() = %q, want %q", got, "boom")
+	}
+}
+
+func TestPrintTxID_PipedOutputUnchanged(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	printTxID("TX ID", stringer("abc"))
+	w.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if got, want := buf.String(), "TX ID: abc\n"; got != want {
+		t.Fatalf("printTxID() output = %q, want %q", got, want)
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
@@ -1,21 +1,34 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	ethcommon "github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/node"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
 	"github.com/spf13/cobra"
 )
 
 var (
 	subnetID               string
 	subnetNewOwner         string
+	subnetOwners           []string
+	subnetOwnerThreshold   uint32
 	subnetChainID          string
 	subnetManager          string
+	subnetManagerTx        string
 	subnetValidatorIPs     string
 	subnetValidatorIDs     string
 	subnetValidatorBLS     string
@@ -23,8 +36,13 @@ var (
 	subnetValBalance       float64
 	subnetMockVal          bool
 	subnetValidatorWeights string
+	subnetValidatorPoPs    []string
+	subnetSkipMissingPoP   bool
+	subnetMaxValidators    int
 
+	subnetValSubnetIDs []string
 	subnetValNodeID    string
+	subnetValNode      string
 	subnetValWeight    uint64
 	subnetValStartTime string
 	subnetValDuration  string
@@ -40,12 +58,21 @@ var subnetCmd = &cobra.Command{
 var subnetCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new subnet (CreateSubnetTx)",
-	Long:  `Create a new subnet on the P-Chain.`,
+	Long: `Create a new subnet on the P-Chain.
+
+By default the subnet is owned by the signing wallet alone. Use --owner to
+make other addresses (or @self / @<key-name> references) the owners instead, and
+--threshold to require that many of them to sign subnet changes.
+
+Examples:
+  platform-cli subnet create
+  platform-cli subnet create --owner P-avax1...
+  platform-cli subnet create --owner P-avax1...,P-avax1...,P-avax1... --threshold 2`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -56,15 +83,40 @@ var subnetCreateCmd = &cobra.Command{
 		}
 		defer cleanup()
 
+		owner, err := subnetOwnerSpec(subnetOwners, subnetOwnerThreshold, cmd.Flags().Changed("threshold"), w.PChainAddress(), netConfig.NetworkID)
+		if err != nil {
+			return err
+		}
+		if err := checkAddressPolicy("subnet owner", owner.Addrs, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
+			return err
+		}
 		fmt.Println("Creating new subnet...")
-		fmt.Printf("Owner: %s\n", w.FormattedPChainAddress())
+		if len(owner.Addrs) == 1 {
+			fmt.Printf("Owner: %s\n", wallet.FormatPChainAddress(owner.Addrs[0], netConfig.NetworkID))
+		} else {
+			fmt.Printf("Owners (%d of %d must sign):\n", owner.Threshold, len(owner.Addrs))
+			for _, addr := range owner.Addrs {
+				fmt.Printf("  %s\n", wallet.FormatPChainAddress(addr, netConfig.NetworkID))
+			}
+		}
+		if !slices.Contains(owner.Addrs, w.PChainAddress()) {
+			printWarning("WARNING: the signing wallet is not an owner; it will not be able to add chains to or convert this subnet.")
+		}
 		fmt.Println("Submitting transaction...")
 
-		txID, err := pchain.CreateSubnet(ctx, w)
+		txID, err := pchain.CreateSubnetWithOwner(ctx, w, owner)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Println("Subnet created successfully!")
 		fmt.Printf("Subnet ID: %s\n", txID)
 		return nil
@@ -86,17 +138,17 @@ var subnetTransferOwnershipCmd = &cobra.Command{
 			return fmt.Errorf("--new-owner is required")
 		}
 
-		sid, err := ids.FromString(subnetID)
+		sid, err := parseIDFlag("subnet-id", subnetID)
 		if err != nil {
-			return fmt.Errorf("invalid subnet ID: %w", err)
+			return err
 		}
 
-		newOwner, err := ids.ShortFromString(subnetNewOwner)
+		newOwner, err := parseShortIDFlag("new-owner", subnetNewOwner)
 		if err != nil {
-			return fmt.Errorf("invalid new owner address: %w", err)
+			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -107,20 +159,84 @@ var subnetTransferOwnershipCmd = &cobra.Command{
 		}
 		defer cleanup()
 
+		if err := checkAddressPolicy("subnet owner", []ids.ShortID{newOwner}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
+			return err
+		}
+
 		txID, err := pchain.TransferSubnetOwnership(ctx, w, sid, newOwner)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
 		fmt.Printf("Transfer Subnet Ownership TX: %s\n", txID)
 		return nil
 	},
 }
 
+// subnetOwnerSpec builds the owner for `subnet create` from --owner and
+// --threshold. Without --owner the signing wallet is the sole owner. Each
+// --owner value is resolved like --change-address, so @self and @<key-name>
+// work and addresses for another network are rejected.
+func subnetOwnerSpec(owners []string, threshold uint32, thresholdSet bool, own ids.ShortID, networkID uint32) (pchain.OutputOwnerSpec, error) {
+	if len(owners) == 0 {
+		if thresholdSet {
+			return pchain.OutputOwnerSpec{}, fmt.Errorf("--threshold requires --owner")
+		}
+		return pchain.SingleOwner(own), nil
+	}
+
+	spec := pchain.OutputOwnerSpec{Threshold: threshold}
+	for _, value := range owners {
+		addr, err := resolveChangeAddress(value, own, networkID)
+		if err != nil {
+			return pchain.OutputOwnerSpec{}, fmt.Errorf("invalid --owner %q: %w", value, err)
+		}
+		spec.Addrs = append(spec.Addrs, addr)
+	}
+	if _, err := spec.OutputOwners(); err != nil {
+		return pchain.OutputOwnerSpec{}, err
+	}
+	return spec, nil
+}
+
+// txStatusAccepted is the status recorded for a transaction the P-Chain accepted.
+const txStatusAccepted = "accepted"
+
+// l1ConversionResult is the JSON receipt of `subnet convert-to-l1`, the step
+// that turns a subnet and its chain into an L1.
+type l1ConversionResult struct {
+	Network        string                 `json:"network"`
+	NetworkID      uint32                 `json:"networkID"`
+	SubnetID       ids.ID                 `json:"subnetID"`
+	ChainID        ids.ID                 `json:"chainID"`
+	ManagerAddress string                 `json:"managerAddress,omitempty"`
+	ValidatorCount int                    `json:"validatorCount"`
+	Validators     []pchain.L1ValidatorID `json:"validators"`
+	ConvertTxID    ids.ID                 `json:"convertTxID"`
+	Status         string                 `json:"status"`
+	SubmittedAt    time.Time              `json:"submittedAt"`
+	AcceptedAt     time.Time              `json:"acceptedAt"`
+}
+
 var subnetConvertL1Cmd = &cobra.Command{
 	Use:   "convert-to-l1",
 	Short: "Convert subnet to L1 (ConvertSubnetToL1Tx)",
-	Long:  `Convert a permissioned subnet to an L1 blockchain.`,
+	Long: `Convert a permissioned subnet to an L1 blockchain.
+
+The validator manager address is given with --manager, or looked up with
+--manager-from-tx from the C-Chain transaction that deployed the contract.
+
+With --output json, progress goes to stderr and stdout receives one JSON
+receipt with the network, subnet and chain IDs, manager address, validator
+count, conversion TX ID, its status, and submission/acceptance timestamps.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -150,14 +266,36 @@ var subnetConvertL1Cmd = &cobra.Command{
 			return fmt.Errorf("at least one validator is required: provide --validators, manual validator flags, or use --mock-validator for testing")
 		}
 
-		sid, err := ids.FromString(subnetID)
+		requested := 1 // --mock-validator
+		switch {
+		case hasValidatorIPs:
+			requested = len(validatorAddrs)
+		case hasManualValidators:
+			requested = len(parseValidatorAddrs(subnetValidatorIDs))
+		}
+		if err := checkValidatorCount(requested, subnetMaxValidators); err != nil {
+			return err
+		}
+		// Catch repeated entries before querying any node.
+		var err error
+		switch {
+		case hasValidatorIPs:
+			err = checkDuplicateValidatorAddrs(validatorAddrs)
+		case hasManualValidators:
+			err = checkDuplicateValidatorNodeIDs(parseValidatorAddrs(subnetValidatorIDs))
+		}
 		if err != nil {
-			return fmt.Errorf("invalid subnet ID: %w", err)
+			return withExitCode(exitUsage, err)
 		}
 
-		cid, err := ids.FromString(subnetChainID)
+		sid, err := parseIDFlag("subnet-id", subnetID)
 		if err != nil {
-			return fmt.Errorf("invalid chain ID: %w", err)
+			return err
+		}
+
+		cid, err := parseIDFlag("chain-id", subnetChainID)
+		if err != nil {
+			return err
 		}
 
 		var managerAddr []byte
@@ -167,6 +305,14 @@ var subnetConvertL1Cmd = &cobra.Command{
 				return fmt.Errorf("invalid manager address: %w", err)
 			}
 		}
+		var managerTxHash ethcommon.Hash
+		if subnetManagerTx != "" {
+			hashBytes, err := decodeHexExactLength(subnetManagerTx, ethcommon.HashLength)
+			if err != nil {
+				return fmt.Errorf("invalid --manager-from-tx hash: %w", err)
+			}
+			managerTxHash = ethcommon.BytesToHash(hashBytes)
+		}
 
 		// Parse optional per-validator weights
 		var weights []uint64
@@ -177,6 +323,25 @@ var subnetConvertL1Cmd = &cobra.Command{
 			}
 		}
 
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		// Check the balance before contacting every validator node.
+		balanceNAVAX, err := avaxToNAVAX(subnetValBalance)
+		if err != nil {
+			return fmt.Errorf("invalid validator balance: %w", err)
+		}
+		feePerSecond, err := pchain.GetValidatorFeePerSecondWithConfig(ctx, netConfig)
+		if err != nil {
+			// Best-effort: without the fee only a zero balance is rejected.
+			printWarning("WARNING: could not read the L1 validator fee, skipping the --validator-balance check: %v", err)
+		}
+		if err := checkL1ValidatorBalance(balanceNAVAX, feePerSecond); err != nil {
+			return withExitCode(exitUsage, err)
+		}
+
 		// Gather validator info from IPs or generate mock
 		var validators []*txs.ConvertSubnetToL1Validator
 		if subnetMockVal {
@@ -193,7 +358,7 @@ var subnetConvertL1Cmd = &cobra.Command{
 				return fmt.Errorf("failed to generate mock validator: %w", err)
 			}
 			validators = []*txs.ConvertSubnetToL1Validator{mockVal}
-			fmt.Printf("Using mock validator (NodeID: %x)\n", mockVal.NodeID)
+			fmt.Fprintf(progressWriter(), "Using mock validator (NodeID: %x)\n", mockVal.NodeID)
 		} else if hasManualValidators {
 			validators, err = buildManualL1Validators(
 				subnetValidatorIDs,
@@ -206,18 +371,35 @@ var subnetConvertL1Cmd = &cobra.Command{
 				return err
 			}
 		} else {
-			validators, err = gatherL1Validators(ctx, validatorAddrs, subnetValBalance, weights)
+			overrides, err := parsePoPOverrides(subnetValidatorPoPs)
 			if err != nil {
 				return err
 			}
+			var skipped []string
+			validators, skipped, err = gatherL1Validators(ctx, validatorAddrs, subnetValBalance, weights, l1PoPFallback{
+				overrides:   overrides,
+				skipMissing: subnetSkipMissingPoP,
+			})
+			if err != nil {
+				return err
+			}
+			for _, node := range skipped {
+				printWarning("WARNING: skipping %s: no BLS proof of possession", node)
+			}
 		}
 		if err := sortAndValidateL1Validators(validators); err != nil {
 			return err
 		}
-
-		netConfig, err := getNetworkConfig(ctx)
+		txSize, err := checkConvertTxSize(validators)
 		if err != nil {
-			return fmt.Errorf("failed to get network config: %w", err)
+			return err
+		}
+
+		if subnetManagerTx != "" {
+			managerAddr, err = managerFromDeployTx(ctx, netConfig, managerTxHash)
+			if err != nil {
+				return err
+			}
 		}
 
 		w, cleanup, err := loadPChainWalletWithSubnet(ctx, netConfig, sid)
@@ -226,23 +408,99 @@ var subnetConvertL1Cmd = &cobra.Command{
 		}
 		defer cleanup()
 
-		fmt.Println("Converting subnet to L1...")
-		fmt.Printf("  Subnet ID: %s\n", sid)
-		fmt.Printf("  Chain ID: %s\n", cid)
-		fmt.Printf("  Validators: %d\n", len(validators))
-		fmt.Println("Submitting transaction...")
+		l1BalanceTotal, err := totalValidatorBalance(validators)
+		if err != nil {
+			return err
+		}
+		if err := checkFunds(ctx, w, l1BalanceTotal, "as L1 validator balance"); err != nil {
+			return err
+		}
+		progress := progressWriter()
+		fmt.Fprintln(progress, "Converting subnet to L1...")
+		fmt.Fprintf(progress, "  Subnet ID: %s\n", sid)
+		fmt.Fprintf(progress, "  Chain ID: %s\n", cid)
+		fmt.Fprintf(progress, "  Validators: %d\n", len(validators))
+		fmt.Fprintf(progress, "  Approx. TX size: %d bytes (limit %d)\n", txSize, pchain.MaxTxSize)
+		fmt.Fprintln(progress, "Submitting transaction...")
+
+		result := l1ConversionResult{
+			Network:        netConfig.Name,
+			NetworkID:      netConfig.NetworkID,
+			SubnetID:       sid,
+			ChainID:        cid,
+			ValidatorCount: len(validators),
+			SubmittedAt:    time.Now().UTC(),
+		}
+		if len(managerAddr) > 0 {
+			result.ManagerAddress = ethcommon.BytesToAddress(managerAddr).Hex()
+		}
+		result.Validators, err = pchain.ConvertedValidationIDs(sid, validators)
+		if err != nil {
+			return err
+		}
 
 		txID, err := pchain.ConvertSubnetToL1(ctx, w, sid, cid, managerAddr, validators)
 		if err != nil {
 			return err
 		}
 
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		// Issuance returns once the P-Chain has accepted the transaction.
+		result.ConvertTxID = txID
+		result.Status = txStatusAccepted
+		result.AcceptedAt = time.Now().UTC()
+		if wantStructured() {
+			return printStructured(result)
+		}
+
 		fmt.Println("Subnet converted to L1 successfully!")
-		fmt.Printf("TX ID: %s\n", txID)
+		printTxID("TX ID", txID)
+		fmt.Println("Validation IDs (for l1 increase-validator-balance and l1 disable-validator):")
+		for _, v := range result.Validators {
+			fmt.Printf("  %s  %s\n", v.NodeID, v.ValidationID)
+		}
 		return nil
 	},
 }
 
+// defaultMaxConvertValidators caps the initial validator set of
+// convert-to-l1. Each validator adds roughly 200 bytes, so this keeps the
+// transaction well inside pchain.MaxTxSize.
+const defaultMaxConvertValidators = 200
+
+// validatorBatchingHint explains how to convert with more validators than fit.
+const validatorBatchingHint = "The initial validator set is fixed at conversion: convert with fewer validators, " +
+	"then add the rest with `l1 register-validator`."
+
+// checkValidatorCount rejects a conversion with more than limit validators
+// before any of them are fetched or verified.
+func checkValidatorCount(count, limit int) error {
+	if limit <= 0 {
+		return fmt.Errorf("--max-validators must be positive, got %d", limit)
+	}
+	if count > limit {
+		return fmt.Errorf("%d validators exceeds --max-validators %d. %s", count, limit, validatorBatchingHint)
+	}
+	return nil
+}
+
+// checkConvertTxSize returns the approximate size of a ConvertSubnetToL1Tx
+// carrying validators, and fails if nodes would refuse a transaction that
+// large.
+func checkConvertTxSize(validators []*txs.ConvertSubnetToL1Validator) (int, error) {
+	size, err := pchain.EstimateConvertSubnetToL1TxSize(validators)
+	if err != nil {
+		return 0, err
+	}
+	if size > pchain.MaxTxSize {
+		return 0, fmt.Errorf("conversion tx would be about %d bytes, over the %d-byte limit. %s", size, pchain.MaxTxSize, validatorBatchingHint)
+	}
+	return size, nil
+}
+
 var subnetAddValidatorCmd = &cobra.Command{
 	Use:   "add-validator",
 	Short: "Add a validator to a permissioned subnet (AddSubnetValidatorTx)",
@@ -250,29 +508,48 @@ var subnetAddValidatorCmd = &cobra.Command{
 
 The node must already be a primary network validator, and the validation period
 must fall within its primary network validation window. The subnet owner key
-authorizes the transaction, so load the owner key via --key-name or --ledger.`,
+authorizes the transaction, so load the owner key via --key-name or --ledger.
+
+Instead of --node-id, pass --node <addr> to read the node ID from the node's
+/ext/info endpoint. The fetched ID is shown for confirmation (skip with --yes).
+
+To add the node to several subnets with the same owner key, repeat --subnet-id
+or give a comma-separated list. One wallet signs for all of them, and one
+transaction is issued per subnet, in order. This cannot be combined with
+--broadcast=false: every transaction after the first would spend the same
+UTXOs.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		if subnetID == "" {
-			return fmt.Errorf("--subnet-id is required")
+		sids, err := parseSubnetIDList(subnetValSubnetIDs)
+		if err != nil {
+			return err
 		}
-		if subnetValNodeID == "" {
-			return fmt.Errorf("--node-id is required")
+		if len(sids) > 1 && !broadcastTx {
+			return fmt.Errorf("--broadcast=false supports a single --subnet-id")
+		}
+		if subnetValNodeID == "" && subnetValNode == "" {
+			return fmt.Errorf("--node-id or --node is required")
 		}
 		if subnetValWeight == 0 {
 			return fmt.Errorf("--weight is required and must be positive")
 		}
 
-		sid, err := ids.FromString(subnetID)
-		if err != nil {
-			return fmt.Errorf("invalid subnet ID: %w", err)
-		}
-
-		nodeID, err := ids.NodeIDFromString(subnetValNodeID)
-		if err != nil {
-			return fmt.Errorf("invalid node ID: %w", err)
+		var nodeID ids.NodeID
+		if subnetValNode != "" {
+			nodeID, err = fetchNodeID(ctx, subnetValNode)
+			if err != nil {
+				return err
+			}
+			if err := confirmFetchedNodeID(os.Stdin, stdinIsTerminal(), nodeID, subnetValNode); err != nil {
+				return err
+			}
+		} else {
+			nodeID, err = parseNodeIDFlag("node-id", subnetValNodeID)
+			if err != nil {
+				return err
+			}
 		}
 
 		start, end, err := parseTimeRange(subnetValStartTime, subnetValDuration)
@@ -280,7 +557,7 @@ authorizes the transaction, so load the owner key via --key-name or --ledger.`,
 			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -288,34 +565,154 @@ authorizes the transaction, so load the owner key via --key-name or --ledger.`,
 			return fmt.Errorf("duration too short for %s: minimum is %s", netConfig.Name, netConfig.MinStakeDuration)
 		}
 
-		w, cleanup, err := loadPChainWalletWithSubnet(ctx, netConfig, sid)
+		w, cleanup, err := loadPChainWalletWithSubnets(ctx, netConfig, sids)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
 		}
 		defer cleanup()
 
-		fmt.Printf("Adding validator %s to subnet %s...\n", nodeID, sid)
-		fmt.Printf("  Weight: %d\n", subnetValWeight)
-		fmt.Printf("  Start: %s\n", start.UTC().Format("2006-01-02 15:04:05 MST"))
-		fmt.Printf("  End: %s\n", end.UTC().Format("2006-01-02 15:04:05 MST"))
-		fmt.Println("Submitting transaction...")
-
-		txID, err := pchain.AddSubnetValidator(ctx, w, pchain.AddSubnetValidatorConfig{
-			SubnetID: sid,
-			NodeID:   nodeID,
-			Start:    start,
-			End:      end,
-			Weight:   subnetValWeight,
-		})
-		if err != nil {
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
 			return err
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		for i, sid := range sids {
+			fmt.Printf("Adding validator %s to subnet %s...\n", nodeID, sid)
+			fmt.Printf("  Weight: %d\n", subnetValWeight)
+			fmt.Printf("  Start: %s\n", start.UTC().Format("2006-01-02 15:04:05 MST"))
+			fmt.Printf("  End: %s\n", end.UTC().Format("2006-01-02 15:04:05 MST"))
+			fmt.Println("Submitting transaction...")
+
+			txID, err := pchain.AddSubnetValidator(ctx, w, pchain.AddSubnetValidatorConfig{
+				SubnetID: sid,
+				NodeID:   nodeID,
+				Start:    start,
+				End:      end,
+				Weight:   subnetValWeight,
+			})
+			if err != nil {
+				if i > 0 {
+					return fmt.Errorf("failed to add validator to subnet %s (added to %d earlier subnet(s) above): %w", sid, i, err)
+				}
+				return err
+			}
+
+			if printSignedTxs(w) {
+				return nil
+			}
+
+			printTxID("TX ID", txID)
+		}
 		return nil
 	},
 }
 
+// parseSubnetIDList parses the values of a repeatable, comma-separated
+// --subnet-id flag. At least one ID is required and duplicates are rejected.
+func parseSubnetIDList(values []string) ([]ids.ID, error) {
+	var out []ids.ID
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		id, err := parseIDFlag("subnet-id", value)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(out, id) {
+			return nil, withExitCode(exitUsage, fmt.Errorf("--subnet-id %s given more than once", id))
+		}
+		out = append(out, id)
+	}
+	if len(out) == 0 {
+		return nil, withExitCode(exitUsage, fmt.Errorf("--subnet-id is required"))
+	}
+	return out, nil
+}
+
+// fetchNodeID reads a node's ID from its /ext/info endpoint.
+func fetchNodeID(ctx context.Context, addr string) (ids.NodeID, error) {
+	info, err := node.GetNodeInfoWithInsecureHTTP(ctx, addr, allowInsecureHTTP)
+	if err != nil {
+		return ids.EmptyNodeID, fmt.Errorf("failed to get node info: %w", err)
+	}
+	nodeID, err := ids.NodeIDFromString(info.NodeID)
+	if err != nil {
+		return ids.EmptyNodeID, fmt.Errorf("node %s returned an invalid node ID %q: %w", addr, info.NodeID, err)
+	}
+	return nodeID, nil
+}
+
+// confirmFetchedNodeID shows a node ID discovered via --node and asks the user
+// to confirm it, unless --yes is set. Non-interactive runs must pass --yes.
+func confirmFetchedNodeID(in io.Reader, interactive bool, nodeID ids.NodeID, addr string) error {
+	fmt.Printf("Fetched node ID %s from %s\n", nodeID, addr)
+	confirmed, err := confirmPrompt(in, interactive, os.Stdout, "Type 'yes' to add this node as a subnet validator: ", confirmYes)
+	if errors.Is(err, errNotInteractive) {
+		return fmt.Errorf("node ID fetched from --node must be confirmed; re-run with --yes to accept %s", nodeID)
+	}
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("node ID not confirmed; aborting")
+	}
+	return nil
+}
+
+// managerFromDeployTx looks up the validator manager address deployed by a
+// C-Chain transaction on the selected network.
+func managerFromDeployTx(ctx context.Context, netConfig network.Config, txHash ethcommon.Hash) ([]byte, error) {
+	client, err := wallet.DialCChain(ctx, netConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	addr, err := wallet.DeployedContractAddress(ctx, client, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve --manager-from-tx: %w", err)
+	}
+	fmt.Fprintf(progressWriter(), "Validator manager %s (deployed by %s)\n", addr.Hex(), txHash.Hex())
+	return addr.Bytes(), nil
+}
+
+// minL1ValidatorBalanceSeconds is how long, at the current fee, each new L1
+// validator's balance must last. The P-Chain accepts any balance, but one
+// that cannot pay the continuous fee deactivates the validator almost at
+// once, so a smaller --validator-balance is almost certainly a mistake.
+const minL1ValidatorBalanceSeconds = secondsPerHour
+
+// checkL1ValidatorBalance rejects a per-validator balance, in nAVAX, that is
+// zero or would not cover minL1ValidatorBalanceSeconds of the continuous
+// validator fee. A zero feePerSecond (unknown or free) checks only for zero.
+func checkL1ValidatorBalance(balanceNAVAX, feePerSecond uint64) error {
+	if balanceNAVAX == 0 {
+		return fmt.Errorf("--validator-balance must be positive: a validator with no balance cannot pay the continuous fee and starts inactive")
+	}
+	if feePerSecond == 0 || balanceNAVAX/feePerSecond >= minL1ValidatorBalanceSeconds {
+		return nil
+	}
+	required := uint64(math.MaxUint64)
+	if feePerSecond <= math.MaxUint64/minL1ValidatorBalanceSeconds {
+		required = feePerSecond * minL1ValidatorBalanceSeconds
+	}
+	return fmt.Errorf("--validator-balance %s AVAX is too low: at the current validator fee of %d nAVAX/s, each validator needs at least %s AVAX to stay active for %s",
+		pchain.FormatAVAX(balanceNAVAX), feePerSecond, pchain.FormatAVAX(required), formatTimeLeft(minL1ValidatorBalanceSeconds))
+}
+
+// totalValidatorBalance sums the balances the conversion locks for its
+// validators, in nAVAX.
+func totalValidatorBalance(validators []*txs.ConvertSubnetToL1Validator) (uint64, error) {
+	var total uint64
+	for _, v := range validators {
+		if v.Balance > math.MaxUint64-total {
+			return 0, fmt.Errorf("total validator balance overflows uint64 nAVAX")
+		}
+		total += v.Balance
+	}
+	return total, nil
+}
+
 func init() {
 	rootCmd.AddCommand(subnetCmd)
 
@@ -324,6 +721,10 @@ func init() {
 	subnetCmd.AddCommand(subnetConvertL1Cmd)
 	subnetCmd.AddCommand(subnetAddValidatorCmd)
 
+	// Create flags
+	subnetCreateCmd.Flags().StringSliceVar(&subnetOwners, "owner", nil, "Subnet owner address(es), comma-separated or repeated (default: the signing wallet)")
+	subnetCreateCmd.Flags().Uint32Var(&subnetOwnerThreshold, "threshold", 1, "Number of --owner addresses that must sign subnet changes")
+
 	// Transfer ownership flags
 	subnetTransferOwnershipCmd.Flags().StringVar(&subnetID, "subnet-id", "", "Subnet ID")
 	subnetTransferOwnershipCmd.Flags().StringVar(&subnetNewOwner, "new-owner", "", "New owner P-Chain address")
@@ -333,17 +734,25 @@ func init() {
 	subnetConvertL1Cmd.Flags().StringVar(&subnetChainID, "chain-id", "", "Chain ID where the validator manager contract lives (often the L1 chain ID)")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetManager, "manager", "", "Validator manager contract address (hex)")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetManager, "contract-address", "", "Alias for --manager")
+	subnetConvertL1Cmd.Flags().StringVar(&subnetManagerTx, "manager-from-tx", "", "C-Chain tx hash that deployed the validator manager; its contract address is used as --manager")
+	subnetConvertL1Cmd.MarkFlagsMutuallyExclusive("manager", "manager-from-tx")
+	subnetConvertL1Cmd.MarkFlagsMutuallyExclusive("contract-address", "manager-from-tx")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetValidatorIPs, "validators", "", "Comma-separated validator node addresses (auto-fetches NodeID + BLS PoP from /ext/info)")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetValidatorIDs, "validator-node-ids", "", "Manual mode: comma-separated validator NodeIDs (must align with --validator-bls-public-keys and --validator-bls-pops)")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetValidatorBLS, "validator-bls-public-keys", "", "Manual mode: comma-separated validator BLS public keys (hex)")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetValidatorPoP, "validator-bls-pops", "", "Manual mode: comma-separated validator BLS proofs of possession (hex)")
 	subnetConvertL1Cmd.Flags().Float64Var(&subnetValBalance, "validator-balance", 1.0, "Balance per validator in AVAX")
 	subnetConvertL1Cmd.Flags().StringVar(&subnetValidatorWeights, "validator-weights", "", "Comma-separated validator weights (uint64). Must match validator count. Defaults to 100 per validator if omitted.")
+	subnetConvertL1Cmd.Flags().StringArrayVar(&subnetValidatorPoPs, "validator-pop", nil, "PoP for a --validators node whose /ext/info returns none: <NodeID>:<bls-public-key>:<bls-pop> (repeatable)")
+	subnetConvertL1Cmd.Flags().BoolVar(&subnetSkipMissingPoP, "skip-nodes-without-pop", false, "Skip --validators nodes that return no BLS proof of possession instead of failing")
 	subnetConvertL1Cmd.Flags().BoolVar(&subnetMockVal, "mock-validator", false, "Use a mock validator (for testing)")
+	subnetConvertL1Cmd.Flags().IntVar(&subnetMaxValidators, "max-validators", defaultMaxConvertValidators, "Refuse to convert with more initial validators than this; add more later with l1 register-validator")
 
 	// Add validator flags
-	subnetAddValidatorCmd.Flags().StringVar(&subnetID, "subnet-id", "", "Subnet ID")
+	subnetAddValidatorCmd.Flags().StringSliceVar(&subnetValSubnetIDs, "subnet-id", nil, "Subnet ID; repeat or comma-separate to add the node to several subnets")
 	subnetAddValidatorCmd.Flags().StringVar(&subnetValNodeID, "node-id", "", "Validator node ID (must already validate the primary network)")
+	subnetAddValidatorCmd.Flags().StringVar(&subnetValNode, "node", "", "Node address to fetch the node ID from (IP, IP:port or URI)")
+	subnetAddValidatorCmd.MarkFlagsMutuallyExclusive("node-id", "node")
 	subnetAddValidatorCmd.Flags().Uint64Var(&subnetValWeight, "weight", 0, "Validator sampling weight on the subnet")
 	subnetAddValidatorCmd.Flags().StringVar(&subnetValStartTime, "start", "now", "Start time (RFC3339 or 'now'). Post-Durango networks ignore this; validation begins at tx acceptance")
 	subnetAddValidatorCmd.Flags().StringVar(&subnetValDuration, "duration", "336h", "Validation duration (must fall within the node's primary network validation period)")
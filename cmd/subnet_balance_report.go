@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 60 * secondsPerMinute
+	secondsPerDay    = 24 * secondsPerHour
+)
+
+var balanceReportSubnetID string
+
+var subnetBalanceReportCmd = &cobra.Command{
+	Use:   "validator-balance-report",
+	Short: "Report L1 validators' fee balances, soonest to run out first",
+	Long: `List every current validator of an L1 with its remaining balance for the
+continuous validator fee and an estimate of when it runs out.
+
+The estimate assumes today's fee stays constant. The fee rises as more L1
+validators join the network, so top up well before a balance runs low, with:
+  platform-cli l1 increase-validator-balance --validation-id <ID> --balance <AVAX>
+
+Examples:
+  platform-cli subnet validator-balance-report --subnet-id <ID>
+  platform-cli subnet validator-balance-report --subnet-id <ID> --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		if balanceReportSubnetID == "" {
+			return withExitCode(exitUsage, fmt.Errorf("--subnet-id is required"))
+		}
+		subnetID, err := parseIDFlag("subnet-id", balanceReportSubnetID)
+		if err != nil {
+			return err
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		report, err := pchain.GetL1ValidatorBalancesWithConfig(ctx, netConfig, subnetID)
+		if err != nil {
+			return err
+		}
+		if wantStructured() {
+			return printStructured(report)
+		}
+
+		if len(report.Validators) == 0 {
+			fmt.Printf("Subnet %s has no current validators.\n", subnetID)
+			return nil
+		}
+		fmt.Printf("Validator fee: %d nAVAX/s per validator (as of %s)\n\n", report.FeePerSecond, report.Time.Format(time.RFC3339))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE ID\tVALIDATION ID\tBALANCE\tTIME LEFT\tRUNS OUT")
+		for _, v := range report.Validators {
+			left, runsOut := "-", "-"
+			if v.SecondsLeft != nil {
+				left = formatTimeLeft(*v.SecondsLeft)
+				runsOut = v.DepletesAt.Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s AVAX\t%s\t%s\n",
+				v.NodeID, v.ValidationID, pchain.FormatAVAX(v.Balance), left, runsOut)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// formatTimeLeft renders seconds as its two largest units ("3d 4h", "5h 10m",
+// "12m"), or "depleted" at zero.
+func formatTimeLeft(seconds uint64) string {
+	days, hours := seconds/secondsPerDay, seconds%secondsPerDay/secondsPerHour
+	minutes := seconds % secondsPerHour / secondsPerMinute
+	switch {
+	case seconds == 0:
+		return "depleted"
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return "<1m"
+	}
+}
+
+func init() {
+	subnetCmd.AddCommand(subnetBalanceReportCmd)
+
+	subnetBalanceReportCmd.Flags().StringVar(&balanceReportSubnetID, "subnet-id", "", "Subnet ID of the L1 (required)")
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+)
+
+func TestL1ConversionResultJSON(t *testing.T) {
+	orig := outputFormat
+	defer func() { outputFormat = orig }()
+	outputFormat = outputJSON
+
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	result := l1ConversionResult{
+		Network:        "fuji",
+		NetworkID:      5,
+		SubnetID:       ids.GenerateTestID(),
+		ChainID:        ids.GenerateTestID(),
+		ValidatorCount: 1,
+		Validators:     []pchain.L1ValidatorID{{NodeID: ids.GenerateTestNodeID(), ValidationID: ids.GenerateTestID()}},
+		ConvertTxID:    ids.GenerateTestID(),
+		Status:         txStatusAccepted,
+		SubmittedAt:    at,
+		AcceptedAt:     at.Add(2 * time.Second),
+	}
+	data, err := marshalStructured(result)
+	if err != nil {
+		t.Fatalf("marshalStructured() error = %v", err)
+	}
+
+	// No managerAddress: it is omitted without a manager.
+	want := fmt.Sprintf(`{
+  "network": "fuji",
+  "networkID": 5,
+  "subnetID": %q,
+  "chainID": %q,
+  "validatorCount": 1,
+  "validators": [
+    {
+      "nodeID": %q,
+      "validationID": %q
+    }
+  ],
+  "convertTxID": %q,
+  "status": "accepted",
+  "submittedAt": "2025-06-01T12:00:00Z",
+  "acceptedAt": "2025-06-01T12:00:02Z"
+}
+`, result.SubnetID, result.ChainID, result.Validators[0].NodeID, result.Validators[0].ValidationID, result.ConvertTxID)
+	if string(data) != want {
+		t.Fatalf("marshalStructured() =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestSubnetOwnerSpec(t *testing.T) {
+	own, treasury, ops := ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	mainnetTreasury, err := address.Format(pChainAlias, constants.GetHRP(constants.MainnetID), treasury.Bytes())
+	if err != nil {
+		t.Fatalf("address.Format() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		owners        []string
+		threshold     uint32
+		thresholdSet  bool
+		wantAddrs     []ids.ShortID
+		wantThreshold uint32
+		wantErr       bool
+	}{
+		{name: "default is the signer", threshold: 1, wantAddrs: []ids.ShortID{own}, wantThreshold: 1},
+		{name: "threshold without owner", threshold: 2, thresholdSet: true, wantErr: true},
+		{name: "other address", owners: []string{treasury.String()}, threshold: 1, wantAddrs: []ids.ShortID{treasury}, wantThreshold: 1},
+		{
+			name:          "2-of-3 including self",
+			owners:        []string{treasury.String(), "@self", ops.String()},
+			threshold:     2,
+			thresholdSet:  true,
+			wantAddrs:     []ids.ShortID{treasury, own, ops},
+			wantThreshold: 2,
+		},
+		{name: "threshold above owners", owners: []string{treasury.String()}, threshold: 2, thresholdSet: true, wantErr: true},
+		{name: "wrong network", owners: []string{mainnetTreasury}, threshold: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := subnetOwnerSpec(tt.owners, tt.threshold, tt.thresholdSet, own, constants.FujiID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("subnetOwnerSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(got.Addrs, tt.wantAddrs) || got.Threshold != tt.wantThreshold {
+				t.Fatalf("subnetOwnerSpec() = %+v, want %d-of-%v", got, tt.wantThreshold, tt.wantAddrs)
+			}
+		})
+	}
+}
+
+func TestCheckValidatorCount(t *testing.T) {
+	tests := []struct {
+		count, limit int
+		wantErr      bool
+	}{
+		{count: 1, limit: defaultMaxConvertValidators},
+		{count: defaultMaxConvertValidators, limit: defaultMaxConvertValidators},
+		{count: defaultMaxConvertValidators + 1, limit: defaultMaxConvertValidators, wantErr: true},
+		{count: 1, limit: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := checkValidatorCount(tt.count, tt.limit); (err != nil) != tt.wantErr {
+			t.Errorf("checkValidatorCount(%d, %d) error = %v, wantErr %v", tt.count, tt.limit, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckConvertTxSize(t *testing.T) {
+	val, err := generateMockValidator(1, 0)
+	if err != nil {
+		t.Fatalf("generateMockValidator() error = %v", err)
+	}
+	repeat := func(n int) []*txs.ConvertSubnetToL1Validator {
+		vals := make([]*txs.ConvertSubnetToL1Validator, n)
+		for i := range vals {
+			vals[i] = val
+		}
+		return vals
+	}
+
+	// The default cap must leave room under the limit.
+	size, err := checkConvertTxSize(repeat(defaultMaxConvertValidators))
+	if err != nil {
+		t.Fatalf("checkConvertTxSize(default cap) error = %v", err)
+	}
+	if size <= defaultMaxConvertValidators*len(val.NodeID) {
+		t.Fatalf("checkConvertTxSize() = %d, implausibly small", size)
+	}
+
+	if _, err := checkConvertTxSize(repeat(4 * defaultMaxConvertValidators)); err == nil {
+		t.Fatal("checkConvertTxSize() expected error above the tx size limit")
+	}
+}
+
+func TestParseSubnetIDList(t *testing.T) {
+	a, b := ids.GenerateTestID(), ids.GenerateTestID()
+
+	got, err := parseSubnetIDList([]string{a.String(), " " + b.String() + " ", ""})
+	if err != nil {
+		t.Fatalf("parseSubnetIDList() error = %v", err)
+	}
+	if !slices.Equal(got, []ids.ID{a, b}) {
+		t.Fatalf("parseSubnetIDList() = %v, want [%s %s]", got, a, b)
+	}
+
+	for name, values := range map[string][]string{
+		"none":      nil,
+		"blank":     {" "},
+		"duplicate": {a.String(), a.String()},
+		"invalid":   {a.String(), "not-an-id"},
+	} {
+		if _, err := parseSubnetIDList(values); err == nil {
+			t.Errorf("parseSubnetIDList(%s) expected error", name)
+		}
+	}
+
+	// The flag splits comma-separated values and accepts repeats.
+	flags := subnetAddValidatorCmd.Flags()
+	orig := subnetValSubnetIDs
+	defer func() { subnetValSubnetIDs = orig }()
+	subnetValSubnetIDs = nil
+	if err := flags.Set("subnet-id", a.String()+","+b.String()); err != nil {
+		t.Fatalf("Set(subnet-id) error = %v", err)
+	}
+	if len(subnetValSubnetIDs) != 2 {
+		t.Fatalf("--subnet-id %s,%s parsed as %v", a, b, subnetValSubnetIDs)
+	}
+}
+
+func TestFormatTimeLeft(t *testing.T) {
+	tests := []struct {
+		seconds uint64
+		want    string
+	}{
+		{0, "depleted"},
+		{59, "<1m"},
+		{12 * 60, "12m"},
+		{5*3600 + 10*60 + 7, "5h 10m"},
+		{3*86400 + 4*3600 + 59*60, "3d 4h"},
+	}
+	for _, tt := range tests {
+		if got := formatTimeLeft(tt.seconds); got != tt.want {
+			t.Errorf("formatTimeLeft(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestCheckL1ValidatorBalance(t *testing.T) {
+	const fee = 512 // nAVAX/s, the minimum validator fee on mainnet
+	tests := []struct {
+		name         string
+		balance      uint64
+		feePerSecond uint64
+		wantErr      string
+	}{
+		{name: "default balance", balance: 1_000_000_000, feePerSecond: fee},
+		{name: "exactly the minimum", balance: fee * minL1ValidatorBalanceSeconds, feePerSecond: fee},
+		{name: "below the minimum", balance: fee*minL1ValidatorBalanceSeconds - 1, feePerSecond: fee, wantErr: "at least 0.0018432 AVAX"},
+		{name: "zero", balance: 0, feePerSecond: fee, wantErr: "must be positive"},
+		{name: "zero with unknown fee", balance: 0, wantErr: "must be positive"},
+		{name: "tiny with unknown fee", balance: 1},
+		{name: "fee overflows minimum", balance: math.MaxUint64 - 1, feePerSecond: math.MaxUint64, wantErr: "too low"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkL1ValidatorBalance(tt.balance, tt.feePerSecond)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkL1ValidatorBalance() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkL1ValidatorBalance() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
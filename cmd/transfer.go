@@ -1,20 +1,35 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/platform-cli/pkg/crosschain"
+	"github.com/ava-labs/platform-cli/pkg/network"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
 	"github.com/spf13/cobra"
 )
 
+// feeReserveTxCount is how many future transactions' worth of fees
+// `transfer send` expects to remain in the wallet before warning.
+const feeReserveTxCount = 2
+
 var (
-	transferAmount      float64
-	transferAmountNAVAX uint64 // Direct nAVAX amount for precision-sensitive operations
-	transferFrom        string
-	transferTo          string
-	transferDest        string
+	transferAmount         string // AVAX, or with an avax/navax unit suffix
+	transferAmountNAVAX    uint64 // Direct nAVAX amount for precision-sensitive operations
+	transferFrom           string
+	transferTo             string
+	transferDest           string
+	transferCBaseFee       uint64 // C-Chain import base fee override in nAVAX (gwei) per gas
+	transferAssumeAccepted bool   // --assume-accepted: skip acceptance polling between export and import
+	transferStateFile      string // --state-file: record a pending export so the transfer can resume
+	transferSubtractFee    bool   // --subtract-fee: the amount is the total spent, fee included
+	transferQuiet          bool   // --quiet: skip advisory warnings such as the low-balance one
 )
 
 var transferCmd = &cobra.Command{
@@ -22,35 +37,94 @@ var transferCmd = &cobra.Command{
 	Short: "Transfer AVAX",
 	Long: `Transfer AVAX on P-Chain or between P-Chain and C-Chain.
 
-Amount Precision:
-  Use --amount for human-readable AVAX amounts (e.g., --amount 10.5).
-  Use --amount-navax for exact nAVAX amounts when precision matters
-  (1 AVAX = 1,000,000,000 nAVAX).
-
-  Warning: Float amounts may lose precision for values > 9007199254740992 nAVAX (~9M AVAX).
-  For large transfers, use --amount-navax for guaranteed precision.`,
+Amounts:
+  --amount takes AVAX (e.g., --amount 10.5) or an amount with a unit suffix:
+  --amount 10.5avax or --amount 10500000000navax (1 AVAX = 1,000,000,000 nAVAX).
+  Amounts are parsed exactly; more than 9 decimal places is an error.
+  --amount-navax is still accepted for exact nAVAX amounts.`,
 	RunE: requireSubcommand,
 }
 
-// getTransferAmountNAVAX returns the transfer amount in nAVAX.
-// Prefers --amount-navax if set, otherwise converts --amount from AVAX.
+// getTransferAmountNAVAX returns the transfer amount in nAVAX, from
+// --amount-navax if set, otherwise by parsing --amount with ParseAmount.
 func getTransferAmountNAVAX() (uint64, error) {
-	if transferAmount > 0 && transferAmountNAVAX > 0 {
+	if transferAmount != "" && transferAmountNAVAX > 0 {
 		return 0, fmt.Errorf("use either --amount or --amount-navax, not both")
 	}
 	if transferAmountNAVAX > 0 {
 		return transferAmountNAVAX, nil
 	}
-	if transferAmount <= 0 {
+	if transferAmount == "" {
 		return 0, fmt.Errorf("--amount or --amount-navax is required and must be positive")
 	}
-	return avaxToNAVAX(transferAmount)
+	amountNAVAX, err := pchain.ParseAmount(transferAmount)
+	if err != nil {
+		return 0, err
+	}
+	if amountNAVAX == 0 {
+		return 0, fmt.Errorf("--amount must be positive")
+	}
+	return amountNAVAX, nil
+}
+
+// skipSendWarnings reports whether transfer send should skip advisory
+// warnings: under --yes (or PLATFORM_CLI_YES) or --quiet.
+func skipSendWarnings() bool {
+	return transferQuiet || skipConfirmations()
+}
+
+// lowBalanceWarning returns a warning when sending amountNAVAX with the given
+// fee would leave less than feeReserveTxCount fees' worth of AVAX in the
+// wallet, or "" if the remaining balance is comfortable (or the send cannot be
+// afforded at all, which issuing will report).
+func lowBalanceWarning(balance, amountNAVAX, feeNAVAX uint64) string {
+	if amountNAVAX > balance || feeNAVAX > balance-amountNAVAX {
+		return ""
+	}
+	remaining := balance - amountNAVAX - feeNAVAX
+	reserve := feeNAVAX * feeReserveTxCount
+	if remaining >= reserve {
+		return ""
+	}
+	return fmt.Sprintf("WARNING: this transfer leaves %s on the P-Chain, less than the ~%s needed to pay for %d more transactions. "+
+		"To empty it, send the whole balance with --subtract-fee; pass --yes or --quiet to silence this warning.",
+		formatAmount(remaining), formatAmount(reserve), feeReserveTxCount)
+}
+
+// cChainImportBaseFee returns the --c-base-fee override in wei, or nil to let
+// the wallet use the node's estimate. It looks up the current base fee to
+// suggest a value, or to warn when the override is below it; a failed lookup
+// only skips that advice.
+func cChainImportBaseFee(ctx context.Context, cmd *cobra.Command, netConfig network.Config) (*big.Int, error) {
+	override := cmd.Flags().Changed("c-base-fee")
+	if override && transferCBaseFee == 0 {
+		return nil, fmt.Errorf("--c-base-fee must be positive")
+	}
+
+	current, err := wallet.GetCChainBaseFee(ctx, netConfig)
+	if err != nil {
+		current = 0
+	}
+	switch {
+	case !override:
+		if current > 0 {
+			fmt.Printf("C-Chain base fee: %d nAVAX/gas (if the import stalls, retry with a higher --c-base-fee)\n", current)
+		}
+		return nil, nil
+	case transferCBaseFee < current:
+		printWarning("WARNING: --c-base-fee %d is below the current C-Chain base fee of %d nAVAX/gas; the import will not be accepted until fees drop.",
+			transferCBaseFee, current)
+	}
+	return wallet.NAVAXToWei(transferCBaseFee), nil
 }
 
 var transferSendCmd = &cobra.Command{
 	Use:   "send",
 	Short: "Send AVAX on P-Chain",
-	Long:  `Send AVAX to another address on the P-Chain.`,
+	Long: `Send AVAX to another address on the P-Chain.
+
+With --subtract-fee the amount is the total spent: the recipient receives the
+amount less the transaction fee, instead of the fee being paid on top.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -64,12 +138,12 @@ var transferSendCmd = &cobra.Command{
 			return fmt.Errorf("invalid amount: %w", err)
 		}
 
-		destAddr, err := ids.ShortFromString(transferDest)
+		destAddr, err := parseShortIDFlag("to", transferDest)
 		if err != nil {
-			return fmt.Errorf("invalid destination address: %w", err)
+			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -80,14 +154,57 @@ var transferSendCmd = &cobra.Command{
 		}
 		defer cleanup()
 
-		fmt.Printf("Sending %d nAVAX (%.9f AVAX) to %s...\n", amountNAVAX, float64(amountNAVAX)/1e9, destAddr)
+		if err := checkAddressPolicy("recipient", []ids.ShortID{destAddr}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		var feeNAVAX uint64
+		if transferSubtractFee {
+			totalNAVAX := amountNAVAX
+			amountNAVAX, feeNAVAX, err = pchain.SendAmountAfterFee(ctx, w, destAddr, totalNAVAX)
+			switch {
+			case errors.Is(err, pchain.ErrFeeExceedsAmount):
+				return withExitCode(exitUsage, err)
+			case err != nil:
+				return fmt.Errorf("failed to estimate fee: %w", err)
+			}
+		}
+
+		if !skipSendWarnings() && destAddr != w.PChainAddress() {
+			// Best-effort: a failed balance or fee lookup just skips the warning.
+			balance, balErr := w.GetPChainBalance(ctx)
+			fee, feeErr := pchain.EstimateSendFee(ctx, w, destAddr, amountNAVAX)
+			if balErr == nil && feeErr == nil {
+				if warning := lowBalanceWarning(balance, amountNAVAX, fee); warning != "" {
+					printWarning("%s", warning)
+				}
+			}
+		}
+
+		if err := checkFunds(ctx, w, amountNAVAX, "to the recipient"); err != nil {
+			return err
+		}
+
+		if transferSubtractFee {
+			fmt.Printf("Sending %s to %s after subtracting a %s fee...\n",
+				formatAmountExact(amountNAVAX), destAddr, formatAmount(feeNAVAX))
+		} else {
+			fmt.Printf("Sending %s to %s...\n", formatAmountExact(amountNAVAX), destAddr)
+		}
 
 		txID, err := pchain.Send(ctx, w, destAddr, amountNAVAX)
 		if err != nil {
 			return fmt.Errorf("transfer failed: %w", err)
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID("TX ID", txID)
+		if transferSubtractFee {
+			fmt.Printf("Recipient receives: %s\n", formatAmount(amountNAVAX))
+		}
 		return nil
 	},
 }
@@ -95,7 +212,12 @@ var transferSendCmd = &cobra.Command{
 var transferPToCCmd = &cobra.Command{
 	Use:   "p-to-c",
 	Short: "Transfer AVAX from P-Chain to C-Chain",
-	Long:  `Transfer AVAX from P-Chain to C-Chain (export + import in one step).`,
+	Long: `Transfer AVAX from P-Chain to C-Chain (export + import in one step).
+
+With --state-file, the export transaction ID is written to the file before
+importing. If the import fails or the command is interrupted, re-run the same
+command with the same --state-file: it skips the export and retries the
+import. The file is deleted when the transfer completes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -105,30 +227,62 @@ var transferPToCCmd = &cobra.Command{
 			return fmt.Errorf("invalid amount: %w", err)
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
+		if err := checkAssumeAccepted(netConfig); err != nil {
+			return err
+		}
+
+		baseFee, err := cChainImportBaseFee(ctx, cmd, netConfig)
+		if err != nil {
+			return err
+		}
 
 		w, cleanup, err := loadFullWallet(ctx, netConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
 		}
 		defer cleanup()
+		if transferAssumeAccepted {
+			w.SetAssumeAccepted()
+		}
 
-		fmt.Printf("Transferring %d nAVAX (%.9f AVAX) from P-Chain to C-Chain...\n", amountNAVAX, float64(amountNAVAX)/1e9)
+		fmt.Printf("Transferring %s from P-Chain to C-Chain...\n", formatAmountExact(amountNAVAX))
 		fmt.Printf("P-Chain Address: %s\n", w.FormattedPChainAddress())
 		fmt.Printf("C-Chain Address: %s\n", w.EthAddress().Hex())
 		fmt.Println("Step 1/2: Exporting from P-Chain...")
 
-		exportTxID, importTxID, err := crosschain.TransferPToC(ctx, w, amountNAVAX)
+		var exportTxID ids.ID
+		var imported crosschain.ImportResult
+		if transferStateFile != "" {
+			exportTxID, imported, err = resumableTransfer(transferStateFile, crosschain.DirectionPToC, netConfig.NetworkID, amountNAVAX,
+				func() (ids.ID, error) {
+					// Only a fresh export needs the funds; a resumed transfer
+					// has already spent them.
+					if err := checkFunds(ctx, w, amountNAVAX, "to export"); err != nil {
+						return ids.Empty, err
+					}
+					return crosschain.ExportFromPChain(ctx, w, amountNAVAX)
+				},
+				func() (crosschain.ImportResult, error) {
+					return crosschain.CompleteImportToCChainWithResult(ctx, w, baseFee)
+				})
+		} else {
+			if err := checkFunds(ctx, w, amountNAVAX, "to export"); err != nil {
+				return err
+			}
+			exportTxID, imported, err = crosschain.TransferPToCWithResult(ctx, w, amountNAVAX, baseFee)
+		}
 		if err != nil {
-			return fmt.Errorf("transfer failed: %w", err)
+			return withTxID(exportTxID, fmt.Errorf("transfer failed: %w", err))
 		}
 
-		fmt.Printf("Export TX ID: %s\n", exportTxID)
+		printTxID("Export TX ID", exportTxID)
 		fmt.Println("Step 2/2: Importing to C-Chain...")
-		fmt.Printf("Import TX ID: %s\n", importTxID)
+		printTxID("Import TX ID", imported.TxID)
+		fmt.Printf("Received: %s on C-Chain\n", formatAmount(imported.ReceivedNAVAX))
 		fmt.Println("Transfer complete!")
 		return nil
 	},
@@ -137,7 +291,12 @@ var transferPToCCmd = &cobra.Command{
 var transferCToPCmd = &cobra.Command{
 	Use:   "c-to-p",
 	Short: "Transfer AVAX from C-Chain to P-Chain",
-	Long:  `Transfer AVAX from C-Chain to P-Chain (export + import in one step).`,
+	Long: `Transfer AVAX from C-Chain to P-Chain (export + import in one step).
+
+With --state-file, the export transaction ID is written to the file before
+importing. If the import fails or the command is interrupted, re-run the same
+command with the same --state-file: it skips the export and retries the
+import. The file is deleted when the transfer completes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -147,35 +306,108 @@ var transferCToPCmd = &cobra.Command{
 			return fmt.Errorf("invalid amount: %w", err)
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
+		if err := checkAssumeAccepted(netConfig); err != nil {
+			return err
+		}
 
 		w, cleanup, err := loadFullWallet(ctx, netConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
 		}
 		defer cleanup()
+		if transferAssumeAccepted {
+			w.SetAssumeAccepted()
+		}
 
-		fmt.Printf("Transferring %d nAVAX (%.9f AVAX) from C-Chain to P-Chain...\n", amountNAVAX, float64(amountNAVAX)/1e9)
+		fmt.Printf("Transferring %s from C-Chain to P-Chain...\n", formatAmountExact(amountNAVAX))
 		fmt.Printf("C-Chain Address: %s\n", w.EthAddress().Hex())
 		fmt.Printf("P-Chain Address: %s\n", w.FormattedPChainAddress())
 		fmt.Println("Step 1/2: Exporting from C-Chain...")
 
-		exportTxID, importTxID, err := crosschain.TransferCToP(ctx, w, amountNAVAX)
+		var exportTxID ids.ID
+		var imported crosschain.ImportResult
+		if transferStateFile != "" {
+			exportTxID, imported, err = resumableTransfer(transferStateFile, crosschain.DirectionCToP, netConfig.NetworkID, amountNAVAX,
+				func() (ids.ID, error) { return crosschain.ExportFromCChain(ctx, w, amountNAVAX) },
+				func() (crosschain.ImportResult, error) { return crosschain.CompleteImportToPChainWithResult(ctx, w) })
+		} else {
+			exportTxID, imported, err = crosschain.TransferCToPWithResult(ctx, w, amountNAVAX)
+		}
 		if err != nil {
-			return fmt.Errorf("transfer failed: %w", err)
+			return withTxID(exportTxID, fmt.Errorf("transfer failed: %w", err))
 		}
 
-		fmt.Printf("Export TX ID: %s\n", exportTxID)
+		printTxID("Export TX ID", exportTxID)
 		fmt.Println("Step 2/2: Importing to P-Chain...")
-		fmt.Printf("Import TX ID: %s\n", importTxID)
+		printTxID("Import TX ID", imported.TxID)
+		fmt.Printf("Received: %s on P-Chain\n", formatAmount(imported.ReceivedNAVAX))
 		fmt.Println("Transfer complete!")
 		return nil
 	},
 }
 
+// resumableTransfer runs a two-leg transfer backed by a --state-file. If the
+// file records a pending export for this transfer, the export is skipped and
+// only the import runs. Otherwise export runs and its transaction ID is saved
+// before importing. The file is removed once the import succeeds.
+func resumableTransfer(
+	stateFile, direction string,
+	networkID uint32,
+	amountNAVAX uint64,
+	export func() (ids.ID, error),
+	complete func() (crosschain.ImportResult, error),
+) (exportTxID ids.ID, imported crosschain.ImportResult, err error) {
+	state, err := crosschain.LoadTransferState(stateFile)
+	if err != nil {
+		return ids.Empty, crosschain.ImportResult{}, err
+	}
+	if state != nil {
+		if err := state.CheckResumable(direction, networkID, amountNAVAX); err != nil {
+			return ids.Empty, crosschain.ImportResult{}, fmt.Errorf("%w; use a different --state-file, or finish that transfer with 'transfer import' and delete %s", err, stateFile)
+		}
+		exportTxID = state.ExportTxID
+		fmt.Fprintf(progressWriter(), "Resuming from %s: export %s was already issued, skipping to the import\n", stateFile, exportTxID)
+	} else {
+		exportTxID, err = export()
+		if err != nil {
+			return ids.Empty, crosschain.ImportResult{}, fmt.Errorf("export failed: %w", err)
+		}
+		if err := crosschain.SaveTransferState(stateFile, crosschain.TransferState{
+			Direction:   direction,
+			NetworkID:   networkID,
+			AmountNAVAX: amountNAVAX,
+			ExportTxID:  exportTxID,
+		}); err != nil {
+			printWarning("WARNING: export %s was issued but could not be recorded (%v); if the import fails, finish it with 'transfer import'", exportTxID, err)
+		}
+	}
+
+	imported, err = complete()
+	if err != nil {
+		return exportTxID, crosschain.ImportResult{}, fmt.Errorf("import failed; re-run with the same --state-file to retry it: %w", err)
+	}
+	if err := crosschain.ClearTransferState(stateFile); err != nil {
+		printWarning("WARNING: transfer complete, but %v; delete %s before reusing it", err, stateFile)
+	}
+	return exportTxID, imported, nil
+}
+
+// checkAssumeAccepted rejects --assume-accepted on Mainnet and Fuji. Skipping
+// acceptance there could build an import on an export that never lands.
+func checkAssumeAccepted(netConfig network.Config) error {
+	if !transferAssumeAccepted {
+		return nil
+	}
+	if netConfig.NetworkID == constants.MainnetID || netConfig.NetworkID == constants.FujiID {
+		return fmt.Errorf("--assume-accepted is only allowed on local and custom networks, not %s", constants.NetworkName(netConfig.NetworkID))
+	}
+	return nil
+}
+
 var transferExportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export AVAX from one chain (step 1 of manual transfer)",
@@ -193,7 +425,7 @@ var transferExportCmd = &cobra.Command{
 			return fmt.Errorf("--from and --to are required (use 'p' or 'c')")
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -208,14 +440,17 @@ var transferExportCmd = &cobra.Command{
 
 		switch {
 		case transferFrom == "p" && transferTo == "c":
-			fmt.Printf("Exporting %d nAVAX (%.9f AVAX) from P-Chain to C-Chain...\n", amountNAVAX, float64(amountNAVAX)/1e9)
+			if err := checkFunds(ctx, w, amountNAVAX, "to export"); err != nil {
+				return err
+			}
+			fmt.Printf("Exporting %s from P-Chain to C-Chain...\n", formatAmountExact(amountNAVAX))
 			id, err := crosschain.ExportFromPChain(ctx, w, amountNAVAX)
 			if err != nil {
 				return fmt.Errorf("export failed: %w", err)
 			}
 			txID = id
 		case transferFrom == "c" && transferTo == "p":
-			fmt.Printf("Exporting %d nAVAX (%.9f AVAX) from C-Chain to P-Chain...\n", amountNAVAX, float64(amountNAVAX)/1e9)
+			fmt.Printf("Exporting %s from C-Chain to P-Chain...\n", formatAmountExact(amountNAVAX))
 			id, err := crosschain.ExportFromCChain(ctx, w, amountNAVAX)
 			if err != nil {
 				return fmt.Errorf("export failed: %w", err)
@@ -225,7 +460,7 @@ var transferExportCmd = &cobra.Command{
 			return fmt.Errorf("invalid --from/--to combination: must be p->c or c->p")
 		}
 
-		fmt.Printf("Export TX ID: %s\n", txID)
+		printTxID("Export TX ID", txID)
 		fmt.Println("Export complete! Run 'transfer import' to complete the transfer.")
 		return nil
 	},
@@ -242,12 +477,23 @@ var transferImportCmd = &cobra.Command{
 		if transferFrom == "" || transferTo == "" {
 			return fmt.Errorf("--from and --to are required (use 'p' or 'c')")
 		}
+		if transferTo != "c" && cmd.Flags().Changed("c-base-fee") {
+			return fmt.Errorf("--c-base-fee only applies to imports to the C-Chain (--to c)")
+		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
 
+		var baseFee *big.Int
+		if transferTo == "c" {
+			baseFee, err = cChainImportBaseFee(ctx, cmd, netConfig)
+			if err != nil {
+				return err
+			}
+		}
+
 		w, cleanup, err := loadFullWallet(ctx, netConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create wallet: %w", err)
@@ -259,7 +505,7 @@ var transferImportCmd = &cobra.Command{
 		switch {
 		case transferFrom == "p" && transferTo == "c":
 			fmt.Println("Importing AVAX to C-Chain from P-Chain...")
-			id, err := crosschain.ImportToCChain(ctx, w)
+			id, err := crosschain.ImportToCChainWithFee(ctx, w, baseFee)
 			if err != nil {
 				return fmt.Errorf("import failed: %w", err)
 			}
@@ -275,7 +521,7 @@ var transferImportCmd = &cobra.Command{
 			return fmt.Errorf("invalid --from/--to combination: must be p->c or c->p")
 		}
 
-		fmt.Printf("Import TX ID: %s\n", txID)
+		printTxID("Import TX ID", txID)
 		fmt.Println("Import complete!")
 		return nil
 	},
@@ -290,21 +536,28 @@ func init() {
 	transferCmd.AddCommand(transferImportCmd)
 
 	// Flags for P-Chain send
-	transferSendCmd.Flags().Float64Var(&transferAmount, "amount", 0, "Amount in AVAX to send")
+	transferSendCmd.Flags().StringVar(&transferAmount, "amount", "", "Amount to send, in AVAX or with a unit suffix (1.5avax, 1500000000navax)")
 	transferSendCmd.Flags().Uint64Var(&transferAmountNAVAX, "amount-navax", 0, "Amount in nAVAX (for precision-sensitive transfers)")
 	transferSendCmd.Flags().StringVar(&transferDest, "to", "", "Destination P-Chain address")
 	transferSendCmd.MarkFlagsMutuallyExclusive("amount", "amount-navax")
+	transferSendCmd.Flags().BoolVar(&transferSubtractFee, "subtract-fee", false, "Treat the amount as the total spent and send it less the fee")
+	transferSendCmd.Flags().BoolVarP(&transferQuiet, "quiet", "q", false, "Skip advisory warnings, such as leaving too little AVAX for future fees")
 
 	// Flags for combined transfer commands
-	transferPToCCmd.Flags().Float64Var(&transferAmount, "amount", 0, "Amount in AVAX to transfer")
+	transferPToCCmd.Flags().StringVar(&transferAmount, "amount", "", "Amount to transfer, in AVAX or with a unit suffix (1.5avax, 1500000000navax)")
 	transferPToCCmd.Flags().Uint64Var(&transferAmountNAVAX, "amount-navax", 0, "Amount in nAVAX (for precision-sensitive transfers)")
-	transferCToPCmd.Flags().Float64Var(&transferAmount, "amount", 0, "Amount in AVAX to transfer")
+	transferCToPCmd.Flags().StringVar(&transferAmount, "amount", "", "Amount to transfer, in AVAX or with a unit suffix (1.5avax, 1500000000navax)")
 	transferCToPCmd.Flags().Uint64Var(&transferAmountNAVAX, "amount-navax", 0, "Amount in nAVAX (for precision-sensitive transfers)")
 	transferPToCCmd.MarkFlagsMutuallyExclusive("amount", "amount-navax")
+	transferPToCCmd.Flags().Uint64Var(&transferCBaseFee, "c-base-fee", 0, "C-Chain import base fee in nAVAX (gwei) per gas (default: the node's estimate)")
 	transferCToPCmd.MarkFlagsMutuallyExclusive("amount", "amount-navax")
+	transferPToCCmd.Flags().BoolVar(&transferAssumeAccepted, "assume-accepted", false, "Don't wait for the export to be accepted before importing (local/custom networks only)")
+	transferCToPCmd.Flags().BoolVar(&transferAssumeAccepted, "assume-accepted", false, "Don't wait for the export to be accepted before importing (local/custom networks only)")
+	transferPToCCmd.Flags().StringVar(&transferStateFile, "state-file", "", "Record the export here so an interrupted transfer resumes at the import when re-run")
+	transferCToPCmd.Flags().StringVar(&transferStateFile, "state-file", "", "Record the export here so an interrupted transfer resumes at the import when re-run")
 
 	// Flags for manual export command
-	transferExportCmd.Flags().Float64Var(&transferAmount, "amount", 0, "Amount in AVAX to export")
+	transferExportCmd.Flags().StringVar(&transferAmount, "amount", "", "Amount to export, in AVAX or with a unit suffix (1.5avax, 1500000000navax)")
 	transferExportCmd.Flags().Uint64Var(&transferAmountNAVAX, "amount-navax", 0, "Amount in nAVAX (for precision-sensitive transfers)")
 	transferExportCmd.Flags().StringVar(&transferFrom, "from", "", "Source chain: 'p' or 'c'")
 	transferExportCmd.Flags().StringVar(&transferTo, "to", "", "Destination chain: 'p' or 'c'")
@@ -313,4 +566,5 @@ func init() {
 	// Flags for manual import command
 	transferImportCmd.Flags().StringVar(&transferFrom, "from", "", "Source chain: 'p' or 'c'")
 	transferImportCmd.Flags().StringVar(&transferTo, "to", "", "Destination chain: 'p' or 'c'")
+	transferImportCmd.Flags().Uint64Var(&transferCBaseFee, "c-base-fee", 0, "C-Chain import base fee in nAVAX (gwei) per gas (default: the node's estimate)")
 }
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/platform-cli/pkg/crosschain"
+	"github.com/spf13/cobra"
+)
+
+var transferConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Move all AVAX from the other chains onto one chain",
+	Long: `Move the wallet's whole spendable AVAX on the other chains to --to: the
+C-Chain or P-Chain, and the X-Chain.
+
+Each chain's export sends everything except its own fee, then the import
+collects it together with any AVAX stranded by an earlier export that was
+never imported. If a source chain holds too little to pay for an export, only
+the stranded AVAX is imported. The import is retried while the exported funds
+are not yet visible on the target chain.
+
+Examples:
+  platform-cli transfer consolidate --to p
+  platform-cli transfer consolidate --to c --c-base-fee 30`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		if transferTo != "p" && transferTo != "c" {
+			return withExitCode(exitUsage, fmt.Errorf("--to is required (use 'p' or 'c')"))
+		}
+		if transferTo != "c" && cmd.Flags().Changed("c-base-fee") {
+			return withExitCode(exitUsage, fmt.Errorf("--c-base-fee only applies to consolidating to the C-Chain (--to c)"))
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+		if err := checkAssumeAccepted(netConfig); err != nil {
+			return err
+		}
+
+		var baseFee *big.Int
+		if transferTo == "c" {
+			baseFee, err = cChainImportBaseFee(ctx, cmd, netConfig)
+			if err != nil {
+				return err
+			}
+		}
+
+		w, cleanup, err := loadFullWallet(ctx, netConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create wallet: %w", err)
+		}
+		defer cleanup()
+		if transferAssumeAccepted {
+			w.SetAssumeAccepted()
+		}
+
+		fmt.Printf("P-Chain Address: %s\n", w.FormattedPChainAddress())
+		fmt.Printf("C-Chain Address: %s\n", w.EthAddress().Hex())
+
+		var legs []func() (crosschain.ConsolidateResult, error)
+		if transferTo == "p" {
+			fmt.Println("Consolidating C-Chain and X-Chain AVAX onto the P-Chain...")
+			legs = append(legs,
+				func() (crosschain.ConsolidateResult, error) { return crosschain.ConsolidateToPChain(ctx, w, nil) },
+				func() (crosschain.ConsolidateResult, error) {
+					return crosschain.ConsolidateFromXChain(ctx, w, crosschain.ChainP, nil)
+				})
+		} else {
+			fmt.Println("Consolidating P-Chain and X-Chain AVAX onto the C-Chain...")
+			legs = append(legs,
+				func() (crosschain.ConsolidateResult, error) { return crosschain.ConsolidateToCChain(ctx, w, baseFee) },
+				func() (crosschain.ConsolidateResult, error) {
+					return crosschain.ConsolidateFromXChain(ctx, w, crosschain.ChainC, baseFee)
+				})
+		}
+
+		consolidated := false
+		for _, leg := range legs {
+			result, err := leg()
+			if errors.Is(err, crosschain.ErrNothingToConsolidate) {
+				fmt.Printf("%s-Chain: nothing to consolidate (balance below the export fee, nothing waiting to be imported)\n", result.From)
+				continue
+			}
+			if err != nil && result.ExportTxID == ids.Empty {
+				return fmt.Errorf("consolidate failed: %w", err)
+			}
+			printConsolidateResult(result)
+			if err != nil {
+				return withTxID(result.ExportTxID, fmt.Errorf("consolidate failed: %w", err))
+			}
+			consolidated = true
+		}
+		if !consolidated {
+			fmt.Println("Nothing to consolidate.")
+			return nil
+		}
+		fmt.Println("Consolidation complete!")
+		return nil
+	},
+}
+
+// printConsolidateResult reports what each chain did, including a partial
+// result whose import failed, so an issued export is never lost from view.
+func printConsolidateResult(r crosschain.ConsolidateResult) {
+	if r.ExportTxID == ids.Empty {
+		fmt.Printf("%s-Chain: nothing exported (balance below the export fee)\n", r.From)
+	} else {
+		fmt.Printf("%s-Chain: exported %s\n", r.From, formatAmount(r.ExportedNAVAX))
+		printTxID("Export TX ID", r.ExportTxID)
+	}
+	if r.ImportTxID == ids.Empty {
+		if r.ExportTxID == ids.Empty {
+			return
+		}
+		if r.From == crosschain.ChainX {
+			// transfer import has no X-Chain source; consolidate imports
+			// whatever an earlier export left waiting.
+			fmt.Printf("%s-Chain: not imported; finish with 'transfer consolidate --to %s'\n",
+				r.To, strings.ToLower(r.To))
+			return
+		}
+		fmt.Printf("%s-Chain: not imported; finish with 'transfer import --from %s --to %s'\n",
+			r.To, strings.ToLower(r.From), strings.ToLower(r.To))
+		return
+	}
+	fmt.Printf("%s-Chain: imported %s, less the import fee\n", r.To, formatAmount(r.ImportableNAVAX))
+	printTxID("Import TX ID", r.ImportTxID)
+}
+
+func init() {
+	transferCmd.AddCommand(transferConsolidateCmd)
+
+	transferConsolidateCmd.Flags().StringVar(&transferTo, "to", "", "Chain to consolidate onto: 'p' or 'c'")
+	transferConsolidateCmd.Flags().Uint64Var(&transferCBaseFee, "c-base-fee", 0, "C-Chain import base fee in nAVAX (gwei) per gas (default: the node's estimate)")
+	transferConsolidateCmd.Flags().BoolVar(&transferAssumeAccepted, "assume-accepted", false, "Don't wait for the export to be accepted before importing (local/custom networks only)")
+}
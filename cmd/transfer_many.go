@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+// maxPaymentsFileLen caps the size of a send-many payments file (1 MB).
+const maxPaymentsFileLen = 1 << 20
+
+// paymentsHeaderField is the first column name of an optional header row.
+const paymentsHeaderField = "address"
+
+var transferPaymentsFile string
+
+var transferSendManyCmd = &cobra.Command{
+	Use:   "send-many",
+	Short: "Pay many P-Chain addresses in one atomic transaction",
+	Long: `Pay every recipient listed in a CSV file in a single P-Chain transaction.
+
+Each row is "address,amount", where amount is in AVAX (suffix "n" for nAVAX).
+Blank lines and lines starting with # are ignored, as is an optional
+"address,amount" header.
+
+The payments are all-or-nothing: either every recipient is paid by the one
+transaction or nobody is. The estimated fee is printed, and the wallet balance
+checked against the total plus that fee, before anything is signed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		if transferPaymentsFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		payments, err := readPaymentsFile(transferPaymentsFile, netConfig.NetworkID)
+		if err != nil {
+			return err
+		}
+		total, err := pchain.SumPayments(payments)
+		if err != nil {
+			return fmt.Errorf("invalid payments: %w", err)
+		}
+
+		w, cleanup, err := loadPChainWallet(ctx, netConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create wallet: %w", err)
+		}
+		defer cleanup()
+
+		recipients := make([]ids.ShortID, len(payments))
+		for i, p := range payments {
+			recipients[i] = p.To
+		}
+		if err := checkAddressPolicy("recipient", recipients, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		fee, err := pchain.EstimateSendManyFee(ctx, w, payments)
+		if err != nil {
+			if errors.Is(err, pchain.ErrInsufficientFunds) {
+				return fmt.Errorf("no payments were made: %w", err)
+			}
+			return fmt.Errorf("failed to estimate fee, no payments were made: %w", err)
+		}
+
+		fmt.Printf("Paying %d recipients %s in total, in one atomic transaction...\n", len(payments), formatAmount(total))
+		fmt.Printf("  Estimated fee: %s\n", formatAmount(fee))
+
+		txID, err := pchain.SendMany(ctx, w, payments)
+		if err != nil {
+			if errors.Is(err, pchain.ErrInsufficientFunds) {
+				return fmt.Errorf("no payments were made: %w", err)
+			}
+			return fmt.Errorf("transfer failed, no payments were made: %w", err)
+		}
+
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID(fmt.Sprintf("Paid all %d recipients in one atomic transaction", len(payments)), txID)
+		return nil
+	},
+}
+
+// readPaymentsFile reads a send-many CSV file, rejecting anything that is not
+// a regular file of at most maxPaymentsFileLen bytes.
+func readPaymentsFile(path string, networkID uint32) ([]pchain.Payment, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat payments file: %w", err)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return nil, fmt.Errorf("payments file must be a regular file")
+	}
+	if fileInfo.Size() > maxPaymentsFileLen {
+		return nil, fmt.Errorf("payments file too large: %d bytes (max: %d bytes / 1 MB)", fileInfo.Size(), maxPaymentsFileLen)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open payments file: %w", err)
+	}
+	defer file.Close()
+
+	return parsePayments(io.LimitReader(file, maxPaymentsFileLen), networkID)
+}
+
+// parsePayments parses "address,amount" CSV rows. Addresses for another
+// network are rejected so a mainnet batch cannot be replayed on Fuji by
+// mistake, and vice versa.
+func parsePayments(r io.Reader, networkID uint32) ([]pchain.Payment, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var payments []pchain.Payment
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid payments file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		addrField, amountField := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if first && strings.EqualFold(addrField, paymentsHeaderField) {
+			continue
+		}
+
+		if err := checkAddressNetwork(addrField, networkID); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		to, err := parsePChainAddress(addrField)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		amount, err := pchain.ParseAmount(amountField)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q: %w", line, amountField, err)
+		}
+		if amount == 0 {
+			return nil, fmt.Errorf("line %d: amount must be positive", line)
+		}
+		payments = append(payments, pchain.Payment{To: to, AmountNAVAX: amount})
+	}
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("payments file has no payments")
+	}
+	return payments, nil
+}
+
+func init() {
+	transferCmd.AddCommand(transferSendManyCmd)
+
+	transferSendManyCmd.Flags().StringVar(&transferPaymentsFile, "file", "", "CSV file of address,amount rows")
+}
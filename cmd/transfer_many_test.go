@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+)
+
+func TestParsePayments(t *testing.T) {
+	alice, bob := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	fujiAlice, err := address.Format(pChainAlias, constants.GetHRP(constants.FujiID), alice.Bytes())
+	if err != nil {
+		t.Fatalf("address.Format() error = %v", err)
+	}
+	mainnetAlice, err := address.Format(pChainAlias, constants.GetHRP(constants.MainnetID), alice.Bytes())
+	if err != nil {
+		t.Fatalf("address.Format() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []pchain.Payment
+		wantErr string
+	}{
+		{
+			name:  "header, comments and blank lines",
+			input: "address,amount\n# payroll\n" + fujiAlice + ", 1.5\n\n" + bob.String() + ",250n\n",
+			want: []pchain.Payment{
+				{To: alice, AmountNAVAX: 1_500_000_000},
+				{To: bob, AmountNAVAX: 250},
+			},
+		},
+		{
+			name:  "unit suffixes",
+			input: fujiAlice + ",2avax\n" + bob.String() + ",1500000000 nAVAX\n",
+			want: []pchain.Payment{
+				{To: alice, AmountNAVAX: 2_000_000_000},
+				{To: bob, AmountNAVAX: 1_500_000_000},
+			},
+		},
+		{name: "wrong network", input: mainnetAlice + ",1\n", wantErr: "line 1"},
+		{name: "bad amount", input: fujiAlice + ",1\n" + bob.String() + ",abc\n", wantErr: "line 2: invalid amount"},
+		{name: "zero amount", input: fujiAlice + ",0\n", wantErr: "amount must be positive"},
+		{name: "missing column", input: fujiAlice + "\n", wantErr: "invalid payments file"},
+		{name: "header only", input: "address,amount\n", wantErr: "no payments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePayments(strings.NewReader(tt.input), constants.FujiID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePayments() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePayments() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parsePayments() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("payment %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+// maxSignedTxHexLen bounds hex input for `tx broadcast`: the hex encoding of
+// a transaction of up to pchain.MaxTxSize (64 KiB) bytes, with its 0x prefix
+// and a trailing newline.
+const maxSignedTxHexLen = 2*pchain.MaxTxSize + len("0x") + len("\n")
+
+var (
+	txBroadcastHex  string
+	txBroadcastFile string
+	txBroadcastWait bool
+	txPollInterval  time.Duration
+	txMaxWait       time.Duration
+)
+
+var txCmd = &cobra.Command{
+	Use:   "tx",
+	Short: "Transaction utilities",
+	Long:  `Work with pre-built P-Chain transactions.`,
+	RunE:  requireSubcommand,
+}
+
+var txBroadcastCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Submit a pre-signed P-Chain transaction",
+	Long: `Submit a signed P-Chain transaction, such as one produced with --broadcast=false.
+
+The transaction is decoded and checked to be a signed P-Chain transaction
+before it is sent to the selected network.
+
+With --wait, the transaction's status is polled until it is accepted. Polling
+starts every --poll-interval and backs off exponentially; --max-wait bounds the
+wait, which never outlasts --timeout. An aborted or dropped transaction fails.
+
+Examples:
+  platform-cli tx broadcast --hex 0x0000...
+  platform-cli tx broadcast --in signed-tx.hex --wait
+  platform-cli tx broadcast --in signed-tx.hex --wait --poll-interval 2s --max-wait 1m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wait, err := waitConfigFromFlags(cmd, txBroadcastWait)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		txHex, err := readSignedTxHex(txBroadcastHex, txBroadcastFile)
+		if err != nil {
+			return err
+		}
+		txBytes, err := decodeHex(txHex)
+		if err != nil {
+			return fmt.Errorf("invalid transaction hex: %w", err)
+		}
+		tx, err := pchain.ParseSignedTx(txBytes)
+		if err != nil {
+			return err
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		fmt.Printf("Broadcasting %T %s...\n", tx.Unsigned, tx.ID())
+		txID, err := pchain.BroadcastSignedTxWithConfig(ctx, netConfig, tx, wait)
+		if err != nil {
+			return err
+		}
+
+		printTxID("TX ID", txID)
+		if txBroadcastWait {
+			fmt.Println("Transaction accepted.")
+		}
+		return nil
+	},
+}
+
+// waitConfigFromFlags returns the acceptance polling schedule for a command
+// with --wait, or nil when not waiting. --poll-interval and --max-wait are
+// rejected without --wait, where they would silently do nothing.
+func waitConfigFromFlags(cmd *cobra.Command, wait bool) (*pchain.WaitConfig, error) {
+	if !wait {
+		for _, name := range []string{"poll-interval", "max-wait"} {
+			if cmd.Flags().Changed(name) {
+				return nil, fmt.Errorf("--%s requires --wait", name)
+			}
+		}
+		return nil, nil
+	}
+	if txPollInterval <= 0 {
+		return nil, fmt.Errorf("--poll-interval must be positive (got %s)", txPollInterval)
+	}
+	if txMaxWait < 0 {
+		return nil, fmt.Errorf("--max-wait must not be negative (got %s)", txMaxWait)
+	}
+	cfg := pchain.DefaultWaitConfig()
+	cfg.PollInterval = txPollInterval
+	cfg.MaxWait = txMaxWait
+	return &cfg, nil
+}
+
+// readSignedTxHex returns the signed transaction hex from exactly one of
+// --hex or --in.
+func readSignedTxHex(hexArg, path string) (string, error) {
+	hexArg = strings.TrimSpace(hexArg)
+	path = strings.TrimSpace(path)
+	switch {
+	case hexArg != "" && path != "":
+		return "", fmt.Errorf("use either --hex or --in, not both")
+	case hexArg != "":
+		return hexArg, nil
+	case path == "":
+		return "", fmt.Errorf("--hex or --in is required")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open transaction file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, int64(maxSignedTxHexLen)+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read transaction file: %w", err)
+	}
+	if len(data) > maxSignedTxHexLen {
+		return "", fmt.Errorf("transaction file too large: more than %d bytes", maxSignedTxHexLen)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// applyWalletFlags applies the global flags that shape how a loaded P-Chain
+// wallet builds and issues transactions: --change-address and --broadcast.
+func applyWalletFlags(w *wallet.Wallet) error {
+	if err := applyChangeAddress(w, w.PChainAddress(), w.Config().NetworkID); err != nil {
+		return err
+	}
+	if !broadcastTx {
+		w.SetSignOnly()
+	}
+	if outputTxFile != "" {
+		if err := signedTxFile.setPath(outputTxFile); err != nil {
+			return err
+		}
+		w.SetSignedTxHandler(signedTxFile.write)
+	}
+	w.SetRefreshBeforeIssue(refreshBefore)
+	w.SetIssueHandlers(trackIssuedTx, trackConfirmedTx)
+	return nil
+}
+
+// signedTxFilePerm is the mode of --output-tx-file files. A signed
+// transaction holds no key material, but it can be broadcast by anyone who
+// reads it, so only the owner may.
+const signedTxFilePerm = 0o600
+
+// signedTxFile writes the transactions signed in this run to --output-tx-file.
+var signedTxFile = &txFileWriter{}
+
+// txFileWriter writes each signed transaction, before it is issued, to its
+// own file as 0x-prefixed hex, the format `tx broadcast --in` reads. The
+// first goes to path; later ones, from commands that sign several, get a
+// numbered name (signed.hex, signed-2.hex, ...). Existing files are never
+// overwritten.
+type txFileWriter struct {
+	path  string
+	count int
+}
+
+// setPath points the writer at path, failing early if the first file it
+// would write already exists.
+func (f *txFileWriter) setPath(path string) error {
+	if f.path == path {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return withExitCode(exitUsage, fmt.Errorf("--output-tx-file %s already exists", path))
+	}
+	f.path, f.count = path, 0
+	return nil
+}
+
+func (f *txFileWriter) write(tx *txs.Tx) error {
+	path := numberedPath(f.path, f.count+1)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, signedTxFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create signed transaction file: %w", err)
+	}
+	if _, err := fmt.Fprintf(file, "0x%s\n", hex.EncodeToString(tx.Bytes())); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write signed transaction file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write signed transaction file: %w", err)
+	}
+	f.count++
+	fmt.Fprintf(progressWriter(), "Signed transaction %s written to %s\n", tx.ID(), path)
+	return nil
+}
+
+// numberedPath returns path for n == 1 and otherwise path with "-n" before
+// its extension.
+func numberedPath(path string, n int) string {
+	if n == 1 {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), n, ext)
+}
+
+// printSignedTxs prints the signed-but-unissued transactions held by a
+// sign-only wallet as 0x-prefixed hex and reports whether it did so. Commands
+// call it right after building a transaction and return early when it is true,
+// so no "submitted" style messages are printed for transactions that were
+// never broadcast.
+func printSignedTxs(w *wallet.Wallet) bool {
+	if !w.IsSignOnly() {
+		return false
+	}
+	for _, tx := range w.SignedTxs() {
+		fmt.Println("Transaction signed but NOT broadcast (--broadcast=false).")
+		fmt.Printf("TX ID: %s\n", tx.ID())
+		fmt.Printf("Signed TX: 0x%s\n", hex.EncodeToString(tx.Bytes()))
+	}
+	fmt.Println("Submit it later with: platform-cli tx broadcast --hex <signed tx>")
+	return true
+}
+
+// checkFunds fails early, with the exact shortfall, when the wallet's P-Chain
+// balance cannot cover amountNAVAX (described by purpose, e.g. "staked") plus
+// the fee. Call it after loading the wallet and before building the
+// transaction; pass 0 for transactions that only pay a fee.
+func checkFunds(ctx context.Context, w pchain.PChainSpender, amountNAVAX uint64, purpose string) error {
+	if skipBalanceCheck {
+		return nil
+	}
+	if err := refreshBeforeCheck(ctx, w); err != nil {
+		return err
+	}
+	return pchain.CheckFunds(ctx, w, amountNAVAX, purpose)
+}
+
+// checkStakeFunds is checkFunds for a stake, counting stakeable-locked
+// funds towards stakeNAVAX.
+func checkStakeFunds(ctx context.Context, w pchain.PChainSpender, stakeNAVAX uint64) error {
+	if skipBalanceCheck {
+		return nil
+	}
+	if err := refreshBeforeCheck(ctx, w); err != nil {
+		return err
+	}
+	return pchain.CheckStakeFunds(ctx, w, stakeNAVAX)
+}
+
+// refreshBeforeCheck applies --refresh-before to the balance check, so it
+// sees the same UTXOs the transaction will be built from.
+func refreshBeforeCheck(ctx context.Context, w pchain.PChainSpender) error {
+	if r, ok := w.(interface{ RefreshBeforeIssue(context.Context) error }); ok {
+		return r.RefreshBeforeIssue(ctx)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(txCmd)
+	txCmd.AddCommand(txBroadcastCmd)
+
+	txBroadcastCmd.Flags().StringVar(&txBroadcastHex, "hex", "", "Signed transaction as hex (0x prefix optional)")
+	txBroadcastCmd.Flags().StringVar(&txBroadcastFile, "in", "", "Read signed transaction hex from a file")
+	txBroadcastCmd.Flags().BoolVar(&txBroadcastWait, "wait", false, "Wait for the transaction to be accepted")
+	txBroadcastCmd.Flags().DurationVar(&txPollInterval, "poll-interval", pchain.DefaultPollInterval, fmt.Sprintf("With --wait, initial delay between status checks; doubles up to %s", pchain.DefaultMaxPollInterval))
+	txBroadcastCmd.Flags().DurationVar(&txMaxWait, "max-wait", 0, "With --wait, give up after this long (default: until --timeout)")
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+func TestLoadFullWallet_RejectsSignOnly(t *testing.T) {
+	orig := broadcastTx
+	defer func() { broadcastTx = orig }()
+
+	broadcastTx = false
+	_, _, err := loadFullWallet(context.Background(), network.Fuji)
+	if err == nil {
+		t.Fatal("loadFullWallet() expected error with --broadcast=false")
+	}
+	if !strings.Contains(err.Error(), "--broadcast=false") {
+		t.Fatalf("loadFullWallet() error = %v, want mention of --broadcast=false", err)
+	}
+}
+
+func TestLoadFullWallet_RejectsRefreshBefore(t *testing.T) {
+	orig := refreshBefore
+	defer func() { refreshBefore = orig }()
+
+	refreshBefore = true
+	_, _, err := loadFullWallet(context.Background(), network.Fuji)
+	if err == nil {
+		t.Fatal("loadFullWallet() expected error with --refresh-before")
+	}
+	if exitCode(err) != exitUsage || !strings.Contains(err.Error(), "--refresh-before") {
+		t.Fatalf("loadFullWallet() error = %v, want a usage error mentioning --refresh-before", err)
+	}
+}
+
+func TestReadSignedTxHex(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tx.hex")
+	if err := os.WriteFile(path, []byte("  0xabcd\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := readSignedTxHex("", path)
+	if err != nil {
+		t.Fatalf("readSignedTxHex(file) returned error: %v", err)
+	}
+	if got != "0xabcd" {
+		t.Fatalf("readSignedTxHex(file) = %q, want %q", got, "0xabcd")
+	}
+
+	got, err = readSignedTxHex(" 0x1234 ", "")
+	if err != nil {
+		t.Fatalf("readSignedTxHex(hex) returned error: %v", err)
+	}
+	if got != "0x1234" {
+		t.Fatalf("readSignedTxHex(hex) = %q, want %q", got, "0x1234")
+	}
+
+	if _, err := readSignedTxHex("0x1234", path); err == nil {
+		t.Fatal("readSignedTxHex() expected error when both --hex and --in are set")
+	}
+	if _, err := readSignedTxHex("", ""); err == nil {
+		t.Fatal("readSignedTxHex() expected error when neither --hex nor --in is set")
+	}
+
+	large := filepath.Join(dir, "large.hex")
+	if err := os.WriteFile(large, make([]byte, maxSignedTxHexLen+1), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := readSignedTxHex("", large); err == nil {
+		t.Fatal("readSignedTxHex() expected error for oversized file")
+	}
+}
+
+func TestWaitConfigFromFlags(t *testing.T) {
+	origInterval, origMaxWait := txPollInterval, txMaxWait
+	defer func() { txPollInterval, txMaxWait = origInterval, origMaxWait }()
+
+	tests := []struct {
+		name         string
+		args         []string
+		wait         bool
+		wantNil      bool
+		wantInterval time.Duration
+		wantMaxWait  time.Duration
+		wantErr      string
+	}{
+		{name: "no wait", wantNil: true},
+		{name: "defaults", wait: true, wantInterval: pchain.DefaultPollInterval},
+		{name: "tuned", args: []string{"--poll-interval", "2s", "--max-wait", "1m"}, wait: true, wantInterval: 2 * time.Second, wantMaxWait: time.Minute},
+		{name: "interval without wait", args: []string{"--poll-interval", "2s"}, wantErr: "--poll-interval requires --wait"},
+		{name: "max-wait without wait", args: []string{"--max-wait", "1m"}, wantErr: "--max-wait requires --wait"},
+		{name: "zero interval", args: []string{"--poll-interval", "0s"}, wait: true, wantErr: "must be positive"},
+		{name: "negative max-wait", args: []string{"--max-wait", "-1s"}, wait: true, wantErr: "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().DurationVar(&txPollInterval, "poll-interval", pchain.DefaultPollInterval, "")
+			cmd.Flags().DurationVar(&txMaxWait, "max-wait", 0, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+			}
+
+			got, err := waitConfigFromFlags(cmd, tt.wait)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitConfigFromFlags() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitConfigFromFlags() error = %v", err)
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("waitConfigFromFlags() = %+v, want nil", got)
+				}
+				return
+			}
+			if got.PollInterval != tt.wantInterval || got.MaxWait != tt.wantMaxWait || got.MaxInterval != pchain.DefaultMaxPollInterval {
+				t.Fatalf("waitConfigFromFlags() = %+v, want interval %s, max wait %s", got, tt.wantInterval, tt.wantMaxWait)
+			}
+		})
+	}
+}
+
+func TestTxFileWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "signed.hex")
+	f := &txFileWriter{}
+	if err := f.setPath(path); err != nil {
+		t.Fatalf("setPath() error = %v", err)
+	}
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{}}
+	tx.SetBytes([]byte{0xab, 0xcd}, []byte{0xab, 0xcd})
+	for range 2 {
+		if err := f.write(tx); err != nil {
+			t.Fatalf("write() error = %v", err)
+		}
+	}
+	for _, p := range []string{path, filepath.Join(dir, "signed-2.hex")} {
+		info, err := os.Stat(p)
+		if err != nil {
+			t.Fatalf("Stat(%s) error = %v", p, err)
+		}
+		if perm := info.Mode().Perm(); perm != signedTxFilePerm {
+			t.Errorf("%s mode = %o, want %o", p, perm, signedTxFilePerm)
+		}
+		got, err := readSignedTxHex("", p)
+		if err != nil || got != "0xabcd" {
+			t.Errorf("readSignedTxHex(%s) = %q, %v; want 0xabcd", p, got, err)
+		}
+	}
+
+	// Existing files are never overwritten.
+	if err := (&txFileWriter{}).setPath(path); err == nil {
+		t.Fatal("setPath() expected error for an existing file")
+	}
+	clash := &txFileWriter{path: filepath.Join(dir, "other.hex")}
+	if err := os.WriteFile(clash.path, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := clash.write(tx); err == nil {
+		t.Fatal("write() expected error when the file already exists")
+	}
+}
+
+func TestNumberedPath(t *testing.T) {
+	tests := []struct {
+		path string
+		n    int
+		want string
+	}{
+		{"signed.hex", 1, "signed.hex"},
+		{"signed.hex", 3, "signed-3.hex"},
+		{"out/signed", 2, "out/signed-2"},
+	}
+	for _, tt := range tests {
+		if got := numberedPath(tt.path, tt.n); got != tt.want {
+			t.Errorf("numberedPath(%q, %d) = %q, want %q", tt.path, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+)
+
+// Supported values for --units.
+const (
+	unitsAVAX  = "avax"
+	unitsNAVAX = "navax"
+)
+
+// displayUnits selects the unit of AVAX amounts in balance and transfer text
+// output. Structured output is unaffected.
+var displayUnits string
+
+// validateUnits rejects unknown --units values. Case is ignored.
+func validateUnits() error {
+	switch strings.ToLower(displayUnits) {
+	case unitsAVAX, unitsNAVAX:
+		displayUnits = strings.ToLower(displayUnits)
+		return nil
+	default:
+		return withExitCode(exitUsage, fmt.Errorf("invalid --units %q: must be %q or %q", displayUnits, unitsAVAX, unitsNAVAX))
+	}
+}
+
+// formatAmount renders an nAVAX amount with its unit, as selected by
+// --units: "1,234.5 AVAX" or the exact integer "1234500000000 nAVAX".
+func formatAmount(nAVAX uint64) string {
+	if displayUnits == unitsNAVAX {
+		return strconv.FormatUint(nAVAX, 10) + " nAVAX"
+	}
+	return pchain.FormatAVAX(nAVAX) + " AVAX"
+}
+
+// formatAmountExact renders an nAVAX amount as the exact integer, followed
+// by AVAX in parentheses unless --units navax is set:
+// "1500000000 nAVAX (1.5 AVAX)".
+func formatAmountExact(nAVAX uint64) string {
+	exact := strconv.FormatUint(nAVAX, 10) + " nAVAX"
+	if displayUnits == unitsNAVAX {
+		return exact
+	}
+	return exact + " (" + pchain.FormatAVAX(nAVAX) + " AVAX)"
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&displayUnits, "units", unitsAVAX, "Unit for balances and transfer amounts in text output: avax or navax (exact integer)")
+}
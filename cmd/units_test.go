@@ -0,0 +1,53 @@
+package cmd
+
+import "testing"
+
+func TestValidateUnits(t *testing.T) {
+	orig := displayUnits
+	defer func() { displayUnits = orig }()
+
+	for _, valid := range []string{unitsAVAX, unitsNAVAX, "nAVAX", "AVAX"} {
+		displayUnits = valid
+		if err := validateUnits(); err != nil {
+			t.Fatalf("validateUnits(%q) returned error: %v", valid, err)
+		}
+	}
+	if displayUnits != unitsAVAX {
+		t.Fatalf("validateUnits() left --units %q, want it lower-cased to %q", displayUnits, unitsAVAX)
+	}
+
+	displayUnits = "wei"
+	err := validateUnits()
+	if err == nil {
+		t.Fatal("validateUnits(\"wei\") expected error")
+	}
+	if got := exitCode(err); got != exitUsage {
+		t.Fatalf("exitCode(validateUnits(\"wei\")) = %d, want %d", got, exitUsage)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	orig := displayUnits
+	defer func() { displayUnits = orig }()
+
+	tests := []struct {
+		units     string
+		nAVAX     uint64
+		want      string
+		wantExact string
+	}{
+		{unitsAVAX, 1_234_500_000_000, "1,234.5 AVAX", "1234500000000 nAVAX (1,234.5 AVAX)"},
+		{unitsAVAX, 1, "0.000000001 AVAX", "1 nAVAX (0.000000001 AVAX)"},
+		{unitsNAVAX, 1_234_500_000_000, "1234500000000 nAVAX", "1234500000000 nAVAX"},
+		{unitsNAVAX, 0, "0 nAVAX", "0 nAVAX"},
+	}
+	for _, tt := range tests {
+		displayUnits = tt.units
+		if got := formatAmount(tt.nAVAX); got != tt.want {
+			t.Errorf("--units %s: formatAmount(%d) = %q, want %q", tt.units, tt.nAVAX, got, tt.want)
+		}
+		if got := formatAmountExact(tt.nAVAX); got != tt.wantExact {
+			t.Errorf("--units %s: formatAmountExact(%d) = %q, want %q", tt.units, tt.nAVAX, got, tt.wantExact)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
@@ -11,12 +14,14 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	nodeutil "github.com/ava-labs/platform-cli/pkg/node"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
 	"github.com/spf13/cobra"
 )
 
 var (
 	valNodeID        string
 	valStakeAmount   float64
+	valStakeNAVAX    uint64
 	valStartTime     string
 	valDuration      string
 	valDelegationFee float64
@@ -34,6 +39,32 @@ var (
 	valSetAutoCompound float64
 )
 
+// confirmRewardAddress echoes the resolved reward address and, when it differs
+// from the signing wallet's own address, requires --yes or an interactive
+// "yes" before a staking transaction is submitted. Rewards sent to a mistyped
+// or uncontrolled address cannot be recovered.
+func confirmRewardAddress(in io.Reader, interactive bool, own, reward ids.ShortID, networkID uint32) error {
+	formatted := wallet.FormatPChainAddress(reward, networkID)
+	fmt.Printf("  Reward Address: %s\n", formatted)
+	if reward == own || skipConfirmations() {
+		return nil
+	}
+
+	printWarning("WARNING: reward address %s is not the signing wallet's address (%s). Staking rewards sent there cannot be recovered if it is wrong.",
+		formatted, wallet.FormatPChainAddress(own, networkID))
+	confirmed, err := confirmPrompt(in, interactive, os.Stdout, "Type 'yes' to confirm the reward address: ", confirmYes)
+	if errors.Is(err, errNotInteractive) {
+		return fmt.Errorf("reward address differs from the signing wallet; re-run with --yes to confirm %s", formatted)
+	}
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("reward address not confirmed; aborting")
+	}
+	return nil
+}
+
 var validatorCmd = &cobra.Command{
 	Use:   "validator",
 	Short: "Primary network staking",
@@ -41,23 +72,76 @@ var validatorCmd = &cobra.Command{
 	RunE:  requireSubcommand,
 }
 
+// stakeMagnitudeWarningAVAX is the --stake value above which the number looks
+// more like a nAVAX amount typed into the AVAX flag (1M AVAX is a third of
+// the maximum validator stake).
+const stakeMagnitudeWarningAVAX = 1_000_000
+
+// stakeFlagNAVAX returns the stake in nAVAX from --stake (AVAX) or
+// --stake-navax, warning when --stake looks like a nAVAX amount.
+func stakeFlagNAVAX(cmd *cobra.Command) (uint64, error) {
+	if cmd.Flags().Changed("stake-navax") {
+		if valStakeNAVAX == 0 {
+			return 0, fmt.Errorf("--stake-navax must be positive")
+		}
+		return valStakeNAVAX, nil
+	}
+	if valStakeAmount <= 0 {
+		return 0, fmt.Errorf("--stake (AVAX) or --stake-navax is required and must be positive")
+	}
+	stakeNAVAX, err := avaxToNAVAX(valStakeAmount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stake amount: %w (--stake is in AVAX; use --stake-navax for nAVAX)", err)
+	}
+	if warning := stakeUnitWarning(valStakeAmount, stakeNAVAX); warning != "" && !skipConfirmations() {
+		printWarning("%s", warning)
+	}
+	return stakeNAVAX, nil
+}
+
+// stakeUnitWarning returns a warning when a --stake value in AVAX is large
+// enough that it was probably meant as nAVAX, or "" otherwise.
+func stakeUnitWarning(stakeAVAX float64, stakeNAVAX uint64) string {
+	if stakeAVAX <= stakeMagnitudeWarningAVAX {
+		return ""
+	}
+	return fmt.Sprintf("WARNING: --stake %s is read as AVAX (%d nAVAX). If you meant nAVAX, use --stake-navax instead.",
+		pchain.FormatAVAX(stakeNAVAX), stakeNAVAX)
+}
+
+// validateDelegationFeeFlag is a PreRunE hook that rejects a bad
+// --delegation-fee before any network or wallet work is done.
+func validateDelegationFeeFlag(_ *cobra.Command, _ []string) error {
+	if _, err := feeToShares(valDelegationFee); err != nil {
+		return fmt.Errorf("invalid --delegation-fee: %w", err)
+	}
+	return nil
+}
+
 var validatorAddCmd = &cobra.Command{
 	Use:   "add-permissionless",
 	Short: "Add a primary network validator (AddPermissionlessValidatorTx)",
-	Long:  `Add a permissionless validator to the Avalanche primary network.`,
+	Long: `Add a permissionless validator to the Avalanche primary network.
+
+--stake is in AVAX; use --stake-navax to give the amount in nAVAX
+(1 AVAX = 1,000,000,000 nAVAX). --delegation-fee is a fraction, not a
+percentage: 0.02 means 2%.
+
+Examples:
+  platform-cli validator add-permissionless --node-id NodeID-... --stake 2000 --delegation-fee 0.02
+  platform-cli validator add-permissionless --node-id NodeID-... --stake-navax 2000000000000 --duration 720h`,
+	PreRunE: validateDelegationFeeFlag,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		if valStakeAmount <= 0 {
-			return fmt.Errorf("--stake is required and must be positive")
-		}
-		if valNodeID == "" {
-			return fmt.Errorf("--node-id is required")
+		stakeNAVAX, err := stakeFlagNAVAX(cmd)
+		if err != nil {
+			return err
 		}
-		nodeID, err := ids.NodeIDFromString(valNodeID)
+		nodeID, err := parseNodeIDFlag("node-id", valNodeID)
 		if err != nil {
-			return fmt.Errorf("invalid node ID: %w", err)
+			return err
 		}
 
 		start, end, err := parseTimeRange(valStartTime, valDuration)
@@ -65,7 +149,7 @@ var validatorAddCmd = &cobra.Command{
 			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -84,20 +168,16 @@ var validatorAddCmd = &cobra.Command{
 		}
 		defer cleanup()
 
-		rewardAddr := w.PChainAddress()
-		if valRewardAddr != "" {
-			rewardAddr, err = ids.ShortFromString(valRewardAddr)
-			if err != nil {
-				return fmt.Errorf("invalid reward address: %w", err)
-			}
-		}
-
-		stakeNAVAX, err := avaxToNAVAX(valStakeAmount)
+		rewardAddr, err := resolveRewardAddress(valRewardAddr, w.PChainAddress())
 		if err != nil {
-			return fmt.Errorf("invalid stake amount: %w", err)
+			return fmt.Errorf("invalid reward address: %w", err)
 		}
-		if stakeNAVAX < netConfig.MinValidatorStake {
-			return fmt.Errorf("stake too low for %s: minimum is %.9f AVAX", netConfig.Name, float64(netConfig.MinValidatorStake)/1e9)
+		if err := checkAddressPolicy("reward address", []ids.ShortID{rewardAddr}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		if err := pchain.ValidateValidatorStake(netConfig, stakeNAVAX); err != nil {
+			return err
 		}
 
 		delegationFeeShares, err := feeToShares(valDelegationFee)
@@ -105,7 +185,7 @@ var validatorAddCmd = &cobra.Command{
 			return fmt.Errorf("invalid delegation fee: %w", err)
 		}
 
-		fmt.Printf("Adding validator %s with %.9f AVAX stake...\n", nodeID, valStakeAmount)
+		fmt.Printf("Adding validator %s with %s AVAX stake...\n", nodeID, pchain.FormatAVAX(stakeNAVAX))
 		fmt.Printf("  Start: %s\n", start.UTC().Format("2006-01-02 15:04:05 MST"))
 		fmt.Printf("  End: %s\n", end.UTC().Format("2006-01-02 15:04:05 MST"))
 		fmt.Printf("  Delegation Fee: %.2f%%\n", valDelegationFee*100)
@@ -114,6 +194,12 @@ var validatorAddCmd = &cobra.Command{
 		} else {
 			fmt.Println("  BLS PoP Source: --bls-public-key/--bls-pop flags")
 		}
+		if err := checkStakeFunds(ctx, w, stakeNAVAX); err != nil {
+			return err
+		}
+		if err := confirmRewardAddress(os.Stdin, stdinIsTerminal(), w.PChainAddress(), rewardAddr, netConfig.NetworkID); err != nil {
+			return err
+		}
 		fmt.Println("Submitting transaction...")
 
 		txID, err := pchain.AddPermissionlessValidator(ctx, w, pchain.AddPermissionlessValidatorConfig{
@@ -129,7 +215,11 @@ var validatorAddCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID("TX ID", txID)
 		return nil
 	},
 }
@@ -137,7 +227,14 @@ var validatorAddCmd = &cobra.Command{
 var validatorDelegateCmd = &cobra.Command{
 	Use:   "add-permissionless-delegator",
 	Short: "Delegate to a primary network validator (AddPermissionlessDelegatorTx)",
-	Long:  `Delegate stake to an existing primary network validator.`,
+	Long: `Delegate stake to an existing primary network validator.
+
+--stake is in AVAX; use --stake-navax to give the amount in nAVAX
+(1 AVAX = 1,000,000,000 nAVAX).
+
+Examples:
+  platform-cli validator add-permissionless-delegator --node-id NodeID-... --stake 25
+  platform-cli validator add-permissionless-delegator --node-id NodeID-... --stake-navax 25000000000 --duration 720h`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
@@ -145,13 +242,14 @@ var validatorDelegateCmd = &cobra.Command{
 		if valNodeID == "" {
 			return fmt.Errorf("--node-id is required")
 		}
-		if valStakeAmount <= 0 {
-			return fmt.Errorf("--stake is required and must be positive")
+		stakeNAVAX, err := stakeFlagNAVAX(cmd)
+		if err != nil {
+			return err
 		}
 
-		nodeID, err := ids.NodeIDFromString(valNodeID)
+		nodeID, err := parseNodeIDFlag("node-id", valNodeID)
 		if err != nil {
-			return fmt.Errorf("invalid node ID: %w", err)
+			return err
 		}
 
 		start, end, err := parseTimeRange(valStartTime, valDuration)
@@ -159,7 +257,7 @@ var validatorDelegateCmd = &cobra.Command{
 			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -173,25 +271,27 @@ var validatorDelegateCmd = &cobra.Command{
 		}
 		defer cleanup()
 
-		rewardAddr := w.PChainAddress()
-		if valRewardAddr != "" {
-			rewardAddr, err = ids.ShortFromString(valRewardAddr)
-			if err != nil {
-				return fmt.Errorf("invalid reward address: %w", err)
-			}
-		}
-
-		stakeNAVAX, err := avaxToNAVAX(valStakeAmount)
+		rewardAddr, err := resolveRewardAddress(valRewardAddr, w.PChainAddress())
 		if err != nil {
-			return fmt.Errorf("invalid stake amount: %w", err)
+			return fmt.Errorf("invalid reward address: %w", err)
 		}
-		if stakeNAVAX < netConfig.MinDelegatorStake {
-			return fmt.Errorf("stake too low for %s: minimum is %.9f AVAX", netConfig.Name, float64(netConfig.MinDelegatorStake)/1e9)
+		if err := checkAddressPolicy("reward address", []ids.ShortID{rewardAddr}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		if err := pchain.ValidateDelegatorStake(netConfig, stakeNAVAX); err != nil {
+			return err
 		}
 
-		fmt.Printf("Delegating %.9f AVAX to validator %s...\n", valStakeAmount, nodeID)
+		fmt.Printf("Delegating %s AVAX to validator %s...\n", pchain.FormatAVAX(stakeNAVAX), nodeID)
 		fmt.Printf("  Start: %s\n", start.UTC().Format("2006-01-02 15:04:05 MST"))
 		fmt.Printf("  End: %s\n", end.UTC().Format("2006-01-02 15:04:05 MST"))
+		if err := checkStakeFunds(ctx, w, stakeNAVAX); err != nil {
+			return err
+		}
+		if err := confirmRewardAddress(os.Stdin, stdinIsTerminal(), w.PChainAddress(), rewardAddr, netConfig.NetworkID); err != nil {
+			return err
+		}
 		fmt.Println("Submitting transaction...")
 
 		txID, err := pchain.AddPermissionlessDelegator(ctx, w, pchain.AddPermissionlessDelegatorConfig{
@@ -205,7 +305,11 @@ var validatorDelegateCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID("TX ID", txID)
 		return nil
 	},
 }
@@ -213,20 +317,27 @@ var validatorDelegateCmd = &cobra.Command{
 var validatorAddAutoRenewedCmd = &cobra.Command{
 	Use:   "add-auto-renewed",
 	Short: "Add an auto-renewed primary network validator (AddAutoRenewedValidatorTx)",
-	Long:  `Add an auto-renewed validator to the Avalanche primary network.`,
+	Long: `Add an auto-renewed validator to the Avalanche primary network.
+
+--stake is in AVAX; use --stake-navax to give the amount in nAVAX
+(1 AVAX = 1,000,000,000 nAVAX). --delegation-fee and --auto-compound are
+fractions: 0.02 means 2%, 1 means 100%.
+
+Examples:
+  platform-cli validator add-auto-renewed --node-id NodeID-... --stake 2000 --period 336h
+  platform-cli validator add-auto-renewed --node-id NodeID-... --stake 2000 --delegation-fee 0.05 --auto-compound 0.5`,
+	PreRunE: validateDelegationFeeFlag,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		if valStakeAmount <= 0 {
-			return fmt.Errorf("--stake is required and must be positive")
-		}
-		if valNodeID == "" {
-			return fmt.Errorf("--node-id is required")
+		stakeNAVAX, err := stakeFlagNAVAX(cmd)
+		if err != nil {
+			return err
 		}
-		nodeID, err := ids.NodeIDFromString(valNodeID)
+		nodeID, err := parseNodeIDFlag("node-id", valNodeID)
 		if err != nil {
-			return fmt.Errorf("invalid node ID: %w", err)
+			return err
 		}
 
 		period, err := parseAutoRenewPeriod(valAutoPeriod)
@@ -234,7 +345,7 @@ var validatorAddAutoRenewedCmd = &cobra.Command{
 			return err
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -256,28 +367,24 @@ var validatorAddAutoRenewedCmd = &cobra.Command{
 		}
 		defer cleanup()
 
-		rewardAddr := w.PChainAddress()
-		if valRewardAddr != "" {
-			rewardAddr, err = ids.ShortFromString(valRewardAddr)
-			if err != nil {
-				return fmt.Errorf("invalid reward address: %w", err)
-			}
-		}
-
-		authorityAddr := w.PChainAddress()
-		if valOwnerAddr != "" {
-			authorityAddr, err = ids.ShortFromString(valOwnerAddr)
-			if err != nil {
-				return fmt.Errorf("invalid owner address: %w", err)
-			}
+		rewardAddr, err := resolveRewardAddress(valRewardAddr, w.PChainAddress())
+		if err != nil {
+			return fmt.Errorf("invalid reward address: %w", err)
 		}
 
-		stakeNAVAX, err := avaxToNAVAX(valStakeAmount)
+		authorityAddr, err := resolveRewardAddress(valOwnerAddr, w.PChainAddress())
 		if err != nil {
-			return fmt.Errorf("invalid stake amount: %w", err)
+			return fmt.Errorf("invalid owner address: %w", err)
 		}
-		if stakeNAVAX < netConfig.MinValidatorStake {
-			return fmt.Errorf("stake too low for %s: minimum is %.9f AVAX", netConfig.Name, float64(netConfig.MinValidatorStake)/1e9)
+		if err := checkAddressPolicy("reward address", []ids.ShortID{rewardAddr}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+		if err := checkAddressPolicy("owner", []ids.ShortID{authorityAddr}, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		if err := pchain.ValidateValidatorStake(netConfig, stakeNAVAX); err != nil {
+			return err
 		}
 
 		delegationFeeShares, err := feeToShares(valDelegationFee)
@@ -289,7 +396,7 @@ var validatorAddAutoRenewedCmd = &cobra.Command{
 			return fmt.Errorf("invalid auto-compound: %w", err)
 		}
 
-		fmt.Printf("Adding auto-renewed validator %s with %.9f AVAX stake...\n", nodeID, valStakeAmount)
+		fmt.Printf("Adding auto-renewed validator %s with %s AVAX stake...\n", nodeID, pchain.FormatAVAX(stakeNAVAX))
 		fmt.Printf("  Period: %s\n", period)
 		fmt.Printf("  Delegation Fee: %.2f%%\n", valDelegationFee*100)
 		fmt.Printf("  Auto-Compound Rewards: %.2f%%\n", valAutoCompound*100)
@@ -299,6 +406,12 @@ var validatorAddAutoRenewedCmd = &cobra.Command{
 		} else {
 			fmt.Println("  BLS PoP Source: --bls-public-key/--bls-pop flags")
 		}
+		if err := checkStakeFunds(ctx, w, stakeNAVAX); err != nil {
+			return err
+		}
+		if err := confirmRewardAddress(os.Stdin, stdinIsTerminal(), w.PChainAddress(), rewardAddr, netConfig.NetworkID); err != nil {
+			return err
+		}
 		fmt.Println("Submitting transaction...")
 
 		txID, err := pchain.AddAutoRenewedValidator(ctx, w, pchain.AddAutoRenewedValidatorConfig{
@@ -315,7 +428,11 @@ var validatorAddAutoRenewedCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID("TX ID", txID)
 		return nil
 	},
 }
@@ -328,20 +445,17 @@ var validatorSetAutoConfigCmd = &cobra.Command{
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		if valSetAutoTxID == "" {
-			return fmt.Errorf("--tx-id is required")
-		}
-		autoRenewedTxID, err := ids.FromString(valSetAutoTxID)
+		autoRenewedTxID, err := parseIDFlag("tx-id", valSetAutoTxID)
 		if err != nil {
-			return fmt.Errorf("invalid tx ID: %w", err)
+			return err
 		}
 
 		// --node-id is optional but narrows the validator lookup to a single node.
 		var nodeID ids.NodeID
 		if valSetAutoNodeID != "" {
-			nodeID, err = ids.NodeIDFromString(valSetAutoNodeID)
+			nodeID, err = parseNodeIDFlag("node-id", valSetAutoNodeID)
 			if err != nil {
-				return fmt.Errorf("invalid node ID: %w", err)
+				return err
 			}
 		}
 
@@ -361,7 +475,7 @@ var validatorSetAutoConfigCmd = &cobra.Command{
 			return fmt.Errorf("invalid auto-compound: %w", err)
 		}
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -372,7 +486,7 @@ var validatorSetAutoConfigCmd = &cobra.Command{
 			return fmt.Errorf("period too long for %s: maximum is %s", netConfig.Name, netConfig.MaxStakeDuration)
 		}
 
-		validatorAuthority, err := pchain.GetAutoRenewedValidatorAuthority(ctx, netConfig.RPCURL, nodeID, autoRenewedTxID)
+		validatorAuthority, err := pchain.GetAutoRenewedValidatorAuthorityWithConfig(ctx, netConfig, nodeID, autoRenewedTxID)
 		if err != nil {
 			return err
 		}
@@ -394,6 +508,10 @@ var validatorSetAutoConfigCmd = &cobra.Command{
 		fmt.Printf("  Auto-Compound Rewards: %.2f%%\n", valSetAutoCompound*100)
 		fmt.Println("Submitting transaction...")
 
+		if err := checkFunds(ctx, w, 0, ""); err != nil {
+			return err
+		}
+
 		txID, err := pchain.SetAutoRenewedValidatorConfig(ctx, w, pchain.SetAutoRenewedValidatorConfigTxConfig{
 			TxID:                     autoRenewedTxID,
 			AutoCompoundRewardShares: autoCompoundShares,
@@ -403,7 +521,11 @@ var validatorSetAutoConfigCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("TX ID: %s\n", txID)
+		if printSignedTxs(w) {
+			return nil
+		}
+
+		printTxID("TX ID", txID)
 		return nil
 	},
 }
@@ -413,11 +535,7 @@ func parseTimeRange(startStr, durationStr string) (time.Time, time.Time, error)
 	var err error
 
 	if startStr == "" || startStr == "now" {
-		offset := 30 * time.Second
-		if useLedger {
-			offset = 5 * time.Minute
-		}
-		start = time.Now().Add(offset)
+		start = defaultStakeStart()
 	} else {
 		start, err = time.Parse(time.RFC3339, startStr)
 		if err != nil {
@@ -434,6 +552,16 @@ func parseTimeRange(startStr, durationStr string) (time.Time, time.Time, error)
 	return start, end, nil
 }
 
+// defaultStakeStart is the start time used for "now": shortly in the future,
+// leaving time to sign (longer on a Ledger) before the tx is issued.
+func defaultStakeStart() time.Time {
+	offset := 30 * time.Second
+	if useLedger {
+		offset = 5 * time.Minute
+	}
+	return time.Now().Add(offset)
+}
+
 // parseAutoRenewPeriod parses a positive, whole-second auto-renewal cycle
 // duration for add-auto-renewed.
 func parseAutoRenewPeriod(periodStr string) (time.Duration, error) {
@@ -524,10 +652,12 @@ func init() {
 	validatorAddCmd.Flags().StringVar(&valBLSPublicKey, "bls-public-key", "", "Validator BLS public key (hex, recommended/manual mode)")
 	validatorAddCmd.Flags().StringVar(&valBLSPoP, "bls-pop", "", "Validator BLS proof of possession signature (hex, recommended/manual mode)")
 	validatorAddCmd.Flags().Float64Var(&valStakeAmount, "stake", 0, "Stake amount in AVAX (min 2000)")
+	validatorAddCmd.Flags().Uint64Var(&valStakeNAVAX, "stake-navax", 0, "Stake amount in nAVAX (1 AVAX = 1,000,000,000 nAVAX), instead of --stake")
+	validatorAddCmd.MarkFlagsMutuallyExclusive("stake", "stake-navax")
 	validatorAddCmd.Flags().StringVar(&valStartTime, "start", "now", "Start time (RFC3339 or 'now'). Post-Durango networks ignore this; validation begins at tx acceptance")
 	validatorAddCmd.Flags().StringVar(&valDuration, "duration", "336h", "Validation duration (min 14 days)")
 	validatorAddCmd.Flags().Float64Var(&valDelegationFee, "delegation-fee", 0.02, "Delegation fee (0.02 = 2%)")
-	validatorAddCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address (default: own address)")
+	validatorAddCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address: bech32, short ID, or @key-name (default: own address)")
 
 	// Add auto-renewed validator flags
 	validatorAddAutoRenewedCmd.Flags().StringVar(&valNodeID, "node-id", "", "Node ID to validate (required)")
@@ -535,11 +665,13 @@ func init() {
 	validatorAddAutoRenewedCmd.Flags().StringVar(&valBLSPublicKey, "bls-public-key", "", "Validator BLS public key (hex, recommended/manual mode)")
 	validatorAddAutoRenewedCmd.Flags().StringVar(&valBLSPoP, "bls-pop", "", "Validator BLS proof of possession signature (hex, recommended/manual mode)")
 	validatorAddAutoRenewedCmd.Flags().Float64Var(&valStakeAmount, "stake", 0, "Stake amount in AVAX (network minimum applies)")
+	validatorAddAutoRenewedCmd.Flags().Uint64Var(&valStakeNAVAX, "stake-navax", 0, "Stake amount in nAVAX (1 AVAX = 1,000,000,000 nAVAX), instead of --stake")
+	validatorAddAutoRenewedCmd.MarkFlagsMutuallyExclusive("stake", "stake-navax")
 	validatorAddAutoRenewedCmd.Flags().StringVar(&valAutoPeriod, "period", "336h", "Auto-renewal cycle duration (for example, 336h for 14 days)")
 	validatorAddAutoRenewedCmd.Flags().Float64Var(&valDelegationFee, "delegation-fee", 0.02, "Delegation fee (0.02 = 2%)")
 	validatorAddAutoRenewedCmd.Flags().Float64Var(&valAutoCompound, "auto-compound", 1, "Fraction of rewards to auto-compound (0.3 = 30%, 1 = 100%)")
-	validatorAddAutoRenewedCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address (default: own address)")
-	validatorAddAutoRenewedCmd.Flags().StringVar(&valOwnerAddr, "owner-address", "", "Address authorized to update auto-renew config (default: own address)")
+	validatorAddAutoRenewedCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address: bech32, short ID, or @key-name (default: own address)")
+	validatorAddAutoRenewedCmd.Flags().StringVar(&valOwnerAddr, "owner-address", "", "Address authorized to update auto-renew config: bech32, short ID, or @key-name (default: own address)")
 
 	// Set auto-renewed validator config flags
 	validatorSetAutoConfigCmd.Flags().StringVar(&valSetAutoTxID, "tx-id", "", "Original AddAutoRenewedValidatorTx ID (required)")
@@ -550,7 +682,9 @@ func init() {
 	// Delegate flags
 	validatorDelegateCmd.Flags().StringVar(&valNodeID, "node-id", "", "Node ID to delegate to")
 	validatorDelegateCmd.Flags().Float64Var(&valStakeAmount, "stake", 0, "Stake amount in AVAX (min 25)")
+	validatorDelegateCmd.Flags().Uint64Var(&valStakeNAVAX, "stake-navax", 0, "Stake amount in nAVAX (1 AVAX = 1,000,000,000 nAVAX), instead of --stake")
+	validatorDelegateCmd.MarkFlagsMutuallyExclusive("stake", "stake-navax")
 	validatorDelegateCmd.Flags().StringVar(&valStartTime, "start", "now", "Start time (RFC3339 or 'now'). Post-Durango networks ignore this; validation begins at tx acceptance")
 	validatorDelegateCmd.Flags().StringVar(&valDuration, "duration", "336h", "Delegation duration (min 14 days)")
-	validatorDelegateCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address (default: own address)")
+	validatorDelegateCmd.Flags().StringVar(&valRewardAddr, "reward-address", "", "Reward address: bech32, short ID, or @key-name (default: own address)")
 }
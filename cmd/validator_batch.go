@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+// maxValidatorBatchFileLen caps the size of a validator batch file (1 MB).
+const maxValidatorBatchFileLen = 1 << 20
+
+// validatorBatchFields is the number of columns in a validator batch row.
+const validatorBatchFields = 6
+
+// validatorBatchHeaderField is the first column name of an optional header row.
+const validatorBatchHeaderField = "nodeid"
+
+var valBatchFile string
+
+// batchValidator is one validated row of a validator batch file. Exactly one
+// of duration and end is set.
+type batchValidator struct {
+	line       int
+	nodeID     ids.NodeID
+	pop        *signer.ProofOfPossession
+	stakeNAVAX uint64
+	duration   time.Duration
+	end        time.Time
+	reward     string // as written; resolved once the wallet is loaded
+}
+
+// timeRange returns the validation period the row would get if issued now.
+func (v batchValidator) timeRange() (time.Time, time.Time) {
+	start := defaultStakeStart()
+	if v.duration > 0 {
+		return start, start.Add(v.duration)
+	}
+	return start, v.end
+}
+
+var validatorAddBatchCmd = &cobra.Command{
+	Use:   "add-permissionless-batch",
+	Short: "Add many primary network validators from a CSV file",
+	Long: `Add a permissionless primary network validator for every row of a CSV file.
+
+Each row is "nodeID,blsPublicKey,blsPoP,stake,durationOrEnd,rewardAddress":
+  stake          AVAX (suffix "n" for nAVAX)
+  durationOrEnd  a duration such as 336h, or an RFC3339 end time
+  rewardAddress  bech32, short ID or @key-name; empty for the signing wallet
+Blank lines and lines starting with # are ignored, as is an optional header
+row starting with "nodeID". --delegation-fee applies to every validator.
+
+Every row is checked before anything is issued: node IDs must be unique, proofs
+of possession must verify, stakes and durations must meet the network's limits,
+and the wallet must hold the total stake. Transactions are then issued one at a
+time, each waiting for acceptance. The first failure stops the batch; the error
+names its line, and validators from earlier lines stay added.
+
+Example:
+  platform-cli validator add-permissionless-batch --file validators.csv --delegation-fee 0.05`,
+	PreRunE: validateDelegationFeeFlag,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		if valBatchFile == "" {
+			return withExitCode(exitUsage, fmt.Errorf("--file is required"))
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		validators, err := readValidatorBatchFile(valBatchFile, netConfig.NetworkID)
+		if err != nil {
+			return err
+		}
+		if len(validators) > 1 && !broadcastTx {
+			return fmt.Errorf("--broadcast=false supports a single validator: every transaction after the first would spend the same UTXOs")
+		}
+		totalStake, err := checkValidatorBatch(validators, netConfig)
+		if err != nil {
+			return err
+		}
+		delegationFeeShares, err := feeToShares(valDelegationFee)
+		if err != nil {
+			return fmt.Errorf("invalid delegation fee: %w", err)
+		}
+
+		w, cleanup, err := loadPChainWallet(ctx, netConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create wallet: %w", err)
+		}
+		defer cleanup()
+
+		rewards := make([]ids.ShortID, len(validators))
+		for i, v := range validators {
+			rewards[i], err = resolveRewardAddress(v.reward, w.PChainAddress())
+			if err != nil {
+				return fmt.Errorf("line %d: invalid reward address: %w", v.line, err)
+			}
+		}
+		if err := checkAddressPolicy("reward address", rewards, w.PChainAddress(), netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		fmt.Printf("Adding %d validators with %s AVAX staked in total:\n", len(validators), pchain.FormatAVAX(totalStake))
+		for i, v := range validators {
+			_, end := v.timeRange()
+			fmt.Printf("  Line %d: %s, %s AVAX until %s, rewards to %s\n", v.line, v.nodeID,
+				pchain.FormatAVAX(v.stakeNAVAX), end.UTC().Format("2006-01-02 15:04:05 MST"),
+				wallet.FormatPChainAddress(rewards[i], netConfig.NetworkID))
+		}
+		fmt.Printf("  Delegation Fee: %.2f%%\n", valDelegationFee*100)
+		if err := checkStakeFunds(ctx, w, totalStake); err != nil {
+			return err
+		}
+		if err := confirmBatchRewardAddresses(os.Stdin, stdinIsTerminal(), w.PChainAddress(), rewards, netConfig.NetworkID); err != nil {
+			return err
+		}
+
+		for i, v := range validators {
+			start, end := v.timeRange()
+			fmt.Printf("Submitting line %d (%s)...\n", v.line, v.nodeID)
+			txID, err := pchain.AddPermissionlessValidator(ctx, w, pchain.AddPermissionlessValidatorConfig{
+				NodeID:        v.nodeID,
+				Start:         start,
+				End:           end,
+				StakeAmt:      v.stakeNAVAX,
+				RewardAddr:    rewards[i],
+				DelegationFee: delegationFeeShares,
+				BLSSigner:     v.pop,
+			})
+			// The wallet waits for each transaction to be accepted before
+			// returning, so a failure stops the batch at this line.
+			if err != nil {
+				return withTxID(txID, fmt.Errorf("line %d (%s): %w\n%d of %d validators were added before it; later lines were not submitted",
+					v.line, v.nodeID, err, i, len(validators)))
+			}
+
+			if printSignedTxs(w) {
+				return nil
+			}
+
+			printTxID(fmt.Sprintf("Line %d accepted, TX ID", v.line), txID)
+		}
+		fmt.Printf("Added all %d validators.\n", len(validators))
+		return nil
+	},
+}
+
+// checkValidatorBatch checks every row's stake and validation period against
+// the network's limits and returns the total stake in nAVAX.
+func checkValidatorBatch(validators []batchValidator, netConfig network.Config) (uint64, error) {
+	var total uint64
+	for _, v := range validators {
+		if err := pchain.ValidateValidatorStake(netConfig, v.stakeNAVAX); err != nil {
+			return 0, fmt.Errorf("line %d: %w", v.line, err)
+		}
+		start, end := v.timeRange()
+		switch period := end.Sub(start); {
+		case period < netConfig.MinStakeDuration:
+			return 0, fmt.Errorf("line %d: validation period too short for %s: minimum is %s", v.line, netConfig.Name, netConfig.MinStakeDuration)
+		case period > netConfig.MaxStakeDuration:
+			return 0, fmt.Errorf("line %d: validation period too long for %s: maximum is %s", v.line, netConfig.Name, netConfig.MaxStakeDuration)
+		}
+		if v.stakeNAVAX > math.MaxUint64-total {
+			return 0, fmt.Errorf("total stake overflows uint64 nAVAX")
+		}
+		total += v.stakeNAVAX
+	}
+	return total, nil
+}
+
+// confirmBatchRewardAddresses asks once for confirmation, unless --yes is
+// set, when any reward address differs from the signing wallet's address.
+func confirmBatchRewardAddresses(in io.Reader, interactive bool, own ids.ShortID, rewards []ids.ShortID, networkID uint32) error {
+	var foreign []string
+	for _, reward := range rewards {
+		if reward != own {
+			foreign = append(foreign, wallet.FormatPChainAddress(reward, networkID))
+		}
+	}
+	if len(foreign) == 0 || skipConfirmations() {
+		return nil
+	}
+
+	printWarning("WARNING: %d reward address(es) are not the signing wallet's address (%s): %s. Staking rewards sent there cannot be recovered if they are wrong.",
+		len(foreign), wallet.FormatPChainAddress(own, networkID), strings.Join(foreign, ", "))
+	confirmed, err := confirmPrompt(in, interactive, os.Stdout, "Type 'yes' to confirm the reward addresses: ", confirmYes)
+	if errors.Is(err, errNotInteractive) {
+		return fmt.Errorf("reward addresses differ from the signing wallet; re-run with --yes to confirm them")
+	}
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("reward addresses not confirmed; aborting")
+	}
+	return nil
+}
+
+// readValidatorBatchFile reads a validator batch CSV file, rejecting anything
+// that is not a regular file of at most maxValidatorBatchFileLen bytes.
+func readValidatorBatchFile(path string, networkID uint32) ([]batchValidator, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat validator file: %w", err)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return nil, fmt.Errorf("validator file must be a regular file")
+	}
+	if fileInfo.Size() > maxValidatorBatchFileLen {
+		return nil, fmt.Errorf("validator file too large: %d bytes (max: %d bytes / 1 MB)", fileInfo.Size(), maxValidatorBatchFileLen)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open validator file: %w", err)
+	}
+	defer file.Close()
+
+	return parseValidatorBatch(io.LimitReader(file, maxValidatorBatchFileLen), networkID)
+}
+
+// parseValidatorBatch parses validator batch CSV rows. Reward addresses for
+// another network are rejected, as are repeated node IDs.
+func parseValidatorBatch(r io.Reader, networkID uint32) ([]batchValidator, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = validatorBatchFields
+	reader.TrimLeadingSpace = true
+
+	var validators []batchValidator
+	seen := make(map[ids.NodeID]int)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if first && strings.EqualFold(record[0], validatorBatchHeaderField) {
+			continue
+		}
+
+		v, err := parseBatchValidator(record, networkID)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[v.nodeID]; ok {
+			return nil, fmt.Errorf("line %d: %s is already listed on line %d", line, v.nodeID, prev)
+		}
+		seen[v.nodeID] = line
+		v.line = line
+		validators = append(validators, v)
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("validator file has no validators")
+	}
+	return validators, nil
+}
+
+// parseBatchValidator parses the trimmed fields of one batch row.
+func parseBatchValidator(record []string, networkID uint32) (batchValidator, error) {
+	nodeIDField, pubKeyField, popField, stakeField, periodField, rewardField :=
+		record[0], record[1], record[2], record[3], record[4], record[5]
+
+	var v batchValidator
+	var err error
+	v.nodeID, err = ids.NodeIDFromString(nodeIDField)
+	if err != nil {
+		return v, fmt.Errorf("invalid node ID %q: %w", nodeIDField, err)
+	}
+	v.pop, err = parseManualPoP(pubKeyField, popField)
+	if err != nil {
+		return v, err
+	}
+	v.stakeNAVAX, err = pchain.ParseAVAX(stakeField)
+	if err != nil {
+		return v, fmt.Errorf("invalid stake %q: %w", stakeField, err)
+	}
+	if v.stakeNAVAX == 0 {
+		return v, fmt.Errorf("stake must be positive")
+	}
+	if v.duration, err = time.ParseDuration(periodField); err == nil {
+		if v.duration <= 0 {
+			return v, fmt.Errorf("duration must be positive (got %s)", periodField)
+		}
+	} else if v.end, err = time.Parse(time.RFC3339, periodField); err != nil {
+		return v, fmt.Errorf("invalid duration or end time %q: use a duration such as 336h or an RFC3339 time", periodField)
+	}
+	if err := checkAddressNetwork(rewardField, networkID); err != nil {
+		return v, err
+	}
+	v.reward = rewardField
+	return v, nil
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorAddBatchCmd)
+
+	validatorAddBatchCmd.Flags().StringVar(&valBatchFile, "file", "", "CSV file of nodeID,blsPublicKey,blsPoP,stake,durationOrEnd,rewardAddress rows")
+	validatorAddBatchCmd.Flags().Float64Var(&valDelegationFee, "delegation-fee", 0.02, "Delegation fee for every validator (0.02 = 2%)")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// batchRow formats a validator batch row with a fresh, valid BLS key and PoP.
+func batchRow(t *testing.T, nodeID ids.NodeID, stake, period, reward string) string {
+	t.Helper()
+	pop := newTestPoP(t)
+	return strings.Join([]string{
+		nodeID.String(),
+		"0x" + hex.EncodeToString(pop.PublicKey[:]),
+		hex.EncodeToString(pop.ProofOfPossession[:]),
+		stake, period, reward,
+	}, ", ")
+}
+
+func TestParseValidatorBatch(t *testing.T) {
+	a, b := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	owner := ids.GenerateTestShortID()
+	fujiOwner, err := address.Format(pChainAlias, constants.GetHRP(constants.FujiID), owner.Bytes())
+	if err != nil {
+		t.Fatalf("address.Format() error = %v", err)
+	}
+	mainnetOwner, err := address.Format(pChainAlias, constants.GetHRP(constants.MainnetID), owner.Bytes())
+	if err != nil {
+		t.Fatalf("address.Format() error = %v", err)
+	}
+
+	input := "nodeID,blsPublicKey,blsPoP,stake,durationOrEnd,rewardAddress\n# fleet\n" +
+		batchRow(t, a, "2000", "336h", fujiOwner) + "\n\n" +
+		batchRow(t, b, "2000000000000n", "2030-01-02T15:04:05Z", "") + "\n"
+	got, err := parseValidatorBatch(strings.NewReader(input), constants.FujiID)
+	if err != nil {
+		t.Fatalf("parseValidatorBatch() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("parseValidatorBatch() returned %d rows, want 2", len(got))
+	}
+	if got[0].line != 3 || got[0].nodeID != a || got[0].stakeNAVAX != 2_000_000_000_000 || got[0].duration != 336*time.Hour || got[0].reward != fujiOwner {
+		t.Errorf("row 0 = %+v", got[0])
+	}
+	wantEnd := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	if got[1].line != 5 || got[1].nodeID != b || got[1].duration != 0 || !got[1].end.Equal(wantEnd) || got[1].reward != "" {
+		t.Errorf("row 1 = %+v", got[1])
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "duplicate node", input: batchRow(t, a, "1", "24h", "") + "\n" + batchRow(t, a, "1", "24h", ""), wantErr: "already listed on line 1"},
+		{name: "bad node ID", input: strings.TrimPrefix(batchRow(t, a, "1", "24h", ""), "NodeID-"), wantErr: "line 1: invalid node ID"},
+		{name: "extra column", input: batchRow(t, a, "1", "24h", "") + ",extra", wantErr: "invalid validator file"},
+		{name: "zero stake", input: batchRow(t, a, "0", "24h", ""), wantErr: "line 1: stake must be positive"},
+		{name: "bad period", input: batchRow(t, a, "1", "two weeks", ""), wantErr: "invalid duration or end time"},
+		{name: "negative duration", input: batchRow(t, a, "1", "-24h", ""), wantErr: "duration must be positive"},
+		{name: "wrong network", input: batchRow(t, a, "1", "24h", mainnetOwner), wantErr: "line 1"},
+		{name: "bad PoP", input: strings.Replace(batchRow(t, a, "1", "24h", ""), ", 0x", ", 0x00", 1), wantErr: "line 1: invalid --bls-public-key"},
+		{name: "header only", input: "nodeID,a,b,c,d,e\n", wantErr: "no validators"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseValidatorBatch(strings.NewReader(tt.input), constants.FujiID)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseValidatorBatch() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckValidatorBatch(t *testing.T) {
+	fuji, err := network.GetConfig("fuji")
+	if err != nil {
+		t.Fatalf("network.GetConfig() error = %v", err)
+	}
+	row := func(line int, stake uint64, duration time.Duration) batchValidator {
+		return batchValidator{line: line, nodeID: ids.GenerateTestNodeID(), stakeNAVAX: stake, duration: duration}
+	}
+
+	total, err := checkValidatorBatch([]batchValidator{
+		row(1, fuji.MinValidatorStake, fuji.MinStakeDuration),
+		row(2, 2*fuji.MinValidatorStake, fuji.MaxStakeDuration),
+	}, fuji)
+	if err != nil {
+		t.Fatalf("checkValidatorBatch() error = %v", err)
+	}
+	if want := 3 * fuji.MinValidatorStake; total != want {
+		t.Errorf("total = %d, want %d", total, want)
+	}
+
+	tests := []struct {
+		name    string
+		row     batchValidator
+		wantErr string
+	}{
+		{name: "stake too low", row: row(4, fuji.MinValidatorStake-1, fuji.MinStakeDuration), wantErr: "line 4"},
+		{name: "too short", row: row(5, fuji.MinValidatorStake, fuji.MinStakeDuration-time.Minute), wantErr: "line 5: validation period too short"},
+		{name: "too long", row: row(6, fuji.MinValidatorStake, fuji.MaxStakeDuration+time.Minute), wantErr: "line 6: validation period too long"},
+		{
+			name:    "end already passed",
+			row:     batchValidator{line: 7, stakeNAVAX: fuji.MinValidatorStake, end: time.Now()},
+			wantErr: "line 7: validation period too short",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkValidatorBatch([]batchValidator{row(1, fuji.MinValidatorStake, fuji.MinStakeDuration), tt.row}, fuji)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkValidatorBatch() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
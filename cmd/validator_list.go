@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/spf13/cobra"
+)
+
+// defaultValidatorListLimit keeps the default listing readable on mainnet,
+// where the primary network has well over a thousand validators.
+const defaultValidatorListLimit = 100
+
+var (
+	valListSubnetID  string
+	valListLimit     int
+	valListOffset    int
+	valListCountOnly bool
+)
+
+// validatorListPage is the JSON shape of `validator list --output json`.
+type validatorListPage struct {
+	Total      int                       `json:"total"`
+	Offset     int                       `json:"offset"`
+	Limit      int                       `json:"limit"`
+	Validators []pchain.ValidatorSummary `json:"validators"`
+}
+
+// pageBounds returns the [start, end) window selected by offset and limit over
+// total items. A limit of 0 means no limit.
+func pageBounds(total, offset, limit int) (int, int) {
+	start := min(offset, total)
+	end := total
+	if limit > 0 && limit < total-start {
+		end = start + limit
+	}
+	return start, end
+}
+
+var validatorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List current validators",
+	Long: `List the current validators of the primary network or a subnet.
+
+Validators are sorted by node ID (then tx ID) so pages are stable across calls.
+Use --limit and --offset to page through large sets (--limit 0 lists all), or
+--count-only to print just the total. With --output json the result includes
+total/offset/limit metadata for iterating.
+
+Examples:
+  platform-cli validator list --network mainnet
+  platform-cli validator list --network mainnet --offset 100 --limit 100
+  platform-cli validator list --network mainnet --count-only
+  platform-cli validator list --subnet-id <ID> --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		if valListLimit < 0 {
+			return fmt.Errorf("--limit cannot be negative")
+		}
+		if valListOffset < 0 {
+			return fmt.Errorf("--offset cannot be negative")
+		}
+
+		subnetID := constants.PrimaryNetworkID
+		if valListSubnetID != "" {
+			var err error
+			subnetID, err = parseIDFlag("subnet-id", valListSubnetID)
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		validators, err := pchain.GetCurrentValidatorsWithConfig(ctx, netConfig, subnetID)
+		if err != nil {
+			return err
+		}
+
+		total := len(validators)
+		start, end := pageBounds(total, valListOffset, valListLimit)
+		page := validators[start:end]
+
+		if valListCountOnly {
+			if wantStructured() {
+				return printStructured(map[string]int{"total": total})
+			}
+			fmt.Println(total)
+			return nil
+		}
+
+		if wantStructured() {
+			return printStructured(validatorListPage{
+				Total:      total,
+				Offset:     valListOffset,
+				Limit:      valListLimit,
+				Validators: page,
+			})
+		}
+
+		if len(page) == 0 {
+			fmt.Printf("No validators in range (total: %d)\n", total)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE ID\tWEIGHT\tSTART\tEND\tTX ID")
+		for _, v := range page {
+			weight := fmt.Sprintf("%d", v.Weight)
+			if subnetID == constants.PrimaryNetworkID {
+				weight = pchain.FormatAVAX(v.Weight) + " AVAX"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				v.NodeID, weight, v.StartTime.Format("2006-01-02"), v.EndTime.Format("2006-01-02"), v.TxID)
+		}
+		w.Flush()
+
+		fmt.Printf("\nShowing %d-%d of %d validator(s)\n", start+1, end, total)
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorListCmd)
+
+	validatorListCmd.Flags().StringVar(&valListSubnetID, "subnet-id", "", "Subnet ID (default: primary network)")
+	validatorListCmd.Flags().IntVar(&valListLimit, "limit", defaultValidatorListLimit, "Maximum number of validators to show (0 = all)")
+	validatorListCmd.Flags().IntVar(&valListOffset, "offset", 0, "Number of validators to skip")
+	validatorListCmd.Flags().BoolVar(&valListCountOnly, "count-only", false, "Only print the total number of validators")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import "testing"
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, offset, limit int
+		wantStart, wantEnd   int
+	}{
+		{"first page", 250, 0, 100, 0, 100},
+		{"middle page", 250, 100, 100, 100, 200},
+		{"partial last page", 250, 200, 100, 200, 250},
+		{"offset past end", 250, 300, 100, 250, 250},
+		{"no limit", 250, 10, 0, 10, 250},
+		{"empty set", 0, 0, 100, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := pageBounds(tt.total, tt.offset, tt.limit)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Fatalf("pageBounds(%d, %d, %d) = (%d, %d), want (%d, %d)",
+					tt.total, tt.offset, tt.limit, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	orig := outputFormat
+	defer func() { outputFormat = orig }()
+
+	for _, valid := range []string{outputText, outputTable, outputJSON, outputYAML} {
+		outputFormat = valid
+		if err := validateOutputFormat(); err != nil {
+			t.Fatalf("validateOutputFormat(%q) returned error: %v", valid, err)
+		}
+	}
+
+	outputFormat = "xml"
+	if err := validateOutputFormat(); err == nil {
+		t.Fatal("validateOutputFormat(\"xml\") expected error")
+	}
+}
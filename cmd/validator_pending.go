@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+var valPendingNodeIDs []string
+
+// stakeImmutableNote explains why there is nothing pending or cancellable.
+const stakeImmutableNote = `The P-Chain has no pending stakers: since the Durango upgrade a validator or
+delegator starts when its transaction is accepted, whatever start time it
+carries. A submitted stake cannot be cancelled or changed; the stake is
+returned, with any reward, at its end time. An auto-renewed validator can stop
+renewing with: validator set-auto-renewed-config --period 0`
+
+// validatorPendingResult is the JSON shape of `validator pending --output json`.
+type validatorPendingResult struct {
+	Address string         `json:"address"`
+	Pending []pchain.Stake `json:"pending"`
+	Active  []pchain.Stake `json:"active"`
+	Note    string         `json:"note"`
+}
+
+var validatorPendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "Show stakes that are pending or active for this wallet",
+	Long: `Show the wallet's primary network stakes that have not yet ended.
+
+` + stakeImmutableNote + `
+
+Validators are found by their validation reward address. The P-Chain only
+lists delegations for validators requested by node ID, so pass the nodes you
+delegated to with --node-id to include them.
+
+Examples:
+  platform-cli validator pending --key-name mykey
+  platform-cli validator pending --node-id NodeID-... --node-id NodeID-...`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		nodeIDs := make([]ids.NodeID, 0, len(valPendingNodeIDs))
+		for _, s := range valPendingNodeIDs {
+			nodeID, err := parseNodeIDFlag("node-id", s)
+			if err != nil {
+				return err
+			}
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+		addr, _, err := loadWalletAddresses(netConfig)
+		if err != nil {
+			return err
+		}
+
+		stakes, err := pchain.GetStakesRewardedToWithConfig(ctx, netConfig, addr, nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		if wantStructured() {
+			if stakes == nil {
+				stakes = []pchain.Stake{}
+			}
+			return printStructured(validatorPendingResult{
+				Address: wallet.FormatPChainAddress(addr, netConfig.NetworkID),
+				Pending: []pchain.Stake{},
+				Active:  stakes,
+				Note:    stakeImmutableNote,
+			})
+		}
+
+		fmt.Printf("Address: %s\n", wallet.FormatPChainAddress(addr, netConfig.NetworkID))
+		fmt.Println("Pending: none")
+		fmt.Println()
+		if len(stakes) == 0 {
+			fmt.Println("No active stakes rewarded to this address.")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KIND\tNODE ID\tSTAKE\tSTARTED\tENDS\tTX ID")
+			for _, s := range stakes {
+				fmt.Fprintf(w, "%s\t%s\t%s AVAX\t%s\t%s\t%s\n",
+					s.Kind, s.NodeID, pchain.FormatAVAX(s.Weight),
+					s.StartTime.Format("2006-01-02 15:04"), s.EndTime.Format("2006-01-02 15:04"), s.TxID)
+			}
+			w.Flush()
+		}
+		fmt.Println()
+		fmt.Println(stakeImmutableNote)
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorPendingCmd)
+
+	validatorPendingCmd.Flags().StringSliceVar(&valPendingNodeIDs, "node-id", nil, "Also list delegations to this node (repeatable)")
+}
@@ -27,36 +27,64 @@ import (
 
 const defaultValidatorWeight uint64 = 100
 
+// l1PoPFallback controls what gatherL1Validators does when a node's
+// /ext/info returns no BLS proof of possession.
+type l1PoPFallback struct {
+	// overrides supplies a proof of possession per node ID, used only when the
+	// node itself returns none.
+	overrides map[ids.NodeID]*signer.ProofOfPossession
+	// skipMissing drops nodes without a proof of possession instead of failing.
+	skipMissing bool
+}
+
 // gatherL1Validators queries validator nodes and builds conversion validators.
-// If weights is non-nil, it must have the same length as validatorAddrs.
-func gatherL1Validators(ctx context.Context, validatorAddrs []string, balance float64, weights []uint64) ([]*txs.ConvertSubnetToL1Validator, error) {
+// Nodes that return no BLS proof of possession are resolved through fallback;
+// the skipped return value describes any nodes dropped by skipMissing. When
+// nodes cannot be resolved, the error lists every one of them rather than just
+// the first.
+func gatherL1Validators(ctx context.Context, validatorAddrs []string, balance float64, weights []uint64, fallback l1PoPFallback) ([]*txs.ConvertSubnetToL1Validator, []string, error) {
 	if len(validatorAddrs) == 0 {
-		return nil, fmt.Errorf("no validator addresses provided")
+		return nil, nil, fmt.Errorf("no validator addresses provided")
 	}
 	if weights != nil && len(weights) != len(validatorAddrs) {
-		return nil, fmt.Errorf("validator-weights count (%d) must match validators count (%d)", len(weights), len(validatorAddrs))
+		return nil, nil, fmt.Errorf("validator-weights count (%d) must match validators count (%d)", len(weights), len(validatorAddrs))
 	}
 
 	// Validate balance to prevent overflow
 	balanceNAVAX, err := avaxToNAVAX(balance)
 	if err != nil {
-		return nil, fmt.Errorf("invalid validator balance: %w", err)
+		return nil, nil, fmt.Errorf("invalid validator balance: %w", err)
 	}
 
 	validators := make([]*txs.ConvertSubnetToL1Validator, 0, len(validatorAddrs))
+	seenNodeIDs := make(map[ids.NodeID]string, len(validatorAddrs)) // node ID -> address
+	var missing, skipped []string
 	for i, addr := range validatorAddrs {
 		uri, err := normalizeNodeURI(addr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid validator address %q: %w", addr, err)
+			return nil, nil, fmt.Errorf("invalid validator address %q: %w", addr, err)
 		}
 		infoClient := info.NewClient(uri)
 
 		nodeID, nodePoP, err := infoClient.GetNodeID(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get node info from %s: %w", uri, err)
+			return nil, nil, fmt.Errorf("failed to get node info from %s: %w", uri, err)
+		}
+		if prev, dup := seenNodeIDs[nodeID]; dup {
+			return nil, nil, fmt.Errorf("--validators %q and %q are the same node %s", prev, addr, nodeID)
+		}
+		seenNodeIDs[nodeID] = addr
+		if nodePoP == nil {
+			nodePoP = fallback.overrides[nodeID]
 		}
 		if nodePoP == nil {
-			return nil, fmt.Errorf("node %s did not return BLS proof of possession from /ext/info", uri)
+			node := fmt.Sprintf("%s (%s)", uri, nodeID)
+			if fallback.skipMissing {
+				skipped = append(skipped, node)
+			} else {
+				missing = append(missing, node)
+			}
+			continue
 		}
 
 		weight := uint64(defaultValidatorWeight)
@@ -72,7 +100,48 @@ func gatherL1Validators(ctx context.Context, validatorAddrs []string, balance fl
 		})
 	}
 
-	return validators, nil
+	for nodeID := range fallback.overrides {
+		if _, ok := seenNodeIDs[nodeID]; !ok {
+			return nil, nil, fmt.Errorf("--validator-pop given for %s, which is not one of the --validators nodes", nodeID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%d node(s) did not return a BLS proof of possession from /ext/info:\n  %s\nSupply each PoP with --validator-pop <NodeID>:<bls-public-key>:<bls-pop>, or drop them with --skip-nodes-without-pop",
+			len(missing), strings.Join(missing, "\n  "))
+	}
+	if len(validators) == 0 {
+		return nil, skipped, fmt.Errorf("no validators left: none of the nodes returned a BLS proof of possession")
+	}
+
+	return validators, skipped, nil
+}
+
+// parsePoPOverrides parses --validator-pop entries of the form
+// NodeID-...:<bls-public-key-hex>:<bls-pop-hex>.
+func parsePoPOverrides(entries []string) (map[ids.NodeID]*signer.ProofOfPossession, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	overrides := make(map[ids.NodeID]*signer.ProofOfPossession, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid --validator-pop %q: expected <NodeID>:<bls-public-key>:<bls-pop>", entry)
+		}
+		nodeID, err := ids.NodeIDFromString(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --validator-pop node ID %q: %w", parts[0], err)
+		}
+		if _, dup := overrides[nodeID]; dup {
+			return nil, fmt.Errorf("duplicate --validator-pop for %s", nodeID)
+		}
+		pop, err := parseManualPoP(parts[1], parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid --validator-pop for %s: %w", nodeID, err)
+		}
+		overrides[nodeID] = pop
+	}
+	return overrides, nil
 }
 
 // buildManualL1Validators builds conversion validators from manually provided data.
@@ -131,6 +200,46 @@ func buildManualL1Validators(nodeIDs, blsPubKeys, blsPoPs string, balance float6
 	return validators, nil
 }
 
+// checkDuplicateValidatorAddrs rejects a --validators list that names the same
+// node address twice, including spellings that normalize to the same URI such
+// as "127.0.0.1" and "http://127.0.0.1:9650". It runs before any node is
+// queried; the same node behind two different addresses is caught by
+// gatherL1Validators once its node ID is known.
+func checkDuplicateValidatorAddrs(validatorAddrs []string) error {
+	seen := make(map[string]string, len(validatorAddrs)) // URI -> address as given
+	for _, addr := range validatorAddrs {
+		uri, err := normalizeNodeURI(addr)
+		if err != nil {
+			return fmt.Errorf("invalid validator address %q: %w", addr, err)
+		}
+		if prev, dup := seen[uri]; dup {
+			if prev == addr {
+				return fmt.Errorf("--validators lists %q more than once", addr)
+			}
+			return fmt.Errorf("--validators lists %q and %q, which are the same node address %s", prev, addr, uri)
+		}
+		seen[uri] = addr
+	}
+	return nil
+}
+
+// checkDuplicateValidatorNodeIDs rejects a --validator-node-ids list that
+// repeats a node ID, naming the positions of both entries.
+func checkDuplicateValidatorNodeIDs(nodeIDs []string) error {
+	seen := make(map[ids.NodeID]int, len(nodeIDs)) // node ID -> index
+	for i, raw := range nodeIDs {
+		nodeID, err := ids.NodeIDFromString(raw)
+		if err != nil {
+			return fmt.Errorf("invalid validator node ID at index %d: %w", i, err)
+		}
+		if prev, dup := seen[nodeID]; dup {
+			return fmt.Errorf("--validator-node-ids lists %s at index %d and %d", nodeID, prev, i)
+		}
+		seen[nodeID] = i
+	}
+	return nil
+}
+
 // sortAndValidateL1Validators sorts validators by NodeID bytes and rejects duplicates.
 func sortAndValidateL1Validators(validators []*txs.ConvertSubnetToL1Validator) error {
 	sort.Slice(validators, func(i, j int) bool {
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -13,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/spf13/cobra"
 )
 
 // newTestPoP generates a valid BLS proof of possession for tests.
@@ -216,6 +220,8 @@ func TestNormalizeNodeURI(t *testing.T) {
 	}{
 		{"ip only", "127.0.0.1", "http://127.0.0.1:9650"},
 		{"ip with port", "127.0.0.1:9650", "http://127.0.0.1:9650"},
+		{"localhost only", "localhost", "http://localhost:9650"},
+		{"localhost with port", "localhost:9651", "http://localhost:9651"},
 		{"hostname shorthand defaults https", "mynode.example.com:9650", "https://mynode.example.com:9650"},
 		{"http uri", "http://127.0.0.1:9650", "http://127.0.0.1:9650"},
 		{"https uri", "https://example.com", "https://example.com"},
@@ -399,30 +405,167 @@ func TestGatherL1Validators_InputValidation(t *testing.T) {
 	ctx := context.Background()
 
 	// No addresses provided.
-	_, err := gatherL1Validators(ctx, nil, 1, nil)
+	_, _, err := gatherL1Validators(ctx, nil, 1, nil, l1PoPFallback{})
 	if err == nil {
 		t.Fatal("gatherL1Validators() expected error for empty validator addresses")
 	}
 
 	// Weights count mismatch.
-	_, err = gatherL1Validators(ctx, []string{"127.0.0.1", "127.0.0.2"}, 1, []uint64{100})
+	_, _, err = gatherL1Validators(ctx, []string{"127.0.0.1", "127.0.0.2"}, 1, []uint64{100}, l1PoPFallback{})
 	if err == nil {
 		t.Fatal("gatherL1Validators() expected error for weights count mismatch")
 	}
 
 	// Negative balance.
-	_, err = gatherL1Validators(ctx, []string{"127.0.0.1"}, -1, nil)
+	_, _, err = gatherL1Validators(ctx, []string{"127.0.0.1"}, -1, nil, l1PoPFallback{})
 	if err == nil {
 		t.Fatal("gatherL1Validators() expected error for negative balance")
 	}
 
 	// Invalid validator address (rejected before any network call).
-	_, err = gatherL1Validators(ctx, []string{"http://127.0.0.1:9650/custom/path"}, 1, nil)
+	_, _, err = gatherL1Validators(ctx, []string{"http://127.0.0.1:9650/custom/path"}, 1, nil, l1PoPFallback{})
 	if err == nil {
 		t.Fatal("gatherL1Validators() expected error for invalid validator address")
 	}
 }
 
+// newNoPoPInfoServer serves an /ext/info endpoint that reports nodeID with no
+// BLS proof of possession, as non-validator or older nodes do.
+func newNoPoPInfoServer(t *testing.T, nodeID ids.NodeID) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"nodeID":%q}}`, nodeID)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGatherL1Validators_MissingPoP(t *testing.T) {
+	ctx := context.Background()
+	nodeID := ids.GenerateTestNodeID()
+	srv := newNoPoPInfoServer(t, nodeID)
+
+	_, _, err := gatherL1Validators(ctx, []string{srv.URL}, 1, nil, l1PoPFallback{})
+	if err == nil || !strings.Contains(err.Error(), nodeID.String()) || !strings.Contains(err.Error(), "--validator-pop") {
+		t.Fatalf("gatherL1Validators() error = %v, want missing-PoP error naming %s", err, nodeID)
+	}
+
+	pop := newTestPoP(t)
+	validators, skipped, err := gatherL1Validators(ctx, []string{srv.URL}, 1, nil, l1PoPFallback{
+		overrides: map[ids.NodeID]*signer.ProofOfPossession{nodeID: pop},
+	})
+	if err != nil {
+		t.Fatalf("gatherL1Validators() with override error = %v", err)
+	}
+	if len(skipped) != 0 || len(validators) != 1 || validators[0].Signer.PublicKey != pop.PublicKey {
+		t.Fatalf("gatherL1Validators() with override = %d validators, skipped %v", len(validators), skipped)
+	}
+
+	_, skipped, err = gatherL1Validators(ctx, []string{srv.URL}, 1, nil, l1PoPFallback{skipMissing: true})
+	if err == nil || len(skipped) != 1 {
+		t.Fatalf("gatherL1Validators() skipping every node = skipped %v, err %v; want 1 skipped and an error", skipped, err)
+	}
+
+	_, _, err = gatherL1Validators(ctx, []string{srv.URL}, 1, nil, l1PoPFallback{
+		overrides: map[ids.NodeID]*signer.ProofOfPossession{
+			nodeID:                   pop,
+			ids.GenerateTestNodeID(): newTestPoP(t),
+		},
+	})
+	if err == nil {
+		t.Fatal("gatherL1Validators() expected error for an override that matches no node")
+	}
+}
+
+func TestGatherL1Validators_SameNodeTwice(t *testing.T) {
+	ctx := context.Background()
+	nodeID := ids.GenerateTestNodeID()
+	a, b := newNoPoPInfoServer(t, nodeID), newNoPoPInfoServer(t, nodeID)
+
+	_, _, err := gatherL1Validators(ctx, []string{a.URL, b.URL}, 1, nil, l1PoPFallback{})
+	if err == nil || !strings.Contains(err.Error(), nodeID.String()) || !strings.Contains(err.Error(), b.URL) {
+		t.Fatalf("gatherL1Validators() error = %v, want a duplicate-node error naming %s and %s", err, nodeID, b.URL)
+	}
+}
+
+func TestCheckDuplicateValidatorAddrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		addrs   []string
+		wantErr string
+	}{
+		{"distinct", []string{"127.0.0.1", "127.0.0.2:9650", "https://node.example.com"}, ""},
+		{"repeated verbatim", []string{"127.0.0.1", "127.0.0.2", "127.0.0.1"}, `lists "127.0.0.1" more than once`},
+		{"same uri spelled differently", []string{"127.0.0.1", "http://127.0.0.1:9650/ext/info"}, "same node address http://127.0.0.1:9650"},
+		{"invalid address", []string{"http://127.0.0.1:9650/custom/path"}, "invalid validator address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDuplicateValidatorAddrs(tt.addrs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkDuplicateValidatorAddrs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkDuplicateValidatorAddrs() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckDuplicateValidatorNodeIDs(t *testing.T) {
+	a, b := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	if err := checkDuplicateValidatorNodeIDs([]string{a.String(), b.String()}); err != nil {
+		t.Fatalf("checkDuplicateValidatorNodeIDs() error = %v", err)
+	}
+	err := checkDuplicateValidatorNodeIDs([]string{a.String(), b.String(), a.String()})
+	if err == nil || !strings.Contains(err.Error(), a.String()) || !strings.Contains(err.Error(), "index 0 and 2") {
+		t.Fatalf("checkDuplicateValidatorNodeIDs() error = %v, want a duplicate error naming %s at index 0 and 2", err, a)
+	}
+	if err := checkDuplicateValidatorNodeIDs([]string{"not-a-node-id"}); err == nil {
+		t.Fatal("checkDuplicateValidatorNodeIDs() expected error for an invalid node ID")
+	}
+}
+
+func TestParsePoPOverrides(t *testing.T) {
+	pop := newTestPoP(t)
+	pubHex := hex.EncodeToString(pop.PublicKey[:])
+	popHex := hex.EncodeToString(pop.ProofOfPossession[:])
+	nodeID := ids.GenerateTestNodeID()
+	entry := nodeID.String() + ":" + pubHex + ":" + popHex
+
+	overrides, err := parsePoPOverrides([]string{entry})
+	if err != nil {
+		t.Fatalf("parsePoPOverrides() error = %v", err)
+	}
+	if got := overrides[nodeID]; got == nil || got.PublicKey != pop.PublicKey || got.ProofOfPossession != pop.ProofOfPossession {
+		t.Fatalf("parsePoPOverrides()[%s] = %v, want parsed PoP", nodeID, got)
+	}
+
+	if overrides, err := parsePoPOverrides(nil); err != nil || overrides != nil {
+		t.Fatalf("parsePoPOverrides(nil) = %v, %v; want nil, nil", overrides, err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		entries []string
+	}{
+		{"missing fields", []string{nodeID.String() + ":" + pubHex}},
+		{"bad node ID", []string{"NodeID-bogus:" + pubHex + ":" + popHex}},
+		{"bad pop", []string{nodeID.String() + ":" + pubHex + ":00"}},
+		{"duplicate", []string{entry, entry}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parsePoPOverrides(tc.entries); err == nil {
+				t.Fatalf("parsePoPOverrides(%v) expected error", tc.entries)
+			}
+		})
+	}
+}
+
 func TestSortAndValidateL1Validators_SortsByNodeID(t *testing.T) {
 	v1 := &txs.ConvertSubnetToL1Validator{NodeID: []byte{0x02}, Weight: 1}
 	v2 := &txs.ConvertSubnetToL1Validator{NodeID: []byte{0x01}, Weight: 1}
@@ -481,3 +624,50 @@ func TestGenerateMockValidator(t *testing.T) {
 		t.Fatal("generateMockValidator() expected error for negative balance")
 	}
 }
+
+func TestVerifyBLSTriple(t *testing.T) {
+	pop := newTestPoP(t)
+	other := newTestPoP(t)
+	pubHex := "0x" + hex.EncodeToString(pop.PublicKey[:])
+	popHex := "0x" + hex.EncodeToString(pop.ProofOfPossession[:])
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name    string
+		nodeID  string
+		pubKey  string
+		pop     string
+		wantID  ids.NodeID
+		wantErr bool
+	}{
+		{name: "valid without node ID", pubKey: pubHex, pop: popHex, wantID: ids.EmptyNodeID},
+		{name: "valid with node ID", nodeID: nodeID.String(), pubKey: pubHex, pop: popHex, wantID: nodeID},
+		{name: "pop from another key", pubKey: pubHex, pop: hex.EncodeToString(other.ProofOfPossession[:]), wantErr: true},
+		{name: "malformed node ID", nodeID: "NodeID-nope", pubKey: pubHex, pop: popHex, wantErr: true},
+		{name: "short public key", pubKey: "0x1234", pop: popHex, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, _, err := verifyBLSTriple(tt.nodeID, tt.pubKey, tt.pop)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyBLSTriple() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && gotID != tt.wantID {
+				t.Fatalf("verifyBLSTriple() node ID = %s, want %s", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestPopFlagAlias(t *testing.T) {
+	var got string
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&got, "bls-pop", "", "")
+	cmd.Flags().SetNormalizeFunc(popFlagAlias)
+	if err := cmd.ParseFlags([]string{"--pop", "0xabc"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got != "0xabc" {
+		t.Fatalf("--pop set bls-pop to %q, want 0xabc", got)
+	}
+}
@@ -3,18 +3,24 @@ package cmd
 import (
 	"context"
 	"crypto/subtle"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
-	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/platform-cli/pkg/keystore"
 	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/qr"
 	"github.com/ava-labs/platform-cli/pkg/wallet"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
 )
 
 // clearBytesWallet securely zeros a byte slice to prevent sensitive data from lingering in memory.
@@ -31,45 +37,225 @@ var walletCmd = &cobra.Command{
 	RunE:  requireSubcommand,
 }
 
+var (
+	// balanceDescriptorFile is a watch-only descriptor (from `keys export-descriptor`)
+	// whose balance `wallet balance` shows instead of the signing wallet's.
+	balanceDescriptorFile string
+
+	// balanceOnlyP and balanceOnlyC restrict `wallet balance` to one chain, so
+	// an unreachable endpoint for the other chain does not get in the way.
+	balanceOnlyP bool
+	balanceOnlyC bool
+
+	// balanceAtHeight and balanceAtTime ask for a past C-Chain balance.
+	balanceAtHeight uint64
+	balanceAtTime   string
+
+	// addressVerify makes `wallet address --ledger` print derivation paths and
+	// show each address on the device for confirmation.
+	addressVerify bool
+
+	// addressQR, addressQROut and addressQRChain render one address as a QR
+	// code on the terminal and/or into a PNG file.
+	addressQR      bool
+	addressQROut   string
+	addressQRChain string
+)
+
+// qrPNGSize is the width and height in pixels of a --qr-out image.
+const qrPNGSize = 256
+
 var balanceCmd = &cobra.Command{
 	Use:   "balance",
-	Short: "Show P-Chain balance",
-	Long:  `Display the P-Chain balance for the specified wallet.`,
+	Short: "Show P-Chain and C-Chain balances",
+	Long: `Display the P-Chain and C-Chain AVAX balances for the specified wallet.
+
+Each chain is queried independently: if one chain's endpoint is unreachable
+its balance is reported as unavailable and the other is still shown. Use
+--only-p or --only-c to query a single chain, e.g. on a devnet that does not
+run the C-Chain.
+
+With --descriptor, show the P-Chain balance of a watch-only descriptor written
+by "keys export-descriptor" instead; no private key is loaded.
+
+With --at-height <block> or --at-time <RFC3339>, show the C-Chain balance as
+of that block (or the last block at or before that time). This needs an
+archive node; the P-Chain API only serves current balances.
+
+With --watch, poll every 5 seconds (or --watch=<interval>, e.g. --watch=30s)
+and re-print the balances until Ctrl-C. On a terminal the line is updated in
+place. With --output json each poll is one line of JSON.
+
+Examples:
+  platform-cli wallet balance --key-name mykey
+  platform-cli wallet balance --key-name mykey --only-p
+  platform-cli wallet balance --key-name mykey --watch=10s
+  platform-cli wallet balance --key-name mykey --at-time 2025-01-01T00:00:00Z`,
+	Args: balanceArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("watch") {
+			return runBalanceWatch(cmd)
+		}
+
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		netConfig, err := getNetworkConfig(ctx)
+		if balanceDescriptorFile != "" && balanceOnlyC {
+			return fmt.Errorf("--descriptor shows P-Chain balances only and cannot be combined with --only-c")
+		}
+		historical := cmd.Flags().Changed("at-height") || cmd.Flags().Changed("at-time")
+		if historical && (balanceDescriptorFile != "" || balanceOnlyP) {
+			return fmt.Errorf("--at-height and --at-time apply to the C-Chain only: the P-Chain API serves current balances only")
+		}
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
 
-		w, cleanup, err := loadPChainWallet(ctx, netConfig)
+		if balanceDescriptorFile != "" {
+			return printDescriptorBalance(ctx, netConfig, balanceDescriptorFile)
+		}
+
+		pAddr, evmAddr, err := loadWalletAddresses(netConfig)
 		if err != nil {
-			return fmt.Errorf("failed to create wallet: %w", err)
+			return err
+		}
+		if historical {
+			return printHistoricalCChainBalance(ctx, cmd, netConfig, evmAddr)
 		}
-		defer cleanup()
+		return printBalances(ctx, netConfig, pAddr, evmAddr, !balanceOnlyC, !balanceOnlyP)
+	},
+}
 
-		balance, err := w.GetPChainBalance(ctx)
+// printBalances prints the P-Chain and/or C-Chain balance of a wallet. When
+// both chains are queried, a failure on one is reported as a warning and only
+// a failure on both is an error.
+func printBalances(ctx context.Context, netConfig network.Config, pAddr ids.ShortID, evmAddr common.Address, queryP, queryC bool) error {
+	var errs []error
+	if queryP {
+		fmt.Printf("P-Chain Address: %s\n", wallet.FormatPChainAddress(pAddr, netConfig.NetworkID))
+		balance, err := wallet.GetAddressBalance(ctx, netConfig, pAddr)
 		if err != nil {
-			return fmt.Errorf("failed to get balance: %w", err)
+			errs = append(errs, fmt.Errorf("P-Chain: %w", err))
+		} else {
+			fmt.Printf("Balance: %s\n", formatAmount(balance))
 		}
+	}
+	if queryC {
+		fmt.Printf("C-Chain Address: %s\n", evmAddr.Hex())
+		balance, err := wallet.GetCChainBalance(ctx, netConfig, evmAddr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("C-Chain: %w", err))
+		} else {
+			fmt.Printf("C-Chain Balance: %s\n", formatAmount(balance))
+		}
+	}
 
-		fmt.Printf("P-Chain Address: %s\n", w.FormattedPChainAddress())
-		fmt.Printf("Balance: %.9f AVAX\n", float64(balance)/1e9)
+	if queryP && queryC && len(errs) == 1 {
+		printWarning("WARNING: %v", errs[0])
 		return nil
-	},
+	}
+	return errors.Join(errs...)
+}
+
+// printHistoricalCChainBalance prints evmAddr's C-Chain balance at the block
+// selected by --at-height or --at-time.
+func printHistoricalCChainBalance(ctx context.Context, cmd *cobra.Command, netConfig network.Config, evmAddr common.Address) error {
+	height := balanceAtHeight
+	if cmd.Flags().Changed("at-time") {
+		at, err := time.Parse(time.RFC3339, balanceAtTime)
+		if err != nil {
+			return fmt.Errorf("invalid --at-time %q: must be RFC3339, e.g. 2025-01-01T00:00:00Z", balanceAtTime)
+		}
+		height, err = wallet.CChainHeightAt(ctx, netConfig, at)
+		if err != nil {
+			return err
+		}
+	}
+
+	balance, err := wallet.GetCChainBalanceAt(ctx, netConfig, evmAddr, height)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("C-Chain Address: %s\n", evmAddr.Hex())
+	fmt.Printf("C-Chain Balance at block %d: %s\n", height, formatAmount(balance))
+	return nil
+}
+
+// loadWalletAddresses returns the P-Chain and C-Chain addresses of the
+// selected wallet without constructing a chain wallet, so no endpoint needs
+// to be reachable.
+func loadWalletAddresses(netConfig network.Config) (ids.ShortID, common.Address, error) {
+	type addresses struct {
+		p   ids.ShortID
+		evm common.Address
+	}
+	addrs, cleanup, err := loadSigningWallet(flagOptions(), netConfig,
+		func(kc *wallet.LedgerKeychain) (addresses, error) {
+			return addresses{kc.GetAddress(), kc.GetEVMPublicKey().EthAddress()}, nil
+		},
+		func(key *secp256k1.PrivateKey) (addresses, error) {
+			return addresses{key.Address(), key.PublicKey().EthAddress()}, nil
+		})
+	if err != nil {
+		return ids.ShortEmpty, common.Address{}, err
+	}
+	cleanup()
+	return addrs.p, addrs.evm, nil
+}
+
+// printDescriptorBalance prints the balance watched by a descriptor file.
+func printDescriptorBalance(ctx context.Context, netConfig network.Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read descriptor: %w", err)
+	}
+	_, pub, err := wallet.ParseDescriptor(data)
+	if err != nil {
+		return err
+	}
+	w, err := wallet.NewReadOnlyWalletFromPublicKey(netConfig, pub)
+	if err != nil {
+		return err
+	}
+
+	balance, err := w.GetPChainBalance(ctx)
+	if err != nil {
+		return err
+	}
+	for _, addr := range w.FormattedPChainAddresses() {
+		fmt.Printf("P-Chain Address: %s (watch-only)\n", addr)
+	}
+	fmt.Printf("Balance: %s\n", formatAmount(balance))
+	return nil
 }
 
 var addressCmd = &cobra.Command{
 	Use:   "address",
 	Short: "Show wallet addresses",
-	Long:  `Display P-Chain and EVM addresses for the specified wallet.`,
+	Long: `Display P-Chain and EVM addresses for the specified wallet.
+
+With --ledger --verify, the derivation path of each address is printed and
+each address is shown on the Ledger screen for confirmation. The P-Chain
+address (m/44'/9000'/0'/0/<index>) and the EVM address (m/44'/60'/0'/0/<index>)
+come from different keys; check both on the device before funding them.
+
+--qr prints the address selected by --qr-chain (p or c) as a QR code, and
+--qr-out writes it to a new PNG file, so it can be scanned instead of retyped.
+QR support needs a build with -tags qr.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if addressVerify && !useLedger {
+			return fmt.Errorf("--verify requires --ledger")
+		}
+		if err := validateQRFlags(); err != nil {
+			return err
+		}
+
 		ctx, cancel := getOperationContext()
 		defer cancel()
 
-		netConfig, err := getNetworkConfig(ctx)
+		ctx, netConfig, err := getNetworkConfig(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get network config: %w", err)
 		}
@@ -78,15 +264,26 @@ var addressCmd = &cobra.Command{
 			if !wallet.LedgerEnabled {
 				return fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
 			}
-			kc, err := wallet.NewLedgerKeychain(ledgerIndex)
+			kc, err := openLedger()
 			if err != nil {
 				return err
 			}
 			defer kc.Close()
 
-			fmt.Printf("P-Chain Address: %s\n", wallet.FormatPChainAddress(kc.GetAddress(), netConfig.NetworkID))
-			fmt.Printf("EVM Address:     %s\n", kc.GetEVMPublicKey().EthAddress().Hex())
-			return nil
+			pAddr := wallet.FormatPChainAddress(kc.GetAddress(), netConfig.NetworkID)
+			evmAddr := kc.GetEVMPublicKey().EthAddress().Hex()
+			fmt.Printf("P-Chain Address (%s): %s\n", addressNetworkLabel(netConfig.NetworkID), pAddr)
+			if addressVerify {
+				fmt.Printf("  Path:          %s\n", kc.PChainPath())
+			}
+			fmt.Printf("EVM Address:     %s\n", evmAddr)
+			if addressVerify {
+				fmt.Printf("  Path:          %s\n", kc.EVMPath())
+				if err := confirmLedgerAddresses(kc, netConfig.NetworkID); err != nil {
+					return err
+				}
+			}
+			return showAddressQR(pAddr, evmAddr)
 		}
 
 		key, err := loadKey()
@@ -97,273 +294,251 @@ var addressCmd = &cobra.Command{
 
 		pAddr, evmAddr := wallet.DeriveAddressesFormatted(key, netConfig.NetworkID)
 
-		fmt.Printf("P-Chain Address: %s\n", pAddr)
+		fmt.Printf("P-Chain Address (%s): %s\n", addressNetworkLabel(netConfig.NetworkID), pAddr)
 		fmt.Printf("EVM Address:     %s\n", evmAddr)
-		return nil
+		return showAddressQR(pAddr, evmAddr)
 	},
 }
 
-func loadKey() ([]byte, error) {
-	// Priority 1: Key from keystore by name
-	if keyNameGlobal != "" {
-		if privateKey != "" {
-			return nil, fmt.Errorf("use either --key-name or --private-key, not both")
-		}
-		return loadFromKeystore(keyNameGlobal)
+// addressNetworkLabel names the network a P-Chain address was formatted
+// for, adding the address HRP when it differs from the network name
+// ("fuji", but "mainnet, avax").
+func addressNetworkLabel(networkID uint32) string {
+	name, hrp := constants.NetworkName(networkID), constants.GetHRP(networkID)
+	if name == hrp {
+		return name
 	}
+	return name + ", " + hrp
+}
 
-	// Priority 2: Direct private key via flag (discouraged; prefer keystore/Ledger)
-	if privateKey != "" {
-		return wallet.ParsePrivateKey(privateKey)
+// validateQRFlags checks the QR flags of wallet address before anything is
+// loaded.
+func validateQRFlags() error {
+	if addressQRChain != "p" && addressQRChain != "c" {
+		return fmt.Errorf("invalid --qr-chain %q: must be 'p' or 'c'", addressQRChain)
+	}
+	if addressQR || addressQROut != "" {
+		if !qr.Enabled {
+			return fmt.Errorf("QR code support not compiled. Rebuild with: go build -tags qr")
+		}
+		if addressQROut != "" && !strings.EqualFold(filepath.Ext(addressQROut), ".png") {
+			return fmt.Errorf("--qr-out must name a .png file")
+		}
+		if addressQROut != "" {
+			if _, err := os.Stat(addressQROut); err == nil {
+				return withExitCode(exitUsage, fmt.Errorf("--qr-out %s already exists", addressQROut))
+			}
+		}
 	}
+	return nil
+}
 
-	// Priority 3: Default key from keystore
-	ks, err := keystore.Load()
-	if err == nil && ks.GetDefault() != "" {
-		return loadFromKeystore(ks.GetDefault())
+// showAddressQR renders the address selected by --qr-chain when --qr or
+// --qr-out is set.
+func showAddressQR(pAddr, evmAddr string) error {
+	content := pAddr
+	if addressQRChain == "c" {
+		content = evmAddr
+	}
+	if addressQR {
+		code, err := qr.Terminal(content)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n%s\n%s", content, code)
 	}
+	if addressQROut != "" {
+		if err := qr.WritePNG(content, addressQROut, qrPNGSize); err != nil {
+			return err
+		}
+		fmt.Printf("QR code for %s written to %s\n", content, addressQROut)
+	}
+	return nil
+}
 
-	// Priority 4: Environment variable
-	if envKey := os.Getenv("AVALANCHE_PRIVATE_KEY"); envKey != "" {
-		return wallet.ParsePrivateKey(envKey)
+// confirmLedgerAddresses shows the P-Chain and then the EVM address on the
+// Ledger screen. The device displays both in bech32 form, so the EVM one is
+// printed in that form too for comparison.
+func confirmLedgerAddresses(kc *wallet.LedgerKeychain, networkID uint32) error {
+	hrp := constants.GetHRP(networkID)
+	checks := []struct {
+		name string
+		evm  bool
+		addr ids.ShortID
+	}{
+		{"P-Chain", false, kc.GetAddress()},
+		{"EVM", true, kc.GetEVMPublicKey().Address()},
+	}
+	for _, c := range checks {
+		expected, err := address.FormatBech32(hrp, c.addr[:])
+		if err != nil {
+			return fmt.Errorf("failed to format %s address: %w", c.name, err)
+		}
+		fmt.Printf("\nConfirm the %s address on your Ledger. It should show: %s\n", c.name, expected)
+		shown, err := kc.ConfirmOnDevice(c.evm, hrp)
+		if err != nil {
+			return withExitCode(exitKey, err)
+		}
+		fmt.Printf("Confirmed on device: %s\n", shown)
 	}
+	return nil
+}
 
-	return nil, fmt.Errorf("no key source provided. Use --key-name (preferred), --private-key, or set AVALANCHE_PRIVATE_KEY env var")
+// loadKey loads the signing key selected by the global flags.
+func loadKey() ([]byte, error) {
+	return flagOptions().LoadKey()
 }
 
-// ewoqPrivateKey is the well-known ewoq test key used in local/test networks.
-// P-Chain: 6Y3kysjF9jnHnYkdS9yGAuoHyae2eNmeV
-// EVM: 0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC
-var ewoqPrivateKey = []byte{
-	0x56, 0x28, 0x9e, 0x99, 0xc9, 0x4b, 0x69, 0x12,
-	0xbf, 0xc1, 0x2a, 0xdc, 0x09, 0x3c, 0x9b, 0x51,
-	0x12, 0x4f, 0x0d, 0xc5, 0x4a, 0xc7, 0xa7, 0x66,
-	0xb2, 0xbc, 0x5c, 0xcf, 0x55, 0x8d, 0x80, 0x27,
+// openLedger opens the Ledger account at --ledger-index.
+func openLedger() (*wallet.LedgerKeychain, error) {
+	return flagOptions().openLedger()
 }
 
-// loadFromKeystore loads a key from the keystore by name.
-// Special built-in key: "ewoq" returns the well-known test key.
-// Note: The returned key bytes should be cleared by the caller when no longer needed.
-func loadFromKeystore(name string) ([]byte, error) {
-	// Built-in: ewoq test key
-	if name == "ewoq" {
-		// SECURITY: Prevent accidental use of ewoq key on mainnet
-		if networkName == "mainnet" || customNetID == constants.MainnetID {
-			return nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
-		}
-		// Return a copy so caller can safely clear it
-		keyCopy := make([]byte, len(ewoqPrivateKey))
-		copy(keyCopy, ewoqPrivateKey)
-		return keyCopy, nil
+// resolveFromKeyName maps a --from value to a keystore key name. The value
+// may be a key name, a P-Chain address (bech32 or short ID) or the 0x EVM
+// address of a stored key.
+func resolveFromKeyName(from string) (string, error) {
+	from = strings.TrimSpace(from)
+	if from == "ewoq" {
+		return from, nil
 	}
 
 	ks, err := keystore.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load keystore: %w", err)
+		return "", fmt.Errorf("failed to load keystore: %w", err)
 	}
-
-	if !ks.HasKey(name) {
-		return nil, fmt.Errorf("key %q not found in keystore (built-in: ewoq)", name)
+	if ks.HasKey(from) {
+		return from, nil
 	}
 
-	// Get password if key is encrypted
-	var password []byte
-	if ks.IsEncrypted(name) {
-		// Try environment variable first
-		if envPwd := os.Getenv("PLATFORM_CLI_KEY_PASSWORD"); envPwd != "" {
-			password = []byte(envPwd)
-		} else {
-			// Prompt for password
-			fmt.Fprintf(os.Stderr, "Key %q is encrypted. Enter password: ", name)
-			password, err = term.ReadPassword(int(os.Stdin.Fd()))
-			fmt.Fprintln(os.Stderr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read password: %w", err)
-			}
+	addr := from
+	if !strings.HasPrefix(from, "0x") && !strings.HasPrefix(from, "0X") {
+		pAddr, err := parsePChainAddress(from)
+		if err != nil {
+			return "", fmt.Errorf("--from %q is not a stored key name or a valid address", from)
 		}
-		// Clear password after use
-		defer clearBytesWallet(password)
+		addr = pAddr.String()
+	}
+	entry, ok := ks.FindByAddress(addr)
+	if !ok {
+		return "", fmt.Errorf("no stored key matches --from %q (see 'platform-cli keys list --show-addresses')", from)
 	}
+	return entry.Name, nil
+}
 
-	return ks.LoadKey(name, password)
+// ewoqPrivateKey is the well-known ewoq test key used in local/test networks.
+// P-Chain: 6Y3kysjF9jnHnYkdS9yGAuoHyae2eNmeV
+// EVM: 0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC
+var ewoqPrivateKey = []byte{
+	0x56, 0x28, 0x9e, 0x99, 0xc9, 0x4b, 0x69, 0x12,
+	0xbf, 0xc1, 0x2a, 0xdc, 0x09, 0x3c, 0x9b, 0x51,
+	0x12, 0x4f, 0x0d, 0xc5, 0x4a, 0xc7, 0xa7, 0x66,
+	0xb2, 0xbc, 0x5c, 0xcf, 0x55, 0x8d, 0x80, 0x27,
 }
 
-// getNetworkConfig returns the network configuration, handling custom RPC URLs.
-// If customRPCURL is set, it creates a custom config (querying network ID if needed).
-// Otherwise, it uses the standard named network config.
-func getNetworkConfig(ctx context.Context) (network.Config, error) {
-	if customRPCURL != "" {
-		config, err := network.NewCustomConfigWithInsecureHTTP(ctx, customRPCURL, customNetID, allowInsecureHTTP)
-		if err != nil {
-			return network.Config{}, err
-		}
-		hrp := constants.GetHRP(config.NetworkID)
-		fmt.Printf("Using custom RPC: %s (network ID: %d, HRP: %s)\n", config.RPCURL, config.NetworkID, hrp)
-		return config, nil
-	}
-	return network.GetConfig(networkName)
+// loadFromKeystore loads a key from the keystore by name, refusing the ewoq
+// test key on the network selected by the global flags if it is mainnet.
+func loadFromKeystore(name string) ([]byte, error) {
+	return flagOptions().loadFromKeystore(name)
 }
 
-// loadPChainWallet creates a P-Chain wallet from either Ledger or private key.
-// Returns the wallet and a cleanup function that must be called when done.
-func loadPChainWallet(ctx context.Context, netConfig network.Config) (*wallet.Wallet, func(), error) {
-	if useLedger {
-		if !wallet.LedgerEnabled {
-			return nil, nil, fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
-		}
-		kc, err := wallet.NewLedgerKeychain(ledgerIndex)
-		if err != nil {
-			return nil, nil, err
-		}
-		w, err := wallet.NewWalletFromKeychain(ctx, kc, kc.GetAddress(), netConfig)
-		if err != nil {
-			kc.Close()
-			return nil, nil, err
-		}
-		return w, kc.Close, nil
-	}
+// getNetworkConfig returns the network selected by the global flags, and a
+// context derived from ctx under which requests fail over between its RPC
+// endpoints. Use the returned context for the rest of the command.
+func getNetworkConfig(ctx context.Context) (context.Context, network.Config, error) {
+	return flagOptions().NetworkContext(ctx)
+}
 
-	keyBytes, err := loadKey()
-	if err != nil {
-		return nil, nil, err
-	}
-	// Clear key bytes after wallet creation
-	defer clearBytesWallet(keyBytes)
-	if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
-		return nil, nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
-	}
+// warnRPCFailover reports that requests moved to a fallback RPC endpoint.
+func warnRPCFailover(from, to string, cause error) {
+	printWarning("WARNING: RPC endpoint %s failed (%v); switching to %s", from, cause, to)
+}
 
-	key, err := wallet.ToPrivateKey(keyBytes)
-	if err != nil {
-		return nil, nil, err
-	}
-	w, err := wallet.NewWallet(ctx, key, netConfig)
-	if err != nil {
-		return nil, nil, err
-	}
-	return w, func() {}, nil
+// loadPChainWallet loads the P-Chain wallet selected by the global flags and
+// applies the flags that shape its transactions. The returned cleanup
+// function must be called when done.
+func loadPChainWallet(ctx context.Context, netConfig network.Config) (*wallet.Wallet, func(), error) {
+	return withWalletFlags(flagOptions().LoadPChainWallet(ctx, netConfig))
 }
 
 // loadPChainWalletWithSubnet creates a P-Chain wallet that tracks a subnet.
 func loadPChainWalletWithSubnet(ctx context.Context, netConfig network.Config, subnetID ids.ID) (*wallet.Wallet, func(), error) {
-	if useLedger {
-		if !wallet.LedgerEnabled {
-			return nil, nil, fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
-		}
-		kc, err := wallet.NewLedgerKeychain(ledgerIndex)
-		if err != nil {
-			return nil, nil, err
-		}
-		w, err := wallet.NewWalletFromKeychainWithSubnet(ctx, kc, kc.GetAddress(), netConfig, subnetID)
-		if err != nil {
-			kc.Close()
-			return nil, nil, err
-		}
-		return w, kc.Close, nil
-	}
+	return loadPChainWalletWithSubnets(ctx, netConfig, []ids.ID{subnetID})
+}
 
-	keyBytes, err := loadKey()
-	if err != nil {
-		return nil, nil, err
-	}
-	// Clear key bytes after wallet creation
-	defer clearBytesWallet(keyBytes)
-	if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
-		return nil, nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
-	}
+// loadPChainWalletWithSubnets creates a P-Chain wallet that tracks several
+// subnets, so one wallet signs for all of them.
+func loadPChainWalletWithSubnets(ctx context.Context, netConfig network.Config, subnetIDs []ids.ID) (*wallet.Wallet, func(), error) {
+	return withWalletFlags(flagOptions().LoadPChainWalletWithSubnets(ctx, netConfig, subnetIDs))
+}
 
-	key, err := wallet.ToPrivateKey(keyBytes)
+// loadPChainWalletWithOwner creates a P-Chain wallet that can sign for the
+// owner registered under ownerID; see Options.LoadPChainWalletWithOwner.
+func loadPChainWalletWithOwner(ctx context.Context, netConfig network.Config, ownerID ids.ID, owner fx.Owner) (*wallet.Wallet, func(), error) {
+	return withWalletFlags(flagOptions().LoadPChainWalletWithOwner(ctx, netConfig, ownerID, owner))
+}
+
+// withWalletFlags applies the global flags that shape transactions to a
+// freshly loaded wallet, closing it if they are invalid.
+func withWalletFlags(w *wallet.Wallet, cleanup func(), err error) (*wallet.Wallet, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	w, err := wallet.NewWalletWithSubnet(ctx, key, netConfig, subnetID)
-	if err != nil {
+	if err := applyWalletFlags(w); err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	return w, func() {}, nil
+	return w, cleanup, nil
 }
 
-// loadPChainWalletWithOwner creates a P-Chain wallet whose backend maps ownerID
-// to owner, enabling owner-authorized transactions (e.g.
-// SetAutoRenewedValidatorConfigTx). It fetches P-Chain state once, replacing the
-// standard wallet load rather than adding a second round-trip.
-func loadPChainWalletWithOwner(ctx context.Context, netConfig network.Config, ownerID ids.ID, owner fx.Owner) (*wallet.Wallet, func(), error) {
-	if useLedger {
-		if !wallet.LedgerEnabled {
-			return nil, nil, fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
-		}
-		kc, err := wallet.NewLedgerKeychain(ledgerIndex)
-		if err != nil {
-			return nil, nil, err
-		}
-		w, err := wallet.NewWalletFromKeychainWithOwner(ctx, kc, kc.GetAddress(), netConfig, ownerID, owner)
-		if err != nil {
-			kc.Close()
-			return nil, nil, err
-		}
-		return w, kc.Close, nil
+// loadFullWallet creates the multi-chain wallet (P-Chain + C-Chain) selected
+// by the global flags.
+func loadFullWallet(ctx context.Context, netConfig network.Config) (*wallet.FullWallet, func(), error) {
+	if !broadcastTx {
+		return nil, nil, fmt.Errorf("--broadcast=false is not supported for cross-chain transfers")
 	}
-
-	keyBytes, err := loadKey()
-	if err != nil {
-		return nil, nil, err
+	if outputTxFile != "" {
+		return nil, nil, withExitCode(exitUsage, fmt.Errorf("--output-tx-file is not supported for cross-chain transfers"))
 	}
-	// Clear key bytes after wallet creation
-	defer clearBytesWallet(keyBytes)
-	if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
-		return nil, nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
+	if refreshBefore {
+		// Cross-chain wallets are loaded fresh, and their imports re-sync
+		// before every attempt.
+		return nil, nil, withExitCode(exitUsage, fmt.Errorf("--refresh-before is not supported for cross-chain transfers"))
 	}
-
-	key, err := wallet.ToPrivateKey(keyBytes)
+	w, cleanup, err := flagOptions().LoadFullWallet(ctx, netConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	kc := secp256k1fx.NewKeychain(key)
-	w, err := wallet.NewWalletFromKeychainWithOwner(ctx, kc, key.Address(), netConfig, ownerID, owner)
-	if err != nil {
+	if err := applyChangeAddress(w, w.PChainAddress(), netConfig.NetworkID); err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	return w, func() {}, nil
+	w.SetIssueHandlers(trackIssuedTx, trackConfirmedTx)
+	return w, cleanup, nil
 }
 
-// loadFullWallet creates a multi-chain wallet (P-Chain + C-Chain).
-func loadFullWallet(ctx context.Context, netConfig network.Config) (*wallet.FullWallet, func(), error) {
-	if useLedger {
-		if !wallet.LedgerEnabled {
-			return nil, nil, fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
-		}
-		kc, err := wallet.NewLedgerKeychain(ledgerIndex)
-		if err != nil {
-			return nil, nil, err
-		}
-		ethAddr := kc.GetEVMPublicKey().EthAddress()
-		w, err := wallet.NewFullWalletFromKeychain(ctx, kc, kc.GetAddress(), ethAddr, netConfig)
-		if err != nil {
-			kc.Close()
-			return nil, nil, err
-		}
-		return w, kc.Close, nil
-	}
+// changeOwnerSetter is a wallet whose change owner can be redirected.
+// *wallet.Wallet and *wallet.FullWallet satisfy it.
+type changeOwnerSetter interface {
+	SetChangeOwner(addr ids.ShortID)
+}
 
-	keyBytes, err := loadKey()
-	if err != nil {
-		return nil, nil, err
-	}
-	// Clear key bytes after wallet creation
-	defer clearBytesWallet(keyBytes)
-	if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
-		return nil, nil, fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
+// applyChangeAddress points w's change at --change-address, if set, after
+// checking it against the address policy. own is the signing address, which
+// "@self" refers to.
+func applyChangeAddress(w changeOwnerSetter, own ids.ShortID, networkID uint32) error {
+	if strings.TrimSpace(changeAddress) == "" {
+		return nil
 	}
-
-	key, err := wallet.ToPrivateKey(keyBytes)
+	addr, err := resolveChangeAddress(changeAddress, own, networkID)
 	if err != nil {
-		return nil, nil, err
+		return fmt.Errorf("invalid --change-address: %w", err)
 	}
-	w, err := wallet.NewFullWallet(ctx, key, netConfig)
-	if err != nil {
-		return nil, nil, err
+	if err := checkAddressPolicy("change address", []ids.ShortID{addr}, own, networkID); err != nil {
+		return err
 	}
-	return w, func() {}, nil
+	w.SetChangeOwner(addr)
+	return nil
 }
 
 func isEwoqKey(keyBytes []byte) bool {
@@ -377,4 +552,19 @@ func init() {
 	rootCmd.AddCommand(walletCmd)
 	walletCmd.AddCommand(balanceCmd)
 	walletCmd.AddCommand(addressCmd)
+
+	addressCmd.Flags().BoolVar(&addressVerify, "verify", false, "With --ledger, print derivation paths and confirm each address on the device")
+	addressCmd.Flags().BoolVar(&addressQR, "qr", false, "Print the address as a QR code (requires a -tags qr build)")
+	addressCmd.Flags().StringVar(&addressQROut, "qr-out", "", "Write the address as a QR code PNG to this file (requires a -tags qr build)")
+	addressCmd.Flags().StringVar(&addressQRChain, "qr-chain", "p", "Address to encode for --qr/--qr-out: 'p' (P-Chain) or 'c' (EVM)")
+
+	balanceCmd.Flags().StringVar(&balanceDescriptorFile, "descriptor", "", "Show the balance of a watch-only descriptor file instead of a key")
+	balanceCmd.Flags().BoolVar(&balanceOnlyP, "only-p", false, "Query only the P-Chain balance")
+	balanceCmd.Flags().BoolVar(&balanceOnlyC, "only-c", false, "Query only the C-Chain balance")
+	balanceCmd.MarkFlagsMutuallyExclusive("only-p", "only-c")
+	balanceCmd.Flags().Uint64Var(&balanceAtHeight, "at-height", 0, "Show the C-Chain balance as of this block height (archive node required)")
+	balanceCmd.Flags().StringVar(&balanceAtTime, "at-time", "", "Show the C-Chain balance as of this time, RFC3339 (archive node required)")
+	balanceCmd.MarkFlagsMutuallyExclusive("at-height", "at-time")
+	balanceCmd.Flags().DurationVar(&balanceWatch, "watch", 0, "Re-print the balances every interval until interrupted (--watch alone polls every 5s; set an interval with --watch=30s)")
+	balanceCmd.Flags().Lookup("watch").NoOptDefVal = defaultBalanceWatchInterval.String()
 }
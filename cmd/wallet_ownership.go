@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// proveChallenge and proveChain select what `wallet prove-ownership`
+	// signs and with which key.
+	proveChallenge string
+	proveChain     string
+	proveOut       string
+
+	// verifyProofFile is the proof `wallet verify-ownership` checks, and
+	// verifyChallenge and verifyAddress what it must contain.
+	verifyProofFile string
+	verifyChallenge string
+	verifyAddress   string
+)
+
+// Values of --chain for prove-ownership.
+const (
+	proveChainP    = "p"
+	proveChainC    = "c"
+	proveChainBoth = "both"
+)
+
+var proveOwnershipCmd = &cobra.Command{
+	Use:   "prove-ownership",
+	Short: "Sign a challenge to prove control of the wallet's addresses",
+	Long: `Sign a challenge with the wallet's P-Chain and/or EVM key and print a proof
+that anyone can check with 'wallet verify-ownership', without a transaction.
+
+The P-Chain key signs the challenge as an Avalanche signed message and the EVM
+key as an EIP-191 personal message, so the EVM signature can also be checked
+with standard Ethereum tooling. The proof is JSON (YAML with -o yaml) holding
+the challenge and, for each key, its address and signature.
+
+With --ledger, each signature is confirmed on the device, which shows the hash
+being signed. Use --out then, since the device prompts are printed to stdout.
+
+Example:
+  platform-cli wallet prove-ownership --key-name mykey --challenge "exchange-nonce-8f3a" --out proof.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := wallet.ValidateChallenge(proveChallenge); err != nil {
+			return withExitCode(exitUsage, fmt.Errorf("invalid --challenge: %w", err))
+		}
+		chains, err := proveChains(proveChain)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := getOperationContext()
+		defer cancel()
+
+		ctx, netConfig, err := getNetworkConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network config: %w", err)
+		}
+
+		proof := wallet.OwnershipProof{
+			Version:   wallet.OwnershipProofVersion,
+			Challenge: proveChallenge,
+		}
+		if useLedger {
+			if !wallet.LedgerEnabled {
+				return fmt.Errorf("ledger support not compiled. Rebuild with: go build -tags ledger")
+			}
+			kc, err := openLedger()
+			if err != nil {
+				return err
+			}
+			defer kc.Close()
+
+			for _, chain := range chains {
+				var (
+					signer wallet.HashSigner
+					addr   string
+				)
+				if chain == wallet.OwnershipChainP {
+					signer, addr = kc, wallet.FormatPChainAddress(kc.GetAddress(), netConfig.NetworkID)
+				} else {
+					signer, addr = wallet.HashSignerFunc(kc.SignHashEVM), kc.GetEVMPublicKey().EthAddress().Hex()
+				}
+				fmt.Fprintf(progressWriter(), "Signing the challenge with %s...\n", addr)
+				sig, err := wallet.SignOwnership(signer, chain, addr, proveChallenge)
+				if err != nil {
+					return withExitCode(exitKey, err)
+				}
+				proof.Signatures = append(proof.Signatures, sig)
+			}
+		} else {
+			keyBytes, err := loadKey()
+			if err != nil {
+				return err
+			}
+			defer clearBytesWallet(keyBytes)
+			if netConfig.NetworkID == constants.MainnetID && isEwoqKey(keyBytes) {
+				return fmt.Errorf("ewoq test key cannot be used on mainnet - this is a well-known key with no security")
+			}
+			key, err := wallet.ToPrivateKey(keyBytes)
+			if err != nil {
+				return err
+			}
+
+			for _, chain := range chains {
+				addr := key.PublicKey().EthAddress().Hex()
+				if chain == wallet.OwnershipChainP {
+					addr = wallet.FormatPChainAddress(key.Address(), netConfig.NetworkID)
+				}
+				sig, err := wallet.SignOwnership(key, chain, addr, proveChallenge)
+				if err != nil {
+					return err
+				}
+				proof.Signatures = append(proof.Signatures, sig)
+			}
+		}
+
+		data, err := marshalStructured(proof)
+		if err != nil {
+			return err
+		}
+		if proveOut == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(proveOut, data, reportFilePerm); err != nil {
+			return fmt.Errorf("failed to write proof: %w", err)
+		}
+		for _, sig := range proof.Signatures {
+			fmt.Fprintf(progressWriter(), "Signed by %s\n", sig.Address)
+		}
+		fmt.Fprintf(progressWriter(), "Ownership proof written to %s\n", proveOut)
+		return nil
+	},
+}
+
+// proveChains maps --chain to the chains whose keys sign, P-Chain first.
+func proveChains(chain string) ([]string, error) {
+	switch strings.ToLower(chain) {
+	case proveChainP:
+		return []string{wallet.OwnershipChainP}, nil
+	case proveChainC:
+		return []string{wallet.OwnershipChainC}, nil
+	case proveChainBoth:
+		return []string{wallet.OwnershipChainP, wallet.OwnershipChainC}, nil
+	default:
+		return nil, withExitCode(exitUsage, fmt.Errorf("invalid --chain %q: must be %q, %q or %q", chain, proveChainP, proveChainC, proveChainBoth))
+	}
+}
+
+// ownershipResult is the structured output of `wallet verify-ownership`.
+type ownershipResult struct {
+	Valid     bool     `json:"valid"`
+	Challenge string   `json:"challenge"`
+	Addresses []string `json:"addresses"`
+}
+
+var verifyOwnershipCmd = &cobra.Command{
+	Use:   "verify-ownership",
+	Short: "Check a proof made by 'wallet prove-ownership'",
+	Long: `Check that every signature in an ownership proof was made by the key of the
+address next to it. The proof may be JSON or YAML. No key, Ledger or network
+connection is needed.
+
+A valid signature only shows that the key holder signed the challenge in the
+proof; pass --challenge with the challenge you issued so a proof made for
+someone else cannot be replayed, and --address to require a particular
+address among the signers.
+
+Examples:
+  platform-cli wallet verify-ownership --proof proof.json --challenge "exchange-nonce-8f3a"
+  cat proof.json | platform-cli wallet verify-ownership --proof - --address P-avax1...`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if verifyProofFile == "" {
+			return withExitCode(exitUsage, fmt.Errorf("--proof is required"))
+		}
+		var (
+			data []byte
+			err  error
+		)
+		if verifyProofFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(verifyProofFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read proof: %w", err)
+		}
+
+		proof, err := wallet.ParseOwnershipProof(data)
+		if err != nil {
+			return withExitCode(exitUsage, err)
+		}
+		if err := checkOwnershipProof(proof, verifyChallenge, verifyAddress); err != nil {
+			return err
+		}
+
+		result := ownershipResult{Valid: true, Challenge: proof.Challenge}
+		for _, sig := range proof.Signatures {
+			result.Addresses = append(result.Addresses, sig.Address)
+		}
+		if wantStructured() {
+			return printStructured(result)
+		}
+		fmt.Printf("Ownership proof is valid for challenge %q\n", proof.Challenge)
+		for _, sig := range proof.Signatures {
+			fmt.Printf("  %s-Chain: %s\n", sig.Chain, sig.Address)
+		}
+		return nil
+	},
+}
+
+// checkOwnershipProof verifies proof and, when given, that it signs
+// challenge and includes addr.
+func checkOwnershipProof(proof wallet.OwnershipProof, challenge, addr string) error {
+	if err := proof.Verify(); err != nil {
+		return err
+	}
+	if challenge != "" && proof.Challenge != challenge {
+		return fmt.Errorf("%w: proof signs challenge %q, not %q", wallet.ErrOwnershipProofInvalid, proof.Challenge, challenge)
+	}
+	if addr == "" {
+		return nil
+	}
+	for _, sig := range proof.Signatures {
+		if sig.Address == addr || (sig.Chain == wallet.OwnershipChainC && strings.EqualFold(sig.Address, addr)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s did not sign the proof", wallet.ErrOwnershipProofInvalid, addr)
+}
+
+func init() {
+	walletCmd.AddCommand(proveOwnershipCmd)
+	walletCmd.AddCommand(verifyOwnershipCmd)
+
+	proveOwnershipCmd.Flags().StringVar(&proveChallenge, "challenge", "", "Challenge to sign, as given by the party asking for the proof")
+	proveOwnershipCmd.Flags().StringVar(&proveChain, "chain", proveChainBoth, "Key to sign with: 'p' (P-Chain), 'c' (EVM) or 'both'")
+	proveOwnershipCmd.Flags().StringVar(&proveOut, "out", "", "Write the proof to this file instead of stdout")
+	_ = proveOwnershipCmd.MarkFlagRequired("challenge")
+
+	verifyOwnershipCmd.Flags().StringVar(&verifyProofFile, "proof", "", "Proof file to check ('-' for stdin)")
+	verifyOwnershipCmd.Flags().StringVar(&verifyChallenge, "challenge", "", "Require the proof to sign this challenge")
+	verifyOwnershipCmd.Flags().StringVar(&verifyAddress, "address", "", "Require this address among the signers")
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/crosschain"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/qr"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+// newCChainOnlyServer answers eth_getBalance on the C-Chain endpoint and
+// 404s everything else, like a devnet without a reachable P-Chain API.
+func newCChainOnlyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ext/bc/C/rpc" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0x0"})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPrintBalances_ChainScope(t *testing.T) {
+	srv := newCChainOnlyServer(t)
+	netConfig := network.Config{RPCURL: srv.URL, NetworkID: 12345}
+
+	tests := []struct {
+		name           string
+		queryP, queryC bool
+		wantErr        bool
+	}{
+		{name: "both chains, P down", queryP: true, queryC: true},
+		{name: "only C", queryC: true},
+		{name: "only P, P down", queryP: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := printBalances(context.Background(), netConfig, ids.GenerateTestShortID(), common.Address{}, tt.queryP, tt.queryC)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("printBalances() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCChainImportBaseFee(t *testing.T) {
+	srv := newCChainOnlyServer(t)
+	netConfig := network.Config{RPCURL: srv.URL, NetworkID: 12345}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantWei int64
+		wantErr bool
+	}{
+		{name: "unset uses the estimate"},
+		{name: "override in nAVAX", args: []string{"--c-base-fee", "30"}, wantWei: 30_000_000_000},
+		{name: "zero", args: []string{"--c-base-fee", "0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferCBaseFee = 0
+			cmd := &cobra.Command{}
+			cmd.Flags().Uint64Var(&transferCBaseFee, "c-base-fee", 0, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := cChainImportBaseFee(context.Background(), cmd, netConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cChainImportBaseFee() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantWei == 0 && got != nil:
+				t.Fatalf("cChainImportBaseFee() = %s, want nil", got)
+			case tt.wantWei != 0 && (got == nil || got.Int64() != tt.wantWei):
+				t.Fatalf("cChainImportBaseFee() = %v, want %d wei", got, tt.wantWei)
+			}
+		})
+	}
+}
+
+func TestCheckAssumeAccepted(t *testing.T) {
+	defer func() { transferAssumeAccepted = false }()
+
+	tests := []struct {
+		name      string
+		set       bool
+		networkID uint32
+		wantErr   bool
+	}{
+		{name: "unset on mainnet", networkID: constants.MainnetID},
+		{name: "mainnet", set: true, networkID: constants.MainnetID, wantErr: true},
+		{name: "fuji", set: true, networkID: constants.FujiID, wantErr: true},
+		{name: "local", set: true, networkID: constants.LocalID},
+		{name: "custom", set: true, networkID: 12345},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferAssumeAccepted = tt.set
+			err := checkAssumeAccepted(network.Config{NetworkID: tt.networkID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkAssumeAccepted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResumableTransfer(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "transfer.json")
+	exportID, importID := ids.GenerateTestID(), ids.GenerateTestID()
+
+	var exports, imports int
+	export := func() (ids.ID, error) {
+		exports++
+		return exportID, nil
+	}
+	failImport := func() (crosschain.ImportResult, error) {
+		imports++
+		return crosschain.ImportResult{}, errors.New("no UTXOs")
+	}
+	okImport := func() (crosschain.ImportResult, error) {
+		imports++
+		return crosschain.ImportResult{TxID: importID, ReceivedNAVAX: 990}, nil
+	}
+
+	// The import fails: the export is recorded for the next run.
+	if _, _, err := resumableTransfer(stateFile, crosschain.DirectionPToC, 5, 1_000, export, failImport); err == nil {
+		t.Fatal("resumableTransfer() expected import error")
+	}
+	state, err := crosschain.LoadTransferState(stateFile)
+	if err != nil || state == nil || state.ExportTxID != exportID {
+		t.Fatalf("state after failed import = %+v, %v; want export %s", state, err, exportID)
+	}
+
+	// A different transfer must not pick up the pending export.
+	if _, _, err := resumableTransfer(stateFile, crosschain.DirectionCToP, 5, 1_000, export, okImport); err == nil {
+		t.Fatal("resumableTransfer() expected error for a mismatched state file")
+	}
+
+	// Re-running resumes at the import and clears the state.
+	gotExport, gotImport, err := resumableTransfer(stateFile, crosschain.DirectionPToC, 5, 1_000, export, okImport)
+	if err != nil {
+		t.Fatalf("resumableTransfer() resume error = %v", err)
+	}
+	if gotExport != exportID || gotImport.TxID != importID || gotImport.ReceivedNAVAX != 990 {
+		t.Fatalf("resumableTransfer() = %s, %+v; want %s, %s receiving 990", gotExport, gotImport, exportID, importID)
+	}
+	if exports != 1 || imports != 2 {
+		t.Fatalf("exports = %d, imports = %d; want 1 export and 2 imports", exports, imports)
+	}
+	if state, err := crosschain.LoadTransferState(stateFile); err != nil || state != nil {
+		t.Fatalf("state after success = %+v, %v; want none", state, err)
+	}
+}
+
+func TestValidateQRFlags(t *testing.T) {
+	origQR, origOut, origChain := addressQR, addressQROut, addressQRChain
+	defer func() { addressQR, addressQROut, addressQRChain = origQR, origOut, origChain }()
+	existing := filepath.Join(t.TempDir(), "existing.png")
+	if err := os.WriteFile(existing, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		qr      bool
+		out     string
+		chain   string
+		wantErr bool
+	}{
+		{name: "no qr", chain: "p"},
+		{name: "bad chain", chain: "x", wantErr: true},
+		{name: "terminal", qr: true, chain: "c", wantErr: !qr.Enabled},
+		{name: "png", out: "addr.PNG", chain: "p", wantErr: !qr.Enabled},
+		{name: "not png", out: "addr.txt", chain: "p", wantErr: true},
+		{name: "existing png", out: existing, chain: "p", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addressQR, addressQROut, addressQRChain = tt.qr, tt.out, tt.chain
+			if err := validateQRFlags(); (err != nil) != tt.wantErr {
+				t.Fatalf("validateQRFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddressNetworkLabel(t *testing.T) {
+	tests := []struct {
+		networkID uint32
+		want      string
+	}{
+		{constants.MainnetID, "mainnet, avax"},
+		{constants.FujiID, "fuji"},
+		{constants.LocalID, "local"},
+		{54321, "network-54321, custom"},
+	}
+	for _, tt := range tests {
+		if got := addressNetworkLabel(tt.networkID); got != tt.want {
+			t.Errorf("addressNetworkLabel(%d) = %q, want %q", tt.networkID, got, tt.want)
+		}
+	}
+}
+
+func TestCheckOwnershipProof(t *testing.T) {
+	key, err := wallet.ToPrivateKey(ewoqPrivateKey)
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+	const challenge = "nonce-1"
+	evmAddr := key.PublicKey().EthAddress().Hex()
+	sig, err := wallet.SignOwnership(key, wallet.OwnershipChainC, evmAddr, challenge)
+	if err != nil {
+		t.Fatalf("SignOwnership() error = %v", err)
+	}
+	proof := wallet.OwnershipProof{Version: wallet.OwnershipProofVersion, Challenge: challenge, Signatures: []wallet.OwnershipSignature{sig}}
+
+	tests := []struct {
+		name      string
+		challenge string
+		addr      string
+		wantErr   bool
+	}{
+		{"no expectations", "", "", false},
+		{"expected challenge and address", challenge, evmAddr, false},
+		{"EVM address in another case", "", "0x8DB97C7CECE249C2B98BDC0226CC4C2A57BF52FC", false},
+		{"other challenge", "nonce-2", "", true},
+		{"other address", "", "0x0000000000000000000000000000000000000001", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOwnershipProof(proof, tt.challenge, tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkOwnershipProof() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && exitCode(err) != exitRejected {
+				t.Fatalf("exitCode() = %d, want %d", exitCode(err), exitRejected)
+			}
+		})
+	}
+}
+
+func TestProveChains(t *testing.T) {
+	if got, err := proveChains("BOTH"); err != nil || len(got) != 2 || got[0] != wallet.OwnershipChainP {
+		t.Fatalf("proveChains(\"BOTH\") = %v, %v; want P then C", got, err)
+	}
+	if _, err := proveChains("x"); err == nil || exitCode(err) != exitUsage {
+		t.Fatalf("proveChains(\"x\") error = %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	// defaultBalanceWatchInterval is the --watch interval when none is given.
+	defaultBalanceWatchInterval = 5 * time.Second
+	// minBalanceWatchInterval keeps --watch from polling the node too hard.
+	minBalanceWatchInterval = time.Second
+	// clearLine returns the cursor to the start of the line and erases it.
+	clearLine = "\r\x1b[K"
+)
+
+// balanceWatch is the `wallet balance --watch` polling interval; zero means
+// no watch.
+var balanceWatch time.Duration
+
+// balanceSnapshot is one `wallet balance --watch` poll. A chain that was not
+// queried, or whose balance could not be fetched, has a nil balance.
+type balanceSnapshot struct {
+	Time          time.Time `json:"time"`
+	PChainAddress string    `json:"pChainAddress,omitempty"`
+	PChainNAVAX   *uint64   `json:"pChainBalanceNAVAX,omitempty"`
+	CChainAddress string    `json:"cChainAddress,omitempty"`
+	CChainNAVAX   *uint64   `json:"cChainBalanceNAVAX,omitempty"`
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+// runBalanceWatch is `wallet balance --watch`: it re-prints the balance every
+// interval until interrupted.
+func runBalanceWatch(cmd *cobra.Command) error {
+	if balanceWatch < minBalanceWatchInterval {
+		return withExitCode(exitUsage, fmt.Errorf("--watch interval must be at least %s", minBalanceWatchInterval))
+	}
+	if balanceDescriptorFile != "" || cmd.Flags().Changed("at-height") || cmd.Flags().Changed("at-time") {
+		return withExitCode(exitUsage, fmt.Errorf("--watch cannot be combined with --descriptor, --at-height or --at-time"))
+	}
+	opts := flagOptions()
+	if !opts.signalCancelEnabled() {
+		return withExitCode(exitUsage, fmt.Errorf("--watch runs until interrupted and cannot be combined with --no-signal-cancel"))
+	}
+
+	ctx, cancel := opts.WatchContext()
+	defer cancel()
+
+	setupCtx, setupCancel := context.WithTimeout(ctx, opts.operationTimeout())
+	netConfig, fallbacks, err := opts.resolveNetwork(setupCtx)
+	setupCancel()
+	if err != nil {
+		return fmt.Errorf("failed to get network config: %w", err)
+	}
+	// Fail over on ctx, not setupCtx: the polls outlive the setup timeout.
+	if ctx, err = network.WithFailover(ctx, netConfig, fallbacks, warnRPCFailover); err != nil {
+		return fmt.Errorf("failed to get network config: %w", err)
+	}
+	pAddr, evmAddr, err := loadWalletAddresses(netConfig)
+	if err != nil {
+		return err
+	}
+
+	queryP, queryC := !balanceOnlyC, !balanceOnlyP
+	poll := func(ctx context.Context) balanceSnapshot {
+		pollCtx, pollCancel := context.WithTimeout(ctx, opts.operationTimeout())
+		defer pollCancel()
+		return pollBalances(pollCtx, netConfig, pAddr, evmAddr, queryP, queryC)
+	}
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	if !wantStructured() {
+		fmt.Printf("Watching balances every %s; press Ctrl-C to stop.\n", balanceWatch)
+	}
+	err = watchBalances(ctx, balanceWatch, poll, func(s balanceSnapshot) error {
+		return writeBalanceSnapshot(os.Stdout, s, tty)
+	})
+	if tty && !wantStructured() {
+		fmt.Println()
+	}
+	return err
+}
+
+// balanceArgs rejects positional arguments to `wallet balance`. The --watch
+// interval is optional, so `--watch 10s` leaves "10s" as an argument; that
+// case gets a hint to write --watch=10s.
+func balanceArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if _, err := time.ParseDuration(args[0]); err == nil && cmd.Flags().Changed("watch") {
+		return withExitCode(exitUsage, fmt.Errorf("unexpected argument %q; set the interval with --watch=%s", args[0], args[0]))
+	}
+	return withExitCode(exitUsage, fmt.Errorf("unexpected argument %q; wallet balance takes no arguments", args[0]))
+}
+
+// pollBalances fetches the balances shown by `wallet balance --watch`.
+func pollBalances(ctx context.Context, netConfig network.Config, pAddr ids.ShortID, evmAddr common.Address, queryP, queryC bool) balanceSnapshot {
+	s := balanceSnapshot{Time: time.Now().UTC()}
+	if queryP {
+		s.PChainAddress = wallet.FormatPChainAddress(pAddr, netConfig.NetworkID)
+		if balance, err := wallet.GetAddressBalance(ctx, netConfig, pAddr); err != nil {
+			s.Errors = append(s.Errors, fmt.Sprintf("P-Chain: %v", err))
+		} else {
+			s.PChainNAVAX = &balance
+		}
+	}
+	if queryC {
+		s.CChainAddress = evmAddr.Hex()
+		if balance, err := wallet.GetCChainBalance(ctx, netConfig, evmAddr); err != nil {
+			s.Errors = append(s.Errors, fmt.Sprintf("C-Chain: %v", err))
+		} else {
+			s.CChainNAVAX = &balance
+		}
+	}
+	return s
+}
+
+// watchBalances polls right away and then every interval, passing each
+// snapshot to render, until ctx is cancelled. Cancellation, normally Ctrl-C,
+// ends the watch without error; a poll cut short by it is not rendered.
+func watchBalances(ctx context.Context, interval time.Duration, poll func(context.Context) balanceSnapshot, render func(balanceSnapshot) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s := poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := render(s); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeBalanceSnapshot writes one poll. With --output json it is a single
+// line of JSON, so the stream is newline-delimited JSON; with yaml, one
+// document. Text is one line per poll, overwritten in place on a terminal.
+func writeBalanceSnapshot(w io.Writer, s balanceSnapshot, tty bool) error {
+	switch outputFormat {
+	case outputJSON:
+		data, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("failed to encode json output: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case outputYAML:
+		data, err := marshalStructured(s)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "---\n%s", data)
+		return err
+	}
+
+	line := formatBalanceSnapshot(s)
+	if tty {
+		_, err := fmt.Fprint(w, clearLine+line)
+		return err
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// formatBalanceSnapshot renders a poll as a single line of text.
+func formatBalanceSnapshot(s balanceSnapshot) string {
+	parts := []string{s.Time.Local().Format(time.TimeOnly)}
+	chain := func(name, addr string, balance *uint64) {
+		switch {
+		case addr == "":
+		case balance == nil:
+			parts = append(parts, name+": n/a")
+		default:
+			parts = append(parts, name+": "+formatAmount(*balance))
+		}
+	}
+	chain("P-Chain", s.PChainAddress, s.PChainNAVAX)
+	chain("C-Chain", s.CChainAddress, s.CChainNAVAX)
+	line := strings.Join(parts, "  ")
+	if len(s.Errors) > 0 {
+		line += "  (" + strings.Join(s.Errors, "; ") + ")"
+	}
+	return line
+}
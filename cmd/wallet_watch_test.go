@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWatchBalances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls, renders int
+	poll := func(context.Context) balanceSnapshot {
+		polls++
+		if polls == 3 {
+			cancel() // Ctrl-C during the third poll
+		}
+		return balanceSnapshot{}
+	}
+	render := func(balanceSnapshot) error {
+		renders++
+		return nil
+	}
+	if err := watchBalances(ctx, time.Millisecond, poll, render); err != nil {
+		t.Fatalf("watchBalances() error = %v, want nil on cancellation", err)
+	}
+	if polls != 3 || renders != 2 {
+		t.Errorf("polls = %d, renders = %d; want 3 polls and 2 renders", polls, renders)
+	}
+
+	renderErr := errors.New("broken pipe")
+	err := watchBalances(context.Background(), time.Millisecond, poll, func(balanceSnapshot) error { return renderErr })
+	if !errors.Is(err, renderErr) {
+		t.Fatalf("watchBalances() error = %v, want %v", err, renderErr)
+	}
+}
+
+func TestWriteBalanceSnapshot(t *testing.T) {
+	origFormat := outputFormat
+	defer func() { outputFormat = origFormat }()
+
+	pBalance := uint64(1_500_000_000)
+	s := balanceSnapshot{
+		Time:          time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		PChainAddress: "P-fuji1abc",
+		PChainNAVAX:   &pBalance,
+		CChainAddress: "0xabc",
+		Errors:        []string{"C-Chain: connection refused"},
+	}
+
+	outputFormat = outputText
+	var buf bytes.Buffer
+	if err := writeBalanceSnapshot(&buf, s, false); err != nil {
+		t.Fatalf("writeBalanceSnapshot() error = %v", err)
+	}
+	line := buf.String()
+	for _, want := range []string{"P-Chain: 1.5 AVAX", "C-Chain: n/a", "(C-Chain: connection refused)"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("text output %q does not contain %q", line, want)
+		}
+	}
+	if !strings.HasSuffix(line, "\n") || strings.HasPrefix(line, clearLine) {
+		t.Errorf("text output %q: want a plain line off a terminal", line)
+	}
+
+	buf.Reset()
+	if err := writeBalanceSnapshot(&buf, s, true); err != nil {
+		t.Fatalf("writeBalanceSnapshot() error = %v", err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, clearLine) || strings.HasSuffix(got, "\n") {
+		t.Errorf("terminal output %q: want the line rewritten in place", got)
+	}
+
+	outputFormat = outputJSON
+	buf.Reset()
+	for range 2 {
+		if err := writeBalanceSnapshot(&buf, s, true); err != nil {
+			t.Fatalf("writeBalanceSnapshot() error = %v", err)
+		}
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("json output has %d lines, want one per poll:\n%s", len(lines), buf.String())
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("json line is not JSON: %v", err)
+	}
+	if got["pChainBalanceNAVAX"] != float64(pBalance) || got["cChainAddress"] != "0xabc" {
+		t.Errorf("json snapshot = %v", got)
+	}
+	if _, ok := got["cChainBalanceNAVAX"]; ok {
+		t.Errorf("json snapshot has a C-Chain balance that failed to load: %v", got)
+	}
+}
+
+func TestBalanceArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   []string
+		args    []string
+		wantErr string
+	}{
+		{name: "none", flags: []string{"--watch"}},
+		{name: "interval after --watch", flags: []string{"--watch"}, args: []string{"10s"}, wantErr: "--watch=10s"},
+		{name: "other argument", args: []string{"mykey"}, wantErr: "takes no arguments"},
+		{name: "duration without --watch", args: []string{"10s"}, wantErr: "takes no arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Duration("watch", 0, "")
+			cmd.Flags().Lookup("watch").NoOptDefVal = defaultBalanceWatchInterval.String()
+			if err := cmd.Flags().Parse(tt.flags); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			err := balanceArgs(cmd, tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("balanceArgs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("balanceArgs() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if code := exitCode(err); code != exitUsage {
+				t.Fatalf("exit code = %d, want %d", code, exitUsage)
+			}
+		})
+	}
+}
@@ -4,10 +4,15 @@ package e2e
 
 import (
 	"bytes"
+	"encoding/hex"
 	"os"
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
 // runCLI executes the platform CLI with the given arguments.
@@ -80,7 +85,7 @@ func TestCLIHelp(t *testing.T) {
 	}
 
 	// Check that help contains expected commands
-	expectedCommands := []string{"wallet", "transfer", "validator", "subnet", "l1", "chain", "keys", "node"}
+	expectedCommands := []string{"wallet", "transfer", "validator", "subnet", "l1", "chain", "keys", "node", "tx", "network"}
 	for _, cmd := range expectedCommands {
 		if !strings.Contains(stdout, cmd) {
 			t.Errorf("help output missing command: %s", cmd)
@@ -135,13 +140,52 @@ func TestCLIValidatorHelp(t *testing.T) {
 	t.Logf("Validator help:\n%s", stdout)
 }
 
+// TestCLIValidatorStakingCommandsUseLedger runs the staking commands with
+// --ledger and no Ledger attached. Each must fail opening the Ledger rather
+// than ignore the flag and sign with a stored key.
+func TestCLIValidatorStakingCommandsUseLedger(t *testing.T) {
+	blsSigner, err := localsigner.New()
+	if err != nil {
+		t.Fatalf("failed to generate BLS signer: %v", err)
+	}
+	pop, err := signer.NewProofOfPossession(blsSigner)
+	if err != nil {
+		t.Fatalf("failed to generate proof of possession: %v", err)
+	}
+	nodeID := ids.GenerateTestNodeID().String()
+	blsFlags := []string{
+		"--bls-public-key", "0x" + hex.EncodeToString(pop.PublicKey[:]),
+		"--bls-pop", "0x" + hex.EncodeToString(pop.ProofOfPossession[:]),
+	}
+
+	tests := []struct {
+		sub  string
+		args []string
+	}{
+		{sub: "add-permissionless", args: append([]string{"--node-id", nodeID, "--stake", "2000", "--duration", "720h"}, blsFlags...)},
+		{sub: "add-permissionless-delegator", args: []string{"--node-id", nodeID, "--stake", "25", "--duration", "720h"}},
+		{sub: "add-auto-renewed", args: append([]string{"--node-id", nodeID, "--stake", "2000", "--period", "720h"}, blsFlags...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sub, func(t *testing.T) {
+			stdout, stderr, err := runCLI(t, append([]string{"validator", tt.sub, "--ledger"}, tt.args...)...)
+			if err == nil {
+				t.Fatalf("%s --ledger succeeded without a Ledger:\n%s", tt.sub, stdout)
+			}
+			if !strings.Contains(strings.ToLower(stderr), "ledger") {
+				t.Fatalf("%s --ledger failed without opening the Ledger: %s", tt.sub, stderr)
+			}
+		})
+	}
+}
+
 func TestCLISubnetHelp(t *testing.T) {
 	stdout, _, err := runCLI(t, "subnet", "--help")
 	if err != nil {
 		t.Fatalf("subnet help failed: %v", err)
 	}
 
-	expected := []string{"create", "transfer-ownership", "convert-to-l1", "add-validator"}
+	expected := []string{"create", "create-chain", "transfer-ownership", "convert-to-l1", "add-validator"}
 	for _, cmd := range expected {
 		if !strings.Contains(stdout, cmd) {
 			t.Errorf("subnet help missing subcommand: %s", cmd)
@@ -181,7 +225,7 @@ func TestCLIWalletAddress(t *testing.T) {
 		t.Fatalf("wallet address failed: %v\nstderr: %s", err, stderr)
 	}
 
-	if !strings.Contains(stdout, "P-Chain Address:") {
+	if !strings.Contains(stdout, "P-Chain Address (") {
 		t.Error("output missing P-Chain address")
 	}
 	if !strings.Contains(stdout, "EVM Address:") {
@@ -208,8 +252,11 @@ func TestCLITransferSend(t *testing.T) {
 	lines := strings.Split(addrOut, "\n")
 	var pAddr string
 	for _, line := range lines {
-		if strings.HasPrefix(line, "P-Chain Address:") {
-			pAddr = strings.TrimSpace(strings.TrimPrefix(line, "P-Chain Address:"))
+		// "P-Chain Address (<network>): <address>"
+		if strings.HasPrefix(line, "P-Chain Address (") {
+			if _, addr, ok := strings.Cut(line, "): "); ok {
+				pAddr = strings.TrimSpace(addr)
+			}
 			break
 		}
 	}
@@ -397,6 +444,34 @@ func TestCLIChainCreateMissingArgs(t *testing.T) {
 	}
 }
 
+func TestCLISubnetCreateChainMissingArgs(t *testing.T) {
+	_, stderr, err := runCLI(t, "subnet", "create-chain")
+	if err == nil {
+		t.Error("expected error when missing required args")
+	}
+
+	if !strings.Contains(stderr, "subnet-id") {
+		t.Logf("stderr: %s", stderr)
+	}
+}
+
+func TestCLIChainCreateHelpShowsWalletFlags(t *testing.T) {
+	for _, args := range [][]string{{"chain", "create"}, {"subnet", "create-chain"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, _, err := runCLI(t, append(args, "--help")...)
+			if err != nil {
+				t.Fatalf("%v help failed: %v", args, err)
+			}
+
+			for _, flag := range []string{"--subnet-id", "--genesis", "--rpc-url", "--ledger", "--ledger-index", "--key-name"} {
+				if !strings.Contains(stdout, flag) {
+					t.Errorf("%v help missing flag: %s", args, flag)
+				}
+			}
+		})
+	}
+}
+
 func TestCLISubnetConvertL1MissingArgs(t *testing.T) {
 	_, stderr, err := runCLI(t, "subnet", "convert-to-l1")
 	if err == nil {
@@ -440,6 +515,8 @@ func TestCLIUnknownSubcommandRejected(t *testing.T) {
 		{"keys", "bogus"},
 		{"wallet", "bogus"},
 		{"node", "bogus"},
+		{"tx", "bogus"},
+		{"network", "bogus"},
 	}
 	for _, args := range cases {
 		_, stderr, err := runCLI(t, args...)
@@ -561,6 +638,9 @@ func TestCLIL1Lifecycle(t *testing.T) {
 	if !strings.Contains(convertOut, "TX ID:") {
 		t.Error("output missing conversion TX ID")
 	}
+	if !strings.Contains(convertOut, "Validation IDs") {
+		t.Error("output missing validation IDs")
+	}
 	t.Logf("Output:\n%s", convertOut)
 
 	t.Log("=== L1 Lifecycle Complete ===")
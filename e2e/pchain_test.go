@@ -30,6 +30,7 @@ import (
 	"github.com/ava-labs/platform-cli/pkg/crosschain"
 	"github.com/ava-labs/platform-cli/pkg/network"
 	"github.com/ava-labs/platform-cli/pkg/pchain"
+	"github.com/ava-labs/platform-cli/pkg/retry"
 	"github.com/ava-labs/platform-cli/pkg/wallet"
 )
 
@@ -48,6 +49,9 @@ const (
 	// Retry transient RPC rate limits from shared public endpoints.
 	rateLimitRetryAttempts = 6
 	rateLimitRetryDelay    = 1 * time.Second
+	// rateLimitRetryBudget caps the backoff spent across the whole run, so a
+	// throttled endpoint fails the suite instead of stalling every test.
+	rateLimitRetryBudget = 5 * time.Minute
 )
 
 func isRateLimitError(err error) bool {
@@ -60,6 +64,9 @@ func isRateLimitError(err error) bool {
 		strings.Contains(errStr, "rate limit")
 }
 
+// rateLimitBudget is shared by every retryRateLimitedOperation call.
+var rateLimitBudget = retry.NewBudget(rateLimitRetryBudget)
+
 func retryRateLimitedOperation[T any](t *testing.T, opName string, fn func() (T, error)) (T, error) {
 	t.Helper()
 
@@ -82,7 +89,9 @@ func retryRateLimitedOperation[T any](t *testing.T, opName string, fn func() (T,
 		}
 
 		t.Logf("%s hit rate limit (attempt %d/%d), retrying in %s: %v", opName, attempt, rateLimitRetryAttempts, delay, err)
-		time.Sleep(delay)
+		if err := retry.Wait(retry.WithBudget(context.Background(), rateLimitBudget), delay); err != nil {
+			return zero, fmt.Errorf("%s gave up after %d rate-limit retries (%v): %w", opName, attempt, err, lastErr)
+		}
 		delay *= 2
 	}
 
@@ -0,0 +1,330 @@
+package crosschain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+)
+
+// maxExportFeeRounds bounds how many times maxExportAmount rebuilds the
+// export while settling its fee.
+const maxExportFeeRounds = 8
+
+// ErrNothingToConsolidate is returned when no chain holds enough AVAX to pay
+// for moving it and nothing is waiting to be imported.
+var ErrNothingToConsolidate = errors.New("nothing to consolidate")
+
+// Chain names used in ConsolidateResult.
+const (
+	ChainP = "P"
+	ChainC = "C"
+	ChainX = "X"
+)
+
+// ConsolidateResult reports a consolidation onto one chain. ExportTxID is
+// empty when the source chain held too little to export. ImportableNAVAX is
+// the atomic AVAX the import spends, before its fee: the export plus anything
+// left over from earlier, unfinished transfers.
+type ConsolidateResult struct {
+	From            string
+	To              string
+	ExportedNAVAX   uint64
+	ExportTxID      ids.ID
+	ImportableNAVAX uint64
+	ImportTxID      ids.ID
+}
+
+// ConsolidateToPChain exports the wallet's whole C-Chain balance, less the
+// export fee, to the P-Chain and imports everything exportable from the
+// C-Chain there. The C-Chain export pays baseFee (wei per gas), or the
+// node's current estimate when nil.
+func ConsolidateToPChain(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ConsolidateResult, error) {
+	result := ConsolidateResult{From: ChainC, To: ChainP}
+	if baseFee == nil {
+		current, err := wallet.GetCChainBaseFee(ctx, w.Config())
+		if err != nil {
+			return result, fmt.Errorf("failed to get C-Chain base fee: %w", err)
+		}
+		baseFee = wallet.NAVAXToWei(current)
+	}
+
+	cBuilder := w.CWallet().Builder()
+	avaxAssetID := cBuilder.Context().AVAXAssetID
+	balanceWei, err := cBuilder.GetBalance(common.WithContext(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to get C-Chain balance: %w", err)
+	}
+	balance := new(big.Int).Div(balanceWei, wallet.NAVAXToWei(1)).Uint64()
+
+	amount, err := maxExportAmount(balance, func(amount uint64) (uint64, error) {
+		utx, err := cBuilder.NewExportTx(constants.PlatformChainID, []*secp256k1fx.TransferOutput{{
+			Amt:          amount,
+			OutputOwners: ownedBy(w.PChainAddress()),
+		}}, baseFee, common.WithContext(ctx))
+		if err != nil {
+			// The C-Chain builder's insufficient funds error is unexported.
+			if strings.Contains(err.Error(), "insufficient funds") {
+				return 0, fmt.Errorf("%w: %v", builder.ErrInsufficientFunds, err)
+			}
+			return 0, err
+		}
+		var consumed uint64
+		for _, in := range utx.Ins {
+			if in.AssetID == avaxAssetID {
+				consumed += in.Amount
+			}
+		}
+		if consumed < amount {
+			return 0, fmt.Errorf("built ExportTx consumes %d nAVAX, less than the %d exported", consumed, amount)
+		}
+		return consumed - amount, nil
+	})
+	if err != nil && !errors.Is(err, ErrNothingToConsolidate) {
+		return result, fmt.Errorf("failed to size C-Chain export: %w", err)
+	}
+	if amount > 0 {
+		tx, err := w.CWallet().IssueExportTx(constants.PlatformChainID, []*secp256k1fx.TransferOutput{{
+			Amt:          amount,
+			OutputOwners: ownedBy(w.PChainAddress()),
+		}}, cChainIssueOptions(ctx, baseFee)...)
+		if err != nil {
+			return result, fmt.Errorf("failed to issue C-Chain export tx: %w", err)
+		}
+		result.ExportedNAVAX, result.ExportTxID = amount, tx.ID()
+	}
+
+	cChainID := w.CWallet().Builder().Context().BlockchainID
+	return completeConsolidation(result, func() (uint64, error) {
+		balances, err := w.PWallet().Builder().GetImportableBalance(cChainID, common.WithContext(ctx))
+		if err != nil {
+			return 0, err
+		}
+		return balances[w.PWallet().Builder().Context().AVAXAssetID], nil
+	}, func() (ids.ID, error) {
+		return CompleteImportToPChain(ctx, w)
+	})
+}
+
+// ConsolidateToCChain exports the wallet's whole unlocked P-Chain balance,
+// less the export fee, to the C-Chain and imports everything exportable from
+// the P-Chain there. The C-Chain import pays baseFee (wei per gas), or the
+// node's current estimate when nil.
+func ConsolidateToCChain(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ConsolidateResult, error) {
+	result := ConsolidateResult{From: ChainP, To: ChainC}
+	pBuilder := w.PWallet().Builder()
+	avaxAssetID := pBuilder.Context().AVAXAssetID
+	cChainID := w.CWallet().Builder().Context().BlockchainID
+
+	balances, err := pBuilder.GetBalance(common.WithContext(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to get P-Chain balance: %w", err)
+	}
+
+	amount, err := maxExportAmount(balances[avaxAssetID], func(amount uint64) (uint64, error) {
+		utx, err := pBuilder.NewExportTx(cChainID, []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount, OutputOwners: ownedBy(w.PChainAddress())},
+		}}, common.WithContext(ctx))
+		if err != nil {
+			return 0, err
+		}
+		return burnedNAVAX(utx.Ins, append(utx.Outs, utx.ExportedOutputs...), avaxAssetID)
+	})
+	if err != nil && !errors.Is(err, ErrNothingToConsolidate) {
+		return result, fmt.Errorf("failed to size P-Chain export: %w", err)
+	}
+	if amount > 0 {
+		txID, err := ExportFromPChain(ctx, w, amount)
+		if err != nil {
+			return result, err
+		}
+		result.ExportedNAVAX, result.ExportTxID = amount, txID
+	}
+
+	return completeConsolidation(result, func() (uint64, error) {
+		return w.CWallet().Builder().GetImportableBalance(constants.PlatformChainID, common.WithContext(ctx))
+	}, func() (ids.ID, error) {
+		return CompleteImportToCChain(ctx, w, baseFee)
+	})
+}
+
+// ConsolidateFromXChain exports the wallet's whole X-Chain AVAX balance, less
+// the export fee, to the to chain (ChainP or ChainC) and imports everything
+// exportable from the X-Chain there. A C-Chain import pays baseFee (wei per
+// gas), or the node's current estimate when nil.
+func ConsolidateFromXChain(ctx context.Context, w *wallet.FullWallet, to string, baseFee *big.Int) (ConsolidateResult, error) {
+	result := ConsolidateResult{From: ChainX, To: to}
+	xBuilder := w.XWallet().Builder()
+	avaxAssetID := xBuilder.Context().AVAXAssetID
+	xChainID := xBuilder.Context().BlockchainID
+
+	var (
+		targetChainID ids.ID
+		importable    func() (uint64, error)
+		importFn      func() (ids.ID, error)
+	)
+	switch to {
+	case ChainP:
+		targetChainID = constants.PlatformChainID
+		importable = func() (uint64, error) {
+			balances, err := w.PWallet().Builder().GetImportableBalance(xChainID, common.WithContext(ctx))
+			return balances[avaxAssetID], err
+		}
+		importFn = func() (ids.ID, error) {
+			imported, err := completeImportToPChainFrom(ctx, w, xChainID)
+			return imported.TxID, err
+		}
+	case ChainC:
+		targetChainID = w.CWallet().Builder().Context().BlockchainID
+		importable = func() (uint64, error) {
+			return w.CWallet().Builder().GetImportableBalance(xChainID, common.WithContext(ctx))
+		}
+		importFn = func() (ids.ID, error) {
+			imported, err := completeImportToCChainFrom(ctx, w, xChainID, baseFee)
+			return imported.TxID, err
+		}
+	default:
+		return result, fmt.Errorf("cannot consolidate X-Chain AVAX onto the %s-Chain", to)
+	}
+
+	balances, err := xBuilder.GetFTBalance(common.WithContext(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to get X-Chain balance: %w", err)
+	}
+	exportFee := xChainExportFee(ctx, xBuilder, targetChainID, w.PChainAddress())
+	amount, err := maxExportAmount(balances[avaxAssetID], exportFee)
+	if err != nil && !errors.Is(err, ErrNothingToConsolidate) {
+		return result, fmt.Errorf("failed to size X-Chain export: %w", err)
+	}
+	if amount > 0 {
+		tx, err := w.XWallet().IssueExportTx(targetChainID, []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount, OutputOwners: ownedBy(w.PChainAddress())},
+		}}, common.WithContext(ctx))
+		if err != nil {
+			return result, fmt.Errorf("failed to issue X-Chain export tx: %w", err)
+		}
+		result.ExportedNAVAX, result.ExportTxID = amount, tx.ID()
+	}
+
+	return completeConsolidation(result, importable, importFn)
+}
+
+// xChainExportFee returns the feeFor func of maxExportAmount for an X-Chain
+// export of AVAX to targetChainID, owned by owner.
+func xChainExportFee(ctx context.Context, xBuilder xbuilder.Builder, targetChainID ids.ID, owner ids.ShortID) func(uint64) (uint64, error) {
+	avaxAssetID := xBuilder.Context().AVAXAssetID
+	return func(amount uint64) (uint64, error) {
+		utx, err := xBuilder.NewExportTx(targetChainID, []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount, OutputOwners: ownedBy(owner)},
+		}}, common.WithContext(ctx))
+		if err != nil {
+			// The X-Chain builder's insufficient funds error is unexported.
+			if strings.Contains(err.Error(), "insufficient funds") {
+				return 0, fmt.Errorf("%w: %v", builder.ErrInsufficientFunds, err)
+			}
+			return 0, err
+		}
+		return burnedNAVAX(utx.Ins, append(utx.Outs, utx.ExportedOuts...), avaxAssetID)
+	}
+}
+
+// burnedNAVAX returns the AVAX a built export burns as its fee: what its
+// inputs consume less what its outputs, local and exported, produce.
+func burnedNAVAX(ins []*avax.TransferableInput, outs []*avax.TransferableOutput, avaxAssetID ids.ID) (uint64, error) {
+	var consumed, produced uint64
+	for _, in := range ins {
+		if in.AssetID() == avaxAssetID {
+			consumed += in.In.Amount()
+		}
+	}
+	for _, out := range outs {
+		if out.AssetID() == avaxAssetID {
+			produced += out.Out.Amount()
+		}
+	}
+	if produced > consumed {
+		return 0, fmt.Errorf("built ExportTx produces %d nAVAX, more than the %d it consumes", produced, consumed)
+	}
+	return consumed - produced, nil
+}
+
+// completeConsolidation imports whatever is waiting on the target chain:
+// the export just issued, plus anything stranded by an earlier transfer.
+func completeConsolidation(result ConsolidateResult, importable func() (uint64, error), importFn func() (ids.ID, error)) (ConsolidateResult, error) {
+	pending, err := importable()
+	if err != nil && result.ExportTxID == ids.Empty {
+		return result, fmt.Errorf("failed to get importable balance: %w", err)
+	}
+	if result.ExportTxID == ids.Empty && pending == 0 {
+		return result, ErrNothingToConsolidate
+	}
+	// The export may not be visible yet; it is imported along with any
+	// stranded UTXOs once it is.
+	result.ImportableNAVAX = max(pending, result.ExportedNAVAX)
+
+	result.ImportTxID, err = importFn()
+	if err != nil {
+		return result, fmt.Errorf("import to %s-Chain failed: %w", result.To, err)
+	}
+	return result, nil
+}
+
+// maxExportAmount returns the largest amount whose export, with the fee
+// feeFor reports for it, fits in balance. A fee grows with the inputs the
+// builder must spend, not with the amount, so it is found by rebuilding with
+// the previous fee set aside until the fee stops changing. feeFor fails with
+// builder.ErrInsufficientFunds when the amount plus fee is unaffordable.
+func maxExportAmount(balance uint64, feeFor func(amount uint64) (uint64, error)) (uint64, error) {
+	if balance == 0 {
+		return 0, ErrNothingToConsolidate
+	}
+	fee, err := feeFor(1)
+	if err != nil {
+		if errors.Is(err, builder.ErrInsufficientFunds) {
+			return 0, ErrNothingToConsolidate
+		}
+		return 0, err
+	}
+
+	var best uint64
+	for range maxExportFeeRounds {
+		if fee >= balance {
+			break
+		}
+		amount := balance - fee
+		got, err := feeFor(amount)
+		switch {
+		case errors.Is(err, builder.ErrInsufficientFunds):
+			fee *= 2
+			continue
+		case err != nil:
+			return 0, err
+		}
+		best = max(best, amount)
+		if got == fee {
+			return amount, nil
+		}
+		fee = got
+	}
+	if best == 0 {
+		return 0, ErrNothingToConsolidate
+	}
+	return best, nil
+}
+
+func ownedBy(addr ids.ShortID) secp256k1fx.OutputOwners {
+	return secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}
+}
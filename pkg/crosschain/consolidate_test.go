@@ -0,0 +1,209 @@
+package crosschain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+// feeModel returns a feeFor func charging baseFee plus perInput for each UTXO
+// spent, where the wallet holds utxos of utxoSize nAVAX each.
+func feeModel(utxos, utxoSize, baseFee, perInput uint64) func(uint64) (uint64, error) {
+	return func(amount uint64) (uint64, error) {
+		for n := uint64(1); n <= utxos; n++ {
+			fee := baseFee + n*perInput
+			if n*utxoSize >= amount+fee {
+				return fee, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: need more than %d", builder.ErrInsufficientFunds, utxos*utxoSize)
+	}
+}
+
+func TestMaxExportAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance uint64
+		feeFor  func(uint64) (uint64, error)
+		want    uint64
+		wantErr error
+	}{
+		{
+			name:    "fixed fee",
+			balance: 1_000_000,
+			feeFor:  func(uint64) (uint64, error) { return 1000, nil },
+			want:    999_000,
+		},
+		{
+			name:    "fee grows with inputs",
+			balance: 10 * 100_000,
+			feeFor:  feeModel(10, 100_000, 500, 100),
+			want:    10*100_000 - 1500,
+		},
+		{
+			name:    "empty balance",
+			balance: 0,
+			feeFor:  func(uint64) (uint64, error) { return 1000, nil },
+			wantErr: ErrNothingToConsolidate,
+		},
+		{
+			name:    "balance below fee",
+			balance: 1000,
+			feeFor:  func(uint64) (uint64, error) { return 1000, nil },
+			wantErr: ErrNothingToConsolidate,
+		},
+		{
+			name:    "cannot afford the probe",
+			balance: 500,
+			feeFor:  feeModel(1, 500, 1000, 0),
+			wantErr: ErrNothingToConsolidate,
+		},
+		{
+			name:    "builder error",
+			balance: 1_000_000,
+			feeFor:  func(uint64) (uint64, error) { return 0, errors.New("node unreachable") },
+			wantErr: errors.New("node unreachable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := maxExportAmount(tt.balance, tt.feeFor)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("maxExportAmount() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("maxExportAmount() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("maxExportAmount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteConsolidation(t *testing.T) {
+	exportTxID := ids.GenerateTestID()
+	importTxID := ids.GenerateTestID()
+	importOK := func() (ids.ID, error) { return importTxID, nil }
+
+	t.Run("nothing exported or pending", func(t *testing.T) {
+		_, err := completeConsolidation(ConsolidateResult{To: ChainP},
+			func() (uint64, error) { return 0, nil }, importOK)
+		if !errors.Is(err, ErrNothingToConsolidate) {
+			t.Fatalf("error = %v, want ErrNothingToConsolidate", err)
+		}
+	})
+
+	t.Run("stranded UTXOs only", func(t *testing.T) {
+		got, err := completeConsolidation(ConsolidateResult{To: ChainP},
+			func() (uint64, error) { return 42, nil }, importOK)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.ImportableNAVAX != 42 || got.ImportTxID != importTxID {
+			t.Errorf("got %+v, want 42 importable and import %s", got, importTxID)
+		}
+	})
+
+	t.Run("export not yet visible", func(t *testing.T) {
+		got, err := completeConsolidation(ConsolidateResult{To: ChainC, ExportedNAVAX: 100, ExportTxID: exportTxID},
+			func() (uint64, error) { return 0, errors.New("not found") }, importOK)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.ImportableNAVAX != 100 {
+			t.Errorf("ImportableNAVAX = %d, want 100", got.ImportableNAVAX)
+		}
+	})
+
+	t.Run("import fails", func(t *testing.T) {
+		got, err := completeConsolidation(ConsolidateResult{To: ChainC, ExportedNAVAX: 100, ExportTxID: exportTxID},
+			func() (uint64, error) { return 100, nil },
+			func() (ids.ID, error) { return ids.Empty, errors.New("rejected") })
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if got.ExportTxID != exportTxID {
+			t.Errorf("ExportTxID = %s, want %s kept for recovery", got.ExportTxID, exportTxID)
+		}
+	})
+}
+
+// newTestXBuilder returns an X-Chain builder for owner over one AVAX UTXO of
+// each of amounts.
+func newTestXBuilder(t *testing.T, owner ids.ShortID, amounts ...uint64) xbuilder.Builder {
+	t.Helper()
+	xContext := &xbuilder.Context{
+		NetworkID:    constants.FujiID,
+		BlockchainID: ids.GenerateTestID(),
+		AVAXAssetID:  ids.GenerateTestID(),
+		BaseTxFee:    1_000_000,
+	}
+	utxos := common.NewUTXOs()
+	for _, amount := range amounts {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: xContext.AVAXAssetID},
+			Out:    &secp256k1fx.TransferOutput{Amt: amount, OutputOwners: ownedBy(owner)},
+		}
+		if err := utxos.AddUTXO(context.Background(), xContext.BlockchainID, xContext.BlockchainID, utxo); err != nil {
+			t.Fatalf("AddUTXO() error = %v", err)
+		}
+	}
+	backend := x.NewBackend(xContext, common.NewChainUTXOs(xContext.BlockchainID, utxos))
+	return xbuilder.New(set.Of(owner), xContext, backend)
+}
+
+func TestXChainExportFee(t *testing.T) {
+	owner := ids.GenerateTestShortID()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		amounts []uint64
+		wantErr error
+	}{
+		{name: "single UTXO", amounts: []uint64{5_000_000}},
+		{name: "several UTXOs", amounts: []uint64{2_000_000, 3_000_000, 4_000_000}},
+		{name: "balance below fee", amounts: []uint64{1_000_000}, wantErr: ErrNothingToConsolidate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xBuilder := newTestXBuilder(t, owner, tt.amounts...)
+			balances, err := xBuilder.GetFTBalance(common.WithContext(ctx))
+			if err != nil {
+				t.Fatalf("GetFTBalance() error = %v", err)
+			}
+			balance := balances[xBuilder.Context().AVAXAssetID]
+
+			got, err := maxExportAmount(balance, xChainExportFee(ctx, xBuilder, constants.PlatformChainID, owner))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("maxExportAmount() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("maxExportAmount() error = %v", err)
+			}
+			if want := balance - xBuilder.Context().BaseTxFee; got != want {
+				t.Fatalf("maxExportAmount() = %d, want the balance less the X-Chain fee, %d", got, want)
+			}
+		})
+	}
+}
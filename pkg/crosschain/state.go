@@ -0,0 +1,112 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Transfer directions recorded in a TransferState.
+const (
+	DirectionPToC = "p-to-c"
+	DirectionCToP = "c-to-p"
+)
+
+// stateFilePerm keeps transfer state private to the user; it names the
+// wallet's pending funds.
+const stateFilePerm = 0o600
+
+// TransferState records a cross-chain transfer whose export has been issued
+// but whose import has not completed, so an interrupted transfer can resume
+// at the import instead of exporting again.
+type TransferState struct {
+	Direction   string `json:"direction"`
+	NetworkID   uint32 `json:"network_id"`
+	AmountNAVAX uint64 `json:"amount_navax"`
+	ExportTxID  ids.ID `json:"export_tx_id"`
+}
+
+// LoadTransferState reads the state file at path. A missing file is not an
+// error: it returns nil, meaning no transfer is pending.
+func LoadTransferState(path string) (*TransferState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transfer state: %w", err)
+	}
+	var state TransferState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse transfer state %s: %w", path, err)
+	}
+	if state.Direction != DirectionPToC && state.Direction != DirectionCToP {
+		return nil, fmt.Errorf("transfer state %s has unknown direction %q", path, state.Direction)
+	}
+	if state.ExportTxID == ids.Empty {
+		return nil, fmt.Errorf("transfer state %s has no export transaction", path)
+	}
+	return &state, nil
+}
+
+// SaveTransferState writes state to path, replacing any previous contents
+// in one step so a crash never leaves a half-written file.
+func SaveTransferState(path string, state TransferState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transfer state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".transfer-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(stateFilePerm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save transfer state: %w", err)
+	}
+	return nil
+}
+
+// ClearTransferState removes the state file at path once the transfer has
+// completed. A file that is already gone is fine.
+func ClearTransferState(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove transfer state: %w", err)
+	}
+	return nil
+}
+
+// CheckResumable reports whether state, loaded from a state file, belongs to
+// the transfer now being run. A pending export for another direction,
+// network or amount is an error rather than being imported silently.
+func (s *TransferState) CheckResumable(direction string, networkID uint32, amountNAVAX uint64) error {
+	switch {
+	case s.Direction != direction:
+		return fmt.Errorf("state file records a pending %s transfer (export %s), not %s", s.Direction, s.ExportTxID, direction)
+	case s.NetworkID != networkID:
+		return fmt.Errorf("state file records a transfer on network ID %d (export %s), but the selected network is %d", s.NetworkID, s.ExportTxID, networkID)
+	case s.AmountNAVAX != amountNAVAX:
+		return fmt.Errorf("state file records a pending export of %d nAVAX (export %s), but --amount is %d nAVAX", s.AmountNAVAX, s.ExportTxID, amountNAVAX)
+	}
+	return nil
+}
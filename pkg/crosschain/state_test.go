@@ -0,0 +1,90 @@
+package crosschain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestTransferStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transfer.json")
+
+	state, err := LoadTransferState(path)
+	if err != nil || state != nil {
+		t.Fatalf("LoadTransferState(missing) = %v, %v; want nil, nil", state, err)
+	}
+
+	want := TransferState{Direction: DirectionPToC, NetworkID: 5, AmountNAVAX: 1_000, ExportTxID: ids.GenerateTestID()}
+	if err := SaveTransferState(path, want); err != nil {
+		t.Fatalf("SaveTransferState() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != stateFilePerm {
+		t.Errorf("state file mode = %o, want %o", perm, stateFilePerm)
+	}
+
+	got, err := LoadTransferState(path)
+	if err != nil {
+		t.Fatalf("LoadTransferState() error = %v", err)
+	}
+	if *got != want {
+		t.Fatalf("LoadTransferState() = %+v, want %+v", *got, want)
+	}
+
+	if err := ClearTransferState(path); err != nil {
+		t.Fatalf("ClearTransferState() error = %v", err)
+	}
+	if err := ClearTransferState(path); err != nil {
+		t.Fatalf("ClearTransferState() on a missing file error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("state file still exists after ClearTransferState(): %v", err)
+	}
+}
+
+func TestLoadTransferStateInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"garbage":       "not json",
+		"bad direction": `{"direction":"x-to-y","export_tx_id":"` + ids.GenerateTestID().String() + `"}`,
+		"no export":     `{"direction":"c-to-p"}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), stateFilePerm); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if _, err := LoadTransferState(path); err == nil {
+			t.Errorf("LoadTransferState(%s) expected error", name)
+		}
+	}
+}
+
+func TestTransferStateCheckResumable(t *testing.T) {
+	state := &TransferState{Direction: DirectionCToP, NetworkID: 5, AmountNAVAX: 1_000, ExportTxID: ids.GenerateTestID()}
+
+	tests := []struct {
+		name      string
+		direction string
+		networkID uint32
+		amount    uint64
+		wantErr   bool
+	}{
+		{name: "same transfer", direction: DirectionCToP, networkID: 5, amount: 1_000},
+		{name: "other direction", direction: DirectionPToC, networkID: 5, amount: 1_000, wantErr: true},
+		{name: "other network", direction: DirectionCToP, networkID: 1, amount: 1_000, wantErr: true},
+		{name: "other amount", direction: DirectionCToP, networkID: 5, amount: 2_000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := state.CheckResumable(tt.direction, tt.networkID, tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckResumable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
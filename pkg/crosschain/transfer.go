@@ -3,15 +3,21 @@ package crosschain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanchego/graft/coreth/plugin/evm/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	ethcommon "github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/retry"
 	"github.com/ava-labs/platform-cli/pkg/wallet"
 )
 
@@ -22,6 +28,15 @@ const (
 	importRetryDelay = 500 * time.Millisecond
 )
 
+// ImportResult is an issued import transaction and the AVAX it credited to
+// the wallet, net of the import fee. The import spends every atomic UTXO
+// waiting for the wallet, so ReceivedNAVAX includes any left over from
+// earlier, unfinished transfers.
+type ImportResult struct {
+	TxID          ids.ID
+	ReceivedNAVAX uint64
+}
+
 // ExportFromPChain exports AVAX from P-Chain to C-Chain.
 // Returns the export transaction ID.
 func ExportFromPChain(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64) (ids.ID, error) {
@@ -56,16 +71,61 @@ func ExportFromPChain(ctx context.Context, w *wallet.FullWallet, amountNAVAX uin
 // ImportToCChain imports AVAX to C-Chain from P-Chain.
 // Returns the import transaction ID.
 func ImportToCChain(ctx context.Context, w *wallet.FullWallet) (ids.ID, error) {
+	return ImportToCChainWithFee(ctx, w, nil)
+}
+
+// ImportToCChainWithFee imports AVAX to C-Chain from P-Chain, paying baseFee
+// (in wei per gas) instead of the node's current estimate. A nil baseFee uses
+// the estimate. Raising it helps an import get accepted during congestion.
+func ImportToCChainWithFee(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ids.ID, error) {
+	result, err := importToCChain(ctx, w, baseFee)
+	return result.TxID, err
+}
+
+func importToCChain(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ImportResult, error) {
+	return importToCChainFrom(ctx, w, constants.PlatformChainID, baseFee)
+}
+
+// importToCChainFrom imports AVAX to C-Chain from sourceChainID.
+func importToCChainFrom(ctx context.Context, w *wallet.FullWallet, sourceChainID ids.ID, baseFee *big.Int) (ImportResult, error) {
 	cWallet := w.CWallet()
 	ethAddr := w.EthAddress()
 
 	// Issue the import transaction
-	importTx, err := cWallet.IssueImportTx(constants.PlatformChainID, ethAddr, common.WithContext(ctx))
+	importTx, err := cWallet.IssueImportTx(sourceChainID, ethAddr, cChainIssueOptions(ctx, baseFee)...)
 	if err != nil {
-		return ids.Empty, fmt.Errorf("failed to issue C-Chain import tx: %w", err)
+		return ImportResult{}, fmt.Errorf("failed to issue C-Chain import tx: %w", err)
+	}
+
+	return ImportResult{
+		TxID:          importTx.ID(),
+		ReceivedNAVAX: cChainImportedNAVAX(importTx, ethAddr, cWallet.Builder().Context().AVAXAssetID),
+	}, nil
+}
+
+// cChainImportedNAVAX sums the AVAX a C-Chain import credits to the address.
+func cChainImportedNAVAX(tx *atomic.Tx, to ethcommon.Address, avaxAssetID ids.ID) uint64 {
+	utx, ok := tx.UnsignedAtomicTx.(*atomic.UnsignedImportTx)
+	if !ok {
+		return 0
+	}
+	var total uint64
+	for _, out := range utx.Outs {
+		if out.Address == to && out.AssetID == avaxAssetID {
+			total += out.Amount
+		}
 	}
+	return total
+}
 
-	return importTx.ID(), nil
+// cChainIssueOptions returns the C-Chain wallet options for ctx, overriding
+// the base fee when baseFee is non-nil.
+func cChainIssueOptions(ctx context.Context, baseFee *big.Int) []common.Option {
+	options := []common.Option{common.WithContext(ctx)}
+	if baseFee != nil {
+		options = append(options, common.WithBaseFee(baseFee))
+	}
+	return options
 }
 
 // ExportFromCChain exports AVAX from C-Chain to P-Chain.
@@ -94,11 +154,17 @@ func ExportFromCChain(ctx context.Context, w *wallet.FullWallet, amountNAVAX uin
 // ImportToPChain imports AVAX to P-Chain from C-Chain.
 // Returns the import transaction ID.
 func ImportToPChain(ctx context.Context, w *wallet.FullWallet) (ids.ID, error) {
-	pWallet := w.PWallet()
-	cWallet := w.CWallet()
+	result, err := importToPChain(ctx, w)
+	return result.TxID, err
+}
 
-	// Get C-Chain blockchain ID
-	cChainID := cWallet.Builder().Context().BlockchainID
+func importToPChain(ctx context.Context, w *wallet.FullWallet) (ImportResult, error) {
+	return importToPChainFrom(ctx, w, w.CWallet().Builder().Context().BlockchainID)
+}
+
+// importToPChainFrom imports AVAX to P-Chain from sourceChainID.
+func importToPChainFrom(ctx context.Context, w *wallet.FullWallet, sourceChainID ids.ID) (ImportResult, error) {
+	pWallet := w.PWallet()
 
 	// Create owner for the imported funds
 	owner := secp256k1fx.OutputOwners{
@@ -107,56 +173,154 @@ func ImportToPChain(ctx context.Context, w *wallet.FullWallet) (ids.ID, error) {
 	}
 
 	// Issue the import transaction
-	importTx, err := pWallet.IssueImportTx(cChainID, &owner, common.WithContext(ctx))
+	importTx, err := pWallet.IssueImportTx(sourceChainID, &owner, common.WithContext(ctx))
 	if err != nil {
-		return ids.Empty, fmt.Errorf("failed to issue P-Chain import tx: %w", err)
+		return ImportResult{}, fmt.Errorf("failed to issue P-Chain import tx: %w", err)
 	}
 
-	return importTx.TxID, nil
+	return ImportResult{
+		TxID:          importTx.TxID,
+		ReceivedNAVAX: pChainImportedNAVAX(importTx, pWallet.Builder().Context().AVAXAssetID),
+	}, nil
+}
+
+// pChainImportedNAVAX sums the AVAX outputs of a P-Chain import. They are
+// all owned by the importing wallet.
+func pChainImportedNAVAX(tx *txs.Tx, avaxAssetID ids.ID) uint64 {
+	utx, ok := tx.Unsigned.(*txs.ImportTx)
+	if !ok {
+		return 0
+	}
+	var total uint64
+	for _, out := range utx.Outs {
+		if out.AssetID() == avaxAssetID {
+			total += out.Out.Amount()
+		}
+	}
+	return total
 }
 
 // TransferPToC performs a complete transfer from P-Chain to C-Chain.
 // This is a convenience function that exports from P-Chain and imports to C-Chain.
 // Returns both transaction IDs.
 func TransferPToC(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64) (exportTxID, importTxID ids.ID, err error) {
+	return TransferPToCWithFee(ctx, w, amountNAVAX, nil)
+}
+
+// TransferPToCWithFee is TransferPToC with the C-Chain import paying baseFee
+// (in wei per gas); nil uses the node's estimate.
+func TransferPToCWithFee(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64, baseFee *big.Int) (exportTxID, importTxID ids.ID, err error) {
+	exportTxID, imported, err := TransferPToCWithResult(ctx, w, amountNAVAX, baseFee)
+	return exportTxID, imported.TxID, err
+}
+
+// TransferPToCWithResult is TransferPToCWithFee, returning the import with
+// the AVAX it credited to the wallet.
+func TransferPToCWithResult(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64, baseFee *big.Int) (exportTxID ids.ID, imported ImportResult, err error) {
 	// Step 1: Export from P-Chain
 	exportTxID, err = ExportFromPChain(ctx, w, amountNAVAX)
 	if err != nil {
-		return ids.Empty, ids.Empty, fmt.Errorf("export failed: %w", err)
+		return ids.Empty, ImportResult{}, fmt.Errorf("export failed: %w", err)
 	}
 
 	// Step 2: Import to C-Chain with retry
-	// Atomic UTXOs may not be immediately visible after export
-	importTxID, err = importWithRetry(ctx, func() (ids.ID, error) {
-		return ImportToCChain(ctx, w)
-	})
+	imported, err = CompleteImportToCChainWithResult(ctx, w, baseFee)
 	if err != nil {
-		return exportTxID, ids.Empty, fmt.Errorf("import failed: %w", err)
+		return exportTxID, ImportResult{}, fmt.Errorf("import failed: %w", err)
 	}
 
-	return exportTxID, importTxID, nil
+	return exportTxID, imported, nil
 }
 
 // TransferCToP performs a complete transfer from C-Chain to P-Chain.
 // This is a convenience function that exports from C-Chain and imports to P-Chain.
 // Returns both transaction IDs.
 func TransferCToP(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64) (exportTxID, importTxID ids.ID, err error) {
+	exportTxID, imported, err := TransferCToPWithResult(ctx, w, amountNAVAX)
+	return exportTxID, imported.TxID, err
+}
+
+// TransferCToPWithResult is TransferCToP, returning the import with the AVAX
+// it credited to the wallet.
+func TransferCToPWithResult(ctx context.Context, w *wallet.FullWallet, amountNAVAX uint64) (exportTxID ids.ID, imported ImportResult, err error) {
 	// Step 1: Export from C-Chain
 	exportTxID, err = ExportFromCChain(ctx, w, amountNAVAX)
 	if err != nil {
-		return ids.Empty, ids.Empty, fmt.Errorf("export failed: %w", err)
+		return ids.Empty, ImportResult{}, fmt.Errorf("export failed: %w", err)
 	}
 
 	// Step 2: Import to P-Chain with retry
-	// Atomic UTXOs may not be immediately visible after export
-	importTxID, err = importWithRetry(ctx, func() (ids.ID, error) {
-		return ImportToPChain(ctx, w)
-	})
+	imported, err = CompleteImportToPChainWithResult(ctx, w)
 	if err != nil {
-		return exportTxID, ids.Empty, fmt.Errorf("import failed: %w", err)
+		return exportTxID, ImportResult{}, fmt.Errorf("import failed: %w", err)
 	}
 
-	return exportTxID, importTxID, nil
+	return exportTxID, imported, nil
+}
+
+// CompleteImportToCChain imports exported AVAX into the C-Chain, retrying
+// while the atomic UTXOs of a recent export are not yet visible. It is the
+// second step of TransferPToC, also used to resume a transfer whose export
+// has already been issued.
+func CompleteImportToCChain(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ids.ID, error) {
+	imported, err := CompleteImportToCChainWithResult(ctx, w, baseFee)
+	return imported.TxID, err
+}
+
+// CompleteImportToCChainWithResult is CompleteImportToCChain, returning the
+// import with the AVAX it credited to the wallet.
+func CompleteImportToCChainWithResult(ctx context.Context, w *wallet.FullWallet, baseFee *big.Int) (ImportResult, error) {
+	return importWithRetry(ctx, refreshing(ctx, w, func() (ImportResult, error) {
+		return importToCChain(ctx, w, baseFee)
+	}))
+}
+
+// CompleteImportToPChain is CompleteImportToCChain for the P-Chain: the
+// second step of TransferCToP.
+func CompleteImportToPChain(ctx context.Context, w *wallet.FullWallet) (ids.ID, error) {
+	imported, err := CompleteImportToPChainWithResult(ctx, w)
+	return imported.TxID, err
+}
+
+// CompleteImportToPChainWithResult is CompleteImportToPChain, returning the
+// import with the AVAX it credited to the wallet.
+func CompleteImportToPChainWithResult(ctx context.Context, w *wallet.FullWallet) (ImportResult, error) {
+	return importWithRetry(ctx, refreshing(ctx, w, func() (ImportResult, error) {
+		return importToPChain(ctx, w)
+	}))
+}
+
+// completeImportToCChainFrom is CompleteImportToCChain for AVAX exported
+// from sourceChainID.
+func completeImportToCChainFrom(ctx context.Context, w *wallet.FullWallet, sourceChainID ids.ID, baseFee *big.Int) (ImportResult, error) {
+	return importWithRetry(ctx, refreshing(ctx, w, func() (ImportResult, error) {
+		return importToCChainFrom(ctx, w, sourceChainID, baseFee)
+	}))
+}
+
+// completeImportToPChainFrom is CompleteImportToPChain for AVAX exported
+// from sourceChainID.
+func completeImportToPChainFrom(ctx context.Context, w *wallet.FullWallet, sourceChainID ids.ID) (ImportResult, error) {
+	return importWithRetry(ctx, refreshing(ctx, w, func() (ImportResult, error) {
+		return importToPChainFrom(ctx, w, sourceChainID)
+	}))
+}
+
+// refreshing wraps importFn for wallets that assume acceptance instead of
+// waiting for it. Each attempt first reloads the wallet from the node, so the
+// import spends only atomic UTXOs the node has seen; an export that is not
+// yet accepted shows up as a retryable "no UTXOs" failure.
+func refreshing[T any](ctx context.Context, w *wallet.FullWallet, importFn func() (T, error)) func() (T, error) {
+	if !w.AssumesAccepted() {
+		return importFn
+	}
+	return func() (T, error) {
+		if err := w.Refresh(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		return importFn()
+	}
 }
 
 // isRetryableImportError checks if an import error is retryable.
@@ -185,19 +349,21 @@ func isRetryableImportError(err error) bool {
 
 // importWithRetry attempts an import operation with retries.
 // This handles the case where atomic UTXOs aren't immediately visible after export.
-func importWithRetry(ctx context.Context, importFn func() (ids.ID, error)) (ids.ID, error) {
+// Backoff waits draw on the retry budget carried by ctx, if any.
+func importWithRetry[T any](ctx context.Context, importFn func() (T, error)) (T, error) {
+	var zero T
 	var lastErr error
 	delay := importRetryDelay
 
 	for attempt := 0; attempt < importRetryAttempts; attempt++ {
-		txID, err := importFn()
+		result, err := importFn()
 		if err == nil {
-			return txID, nil
+			return result, nil
 		}
 
 		// Only retry on transient UTXO visibility errors
 		if !isRetryableImportError(err) {
-			return ids.Empty, err
+			return zero, err
 		}
 
 		lastErr = err
@@ -206,14 +372,16 @@ func importWithRetry(ctx context.Context, importFn func() (ids.ID, error)) (ids.
 			break
 		}
 
-		// Wait before retrying (with exponential backoff)
-		select {
-		case <-ctx.Done():
-			return ids.Empty, ctx.Err()
-		case <-time.After(delay):
-			delay *= 2 // exponential backoff
+		// Wait before retrying (with exponential backoff), within the
+		// command's shared retry budget.
+		if err := retry.Wait(ctx, delay); err != nil {
+			if errors.Is(err, retry.ErrBudgetExhausted) {
+				return zero, fmt.Errorf("import failed after %d attempts (%v): %w", attempt+1, err, lastErr)
+			}
+			return zero, err
 		}
+		delay *= 2
 	}
 
-	return ids.Empty, fmt.Errorf("import failed after %d attempts: %w", importRetryAttempts, lastErr)
+	return zero, fmt.Errorf("import failed after %d attempts: %w", importRetryAttempts, lastErr)
 }
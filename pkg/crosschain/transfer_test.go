@@ -3,10 +3,17 @@ package crosschain
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/graft/coreth/plugin/evm/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	ethcommon "github.com/ava-labs/libevm/common"
 )
 
 func TestIsRetryableImportError(t *testing.T) {
@@ -216,3 +223,50 @@ func TestImportRetryConstants(t *testing.T) {
 		t.Errorf("importRetryDelay = %v, should be at most 5s", importRetryDelay)
 	}
 }
+
+func TestCChainIssueOptions(t *testing.T) {
+	ctx := context.Background()
+
+	ops := common.NewOptions(cChainIssueOptions(ctx, nil))
+	if got := ops.BaseFee(nil); got != nil {
+		t.Fatalf("BaseFee() = %s, want nil (use the node's estimate)", got)
+	}
+
+	want := big.NewInt(50_000_000_000)
+	ops = common.NewOptions(cChainIssueOptions(ctx, want))
+	if got := ops.BaseFee(nil); got == nil || got.Cmp(want) != 0 {
+		t.Fatalf("BaseFee() = %v, want %s", got, want)
+	}
+}
+
+func TestCChainImportedNAVAX(t *testing.T) {
+	avaxAssetID, otherAssetID := ids.GenerateTestID(), ids.GenerateTestID()
+	to, other := ethcommon.Address{1}, ethcommon.Address{2}
+	tx := &atomic.Tx{UnsignedAtomicTx: &atomic.UnsignedImportTx{Outs: []atomic.EVMOutput{
+		{Address: to, Amount: 700, AssetID: avaxAssetID},
+		{Address: to, Amount: 300, AssetID: avaxAssetID},
+		{Address: to, Amount: 50, AssetID: otherAssetID},
+		{Address: other, Amount: 20, AssetID: avaxAssetID},
+	}}}
+	if got := cChainImportedNAVAX(tx, to, avaxAssetID); got != 1_000 {
+		t.Errorf("cChainImportedNAVAX() = %d, want 1000", got)
+	}
+	if got := cChainImportedNAVAX(&atomic.Tx{UnsignedAtomicTx: &atomic.UnsignedExportTx{}}, to, avaxAssetID); got != 0 {
+		t.Errorf("cChainImportedNAVAX(export) = %d, want 0", got)
+	}
+}
+
+func TestPChainImportedNAVAX(t *testing.T) {
+	avaxAssetID, otherAssetID := ids.GenerateTestID(), ids.GenerateTestID()
+	out := func(asset ids.ID, amt uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{Asset: avax.Asset{ID: asset}, Out: &secp256k1fx.TransferOutput{Amt: amt}}
+	}
+	utx := &txs.ImportTx{}
+	utx.Outs = []*avax.TransferableOutput{out(avaxAssetID, 900), out(otherAssetID, 40), out(avaxAssetID, 100)}
+	if got := pChainImportedNAVAX(&txs.Tx{Unsigned: utx}, avaxAssetID); got != 1_000 {
+		t.Errorf("pChainImportedNAVAX() = %d, want 1000", got)
+	}
+	if got := pChainImportedNAVAX(&txs.Tx{Unsigned: &txs.BaseTx{}}, avaxAssetID); got != 0 {
+		t.Errorf("pChainImportedNAVAX(BaseTx) = %d, want 0", got)
+	}
+}
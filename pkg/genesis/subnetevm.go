@@ -0,0 +1,477 @@
+// Package genesis builds chain genesis files for chain create.
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/common/math"
+)
+
+// Subnet-EVM fee config defaults, matching Subnet-EVM's DefaultFeeConfig.
+const (
+	DefaultGasLimit                 = 8_000_000
+	DefaultTargetBlockRate          = 2
+	DefaultMinBaseFee               = 25_000_000_000
+	DefaultTargetGas                = 15_000_000
+	DefaultBaseFeeChangeDenominator = 36
+	DefaultMinBlockGasCost          = 0
+	DefaultMaxBlockGasCost          = 1_000_000
+	DefaultBlockGasCostStep         = 200_000
+)
+
+// DefaultWarpQuorumNumerator is the share of stake, out of 100, that must
+// sign a Warp message for Subnet-EVM to accept it.
+const DefaultWarpQuorumNumerator = 67
+
+// Bounds on a Warp quorum numerator, as enforced by Subnet-EVM. Zero selects
+// DefaultWarpQuorumNumerator.
+const (
+	warpQuorumDenominator  = 100
+	minWarpQuorumNumerator = 33
+)
+
+// nativeDecimals is the number of decimal places in one unit of a
+// Subnet-EVM chain's native token (1 token = 10^18 wei).
+const nativeDecimals = 18
+
+// Precompiles that gen-genesis can enable. Each is configured with the same
+// admin addresses.
+const (
+	PrecompileContractDeployerAllowList = "contract-deployer-allowlist"
+	PrecompileTxAllowList               = "tx-allowlist"
+	PrecompileNativeMinter              = "native-minter"
+	PrecompileFeeManager                = "fee-manager"
+	PrecompileRewardManager             = "reward-manager"
+)
+
+// precompileConfigKeys maps each precompile to its key in the chain config.
+var precompileConfigKeys = map[string]string{
+	PrecompileContractDeployerAllowList: "contractDeployerAllowListConfig",
+	PrecompileTxAllowList:               "txAllowListConfig",
+	PrecompileNativeMinter:              "contractNativeMinterConfig",
+	PrecompileFeeManager:                "feeManagerConfig",
+	PrecompileRewardManager:             "rewardManagerConfig",
+}
+
+var (
+	ErrInvalidChainID      = errors.New("chain ID must be greater than zero")
+	ErrUnknownPrecompile   = errors.New("unknown precompile")
+	ErrMissingAdmin        = errors.New("precompiles need at least one admin address")
+	ErrInvalidEVMAddress   = errors.New("invalid EVM address")
+	ErrInvalidTokenAmount  = errors.New("invalid token amount")
+	ErrInvalidFeeConfig    = errors.New("invalid fee config")
+	ErrDuplicateAllocation = errors.New("duplicate allocation")
+	ErrInvalidGenesis      = errors.New("invalid Subnet-EVM genesis")
+)
+
+// Precompiles lists the precompile names accepted in SubnetEVMConfig.
+func Precompiles() []string {
+	names := make([]string, 0, len(precompileConfigKeys))
+	for name := range precompileConfigKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FeeConfig is Subnet-EVM's dynamic fee configuration.
+type FeeConfig struct {
+	GasLimit                 uint64 `json:"gasLimit"`
+	TargetBlockRate          uint64 `json:"targetBlockRate"`
+	MinBaseFee               uint64 `json:"minBaseFee"`
+	TargetGas                uint64 `json:"targetGas"`
+	BaseFeeChangeDenominator uint64 `json:"baseFeeChangeDenominator"`
+	MinBlockGasCost          uint64 `json:"minBlockGasCost"`
+	MaxBlockGasCost          uint64 `json:"maxBlockGasCost"`
+	BlockGasCostStep         uint64 `json:"blockGasCostStep"`
+}
+
+// DefaultFeeConfig returns Subnet-EVM's default fee config.
+func DefaultFeeConfig() FeeConfig {
+	return FeeConfig{
+		GasLimit:                 DefaultGasLimit,
+		TargetBlockRate:          DefaultTargetBlockRate,
+		MinBaseFee:               DefaultMinBaseFee,
+		TargetGas:                DefaultTargetGas,
+		BaseFeeChangeDenominator: DefaultBaseFeeChangeDenominator,
+		MinBlockGasCost:          DefaultMinBlockGasCost,
+		MaxBlockGasCost:          DefaultMaxBlockGasCost,
+		BlockGasCostStep:         DefaultBlockGasCostStep,
+	}
+}
+
+// Verify checks the fee config for values Subnet-EVM rejects.
+func (c FeeConfig) Verify() error {
+	switch {
+	case c.GasLimit == 0:
+		return fmt.Errorf("%w: gas limit must be greater than zero", ErrInvalidFeeConfig)
+	case c.TargetBlockRate == 0:
+		return fmt.Errorf("%w: target block rate must be greater than zero", ErrInvalidFeeConfig)
+	case c.TargetGas == 0:
+		return fmt.Errorf("%w: target gas must be greater than zero", ErrInvalidFeeConfig)
+	case c.BaseFeeChangeDenominator == 0:
+		return fmt.Errorf("%w: base fee change denominator must be greater than zero", ErrInvalidFeeConfig)
+	case c.MinBlockGasCost > c.MaxBlockGasCost:
+		return fmt.Errorf("%w: min block gas cost %d exceeds max block gas cost %d", ErrInvalidFeeConfig, c.MinBlockGasCost, c.MaxBlockGasCost)
+	}
+	return nil
+}
+
+// Allocation funds an address in the genesis state.
+type Allocation struct {
+	Address common.Address
+	Balance *big.Int // wei
+}
+
+// SubnetEVMConfig describes a Subnet-EVM genesis.
+type SubnetEVMConfig struct {
+	ChainID     uint64
+	FeeConfig   FeeConfig
+	Allocations []Allocation
+	Precompiles []string
+	Admins      []common.Address
+	Timestamp   time.Time
+}
+
+// precompileConfig enables an allowlist-style precompile at genesis.
+type precompileConfig struct {
+	BlockTimestamp uint64           `json:"blockTimestamp"`
+	AdminAddresses []common.Address `json:"adminAddresses"`
+}
+
+type warpConfig struct {
+	BlockTimestamp  uint64 `json:"blockTimestamp"`
+	QuorumNumerator uint64 `json:"quorumNumerator"`
+}
+
+type allocAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+type subnetEVMGenesis struct {
+	Config     map[string]any                  `json:"config"`
+	Alloc      map[common.Address]allocAccount `json:"alloc"`
+	Nonce      hexutil.Uint64                  `json:"nonce"`
+	Timestamp  hexutil.Uint64                  `json:"timestamp"`
+	ExtraData  hexutil.Bytes                   `json:"extraData"`
+	GasLimit   hexutil.Uint64                  `json:"gasLimit"`
+	Difficulty *hexutil.Big                    `json:"difficulty"`
+	MixHash    common.Hash                     `json:"mixHash"`
+	Coinbase   common.Address                  `json:"coinbase"`
+	Number     hexutil.Uint64                  `json:"number"`
+	GasUsed    hexutil.Uint64                  `json:"gasUsed"`
+	ParentHash common.Hash                     `json:"parentHash"`
+}
+
+// forkBlocks are the Ethereum forks Subnet-EVM expects active from genesis.
+var forkBlocks = []string{
+	"homesteadBlock",
+	"eip150Block",
+	"eip155Block",
+	"eip158Block",
+	"byzantiumBlock",
+	"constantinopleBlock",
+	"petersburgBlock",
+	"istanbulBlock",
+	"muirGlacierBlock",
+}
+
+// NewSubnetEVM returns an indented Subnet-EVM genesis JSON for cfg. Warp is
+// always enabled so the chain can be converted to an L1. The output depends
+// only on cfg: config keys and alloc addresses are sorted, so the same
+// allocations in any order give the same genesis.
+func NewSubnetEVM(cfg SubnetEVMConfig) ([]byte, error) {
+	if cfg.ChainID == 0 {
+		return nil, ErrInvalidChainID
+	}
+	if err := cfg.FeeConfig.Verify(); err != nil {
+		return nil, err
+	}
+	if len(cfg.Precompiles) > 0 && len(cfg.Admins) == 0 {
+		return nil, ErrMissingAdmin
+	}
+	timestamp := uint64(cfg.Timestamp.Unix())
+
+	config := map[string]any{
+		"chainId":            cfg.ChainID,
+		"feeConfig":          cfg.FeeConfig,
+		"allowFeeRecipients": false,
+		"warpConfig": warpConfig{
+			BlockTimestamp:  timestamp,
+			QuorumNumerator: DefaultWarpQuorumNumerator,
+		},
+	}
+	for _, fork := range forkBlocks {
+		config[fork] = 0
+	}
+	for _, name := range cfg.Precompiles {
+		key, ok := precompileConfigKeys[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q (valid: %s)", ErrUnknownPrecompile, name, strings.Join(Precompiles(), ", "))
+		}
+		config[key] = precompileConfig{AdminAddresses: cfg.Admins}
+	}
+
+	alloc := make(map[common.Address]allocAccount, len(cfg.Allocations))
+	for _, a := range cfg.Allocations {
+		if _, ok := alloc[a.Address]; ok {
+			return nil, fmt.Errorf("%w for %s", ErrDuplicateAllocation, a.Address)
+		}
+		alloc[a.Address] = allocAccount{Balance: (*hexutil.Big)(a.Balance)}
+	}
+
+	genesis := subnetEVMGenesis{
+		Config:     config,
+		Alloc:      alloc,
+		Timestamp:  hexutil.Uint64(timestamp),
+		ExtraData:  hexutil.Bytes{},
+		GasLimit:   hexutil.Uint64(cfg.FeeConfig.GasLimit),
+		Difficulty: (*hexutil.Big)(new(big.Int)),
+	}
+	out, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode genesis: %w", err)
+	}
+	return out, nil
+}
+
+// ParseAllocation parses "address:amount", where address is a 0x EVM address
+// and amount is in whole native tokens with up to 18 decimals ("1000.5").
+func ParseAllocation(s string) (Allocation, error) {
+	addrStr, amountStr, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return Allocation{}, fmt.Errorf("invalid allocation %q: expected address:amount", s)
+	}
+	return NewAllocation(addrStr, amountStr)
+}
+
+// NewAllocation parses a 0x EVM address and a positive amount of native
+// tokens into an Allocation.
+func NewAllocation(addrStr, amountStr string) (Allocation, error) {
+	addr, err := ParseEVMAddress(addrStr)
+	if err != nil {
+		return Allocation{}, err
+	}
+	balance, err := ParseTokenAmount(amountStr)
+	if err != nil {
+		return Allocation{}, err
+	}
+	if balance.Sign() == 0 {
+		return Allocation{}, fmt.Errorf("%w %q: must be positive", ErrInvalidTokenAmount, amountStr)
+	}
+	return Allocation{Address: addr, Balance: balance}, nil
+}
+
+// ParseEVMAddress parses a 0x-prefixed hex EVM address.
+func ParseEVMAddress(s string) (common.Address, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "0x") || !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("%w %q: expected 0x followed by 40 hex digits", ErrInvalidEVMAddress, s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// ParseTokenAmount parses a decimal amount of native tokens into wei. Parsing
+// is exact: more than 18 decimal places is an error rather than rounded.
+func ParseTokenAmount(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || !isDigits(whole) || (frac != "" && !isDigits(frac)) || strings.HasSuffix(s, ".") {
+		return nil, fmt.Errorf("%w %q: must be a non-negative decimal", ErrInvalidTokenAmount, s)
+	}
+	if len(frac) > nativeDecimals {
+		return nil, fmt.Errorf("%w %q: at most %d decimal places", ErrInvalidTokenAmount, s, nativeDecimals)
+	}
+	wei, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", nativeDecimals-len(frac)), 10)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrInvalidTokenAmount, s)
+	}
+	return wei, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// feeConfigFields are the fee config fields Subnet-EVM requires. A missing
+// field is rejected by the VM even when its zero value would be valid.
+var feeConfigFields = []string{
+	"gasLimit",
+	"targetBlockRate",
+	"minBaseFee",
+	"targetGas",
+	"baseFeeChangeDenominator",
+	"minBlockGasCost",
+	"maxBlockGasCost",
+	"blockGasCostStep",
+}
+
+// genesisFields holds the parts of a Subnet-EVM genesis that
+// ValidateSubnetEVM checks. Pointers and maps tell missing fields from zero
+// ones.
+type genesisFields struct {
+	Config     map[string]json.RawMessage `json:"config"`
+	Alloc      map[string]json.RawMessage `json:"alloc"`
+	GasLimit   *math.HexOrDecimal64       `json:"gasLimit"`
+	Difficulty *math.HexOrDecimal256      `json:"difficulty"`
+}
+
+// allocFields is one alloc account; the balance is required.
+type allocFields struct {
+	Balance *math.HexOrDecimal256 `json:"balance"`
+}
+
+// allowListFields are the role lists of an allowlist-style precompile.
+type allowListFields struct {
+	AdminAddresses   []common.Address `json:"adminAddresses"`
+	ManagerAddresses []common.Address `json:"managerAddresses"`
+	EnabledAddresses []common.Address `json:"enabledAddresses"`
+}
+
+// ValidateSubnetEVM checks a Subnet-EVM genesis for the mistakes that let
+// CreateChainTx succeed but keep the chain from starting: missing required
+// fields, a chain ID of zero, an incomplete or invalid fee config, bad alloc
+// entries, an out-of-range Warp quorum and malformed precompile allowlists.
+// It is a structural check run without Subnet-EVM itself, so a genesis that
+// passes can still be rejected by the VM for reasons not covered here.
+func ValidateSubnetEVM(data []byte) error {
+	var g genesisFields
+	if err := json.Unmarshal(data, &g); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidGenesis, err)
+	}
+	switch {
+	case g.Config == nil:
+		return fmt.Errorf("%w: missing required field 'config'", ErrInvalidGenesis)
+	case g.Alloc == nil:
+		return fmt.Errorf("%w: missing required field 'alloc'", ErrInvalidGenesis)
+	case g.GasLimit == nil:
+		return fmt.Errorf("%w: missing required field 'gasLimit'", ErrInvalidGenesis)
+	case *g.GasLimit == 0:
+		return fmt.Errorf("%w: gasLimit must be greater than zero", ErrInvalidGenesis)
+	case g.Difficulty == nil:
+		return fmt.Errorf("%w: missing required field 'difficulty'", ErrInvalidGenesis)
+	}
+	if err := validateChainConfig(g.Config); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidGenesis, err)
+	}
+
+	addrs := make([]string, 0, len(g.Alloc))
+	for addr := range g.Alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("%w: alloc: %w %q", ErrInvalidGenesis, ErrInvalidEVMAddress, addr)
+		}
+		var account allocFields
+		if err := json.Unmarshal(g.Alloc[addr], &account); err != nil {
+			return fmt.Errorf("%w: alloc %s: %w", ErrInvalidGenesis, addr, err)
+		}
+		if account.Balance == nil {
+			return fmt.Errorf("%w: alloc %s: missing required field 'balance'", ErrInvalidGenesis, addr)
+		}
+	}
+	return nil
+}
+
+// validateChainConfig checks the config object of a Subnet-EVM genesis.
+func validateChainConfig(config map[string]json.RawMessage) error {
+	rawChainID, ok := config["chainId"]
+	if !ok {
+		return errors.New("missing required field 'config.chainId'")
+	}
+	var chainID *big.Int
+	if err := json.Unmarshal(rawChainID, &chainID); err != nil {
+		return fmt.Errorf("config.chainId: %w", err)
+	}
+	if chainID == nil || chainID.Sign() <= 0 {
+		return ErrInvalidChainID
+	}
+
+	// Subnet-EVM uses its default fee config when none is given, but a
+	// partial one is rejected.
+	if raw, ok := config["feeConfig"]; ok {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return fmt.Errorf("config.feeConfig: %w", err)
+		}
+		for _, field := range feeConfigFields {
+			if _, ok := fields[field]; !ok {
+				return fmt.Errorf("%w: missing required field '%s'", ErrInvalidFeeConfig, field)
+			}
+		}
+		var fc FeeConfig
+		if err := json.Unmarshal(raw, &fc); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidFeeConfig, err)
+		}
+		if err := fc.Verify(); err != nil {
+			return err
+		}
+	}
+
+	if raw, ok := config["warpConfig"]; ok {
+		var warp warpConfig
+		if err := json.Unmarshal(raw, &warp); err != nil {
+			return fmt.Errorf("config.warpConfig: %w", err)
+		}
+		if n := warp.QuorumNumerator; n != 0 && (n < minWarpQuorumNumerator || n > warpQuorumDenominator) {
+			return fmt.Errorf("config.warpConfig: quorumNumerator %d must be 0 (default) or between %d and %d", n, minWarpQuorumNumerator, warpQuorumDenominator)
+		}
+	}
+
+	for _, name := range Precompiles() {
+		key := precompileConfigKeys[name]
+		raw, ok := config[key]
+		if !ok {
+			continue
+		}
+		if err := validateAllowList(raw); err != nil {
+			return fmt.Errorf("config.%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateAllowList checks that each address of an allowlist precompile
+// config is well formed and holds a single role.
+func validateAllowList(raw json.RawMessage) error {
+	var list allowListFields
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return err
+	}
+	roles := make(map[common.Address]string)
+	for _, r := range []struct {
+		name  string
+		addrs []common.Address
+	}{
+		{"admin", list.AdminAddresses},
+		{"manager", list.ManagerAddresses},
+		{"enabled", list.EnabledAddresses},
+	} {
+		for _, addr := range r.addrs {
+			prev, ok := roles[addr]
+			if ok && prev == r.name {
+				return fmt.Errorf("%s is listed twice as %s", addr, r.name)
+			}
+			if ok {
+				return fmt.Errorf("%s is listed as both %s and %s", addr, prev, r.name)
+			}
+			roles[addr] = r.name
+		}
+	}
+	return nil
+}
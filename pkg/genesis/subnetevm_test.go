@@ -0,0 +1,283 @@
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+)
+
+func TestNewSubnetEVM(t *testing.T) {
+	admin := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	funded := common.HexToAddress("0x0100000000000000000000000000000000000000")
+	out, err := NewSubnetEVM(SubnetEVMConfig{
+		ChainID:     12345,
+		FeeConfig:   DefaultFeeConfig(),
+		Allocations: []Allocation{{Address: funded, Balance: big.NewInt(1_000)}},
+		Precompiles: []string{PrecompileTxAllowList, PrecompileNativeMinter},
+		Admins:      []common.Address{admin},
+		Timestamp:   time.Unix(1_700_000_000, 0),
+	})
+	if err != nil {
+		t.Fatalf("NewSubnetEVM() error = %v", err)
+	}
+
+	var got struct {
+		Config struct {
+			ChainID    uint64    `json:"chainId"`
+			FeeConfig  FeeConfig `json:"feeConfig"`
+			WarpConfig struct {
+				BlockTimestamp uint64 `json:"blockTimestamp"`
+			} `json:"warpConfig"`
+			TxAllowList    *precompileConfig `json:"txAllowListConfig"`
+			NativeMinter   *precompileConfig `json:"contractNativeMinterConfig"`
+			DeployerList   *precompileConfig `json:"contractDeployerAllowListConfig"`
+			MuirGlacierBlk *uint64           `json:"muirGlacierBlock"`
+		} `json:"config"`
+		Alloc     map[string]struct{ Balance string } `json:"alloc"`
+		GasLimit  string                              `json:"gasLimit"`
+		Timestamp string                              `json:"timestamp"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("generated genesis is not valid JSON: %v", err)
+	}
+	if got.Config.ChainID != 12345 || got.Config.FeeConfig != DefaultFeeConfig() {
+		t.Errorf("config = %+v", got.Config)
+	}
+	if got.Config.WarpConfig.BlockTimestamp != 1_700_000_000 || got.Timestamp != "0x6553f100" {
+		t.Errorf("timestamps = %d, %s", got.Config.WarpConfig.BlockTimestamp, got.Timestamp)
+	}
+	if got.Config.MuirGlacierBlk == nil || *got.Config.MuirGlacierBlk != 0 {
+		t.Error("fork blocks not activated at genesis")
+	}
+	if got.Config.TxAllowList == nil || got.Config.NativeMinter == nil || got.Config.DeployerList != nil {
+		t.Errorf("precompiles = tx %v, minter %v, deployer %v", got.Config.TxAllowList, got.Config.NativeMinter, got.Config.DeployerList)
+	}
+	if got.Config.TxAllowList != nil && (len(got.Config.TxAllowList.AdminAddresses) != 1 || got.Config.TxAllowList.AdminAddresses[0] != admin) {
+		t.Errorf("admins = %v", got.Config.TxAllowList.AdminAddresses)
+	}
+	if len(got.Alloc) != 1 || got.Alloc[funded.Hex()].Balance != "0x3e8" {
+		t.Errorf("alloc = %v", got.Alloc)
+	}
+	if got.GasLimit != "0x7a1200" {
+		t.Errorf("gasLimit = %s, want 0x7a1200", got.GasLimit)
+	}
+}
+
+func TestNewSubnetEVMErrors(t *testing.T) {
+	admin := common.Address{0x01}
+	badFee := DefaultFeeConfig()
+	badFee.MinBlockGasCost = badFee.MaxBlockGasCost + 1
+
+	tests := []struct {
+		name string
+		cfg  SubnetEVMConfig
+		want error
+	}{
+		{name: "zero chain ID", cfg: SubnetEVMConfig{FeeConfig: DefaultFeeConfig()}, want: ErrInvalidChainID},
+		{name: "zero gas limit", cfg: SubnetEVMConfig{ChainID: 1}, want: ErrInvalidFeeConfig},
+		{name: "block gas cost range", cfg: SubnetEVMConfig{ChainID: 1, FeeConfig: badFee}, want: ErrInvalidFeeConfig},
+		{
+			name: "precompile without admin",
+			cfg:  SubnetEVMConfig{ChainID: 1, FeeConfig: DefaultFeeConfig(), Precompiles: []string{PrecompileFeeManager}},
+			want: ErrMissingAdmin,
+		},
+		{
+			name: "unknown precompile",
+			cfg:  SubnetEVMConfig{ChainID: 1, FeeConfig: DefaultFeeConfig(), Precompiles: []string{"warp"}, Admins: []common.Address{admin}},
+			want: ErrUnknownPrecompile,
+		},
+		{
+			name: "duplicate allocation",
+			cfg: SubnetEVMConfig{ChainID: 1, FeeConfig: DefaultFeeConfig(), Allocations: []Allocation{
+				{Address: admin, Balance: big.NewInt(1)},
+				{Address: admin, Balance: big.NewInt(2)},
+			}},
+			want: ErrDuplicateAllocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSubnetEVM(tt.cfg); !errors.Is(err, tt.want) {
+				t.Fatalf("NewSubnetEVM() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAllocation(t *testing.T) {
+	addr := "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	tests := []struct {
+		in      string
+		want    string // wei
+		wantErr bool
+	}{
+		{in: addr + ":1000", want: "1000000000000000000000"},
+		{in: addr + ":0.5", want: "500000000000000000"},
+		{in: addr + ":0.000000000000000001", want: "1"},
+		{in: addr + ":0.0000000000000000001", wantErr: true},
+		{in: addr + ":-1", wantErr: true},
+		{in: addr + ":1.", wantErr: true},
+		{in: addr + ":", wantErr: true},
+		{in: addr, wantErr: true},
+		{in: "8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC:1", wantErr: true},
+		{in: "0x1234:1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAllocation(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAllocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Balance.String() != tt.want || got.Address != common.HexToAddress(addr)) {
+				t.Fatalf("ParseAllocation() = %s %s, want %s", got.Address, got.Balance, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSubnetEVMReproducible(t *testing.T) {
+	a := Allocation{Address: common.Address{0x02}, Balance: big.NewInt(2)}
+	b := Allocation{Address: common.Address{0x01}, Balance: big.NewInt(1)}
+	cfg := SubnetEVMConfig{ChainID: 1, FeeConfig: DefaultFeeConfig(), Timestamp: time.Unix(1, 0)}
+
+	cfg.Allocations = []Allocation{a, b}
+	first, err := NewSubnetEVM(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Allocations = []Allocation{b, a}
+	second, err := NewSubnetEVM(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("genesis depends on allocation order:\n%s\n%s", first, second)
+	}
+}
+
+func TestValidateSubnetEVM(t *testing.T) {
+	admin := common.Address{0x01}
+	base, err := NewSubnetEVM(SubnetEVMConfig{
+		ChainID:     12345,
+		FeeConfig:   DefaultFeeConfig(),
+		Allocations: []Allocation{{Address: common.Address{0x02}, Balance: big.NewInt(1_000)}},
+		Precompiles: []string{PrecompileTxAllowList},
+		Admins:      []common.Address{admin},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		edit   func(g, config map[string]any)
+		want   error
+		substr string
+	}{
+		{name: "generated genesis", edit: func(g, config map[string]any) {}},
+		{name: "default fee config", edit: func(g, config map[string]any) { delete(config, "feeConfig") }},
+		{name: "decimal gas limit", edit: func(g, config map[string]any) { g["gasLimit"] = 8_000_000 }},
+		{name: "missing config", edit: func(g, config map[string]any) { delete(g, "config") }, want: ErrInvalidGenesis, substr: "'config'"},
+		{name: "missing alloc", edit: func(g, config map[string]any) { delete(g, "alloc") }, want: ErrInvalidGenesis, substr: "'alloc'"},
+		{name: "missing gas limit", edit: func(g, config map[string]any) { delete(g, "gasLimit") }, want: ErrInvalidGenesis, substr: "'gasLimit'"},
+		{name: "zero gas limit", edit: func(g, config map[string]any) { g["gasLimit"] = "0x0" }, want: ErrInvalidGenesis, substr: "gasLimit"},
+		{name: "missing difficulty", edit: func(g, config map[string]any) { delete(g, "difficulty") }, want: ErrInvalidGenesis, substr: "'difficulty'"},
+		{name: "missing chain ID", edit: func(g, config map[string]any) { delete(config, "chainId") }, want: ErrInvalidGenesis, substr: "chainId"},
+		{name: "zero chain ID", edit: func(g, config map[string]any) { config["chainId"] = 0 }, want: ErrInvalidChainID},
+		{
+			name: "partial fee config",
+			edit: func(g, config map[string]any) {
+				config["feeConfig"] = map[string]any{"gasLimit": 8_000_000, "targetBlockRate": 2}
+			},
+			want:   ErrInvalidFeeConfig,
+			substr: "'minBaseFee'",
+		},
+		{
+			name:   "negative fee value",
+			edit:   func(g, config map[string]any) { config["feeConfig"].(map[string]any)["minBaseFee"] = -1 },
+			want:   ErrInvalidFeeConfig,
+			substr: "minBaseFee",
+		},
+		{
+			name:   "zero target gas",
+			edit:   func(g, config map[string]any) { config["feeConfig"].(map[string]any)["targetGas"] = 0 },
+			want:   ErrInvalidFeeConfig,
+			substr: "target gas",
+		},
+		{
+			name:   "warp quorum too low",
+			edit:   func(g, config map[string]any) { config["warpConfig"].(map[string]any)["quorumNumerator"] = 20 },
+			want:   ErrInvalidGenesis,
+			substr: "quorumNumerator",
+		},
+		{
+			name: "bad alloc address",
+			edit: func(g, config map[string]any) {
+				g["alloc"].(map[string]any)["0x1234"] = map[string]any{"balance": "0x1"}
+			},
+			want:   ErrInvalidEVMAddress,
+			substr: "0x1234",
+		},
+		{
+			name: "alloc without balance",
+			edit: func(g, config map[string]any) {
+				g["alloc"].(map[string]any)[common.Address{0x03}.Hex()] = map[string]any{}
+			},
+			want:   ErrInvalidGenesis,
+			substr: "'balance'",
+		},
+		{
+			name: "address in two roles",
+			edit: func(g, config map[string]any) {
+				config["txAllowListConfig"].(map[string]any)["enabledAddresses"] = []string{admin.Hex()}
+			},
+			want:   ErrInvalidGenesis,
+			substr: "both admin and enabled",
+		},
+		{
+			name: "malformed admin address",
+			edit: func(g, config map[string]any) {
+				config["txAllowListConfig"].(map[string]any)["adminAddresses"] = []string{"0xabc"}
+			},
+			want:   ErrInvalidGenesis,
+			substr: "txAllowListConfig",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g map[string]any
+			if err := json.Unmarshal(base, &g); err != nil {
+				t.Fatal(err)
+			}
+			config, _ := g["config"].(map[string]any)
+			tt.edit(g, config)
+			data, err := json.Marshal(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ValidateSubnetEVM(data)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateSubnetEVM() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) || !errors.Is(err, ErrInvalidGenesis) {
+				t.Fatalf("ValidateSubnetEVM() error = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.substr) {
+				t.Errorf("ValidateSubnetEVM() error = %v, want it to mention %q", err, tt.substr)
+			}
+		})
+	}
+
+	if err := ValidateSubnetEVM([]byte(`[]`)); !errors.Is(err, ErrInvalidGenesis) {
+		t.Errorf("ValidateSubnetEVM(array) error = %v, want %v", err, ErrInvalidGenesis)
+	}
+}
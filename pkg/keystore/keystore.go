@@ -200,6 +200,16 @@ func (ks *KeyStore) Save() error {
 // ImportKey imports a private key with the given name.
 // If password is provided, the key will be encrypted.
 func (ks *KeyStore) ImportKey(name string, keyBytes []byte, password []byte) error {
+	return ks.importKey(name, keyBytes, password, false)
+}
+
+// ImportTestKey imports a well-known test key with the given name. The key
+// is tagged as a test key in the same index save and is never made the default.
+func (ks *KeyStore) ImportTestKey(name string, keyBytes []byte, password []byte) error {
+	return ks.importKey(name, keyBytes, password, true)
+}
+
+func (ks *KeyStore) importKey(name string, keyBytes []byte, password []byte, testKey bool) error {
 	if err := ValidateKeyName(name); err != nil {
 		return err
 	}
@@ -209,16 +219,61 @@ func (ks *KeyStore) ImportKey(name string, keyBytes []byte, password []byte) err
 		return fmt.Errorf("key with name %q already exists", name)
 	}
 
-	// Validate key by parsing it
-	key, err := secp256k1.ToPrivateKey(keyBytes)
+	data, err := marshalKeyFile(keyBytes, password)
 	if err != nil {
-		return fmt.Errorf("invalid private key: %w", err)
+		return err
 	}
 
 	// Derive addresses
 	pAddr, evmAddr := wallet.DeriveAddresses(keyBytes)
 
-	// Create key file
+	// Write key file
+	keyPath := filepath.Join(ks.basePath, name+keyExtension)
+	if err := writeFileAtomic(keyPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	previousDefault := ks.index.Default
+	// Update index
+	ks.index.Keys[name] = KeyEntry{
+		Name:          name,
+		Encrypted:     len(password) > 0,
+		PChainAddress: pAddr,
+		EVMAddress:    evmAddr,
+		CreatedAt:     time.Now().UTC(),
+		TestKey:       testKey,
+	}
+
+	// Set as default if it's the first key
+	if len(ks.index.Keys) == 1 && !testKey {
+		ks.index.Default = name
+	}
+
+	if err := ks.Save(); err != nil {
+		// Roll back in-memory index and key file on persistence failure.
+		delete(ks.index.Keys, name)
+		ks.index.Default = previousDefault
+		removeErr := os.Remove(keyPath)
+		if removeErr != nil && !os.IsNotExist(removeErr) {
+			return errors.Join(
+				fmt.Errorf("failed to save key index: %w", err),
+				fmt.Errorf("failed to rollback key file %s: %w", keyPath, removeErr),
+			)
+		}
+		return fmt.Errorf("failed to save key index: %w", err)
+	}
+	return nil
+}
+
+// marshalKeyFile validates keyBytes and returns the serialized key file,
+// encrypting it when a password is provided.
+func marshalKeyFile(keyBytes []byte, password []byte) ([]byte, error) {
+	// Validate key by parsing it
+	key, err := secp256k1.ToPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
 	keyFile := &KeyFile{
 		Version: 1,
 	}
@@ -227,7 +282,7 @@ func (ks *KeyStore) ImportKey(name string, keyBytes []byte, password []byte) err
 		// Encrypt the key
 		salt, nonce, ciphertext, err := Encrypt(keyBytes, password)
 		if err != nil {
-			return fmt.Errorf("failed to encrypt key: %w", err)
+			return nil, fmt.Errorf("failed to encrypt key: %w", err)
 		}
 		keyFile.Encrypted = true
 		keyFile.Salt = salt
@@ -238,45 +293,81 @@ func (ks *KeyStore) ImportKey(name string, keyBytes []byte, password []byte) err
 		keyFile.Format = "cb58"
 		encoded, err := cb58.Encode(key.Bytes())
 		if err != nil {
-			return fmt.Errorf("failed to encode key: %w", err)
+			return nil, fmt.Errorf("failed to encode key: %w", err)
 		}
 		keyFile.Key = "PrivateKey-" + encoded
 	}
 
-	// Write key file
-	keyPath := filepath.Join(ks.basePath, name+keyExtension)
 	data, err := json.MarshalIndent(keyFile, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal key file: %w", err)
+		return nil, fmt.Errorf("failed to marshal key file: %w", err)
+	}
+	return data, nil
+}
+
+// ReplaceKey overwrites an existing key with new key material.
+// The new key is parsed and (if password is provided) encrypted before anything
+// on disk is touched. The key's creation time and default status are preserved.
+// If persisting the index fails, the original key file and entry are restored.
+func (ks *KeyStore) ReplaceKey(name string, keyBytes []byte, password []byte) error {
+	return ks.replaceKey(name, keyBytes, password, false)
+}
+
+// ReplaceTestKey is like ReplaceKey, but tags the new key as a well-known test
+// key and clears the default if it was this key.
+func (ks *KeyStore) ReplaceTestKey(name string, keyBytes []byte, password []byte) error {
+	return ks.replaceKey(name, keyBytes, password, true)
+}
+
+func (ks *KeyStore) replaceKey(name string, keyBytes []byte, password []byte, testKey bool) error {
+	if err := ValidateKeyName(name); err != nil {
+		return err
+	}
+
+	oldEntry, exists := ks.index.Keys[name]
+	if !exists {
+		return fmt.Errorf("key %q not found", name)
+	}
+
+	data, err := marshalKeyFile(keyBytes, password)
+	if err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(ks.basePath, name+keyExtension)
+	oldData, err := readFileWithLimit(keyPath, maxKeyFileSize)
+	if err != nil {
+		return fmt.Errorf("failed to read existing key file: %w", err)
 	}
+	defer clearKeyBytes(oldData)
+
+	pAddr, evmAddr := wallet.DeriveAddresses(keyBytes)
+
 	if err := writeFileAtomic(keyPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write key file: %w", err)
 	}
 
-	previousDefault := ks.index.Default
-	// Update index
 	ks.index.Keys[name] = KeyEntry{
 		Name:          name,
 		Encrypted:     len(password) > 0,
 		PChainAddress: pAddr,
 		EVMAddress:    evmAddr,
-		CreatedAt:     time.Now().UTC(),
+		CreatedAt:     oldEntry.CreatedAt,
+		TestKey:       testKey,
 	}
-
-	// Set as default if it's the first key
-	if len(ks.index.Keys) == 1 {
-		ks.index.Default = name
+	previousDefault := ks.index.Default
+	if testKey && ks.index.Default == name {
+		ks.index.Default = ""
 	}
 
 	if err := ks.Save(); err != nil {
-		// Roll back in-memory index and key file on persistence failure.
-		delete(ks.index.Keys, name)
+		// Restore the previous key file and index entry.
+		ks.index.Keys[name] = oldEntry
 		ks.index.Default = previousDefault
-		removeErr := os.Remove(keyPath)
-		if removeErr != nil && !os.IsNotExist(removeErr) {
+		if restoreErr := writeFileAtomic(keyPath, oldData, 0600); restoreErr != nil {
 			return errors.Join(
 				fmt.Errorf("failed to save key index: %w", err),
-				fmt.Errorf("failed to rollback key file %s: %w", keyPath, removeErr),
+				fmt.Errorf("failed to restore key file %s: %w", keyPath, restoreErr),
 			)
 		}
 		return fmt.Errorf("failed to save key index: %w", err)
@@ -362,10 +453,12 @@ func (ks *KeyStore) DeleteKey(name string) error {
 	// Clear default if it was the deleted key
 	if ks.index.Default == name {
 		ks.index.Default = ""
-		// Set a new default if there are remaining keys
-		for k := range ks.index.Keys {
-			ks.index.Default = k
-			break
+		// Set a new default if there are remaining keys, never a test key
+		for k, e := range ks.index.Keys {
+			if !e.TestKey {
+				ks.index.Default = k
+				break
+			}
 		}
 	}
 
@@ -400,6 +493,21 @@ func (ks *KeyStore) GetKey(name string) (KeyEntry, bool) {
 	return entry, exists
 }
 
+// FindByAddress returns the key whose P-Chain address (CB58 short ID) or EVM
+// address (0x hex, case-insensitive) equals addr.
+func (ks *KeyStore) FindByAddress(addr string) (KeyEntry, bool) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return KeyEntry{}, false
+	}
+	for _, entry := range ks.index.Keys {
+		if entry.PChainAddress == addr || strings.EqualFold(entry.EVMAddress, addr) {
+			return entry, true
+		}
+	}
+	return KeyEntry{}, false
+}
+
 // SetDefault sets the default key name.
 func (ks *KeyStore) SetDefault(name string) error {
 	if err := ValidateKeyName(name); err != nil {
@@ -461,6 +569,11 @@ func (ks *KeyStore) ExportKey(name string, password []byte, format string) (stri
 	}
 }
 
+// Path returns the directory the keystore is stored in.
+func (ks *KeyStore) Path() string {
+	return ks.basePath
+}
+
 // KeyCount returns the number of stored keys.
 func (ks *KeyStore) KeyCount() int {
 	return len(ks.index.Keys)
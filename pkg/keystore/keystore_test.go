@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -262,6 +263,127 @@ func TestKeyStore_ImportKey_Duplicate(t *testing.T) {
 	}
 }
 
+// replacementKeyBytes is a second valid secp256k1 private key for replace tests.
+var replacementKeyBytes = []byte{
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+	0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+	0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+}
+
+func TestKeyStore_ReplaceKey(t *testing.T) {
+	ks, tempDir := setupTestKeystore(t)
+	defer os.RemoveAll(tempDir)
+
+	if err := ks.ImportKey("testkey", testKeyBytes, nil); err != nil {
+		t.Fatalf("ImportKey() error = %v", err)
+	}
+	original, _ := ks.GetKey("testkey")
+
+	if err := ks.ReplaceKey("testkey", replacementKeyBytes, []byte("password123")); err != nil {
+		t.Fatalf("ReplaceKey() error = %v", err)
+	}
+
+	entry, _ := ks.GetKey("testkey")
+	if entry.PChainAddress == original.PChainAddress {
+		t.Error("ReplaceKey() did not update P-Chain address")
+	}
+	if !entry.Encrypted {
+		t.Error("ReplaceKey() with password should mark key encrypted")
+	}
+	if !entry.CreatedAt.Equal(original.CreatedAt) {
+		t.Errorf("ReplaceKey() CreatedAt = %v, want %v", entry.CreatedAt, original.CreatedAt)
+	}
+	if ks.GetDefault() != "testkey" {
+		t.Errorf("ReplaceKey() changed default to %q", ks.GetDefault())
+	}
+
+	loaded, err := ks.LoadKey("testkey", []byte("password123"))
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	if string(loaded) != string(replacementKeyBytes) {
+		t.Error("LoadKey() after ReplaceKey() returned the old key")
+	}
+
+	if err := ks.ReplaceKey("missing", replacementKeyBytes, nil); err == nil {
+		t.Error("ReplaceKey() on non-existent key should fail")
+	}
+}
+
+func TestKeyStore_ReplaceKey_FailureLeavesOriginalIntact(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory rename semantics differ on windows")
+	}
+
+	ks, tempDir := setupTestKeystore(t)
+	defer os.RemoveAll(tempDir)
+
+	if err := ks.ImportKey("testkey", testKeyBytes, nil); err != nil {
+		t.Fatalf("ImportKey() error = %v", err)
+	}
+	original, _ := ks.GetKey("testkey")
+
+	// An invalid key must be rejected before anything is written.
+	if err := ks.ReplaceKey("testkey", []byte{0x01}, nil); err == nil {
+		t.Fatal("ReplaceKey() with invalid key should fail")
+	}
+
+	// Force Save() to fail after the key file is written by turning the index
+	// path into a non-empty directory.
+	indexPath := filepath.Join(tempDir, indexFile)
+	if err := os.Remove(indexPath); err != nil {
+		t.Fatalf("failed to remove index: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(indexPath, "block"), 0700); err != nil {
+		t.Fatalf("failed to create blocking directory: %v", err)
+	}
+
+	if err := ks.ReplaceKey("testkey", replacementKeyBytes, nil); err == nil {
+		t.Fatal("ReplaceKey() expected error when index save fails")
+	}
+
+	entry, _ := ks.GetKey("testkey")
+	if entry != original {
+		t.Errorf("ReplaceKey() changed entry on failure: got %+v, want %+v", entry, original)
+	}
+	loaded, err := ks.LoadKey("testkey", nil)
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	if string(loaded) != string(testKeyBytes) {
+		t.Error("LoadKey() after failed ReplaceKey() did not return the original key")
+	}
+}
+
+func TestKeyStore_FindByAddress(t *testing.T) {
+	ks, tempDir := setupTestKeystore(t)
+	defer os.RemoveAll(tempDir)
+
+	if err := ks.ImportKey("testkey", testKeyBytes, nil); err != nil {
+		t.Fatalf("ImportKey() error = %v", err)
+	}
+	entry, _ := ks.GetKey("testkey")
+
+	for _, addr := range []string{
+		entry.PChainAddress,
+		entry.EVMAddress,
+		strings.ToLower(entry.EVMAddress),
+		" " + entry.PChainAddress + " ",
+	} {
+		got, ok := ks.FindByAddress(addr)
+		if !ok || got.Name != "testkey" {
+			t.Errorf("FindByAddress(%q) = %q, %v; want testkey", addr, got.Name, ok)
+		}
+	}
+
+	for _, addr := range []string{"", "111111111111111111116DBWJs", "0x0000000000000000000000000000000000000000"} {
+		if got, ok := ks.FindByAddress(addr); ok {
+			t.Errorf("FindByAddress(%q) = %q, want no match", addr, got.Name)
+		}
+	}
+}
+
 func TestKeyStore_GenerateKey(t *testing.T) {
 	ks, tempDir := setupTestKeystore(t)
 	defer os.RemoveAll(tempDir)
@@ -587,6 +709,65 @@ func TestKeyStore_DeleteDefaultKey(t *testing.T) {
 	}
 }
 
+func TestKeyStore_TestKey(t *testing.T) {
+	ks, tempDir := setupTestKeystore(t)
+	defer os.RemoveAll(tempDir)
+
+	// A test key imported first is not made the default.
+	if err := ks.ImportTestKey("key1", testKeyBytes, nil); err != nil {
+		t.Fatalf("ImportTestKey() key1 error = %v", err)
+	}
+	if ks.GetDefault() != "" {
+		t.Errorf("GetDefault() after importing a test key = %q, want none", ks.GetDefault())
+	}
+	key2Bytes := make([]byte, len(testKeyBytes))
+	copy(key2Bytes, testKeyBytes)
+	key2Bytes[0] ^= 0xFF
+	if err := ks.ImportKey("key2", key2Bytes, nil); err != nil {
+		t.Fatalf("ImportKey() key2 error = %v", err)
+	}
+
+	// The tag persists.
+	reloaded, err := LoadFrom(tempDir)
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if entry, _ := reloaded.GetKey("key1"); !entry.TestKey {
+		t.Error("key1 TestKey = false after reload, want true")
+	}
+	if entry, _ := reloaded.GetKey("key2"); entry.TestKey {
+		t.Error("key2 TestKey = true, want false")
+	}
+
+	// Replacing the default with a test key clears the default.
+	if err := ks.SetDefault("key2"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if err := ks.ReplaceTestKey("key2", key2Bytes, nil); err != nil {
+		t.Fatalf("ReplaceKey() error = %v", err)
+	}
+	if entry, _ := ks.GetKey("key2"); !entry.TestKey {
+		t.Error("key2 TestKey = false after ReplaceTestKey, want true")
+	}
+	if ks.GetDefault() != "" {
+		t.Errorf("GetDefault() after replacing with a test key = %q, want none", ks.GetDefault())
+	}
+
+	// Deleting the default never falls back to a test key.
+	if err := ks.ReplaceKey("key2", key2Bytes, nil); err != nil {
+		t.Fatalf("ReplaceKey() error = %v", err)
+	}
+	if err := ks.SetDefault("key2"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if err := ks.DeleteKey("key2"); err != nil {
+		t.Fatalf("DeleteKey() error = %v", err)
+	}
+	if ks.GetDefault() != "" {
+		t.Errorf("GetDefault() after deleting the only real key = %q, want none", ks.GetDefault())
+	}
+}
+
 func TestNewKeyIndex(t *testing.T) {
 	idx := NewKeyIndex()
 	if idx == nil {
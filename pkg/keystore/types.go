@@ -16,6 +16,9 @@ type KeyEntry struct {
 	PChainAddress string    `json:"p_chain_address"`
 	EVMAddress    string    `json:"evm_address"`
 	CreatedAt     time.Time `json:"created_at"`
+	// TestKey marks a well-known test key, such as ewoq, whose private key
+	// is public.
+	TestKey bool `json:"test_key,omitempty"`
 }
 
 // KeyFile represents an individual key file (encrypted or plain).
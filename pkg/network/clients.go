@@ -0,0 +1,112 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// API paths of the node services, relative to the node URL.
+const (
+	infoAPIPath   = "/ext/info"
+	pChainAPIPath = "/ext/P"
+	xChainAPIPath = "/ext/bc/X"
+)
+
+// httpClientKey is the context key of the failover client set by
+// WithFailover.
+type httpClientKey struct{}
+
+// HTTPClient returns the http.Client for RPC requests made under ctx: the
+// failover client set by WithFailover, or http.DefaultClient.
+func HTTPClient(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(httpClientKey{}).(*http.Client); ok {
+		return client
+	}
+	return http.DefaultClient
+}
+
+// NewInfoClient returns an info API client for config's node that sends its
+// requests with HTTPClient(ctx). ctx is only read here; it does not bound
+// the client's requests.
+func NewInfoClient(ctx context.Context, config Config) *info.Client {
+	client := info.NewClient(config.RPCURL)
+	if httpClient, ok := ctx.Value(httpClientKey{}).(*http.Client); ok {
+		client.Requester = newRequester(httpClient, config.RPCURL+infoAPIPath)
+	}
+	return client
+}
+
+// NewPChainClient returns a P-Chain API client for config's node that sends
+// its requests with HTTPClient(ctx).
+func NewPChainClient(ctx context.Context, config Config) *platformvm.Client {
+	client := platformvm.NewClient(config.RPCURL)
+	if httpClient, ok := ctx.Value(httpClientKey{}).(*http.Client); ok {
+		client.Requester = newRequester(httpClient, config.RPCURL+pChainAPIPath)
+	}
+	return client
+}
+
+// NewXChainClient returns an X-Chain API client for config's node that sends
+// its requests with HTTPClient(ctx).
+func NewXChainClient(ctx context.Context, config Config) *avm.Client {
+	client := avm.NewClient(config.RPCURL, "X")
+	if httpClient, ok := ctx.Value(httpClientKey{}).(*http.Client); ok {
+		client.Requester = newRequester(httpClient, config.RPCURL+xChainAPIPath)
+	}
+	return client
+}
+
+// requester is an rpc.EndpointRequester that sends requests with its own
+// http.Client. avalanchego's requester always uses http.DefaultClient; this
+// one matches it otherwise, down to the error messages.
+type requester struct {
+	client *http.Client
+	uri    string
+}
+
+func newRequester(client *http.Client, uri string) rpc.EndpointRequester {
+	return &requester{client: client, uri: uri}
+}
+
+func (r *requester) SendRequest(ctx context.Context, method string, params any, reply any, options ...rpc.Option) error {
+	uri, err := url.Parse(r.uri)
+	if err != nil {
+		return err
+	}
+	body, err := json2.EncodeClientRequest(method, params)
+	if err != nil {
+		return fmt.Errorf("failed to encode client params: %w", err)
+	}
+
+	ops := rpc.NewOptions(options)
+	uri.RawQuery = ops.QueryParams().Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = ops.Headers()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to issue request: %w", err)
+	}
+	defer rpc.CleanlyCloseBody(resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	if err := json2.DecodeClientResponse(resp.Body, reply); err != nil {
+		return fmt.Errorf("failed to decode client response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	nodeutil "github.com/ava-labs/platform-cli/pkg/node"
+)
+
+// FailoverTransport is an http.RoundTripper that sends each request for one
+// of its endpoints to the endpoint currently in use and, when that endpoint
+// fails with a connection error, a 429 or a 5xx status, retries the request
+// on the next one. After a failover the working endpoint stays in use, so
+// later requests do not keep hitting the failing one. Requests for other
+// hosts pass straight through to Base.
+//
+// Install it with WithFailover; the clients built under the returned context
+// use it, and nothing else in the process does.
+//
+// A retried request may already have been processed by the failed endpoint;
+// re-issuing a signed transaction is harmless since the network accepts it
+// at most once.
+type FailoverTransport struct {
+	// Base performs the requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	// OnFailover, if set, is called each time a request moves from one
+	// endpoint to the next.
+	OnFailover func(from, to string, cause error)
+
+	endpoints []*url.URL
+
+	mu      sync.Mutex
+	current int // index into endpoints of the endpoint in use
+}
+
+// NewFailoverTransport returns a FailoverTransport over endpoints, in order of
+// preference. Endpoints are base node URIs such as https://host:port.
+func NewFailoverTransport(endpoints []string) (*FailoverTransport, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no RPC endpoints")
+	}
+	t := &FailoverTransport{endpoints: make([]*url.URL, 0, len(endpoints))}
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid RPC endpoint %q", endpoint)
+		}
+		t.endpoints = append(t.endpoints, u)
+	}
+	return t, nil
+}
+
+// WithFailover returns a context carrying an http.Client whose
+// FailoverTransport moves requests between config.RPCURL and fallbackURLs.
+// Only clients built under the returned context, such as those from
+// NewPChainClient, fail over; http.DefaultClient and http.DefaultTransport
+// are left alone. ctx is returned unchanged when there are no fallbacks.
+func WithFailover(ctx context.Context, config Config, fallbackURLs []string, onFailover func(from, to string, cause error)) (context.Context, error) {
+	if len(fallbackURLs) == 0 {
+		return ctx, nil
+	}
+	t, err := NewFailoverTransport(append([]string{config.RPCURL}, fallbackURLs...))
+	if err != nil {
+		return nil, err
+	}
+	t.OnFailover = onFailover
+	return context.WithValue(ctx, httpClientKey{}, &http.Client{Transport: t}), nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FailoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !t.handles(req.URL) {
+		return base.RoundTrip(req)
+	}
+	// Without GetBody the body can be sent only once, so there is nothing to
+	// fail over with.
+	attempts := len(t.endpoints)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	t.mu.Lock()
+	start := t.current
+	t.mu.Unlock()
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for i := 0; i < attempts; i++ {
+		idx := (start + i) % len(t.endpoints)
+		if i > 0 {
+			cause := err
+			if cause == nil {
+				cause = fmt.Errorf("received status code: %d", resp.StatusCode)
+				resp.Body.Close()
+			}
+			if t.OnFailover != nil {
+				prev := t.endpoints[(start+i-1)%len(t.endpoints)]
+				t.OnFailover(endpointString(prev), endpointString(t.endpoints[idx]), cause)
+			}
+		}
+
+		var attempt *http.Request
+		attempt, err = t.requestFor(req, t.endpoints[idx], i > 0)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = base.RoundTrip(attempt)
+		if req.Context().Err() != nil || !shouldFailover(resp, err) {
+			if err == nil {
+				t.mu.Lock()
+				t.current = idx
+				t.mu.Unlock()
+			}
+			return resp, err
+		}
+	}
+	return resp, err
+}
+
+// handles reports whether u addresses one of t's endpoints.
+func (t *FailoverTransport) handles(u *url.URL) bool {
+	for _, endpoint := range t.endpoints {
+		if strings.EqualFold(u.Scheme, endpoint.Scheme) && strings.EqualFold(u.Host, endpoint.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestFor returns req redirected to endpoint, with a fresh body when the
+// request is being retried.
+func (t *FailoverTransport) requestFor(req *http.Request, endpoint *url.URL, retry bool) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	out.URL.Scheme = endpoint.Scheme
+	out.URL.Host = endpoint.Host
+	out.Host = ""
+	if retry && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+		out.Body = body
+	}
+	return out, nil
+}
+
+// shouldFailover reports whether a round trip failed in a way another
+// endpoint might not: no response at all, rate limiting, or a server error.
+func shouldFailover(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// endpointString renders an endpoint URL as given to NewFailoverTransport.
+func endpointString(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// CheckFallbacks returns the fallback endpoints among fallbackURLs to pass to
+// WithFailover. Each URL is normalized like --rpc-url and must report
+// config's network ID; a mismatch wraps ErrNetworkIDMismatch and fails the
+// whole call, so a mistyped endpoint cannot send transactions to another
+// network. Endpoints whose network ID cannot be queried are left out and
+// returned in skipped, each with the reason.
+func CheckFallbacks(ctx context.Context, config Config, fallbackURLs []string, allowInsecureHTTP bool) (fallbacks []string, skipped []error, err error) {
+	for _, rpcURL := range fallbackURLs {
+		normalized, err := nodeutil.NormalizeNodeURIWithInsecureHTTP(rpcURL, allowInsecureHTTP)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --rpc-url %q: %w", rpcURL, err)
+		}
+		if normalized == config.RPCURL || slices.Contains(fallbacks, normalized) {
+			continue
+		}
+		actual, err := GetNetworkID(ctx, normalized)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		if actual != config.NetworkID {
+			return nil, nil, fmt.Errorf("%w: %s reports network ID %d (HRP %q), but %s is network ID %d",
+				ErrNetworkIDMismatch, normalized, actual, GetHRP(actual), config.RPCURL, config.NetworkID)
+		}
+		fallbacks = append(fallbacks, normalized)
+	}
+	return fallbacks, skipped, nil
+}
@@ -0,0 +1,181 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newCountingServer answers every request with status and counts the hits.
+// It fails the test if a request arrives without the body the client sent.
+func newCountingServer(t *testing.T, status int, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("request body = %q, want %q", body, "payload")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFailoverTransport(t *testing.T) {
+	var primaryHits, fallbackHits atomic.Int32
+	primary := newCountingServer(t, http.StatusServiceUnavailable, &primaryHits)
+	fallback := newCountingServer(t, http.StatusOK, &fallbackHits)
+
+	transport, err := NewFailoverTransport([]string{primary.URL, fallback.URL})
+	if err != nil {
+		t.Fatalf("NewFailoverTransport() error = %v", err)
+	}
+	var failovers []string
+	transport.OnFailover = func(from, to string, cause error) {
+		failovers = append(failovers, from+" -> "+to)
+	}
+	client := &http.Client{Transport: transport}
+	post := func(url string) int {
+		t.Helper()
+		resp, err := client.Post(url+"/ext/bc/P", "application/json", strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("POST %s error = %v", url, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(primary.URL); status != http.StatusOK {
+		t.Fatalf("status = %d, want the fallback's %d", status, http.StatusOK)
+	}
+	if len(failovers) != 1 || failovers[0] != primary.URL+" -> "+fallback.URL {
+		t.Fatalf("failovers = %v, want one from the primary to the fallback", failovers)
+	}
+
+	// The working endpoint stays in use.
+	post(primary.URL)
+	if primaryHits.Load() != 1 || fallbackHits.Load() != 2 {
+		t.Fatalf("hits = primary %d, fallback %d; want 1 and 2", primaryHits.Load(), fallbackHits.Load())
+	}
+
+	// Other hosts are not redirected.
+	var otherHits atomic.Int32
+	other := newCountingServer(t, http.StatusTeapot, &otherHits)
+	if status := post(other.URL); status != http.StatusTeapot || otherHits.Load() != 1 {
+		t.Fatalf("request to another host: status %d, hits %d", status, otherHits.Load())
+	}
+}
+
+func TestFailoverTransport_NoFailoverOnClientError(t *testing.T) {
+	var primaryHits, fallbackHits atomic.Int32
+	primary := newCountingServer(t, http.StatusBadRequest, &primaryHits)
+	fallback := newCountingServer(t, http.StatusOK, &fallbackHits)
+
+	transport, err := NewFailoverTransport([]string{primary.URL, fallback.URL})
+	if err != nil {
+		t.Fatalf("NewFailoverTransport() error = %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Post(primary.URL, "application/json", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || fallbackHits.Load() != 0 {
+		t.Fatalf("status = %d, fallback hits = %d; want the primary's 400 and no failover", resp.StatusCode, fallbackHits.Load())
+	}
+}
+
+func TestFailoverTransport_AllFail(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	var hits atomic.Int32
+	limited := newCountingServer(t, http.StatusTooManyRequests, &hits)
+
+	transport, err := NewFailoverTransport([]string{limited.URL, down.URL})
+	if err != nil {
+		t.Fatalf("NewFailoverTransport() error = %v", err)
+	}
+	_, err = (&http.Client{Transport: transport}).Post(limited.URL, "application/json", strings.NewReader("payload"))
+	if err == nil {
+		t.Fatal("POST expected an error when every endpoint fails")
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("rate-limited endpoint hits = %d, want 1", hits.Load())
+	}
+}
+
+func TestNewFailoverTransport_Invalid(t *testing.T) {
+	if _, err := NewFailoverTransport(nil); err == nil {
+		t.Fatal("NewFailoverTransport(nil) expected error")
+	}
+	if _, err := NewFailoverTransport([]string{"not a url"}); err == nil {
+		t.Fatal("NewFailoverTransport() expected error for an endpoint without scheme and host")
+	}
+}
+
+func TestWithFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	fallback := newNetworkIDServer(t, 12345)
+	defer fallback.Close()
+	defaultTransport := http.DefaultTransport
+
+	config := Config{NetworkID: 12345, RPCURL: down.URL}
+	background := context.Background()
+	if ctx, err := WithFailover(background, config, nil, nil); err != nil || ctx != background || HTTPClient(ctx) != http.DefaultClient {
+		t.Fatalf("WithFailover() without fallbacks = %v, %v; want the context unchanged", ctx, err)
+	}
+
+	var failovers int
+	ctx, err := WithFailover(background, config, []string{fallback.URL}, func(from, to string, cause error) { failovers++ })
+	if err != nil {
+		t.Fatalf("WithFailover() error = %v", err)
+	}
+	if http.DefaultTransport != defaultTransport {
+		t.Fatal("WithFailover() replaced http.DefaultTransport")
+	}
+
+	networkID, err := NewInfoClient(ctx, config).GetNetworkID(ctx)
+	if err != nil {
+		t.Fatalf("GetNetworkID() through the failover client error = %v", err)
+	}
+	if networkID != 12345 || failovers != 1 {
+		t.Fatalf("network ID %d after %d failovers, want 12345 after 1", networkID, failovers)
+	}
+
+	// Clients built under another context do not fail over.
+	if _, err := NewInfoClient(background, config).GetNetworkID(ctx); err == nil {
+		t.Fatal("GetNetworkID() without failover expected an error from the closed endpoint")
+	}
+}
+
+func TestCheckFallbacks(t *testing.T) {
+	ctx := context.Background()
+	config := Config{Name: "custom", NetworkID: 12345, RPCURL: "http://127.0.0.1:1"}
+	same := newNetworkIDServer(t, config.NetworkID)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	got, skipped, err := CheckFallbacks(ctx, config, []string{same.URL, down.URL, same.URL, config.RPCURL}, false)
+	if err != nil {
+		t.Fatalf("CheckFallbacks() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != same.URL {
+		t.Fatalf("CheckFallbacks() = %v, want [%s]", got, same.URL)
+	}
+	if len(skipped) != 1 || !errors.Is(skipped[0], ErrNodeUnreachable) {
+		t.Fatalf("skipped = %v, want the unreachable endpoint", skipped)
+	}
+
+	other := newNetworkIDServer(t, Fuji.NetworkID)
+	_, _, err = CheckFallbacks(ctx, config, []string{same.URL, other.URL}, false)
+	if !errors.Is(err, ErrNetworkIDMismatch) {
+		t.Fatalf("CheckFallbacks() error = %v, want %v", err, ErrNetworkIDMismatch)
+	}
+}
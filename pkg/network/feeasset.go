@@ -0,0 +1,55 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+const (
+	// feeAssetSymbol is the symbol the wallet builder looks up to find the
+	// fee asset.
+	feeAssetSymbol = "AVAX"
+
+	// feeAssetDenomination is the denomination the CLI's amount helpers
+	// assume: 1 AVAX = 10^9 nAVAX.
+	feeAssetDenomination = 9
+)
+
+// ErrUnexpectedFeeAsset is returned when a network's fee asset does not look
+// like primary-network AVAX, so AVAX/nAVAX amounts and fee estimates printed
+// by the CLI may be wrong.
+var ErrUnexpectedFeeAsset = errors.New("unexpected fee asset")
+
+// CheckFeeAsset verifies that the fee asset the wallet will use on rpcURL (the
+// X-Chain asset with symbol AVAX) has 9 decimal places and is also the
+// primary network's staking asset. Built-in networks always pass; this is
+// meant for custom networks reached with --rpc-url.
+func CheckFeeAsset(ctx context.Context, rpcURL string) error {
+	asset, err := avm.NewClient(rpcURL, "X").GetAssetDescription(ctx, feeAssetSymbol)
+	if err != nil {
+		return fmt.Errorf("failed to look up the %s asset: %w", feeAssetSymbol, err)
+	}
+	stakingAssetID, err := platformvm.NewClient(rpcURL).GetStakingAssetID(ctx, constants.PrimaryNetworkID)
+	if err != nil {
+		return fmt.Errorf("failed to get the staking asset ID: %w", err)
+	}
+	return checkFeeAsset(asset.AssetID, uint8(asset.Denomination), stakingAssetID)
+}
+
+func checkFeeAsset(feeAssetID ids.ID, denomination uint8, stakingAssetID ids.ID) error {
+	if denomination != feeAssetDenomination {
+		return fmt.Errorf("%w: %s (%s) has %d decimal places, but amounts are converted assuming %d",
+			ErrUnexpectedFeeAsset, feeAssetSymbol, feeAssetID, denomination, feeAssetDenomination)
+	}
+	if feeAssetID != stakingAssetID {
+		return fmt.Errorf("%w: fees are paid in %s (%s) but the P-Chain stakes %s",
+			ErrUnexpectedFeeAsset, feeAssetSymbol, feeAssetID, stakingAssetID)
+	}
+	return nil
+}
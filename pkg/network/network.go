@@ -3,12 +3,36 @@ package network
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	nodeutil "github.com/ava-labs/platform-cli/pkg/node"
+	"github.com/ava-labs/platform-cli/pkg/retry"
+)
+
+const (
+	// networkIDAttempts bounds how often GetNetworkID asks the node before
+	// giving up on a transient failure.
+	networkIDAttempts = 3
+	// networkIDRetryDelay is the initial delay between GetNetworkID attempts.
+	networkIDRetryDelay = 250 * time.Millisecond
+)
+
+var (
+	// ErrNodeUnreachable is returned by GetNetworkID when nothing accepts
+	// connections at the RPC URL, usually because the node is down.
+	ErrNodeUnreachable = errors.New("node unreachable")
+
+	// ErrInfoAPIUnavailable is returned by GetNetworkID when the node answers
+	// but does not serve the info API, so the network ID cannot be queried.
+	ErrInfoAPIUnavailable = errors.New("info API unavailable")
 )
 
 // Config holds network-specific configuration.
@@ -20,6 +44,7 @@ type Config struct {
 	// Staking parameters
 	MinValidatorStake uint64        // Minimum stake to become a validator (in nAVAX)
 	MinDelegatorStake uint64        // Minimum stake to delegate (in nAVAX)
+	MaxValidatorStake uint64        // Maximum weight of a validator, including delegations (in nAVAX)
 	MinStakeDuration  time.Duration // Minimum staking duration
 	MaxStakeDuration  time.Duration // Maximum staking duration (also bounds auto-renewal cycle length)
 }
@@ -29,10 +54,11 @@ var Fuji = Config{
 	Name:              "fuji",
 	NetworkID:         5,
 	RPCURL:            "https://api.avax-test.network",
-	MinValidatorStake: 1_000_000_000,        // 1 AVAX
-	MinDelegatorStake: 1_000_000_000,        // 1 AVAX
-	MinStakeDuration:  24 * time.Hour,       // 24 hours
-	MaxStakeDuration:  365 * 24 * time.Hour, // 1 year
+	MinValidatorStake: 1_000_000_000,         // 1 AVAX
+	MinDelegatorStake: 1_000_000_000,         // 1 AVAX
+	MaxValidatorStake: 3_000_000_000_000_000, // 3M AVAX
+	MinStakeDuration:  24 * time.Hour,        // 24 hours
+	MaxStakeDuration:  365 * 24 * time.Hour,  // 1 year
 }
 
 // Mainnet configuration
@@ -40,23 +66,31 @@ var Mainnet = Config{
 	Name:              "mainnet",
 	NetworkID:         1,
 	RPCURL:            "https://api.avax.network",
-	MinValidatorStake: 2000_000_000_000,     // 2000 AVAX
-	MinDelegatorStake: 25_000_000_000,       // 25 AVAX
-	MinStakeDuration:  14 * 24 * time.Hour,  // 14 days
-	MaxStakeDuration:  365 * 24 * time.Hour, // 1 year
+	MinValidatorStake: 2000_000_000_000,      // 2000 AVAX
+	MinDelegatorStake: 25_000_000_000,        // 25 AVAX
+	MaxValidatorStake: 3_000_000_000_000_000, // 3M AVAX
+	MinStakeDuration:  14 * 24 * time.Hour,   // 14 days
+	MaxStakeDuration:  365 * 24 * time.Hour,  // 1 year
+}
+
+// Builtin returns the networks selectable by name with --network, sorted by
+// name.
+func Builtin() []Config {
+	return []Config{Fuji, Mainnet}
 }
 
 // GetConfig returns the network configuration for the given network name.
 // For local/custom networks, use --rpc-url instead.
 func GetConfig(name string) (Config, error) {
-	switch name {
-	case "mainnet":
-		return Mainnet, nil
-	case "fuji":
-		return Fuji, nil
-	default:
-		return Config{}, fmt.Errorf("unsupported network %q (supported: fuji, mainnet)", name)
+	builtin := Builtin()
+	names := make([]string, 0, len(builtin))
+	for _, config := range builtin {
+		if config.Name == name {
+			return config, nil
+		}
+		names = append(names, config.Name)
 	}
+	return Config{}, fmt.Errorf("unsupported network %q (supported: %s)", name, strings.Join(names, ", "))
 }
 
 // GetNetworkIDAndRPC is a convenience function that returns both networkID and RPC URL.
@@ -68,14 +102,103 @@ func GetNetworkIDAndRPC(name string) (uint32, string, error) {
 	return config.NetworkID, config.RPCURL, nil
 }
 
-// GetNetworkID queries the network ID from an RPC endpoint.
+// GetNetworkID queries the network ID from an RPC endpoint. Transient
+// failures are retried a few times within the retry budget carried by ctx.
+// A refused connection wraps ErrNodeUnreachable and a missing info endpoint
+// wraps ErrInfoAPIUnavailable, since the remedies differ.
 func GetNetworkID(ctx context.Context, rpcURL string) (uint32, error) {
 	client := info.NewClient(rpcURL)
-	networkID, err := client.GetNetworkID(ctx)
+	delay := networkIDRetryDelay
+	for attempt := 1; ; attempt++ {
+		networkID, err := client.GetNetworkID(ctx)
+		if err == nil {
+			return networkID, nil
+		}
+		if attempt == networkIDAttempts || !isTransientRPCError(err) {
+			return 0, networkIDError(rpcURL, err)
+		}
+		if waitErr := retry.Wait(ctx, delay); waitErr != nil {
+			if errors.Is(waitErr, retry.ErrBudgetExhausted) {
+				return 0, networkIDError(rpcURL, err)
+			}
+			return 0, waitErr
+		}
+		delay *= 2
+	}
+}
+
+// isTransientRPCError reports whether err is a momentary endpoint failure
+// worth retrying: a timeout, a dropped connection, or a rate-limit or
+// gateway status. A refused connection is not: the node is down.
+func isTransientRPCError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := err.Error()
+	for _, code := range []string{"429", "502", "503", "504"} {
+		if strings.Contains(msg, "received status code: "+code) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkIDError explains a failed network ID query at rpcURL.
+func networkIDError(rpcURL string, err error) error {
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("%w: connection to %s refused; is the node running and listening on that address? (%w)", ErrNodeUnreachable, rpcURL, err)
+	case strings.Contains(err.Error(), "received status code: 404"),
+		strings.Contains(err.Error(), "received status code: 405"):
+		return fmt.Errorf("%w: %s has no /ext/info endpoint; enable the info API on the node (%w)", ErrInfoAPIUnavailable, rpcURL, err)
+	default:
+		return fmt.Errorf("failed to get network ID from %s: %w", rpcURL, err)
+	}
+}
+
+// ErrNetworkIDMismatch is returned by VerifyNetworkID when the node reports a
+// different network ID than expected.
+var ErrNetworkIDMismatch = errors.New("network ID mismatch")
+
+// VerifyNetworkID checks that the node at rpcURL reports the expected network
+// ID. A mismatch wraps ErrNetworkIDMismatch; any other error means the ID
+// could not be queried.
+func VerifyNetworkID(ctx context.Context, rpcURL string, expected uint32) error {
+	actual, err := GetNetworkID(ctx, rpcURL)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get network ID from %s: %w", rpcURL, err)
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: --network-id is %d (HRP %q) but %s reports %d (HRP %q)",
+			ErrNetworkIDMismatch, expected, GetHRP(expected), rpcURL, actual, GetHRP(actual))
 	}
-	return networkID, nil
+	return nil
+}
+
+// WithEndpoint returns config with its RPC URL replaced by rpcURL, such as a
+// mirror of a rate-limited public endpoint. The network's name, ID and staking
+// parameters are kept. The endpoint must report config's network ID, so a
+// mistyped mirror cannot send transactions to another network; a mismatch
+// wraps ErrNetworkIDMismatch.
+func WithEndpoint(ctx context.Context, config Config, rpcURL string, allowInsecureHTTP bool) (Config, error) {
+	normalizedRPCURL, err := nodeutil.NormalizeNodeURIWithInsecureHTTP(rpcURL, allowInsecureHTTP)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid --rpc-url: %w", err)
+	}
+	actual, err := GetNetworkID(ctx, normalizedRPCURL)
+	if err != nil {
+		return Config{}, err
+	}
+	if actual != config.NetworkID {
+		return Config{}, fmt.Errorf("%w: %s is network ID %d but %s reports %d (HRP %q)",
+			ErrNetworkIDMismatch, config.Name, config.NetworkID, normalizedRPCURL, actual, GetHRP(actual))
+	}
+	config.RPCURL = normalizedRPCURL
+	return config, nil
 }
 
 // GetHRP returns the Human-Readable Part (HRP) for bech32 addresses based on network ID.
@@ -83,6 +206,14 @@ func GetHRP(networkID uint32) string {
 	return constants.GetHRP(networkID)
 }
 
+// GetNetworkIDForHRP returns the network ID whose bech32 addresses use hrp.
+// It reports false for unknown HRPs and for the fallback HRP ("custom"),
+// which every network without a registered HRP shares.
+func GetNetworkIDForHRP(hrp string) (uint32, bool) {
+	id, ok := constants.NetworkHRPToNetworkID[strings.ToLower(hrp)]
+	return id, ok
+}
+
 // NewCustomConfig creates a config for a custom network (devnet).
 // If networkID is 0, it will be queried from the node.
 // If the node doesn't expose /ext/info, use --network-id flag.
@@ -102,6 +233,10 @@ func NewCustomConfigWithInsecureHTTP(ctx context.Context, rpcURL string, network
 
 	if networkID == 0 {
 		networkID, err = GetNetworkID(ctx, normalizedRPCURL)
+		if errors.Is(err, ErrNodeUnreachable) {
+			// Knowing the network ID would not help: nothing is listening.
+			return Config{}, err
+		}
 		if err != nil {
 			return Config{}, fmt.Errorf("%w\n\nUse --network-id to specify the network ID manually:\n  --network-id 1     (mainnet)\n  --network-id 5     (fuji)\n  --network-id 12345 (custom)", err)
 		}
@@ -137,6 +272,7 @@ func NewCustomConfigWithInsecureHTTP(ctx context.Context, rpcURL string, network
 		RPCURL:            normalizedRPCURL,
 		MinValidatorStake: minValidatorStake,
 		MinDelegatorStake: minDelegatorStake,
+		MaxValidatorStake: 3_000_000_000_000_000, // 3M AVAX
 		MinStakeDuration:  minStakeDuration,
 		MaxStakeDuration:  365 * 24 * time.Hour, // 1 year
 	}, nil
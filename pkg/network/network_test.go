@@ -2,8 +2,17 @@ package network
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 func TestGetConfig_Fuji(t *testing.T) {
@@ -65,6 +74,30 @@ func TestGetConfig_Unknown(t *testing.T) {
 	}
 }
 
+func TestBuiltin(t *testing.T) {
+	builtin := Builtin()
+	if len(builtin) == 0 {
+		t.Fatal("Builtin() returned no networks")
+	}
+	for i, cfg := range builtin {
+		if i > 0 && builtin[i-1].Name >= cfg.Name {
+			t.Errorf("Builtin() not sorted by name: %s before %s", builtin[i-1].Name, cfg.Name)
+		}
+		got, err := GetConfig(cfg.Name)
+		if err != nil {
+			t.Fatalf("GetConfig(%s) returned error: %v", cfg.Name, err)
+		}
+		if got != cfg {
+			t.Errorf("GetConfig(%s) = %+v, want %+v", cfg.Name, got, cfg)
+		}
+	}
+
+	_, err := GetConfig("unknown")
+	if err == nil || !strings.Contains(err.Error(), "supported: fuji, mainnet") {
+		t.Errorf("GetConfig(unknown) error = %v, want list of supported networks", err)
+	}
+}
+
 func TestGetNetworkIDAndRPC(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -119,6 +152,29 @@ func TestGetHRP(t *testing.T) {
 	}
 }
 
+func TestGetNetworkIDForHRP(t *testing.T) {
+	tests := []struct {
+		hrp    string
+		wantID uint32
+		wantOK bool
+	}{
+		{"avax", 1, true},
+		{"FUJI", 5, true},
+		{"local", 12345, true},
+		{"custom", 0, false},
+		{"nope", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hrp, func(t *testing.T) {
+			id, ok := GetNetworkIDForHRP(tt.hrp)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("GetNetworkIDForHRP(%q) = %d, %v; want %d, %v", tt.hrp, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestFujiConfig(t *testing.T) {
 	// Verify Fuji config is properly initialized
 	if Fuji.NetworkID != 5 {
@@ -218,3 +274,205 @@ func TestNewCustomConfigWithInsecureHTTP(t *testing.T) {
 		})
 	}
 }
+
+func newNetworkIDServer(t *testing.T, networkID uint32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ext/info" {
+			t.Errorf("request path = %q, want /ext/info", r.URL.Path)
+		}
+		var req struct {
+			Method string `json:"method"`
+			ID     any    `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode JSON-RPC request: %v", err)
+		}
+		if req.Method != "info.getNetworkID" {
+			t.Errorf("method = %q, want info.getNetworkID", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"result": map[string]any{
+				"networkID": strconv.FormatUint(uint64(networkID), 10),
+			},
+			"id": req.ID,
+		})
+	}))
+}
+
+func TestVerifyNetworkID(t *testing.T) {
+	server := newNetworkIDServer(t, 12345)
+	defer server.Close()
+
+	ctx := context.Background()
+	if err := VerifyNetworkID(ctx, server.URL, 12345); err != nil {
+		t.Fatalf("VerifyNetworkID(matching) returned error: %v", err)
+	}
+
+	err := VerifyNetworkID(ctx, server.URL, 5)
+	if !errors.Is(err, ErrNetworkIDMismatch) {
+		t.Fatalf("VerifyNetworkID(mismatch) error = %v, want ErrNetworkIDMismatch", err)
+	}
+}
+
+func TestVerifyNetworkID_Unreachable(t *testing.T) {
+	server := newNetworkIDServer(t, 5)
+	server.Close()
+
+	err := VerifyNetworkID(context.Background(), server.URL, 5)
+	if err == nil {
+		t.Fatal("VerifyNetworkID() expected error for unreachable node")
+	}
+	if errors.Is(err, ErrNetworkIDMismatch) {
+		t.Fatalf("VerifyNetworkID() unreachable error should not be a mismatch: %v", err)
+	}
+}
+
+func TestGetNetworkID_RetriesTransientFailure(t *testing.T) {
+	healthy := newNetworkIDServer(t, 12345)
+	defer healthy.Close()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		healthy.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	got, err := GetNetworkID(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("GetNetworkID() error = %v", err)
+	}
+	if got != 12345 || calls.Load() != 2 {
+		t.Fatalf("GetNetworkID() = %d after %d calls, want 12345 after 2", got, calls.Load())
+	}
+}
+
+func TestGetNetworkID_Errors(t *testing.T) {
+	var calls atomic.Int32
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer notFound.Close()
+
+	_, err := GetNetworkID(context.Background(), notFound.URL)
+	if !errors.Is(err, ErrInfoAPIUnavailable) {
+		t.Fatalf("GetNetworkID(404) error = %v, want ErrInfoAPIUnavailable", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("GetNetworkID(404) made %d calls, want 1 (not transient)", calls.Load())
+	}
+
+	down := newNetworkIDServer(t, 5)
+	down.Close()
+	_, err = GetNetworkID(context.Background(), down.URL)
+	if !errors.Is(err, ErrNodeUnreachable) {
+		t.Fatalf("GetNetworkID(closed) error = %v, want ErrNodeUnreachable", err)
+	}
+}
+
+func TestWithEndpoint(t *testing.T) {
+	mirror := newNetworkIDServer(t, Mainnet.NetworkID)
+	defer mirror.Close()
+
+	got, err := WithEndpoint(context.Background(), Mainnet, mirror.URL, false)
+	if err != nil {
+		t.Fatalf("WithEndpoint() returned error: %v", err)
+	}
+	want := Mainnet
+	want.RPCURL = mirror.URL
+	if got != want {
+		t.Fatalf("WithEndpoint() = %+v, want %+v", got, want)
+	}
+
+	_, err = WithEndpoint(context.Background(), Fuji, mirror.URL, false)
+	if !errors.Is(err, ErrNetworkIDMismatch) {
+		t.Fatalf("WithEndpoint(wrong network) error = %v, want ErrNetworkIDMismatch", err)
+	}
+
+	if _, err := WithEndpoint(context.Background(), Mainnet, "http://mirror.example.com", false); err == nil {
+		t.Fatal("WithEndpoint() expected error for a non-local plain-HTTP mirror")
+	}
+}
+
+// newFeeAssetServer serves the X-Chain AVAX asset description and the P-Chain
+// staking asset ID.
+func newFeeAssetServer(t *testing.T, feeAssetID ids.ID, denomination uint8, stakingAssetID ids.ID) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			ID     any    `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode JSON-RPC request: %v", err)
+		}
+
+		var result map[string]any
+		switch req.Method {
+		case "avm.getAssetDescription":
+			result = map[string]any{
+				"assetID":      feeAssetID.String(),
+				"name":         "Avalanche",
+				"symbol":       "AVAX",
+				"denomination": strconv.FormatUint(uint64(denomination), 10),
+			}
+		case "platform.getStakingAssetID":
+			result = map[string]any{"assetID": stakingAssetID.String()}
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "result": result, "id": req.ID})
+	}))
+}
+
+func TestCheckFeeAsset(t *testing.T) {
+	avax := ids.GenerateTestID()
+	other := ids.GenerateTestID()
+
+	tests := []struct {
+		name           string
+		denomination   uint8
+		stakingAssetID ids.ID
+		wantErr        bool
+	}{
+		{name: "avax", denomination: 9, stakingAssetID: avax},
+		{name: "different denomination", denomination: 18, stakingAssetID: avax, wantErr: true},
+		{name: "different staking asset", denomination: 9, stakingAssetID: other, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFeeAssetServer(t, avax, tt.denomination, tt.stakingAssetID)
+			defer server.Close()
+
+			err := CheckFeeAsset(context.Background(), server.URL)
+			if tt.wantErr != errors.Is(err, ErrUnexpectedFeeAsset) {
+				t.Fatalf("CheckFeeAsset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("CheckFeeAsset() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckFeeAsset_Unreachable(t *testing.T) {
+	server := newFeeAssetServer(t, ids.Empty, 9, ids.Empty)
+	server.Close()
+
+	err := CheckFeeAsset(context.Background(), server.URL)
+	if err == nil || errors.Is(err, ErrUnexpectedFeeAsset) {
+		t.Fatalf("CheckFeeAsset() error = %v, want a lookup failure", err)
+	}
+}
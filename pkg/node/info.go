@@ -19,6 +19,10 @@ type NodeInfo struct {
 	BLSProofOfPossession string
 }
 
+// DefaultNodePort is the avalanchego HTTP API port assumed when a host
+// shorthand omits one.
+const DefaultNodePort = "9650"
+
 // NormalizeNodeURI converts a node address to a base URI suitable for info.NewClient.
 // Accepts: "127.0.0.1", "127.0.0.1:9650", "http://127.0.0.1:9650".
 //
@@ -34,6 +38,7 @@ func NormalizeNodeURI(addr string) (string, error) {
 // Security defaults:
 //   - host[:port] shorthand defaults to HTTPS for non-local hosts.
 //   - localhost / loopback shorthand defaults to HTTP.
+//   - shorthand without a port uses DefaultNodePort.
 //   - explicit HTTP for non-local hosts is rejected unless allowInsecureHTTP is true.
 func NormalizeNodeURIWithInsecureHTTP(addr string, allowInsecureHTTP bool) (string, error) {
 	addr = strings.TrimSpace(addr)
@@ -45,14 +50,11 @@ func NormalizeNodeURIWithInsecureHTTP(addr string, allowInsecureHTTP bool) (stri
 
 	// Allow host[:port] shorthand.
 	if !hasScheme {
-		if strings.Contains(addr, "/") {
-			return "", fmt.Errorf("invalid node address %q: use host[:port] or http(s)://host[:port]", addr)
-		}
-		if strings.Contains(addr, ":") {
-			addr = "https://" + addr
-		} else {
-			addr = fmt.Sprintf("https://%s:9650", addr)
+		uri, err := shorthandNodeURI(addr)
+		if err != nil {
+			return "", err
 		}
+		addr = uri
 	}
 
 	parsed, err := url.Parse(addr)
@@ -79,11 +81,6 @@ func NormalizeNodeURIWithInsecureHTTP(addr string, allowInsecureHTTP bool) (stri
 	}
 
 	hostname := parsed.Hostname()
-	if !hasScheme && isLoopbackHost(hostname) {
-		// Preserve legacy local-node ergonomics for shorthand inputs.
-		parsed.Scheme = "http"
-	}
-
 	if parsed.Scheme == "http" && !allowInsecureHTTP && !isLoopbackHost(hostname) {
 		return "", fmt.Errorf(
 			"insecure HTTP is disabled for non-local host %q (use https:// or enable insecure HTTP explicitly)",
@@ -94,6 +91,36 @@ func NormalizeNodeURIWithInsecureHTTP(addr string, allowInsecureHTTP bool) (stri
 	return parsed.String(), nil
 }
 
+// shorthandNodeURI expands host[:port] shorthand into a URI. A missing or
+// empty port becomes DefaultNodePort. Loopback hosts get HTTP, as a local
+// node serves its API without TLS; every other host gets HTTPS. IPv6
+// addresses may be given bare ("::1") or bracketed ("[::1]", "[::1]:9650").
+func shorthandNodeURI(addr string) (string, error) {
+	if strings.Contains(addr, "/") {
+		return "", fmt.Errorf("invalid node address %q: use host[:port] or http(s)://host[:port]", addr)
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		// No port: a hostname, an IPv4 address, or a bare or bracketed IPv6 address.
+		host, port = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"), ""
+	}
+	if host == "" {
+		return "", fmt.Errorf("invalid node address %q: missing host", addr)
+	}
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return "", fmt.Errorf("invalid node address %q: use host[:port] or http(s)://host[:port]", addr)
+	}
+	if port == "" {
+		port = DefaultNodePort
+	}
+
+	scheme := "https"
+	if isLoopbackHost(host) {
+		scheme = "http"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port), nil
+}
+
 func isLoopbackHost(host string) bool {
 	if strings.EqualFold(host, "localhost") {
 		return true
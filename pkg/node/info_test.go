@@ -65,6 +65,36 @@ func TestNormalizeNodeURI(t *testing.T) {
 			input: "localhost:9650",
 			want:  "http://localhost:9650",
 		},
+		{
+			name:  "localhost with custom port",
+			input: "localhost:19650",
+			want:  "http://localhost:19650",
+		},
+		{
+			name:  "localhost with empty port",
+			input: "localhost:",
+			want:  "http://localhost:9650",
+		},
+		{
+			name:  "localhost uppercase",
+			input: "LOCALHOST",
+			want:  "http://LOCALHOST:9650",
+		},
+		{
+			name:  "loopback IP with custom port",
+			input: "127.0.0.1:19650",
+			want:  "http://127.0.0.1:19650",
+		},
+		{
+			name:  "other loopback IP",
+			input: "127.0.0.2",
+			want:  "http://127.0.0.2:9650",
+		},
+		{
+			name:  "explicit https localhost kept",
+			input: "https://localhost:9650",
+			want:  "https://localhost:9650",
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,6 +122,21 @@ func TestNormalizeNodeURI_IPv6(t *testing.T) {
 			input: "[::1]:9650",
 			want:  "http://[::1]:9650",
 		},
+		{
+			name:  "bare IPv6 loopback",
+			input: "::1",
+			want:  "http://[::1]:9650",
+		},
+		{
+			name:  "bracketed IPv6 loopback without port",
+			input: "[::1]",
+			want:  "http://[::1]:9650",
+		},
+		{
+			name:  "bare IPv6 non-local",
+			input: "2001:db8::1",
+			want:  "https://[2001:db8::1]:9650",
+		},
 		{
 			name:  "IPv6 full URI",
 			input: "http://[::1]:9650",
@@ -141,6 +186,18 @@ func TestNormalizeNodeURI_Invalid(t *testing.T) {
 			name:  "host shorthand with path",
 			input: "127.0.0.1:9650/ext/info",
 		},
+		{
+			name:  "shorthand port only",
+			input: ":9650",
+		},
+		{
+			name:  "shorthand non-numeric port",
+			input: "localhost:abc",
+		},
+		{
+			name:  "shorthand too many colons",
+			input: "mynode:9650:1",
+		},
 		{
 			name:  "non-local http disallowed by default",
 			input: "http://mynode.example.com:9650",
@@ -0,0 +1,154 @@
+package pchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// avaxDecimals is the number of decimal places in one AVAX (1 AVAX = 10^9 nAVAX).
+const avaxDecimals = 9
+
+// FormatAVAX formats an nAVAX amount as a human-readable AVAX string.
+// Trailing fractional zeros are trimmed and the whole part is grouped in
+// thousands (e.g. 1234500000000 -> "1,234.5", 0 -> "0").
+func FormatAVAX(nAVAX uint64) string {
+	whole := groupThousands(strconv.FormatUint(nAVAX/units.Avax, 10))
+	frac := nAVAX % units.Avax
+	if frac == 0 {
+		return whole
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*d", avaxDecimals, frac), "0")
+	return whole + "." + fracStr
+}
+
+// groupThousands inserts a comma every three digits of a base-10 integer string.
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// ParseAVAX parses an amount string into nAVAX.
+//
+// Plain decimals are interpreted as AVAX ("10.5" -> 10_500_000_000). A trailing
+// "n" marks a whole-number nAVAX amount ("10500000000n"). Decimal parsing is
+// exact: digits beyond nAVAX precision are rejected rather than rounded, and
+// values that would overflow uint64 are rejected.
+func ParseAVAX(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("amount cannot be empty")
+	}
+
+	if digits, ok := strings.CutSuffix(s, "n"); ok {
+		nAVAX, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid nAVAX amount %q: must be a whole number", s)
+		}
+		return nAVAX, nil
+	}
+
+	return parseAVAXDecimal(s)
+}
+
+// Unit suffixes accepted by ParseAmount.
+const (
+	unitAVAX  = "avax"
+	unitNAVAX = "navax"
+)
+
+// ParseAmount parses an amount with an optional unit suffix into nAVAX.
+//
+// "1.5avax" and a bare "1.5" are AVAX, parsed exactly as ParseAVAX does;
+// "1500000000navax" is nAVAX and must be a whole number. Suffixes are
+// case-insensitive and may be separated from the number by spaces. Any other
+// suffix is rejected, as is a number carrying two units ("5n avax").
+func ParseAmount(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, unitNAVAX):
+		digits := strings.TrimSpace(s[:len(s)-len(unitNAVAX)])
+		nAVAX, err := strconv.ParseUint(digits, 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("invalid nAVAX amount %q: too large", s)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid nAVAX amount %q: must be a whole number", s)
+		}
+		return nAVAX, nil
+	case strings.HasSuffix(lower, unitAVAX):
+		number := strings.TrimSpace(s[:len(s)-len(unitAVAX)])
+		if number == "" {
+			return 0, fmt.Errorf("invalid AVAX amount %q", s)
+		}
+		return parseAVAXDecimal(number)
+	default:
+		return ParseAVAX(s)
+	}
+}
+
+// parseAVAXDecimal parses a plain decimal AVAX amount into nAVAX.
+func parseAVAXDecimal(s string) (uint64, error) {
+	wholeStr, fracStr, hasPoint := strings.Cut(s, ".")
+	if wholeStr == "" && (!hasPoint || fracStr == "") {
+		return 0, fmt.Errorf("invalid AVAX amount %q", s)
+	}
+	if !isDigits(wholeStr) || !isDigits(fracStr) {
+		return 0, fmt.Errorf("invalid AVAX amount %q", s)
+	}
+
+	// Extra trailing zeros are harmless; any other digit past nAVAX precision is not.
+	if len(fracStr) > avaxDecimals {
+		if strings.TrimRight(fracStr[avaxDecimals:], "0") != "" {
+			return 0, fmt.Errorf("invalid AVAX amount %q: more than %d decimal places (smallest unit is 1 nAVAX)", s, avaxDecimals)
+		}
+		fracStr = fracStr[:avaxDecimals]
+	}
+	fracStr += strings.Repeat("0", avaxDecimals-len(fracStr))
+
+	var whole uint64
+	if wholeStr != "" {
+		var err error
+		whole, err = strconv.ParseUint(wholeStr, 10, 64)
+		if err != nil || whole > math.MaxUint64/units.Avax {
+			return 0, fmt.Errorf("invalid AVAX amount %q: too large", s)
+		}
+	}
+	frac, err := strconv.ParseUint(fracStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AVAX amount %q", s)
+	}
+
+	nAVAX := whole * units.Avax
+	if nAVAX > math.MaxUint64-frac {
+		return 0, fmt.Errorf("invalid AVAX amount %q: too large", s)
+	}
+	return nAVAX + frac, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package pchain
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormatAVAX(t *testing.T) {
+	tests := []struct {
+		name  string
+		nAVAX uint64
+		want  string
+	}{
+		{"zero", 0, "0"},
+		{"one nAVAX", 1, "0.000000001"},
+		{"half AVAX", 500_000_000, "0.5"},
+		{"one AVAX", 1_000_000_000, "1"},
+		{"trims trailing zeros", 10_500_000_000, "10.5"},
+		{"groups thousands", 1_234_500_000_000, "1,234.5"},
+		{"exactly one thousand", 1_000_000_000_000, "1,000"},
+		{"millions with fraction", 2_000_000_000_000_123, "2,000,000.000000123"},
+		{"max uint64", math.MaxUint64, "18,446,744,073.709551615"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAVAX(tt.nAVAX); got != tt.want {
+				t.Errorf("FormatAVAX(%d) = %q, want %q", tt.nAVAX, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAVAX(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"0", 0},
+		{"1", 1_000_000_000},
+		{"10.5", 10_500_000_000},
+		{".5", 500_000_000},
+		{"5.", 5_000_000_000},
+		{"0.000000001", 1},
+		{"1.0000000000", 1_000_000_000},
+		{" 2 ", 2_000_000_000},
+		{"10500000000n", 10_500_000_000},
+		{"0n", 0},
+		{"18446744073.709551615", math.MaxUint64},
+		{"18446744073709551615n", math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAVAX(tt.input)
+			if err != nil {
+				t.Fatalf("ParseAVAX(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAVAX(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAVAX_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"lone point", "."},
+		{"negative", "-1"},
+		{"sub-nAVAX precision", "0.0000000001"},
+		{"sub-nAVAX precision after zeros", "1.0000000005"},
+		{"overflow whole", "18446744074"},
+		{"overflow fraction", "18446744073.709551616"},
+		{"nAVAX overflow", "18446744073709551616n"},
+		{"fractional nAVAX", "1.5n"},
+		{"exponent", "1e9"},
+		{"letters", "ten"},
+		{"thousands separator", "1,000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseAVAX(tt.input); err == nil {
+				t.Fatalf("ParseAVAX(%q) expected error", tt.input)
+			}
+		})
+	}
+}
+
+func TestParseAVAX_RoundTrip(t *testing.T) {
+	for _, nAVAX := range []uint64{0, 1, 999_999_999, 1_000_000_000, 123_456_789_012, math.MaxUint64 - 1} {
+		// FormatAVAX groups thousands, which ParseAVAX intentionally rejects.
+		stripped := strings.ReplaceAll(FormatAVAX(nAVAX), ",", "")
+		got, err := ParseAVAX(stripped)
+		if err != nil {
+			t.Fatalf("ParseAVAX(%q) returned error: %v", stripped, err)
+		}
+		if got != nAVAX {
+			t.Errorf("ParseAVAX(FormatAVAX(%d)) = %d", nAVAX, got)
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{input: "1.5", want: 1_500_000_000},
+		{input: "1.5avax", want: 1_500_000_000},
+		{input: "1.5 AVAX", want: 1_500_000_000},
+		{input: "0.000000001avax", want: 1},
+		{input: "1500000000navax", want: 1_500_000_000},
+		{input: "1500000000 nAVAX", want: 1_500_000_000},
+		{input: "1500000000n", want: 1_500_000_000},
+		{input: "18446744073709551615navax", want: math.MaxUint64},
+		{input: "18446744073.709551615avax", want: math.MaxUint64},
+
+		{input: "18446744073709551616navax", wantErr: true},
+		{input: "18446744074avax", wantErr: true},
+		{input: "1.5navax", wantErr: true},
+		{input: "0.0000000001avax", wantErr: true},
+		{input: "5n avax", wantErr: true},
+		{input: "avax", wantErr: true},
+		{input: "navax", wantErr: true},
+		{input: "1.5eth", wantErr: true},
+		{input: "1.5 avaxx", wantErr: true},
+		{input: "1.5mavax", wantErr: true},
+		{input: "-1avax", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAmount(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
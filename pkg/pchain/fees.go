@@ -0,0 +1,114 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// ErrDynamicFeesUnavailable is returned when a node does not serve the
+// dynamic fee RPCs (platform.getFeeConfig / platform.getFeeState), e.g. a
+// pre-Etna node or a network still on static fees.
+var ErrDynamicFeesUnavailable = errors.New("node does not expose dynamic fee state")
+
+// FeeStateClient reads the P-Chain dynamic fee config and state.
+// platformvm.Client satisfies it.
+type FeeStateClient interface {
+	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
+	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+}
+
+// FeeState is a snapshot of the P-Chain dynamic fee mechanism (ACP-103).
+// Gas prices are in nAVAX per unit of gas; BaseTxFee is in nAVAX.
+type FeeState struct {
+	Price           gas.Price `json:"price"`
+	MinPrice        gas.Price `json:"minPrice"`
+	Capacity        gas.Gas   `json:"capacity"`
+	Excess          gas.Gas   `json:"excess"`
+	TargetPerSecond gas.Gas   `json:"targetPerSecond"`
+	MaxPerSecond    gas.Gas   `json:"maxPerSecond"`
+	Time            time.Time `json:"time"`
+	// BaseTxFee estimates the fee of a simple send (one input, a payment
+	// output and a change output) at Price.
+	BaseTxFee uint64 `json:"baseTxFee"`
+}
+
+// GetFeeState fetches the current dynamic fee state and estimates the fee of
+// a base tx at the current gas price. Nodes that do not serve dynamic fees
+// yield an error wrapping ErrDynamicFeesUnavailable.
+func GetFeeState(ctx context.Context, client FeeStateClient) (FeeState, error) {
+	config, err := client.GetFeeConfig(ctx)
+	if err != nil {
+		return FeeState{}, wrapFeeRPCError("fee config", err)
+	}
+	state, price, at, err := client.GetFeeState(ctx)
+	if err != nil {
+		return FeeState{}, wrapFeeRPCError("fee state", err)
+	}
+
+	baseTxFee, err := estimateBaseTxFee(config.Weights, price)
+	if err != nil {
+		return FeeState{}, fmt.Errorf("failed to estimate base tx fee: %w", err)
+	}
+
+	return FeeState{
+		Price:           price,
+		MinPrice:        config.MinPrice,
+		Capacity:        state.Capacity,
+		Excess:          state.Excess,
+		TargetPerSecond: config.TargetPerSecond,
+		MaxPerSecond:    config.MaxPerSecond,
+		Time:            at,
+		BaseTxFee:       baseTxFee,
+	}, nil
+}
+
+// wrapFeeRPCError maps "unknown method" replies to ErrDynamicFeesUnavailable.
+// The JSON-RPC layer only reports this as text, so the message is matched.
+func wrapFeeRPCError(what string, err error) error {
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "can't find method") || strings.Contains(msg, "method not found") || strings.Contains(msg, "does not exist") {
+		return fmt.Errorf("%w: %v", ErrDynamicFeesUnavailable, err)
+	}
+	return fmt.Errorf("failed to fetch %s: %w", what, err)
+}
+
+// estimateBaseTxFee prices a BaseTx spending one single-signature UTXO into a
+// payment output and a change output.
+func estimateBaseTxFee(weights gas.Dimensions, price gas.Price) (uint64, error) {
+	output := func() *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Out: &secp256k1fx.TransferOutput{
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.ShortEmpty},
+				},
+			},
+		}
+	}
+	utx := &txs.BaseTx{}
+	utx.Ins = []*avax.TransferableInput{{
+		In: &secp256k1fx.TransferInput{Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+	}}
+	utx.Outs = []*avax.TransferableOutput{output(), output()}
+
+	complexity, err := fee.TxComplexity(utx)
+	if err != nil {
+		return 0, err
+	}
+	gasUsed, err := complexity.ToGas(weights)
+	if err != nil {
+		return 0, err
+	}
+	return gasUsed.Cost(price)
+}
@@ -0,0 +1,100 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+)
+
+// stubFeeStateClient implements FeeStateClient.
+type stubFeeStateClient struct {
+	config    gas.Config
+	state     gas.State
+	price     gas.Price
+	time      time.Time
+	configErr error
+	stateErr  error
+}
+
+func (s *stubFeeStateClient) GetFeeConfig(context.Context, ...rpc.Option) (*gas.Config, error) {
+	return &s.config, s.configErr
+}
+
+func (s *stubFeeStateClient) GetFeeState(context.Context, ...rpc.Option) (gas.State, gas.Price, time.Time, error) {
+	return s.state, s.price, s.time, s.stateErr
+}
+
+func TestGetFeeState(t *testing.T) {
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	client := &stubFeeStateClient{
+		config: gas.Config{
+			Weights:         gas.Dimensions{gas.Bandwidth: 1, gas.DBRead: 1000, gas.DBWrite: 1000, gas.Compute: 4},
+			MaxPerSecond:    1_000_000,
+			TargetPerSecond: 500_000,
+			MinPrice:        1,
+		},
+		state: gas.State{Capacity: 900_000, Excess: 1_234},
+		price: 2,
+		time:  at,
+	}
+
+	got, err := GetFeeState(context.Background(), client)
+	if err != nil {
+		t.Fatalf("GetFeeState() error = %v", err)
+	}
+	if got.Price != 2 || got.MinPrice != 1 || got.Capacity != 900_000 || got.Excess != 1_234 ||
+		got.TargetPerSecond != 500_000 || got.MaxPerSecond != 1_000_000 || !got.Time.Equal(at) {
+		t.Fatalf("GetFeeState() = %+v, want fields copied from config and state", got)
+	}
+	if got.BaseTxFee == 0 {
+		t.Fatal("GetFeeState() BaseTxFee = 0, want a positive estimate")
+	}
+
+	// The estimate scales linearly with the gas price.
+	client.price = 4
+	doubled, err := GetFeeState(context.Background(), client)
+	if err != nil {
+		t.Fatalf("GetFeeState() error = %v", err)
+	}
+	if doubled.BaseTxFee != 2*got.BaseTxFee {
+		t.Fatalf("BaseTxFee at 2x price = %d, want %d", doubled.BaseTxFee, 2*got.BaseTxFee)
+	}
+}
+
+func TestGetFeeStateErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		client          *stubFeeStateClient
+		wantUnavailable bool
+	}{
+		{
+			name:            "config method missing",
+			client:          &stubFeeStateClient{configErr: errors.New(`rpc: can't find method "platform.getFeeConfig"`)},
+			wantUnavailable: true,
+		},
+		{
+			name:            "state method missing",
+			client:          &stubFeeStateClient{stateErr: errors.New("the method platform.getFeeState does not exist/is not available")},
+			wantUnavailable: true,
+		},
+		{
+			name:   "transport error",
+			client: &stubFeeStateClient{stateErr: errors.New("connection refused")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetFeeState(context.Background(), tt.client)
+			if err == nil {
+				t.Fatal("GetFeeState() expected error")
+			}
+			if got := errors.Is(err, ErrDynamicFeesUnavailable); got != tt.wantUnavailable {
+				t.Fatalf("errors.Is(err, ErrDynamicFeesUnavailable) = %v, want %v (err: %v)", got, tt.wantUnavailable, err)
+			}
+		})
+	}
+}
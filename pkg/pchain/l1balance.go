@@ -0,0 +1,142 @@
+package pchain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// validatorFeeGetter reads the continuous L1 validator fee.
+// platformvm.Client satisfies it.
+type validatorFeeGetter interface {
+	GetValidatorFeeState(ctx context.Context, options ...rpc.Option) (gas.Gas, gas.Price, time.Time, error)
+}
+
+// l1BalanceClient reads an L1's validators and the continuous validator fee.
+// platformvm.Client satisfies it.
+type l1BalanceClient interface {
+	currentValidatorsGetter
+	validatorFeeGetter
+}
+
+// L1ValidatorBalance is an L1 validator's remaining balance for the
+// continuous validator fee. SecondsLeft and DepletesAt are nil when the fee
+// is currently zero.
+type L1ValidatorBalance struct {
+	NodeID       ids.NodeID `json:"nodeID"`
+	ValidationID ids.ID     `json:"validationID"`
+	Weight       uint64     `json:"weight"`
+	Balance      uint64     `json:"balance"` // nAVAX
+	SecondsLeft  *uint64    `json:"secondsLeft,omitempty"`
+	DepletesAt   *time.Time `json:"depletesAt,omitempty"`
+}
+
+// L1BalanceReport lists an L1's validator balances, soonest to deplete first.
+// FeePerSecond is the continuous fee, in nAVAX per second per validator, as
+// of Time.
+type L1BalanceReport struct {
+	SubnetID     ids.ID               `json:"subnetID"`
+	FeePerSecond uint64               `json:"feePerSecond"`
+	Time         time.Time            `json:"time"`
+	Validators   []L1ValidatorBalance `json:"validators"`
+}
+
+// GetL1ValidatorBalances returns the balance of each current validator of the
+// L1 subnetID with an estimate of when it runs out. The estimate holds the
+// current fee constant; the fee rises as more validators join the network,
+// so balances can deplete sooner.
+func GetL1ValidatorBalances(ctx context.Context, rpcURL string, subnetID ids.ID) (L1BalanceReport, error) {
+	return GetL1ValidatorBalancesWithConfig(ctx, network.Config{RPCURL: rpcURL}, subnetID)
+}
+
+// GetL1ValidatorBalancesWithConfig is GetL1ValidatorBalances for the node of
+// config.
+func GetL1ValidatorBalancesWithConfig(ctx context.Context, config network.Config, subnetID ids.ID) (L1BalanceReport, error) {
+	return getL1ValidatorBalances(ctx, network.NewPChainClient(ctx, config), subnetID)
+}
+
+func getL1ValidatorBalances(ctx context.Context, client l1BalanceClient, subnetID ids.ID) (L1BalanceReport, error) {
+	report := L1BalanceReport{SubnetID: subnetID, Validators: []L1ValidatorBalance{}}
+
+	validators, err := client.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch current validators: %w", err)
+	}
+	_, price, at, err := client.GetValidatorFeeState(ctx)
+	if err != nil {
+		return report, wrapFeeRPCError("validator fee state", err)
+	}
+	report.FeePerSecond, report.Time = uint64(price), at
+
+	for _, v := range validators {
+		// Validators of a subnet that was never converted have no
+		// validation ID and pay no continuous fee.
+		if v.ValidationID == nil {
+			continue
+		}
+		balance := L1ValidatorBalance{
+			NodeID:       v.NodeID,
+			ValidationID: *v.ValidationID,
+			Weight:       v.Weight,
+		}
+		if v.Balance != nil {
+			balance.Balance = *v.Balance
+		}
+		if price > 0 {
+			seconds := balance.Balance / uint64(price)
+			depletes := at.Add(secondsToDuration(seconds))
+			balance.SecondsLeft, balance.DepletesAt = &seconds, &depletes
+		}
+		report.Validators = append(report.Validators, balance)
+	}
+	if len(validators) > 0 && len(report.Validators) == 0 {
+		return report, fmt.Errorf("subnet %s has validators but none are L1 validators; convert it to an L1 first", subnetID)
+	}
+
+	// Every validator pays the same fee, so the smallest balance runs out
+	// first.
+	sort.Slice(report.Validators, func(i, j int) bool {
+		a, b := report.Validators[i], report.Validators[j]
+		if a.Balance != b.Balance {
+			return a.Balance < b.Balance
+		}
+		return a.NodeID.Compare(b.NodeID) < 0
+	})
+	return report, nil
+}
+
+// GetValidatorFeePerSecond returns the continuous fee each L1 validator
+// currently pays, in nAVAX per second.
+func GetValidatorFeePerSecond(ctx context.Context, rpcURL string) (uint64, error) {
+	return GetValidatorFeePerSecondWithConfig(ctx, network.Config{RPCURL: rpcURL})
+}
+
+// GetValidatorFeePerSecondWithConfig is GetValidatorFeePerSecond for the node
+// of config.
+func GetValidatorFeePerSecondWithConfig(ctx context.Context, config network.Config) (uint64, error) {
+	return getValidatorFeePerSecond(ctx, network.NewPChainClient(ctx, config))
+}
+
+func getValidatorFeePerSecond(ctx context.Context, client validatorFeeGetter) (uint64, error) {
+	_, price, _, err := client.GetValidatorFeeState(ctx)
+	if err != nil {
+		return 0, wrapFeeRPCError("validator fee state", err)
+	}
+	return uint64(price), nil
+}
+
+// secondsToDuration converts seconds to a Duration, saturating rather than
+// overflowing.
+func secondsToDuration(seconds uint64) time.Duration {
+	if seconds > uint64(math.MaxInt64/int64(time.Second)) {
+		return math.MaxInt64
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,136 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// stubL1BalanceClient implements l1BalanceClient.
+type stubL1BalanceClient struct {
+	stubCurrentValidatorsGetter
+	price  gas.Price
+	time   time.Time
+	feeErr error
+}
+
+func (s *stubL1BalanceClient) GetValidatorFeeState(context.Context, ...rpc.Option) (gas.Gas, gas.Price, time.Time, error) {
+	return 0, s.price, s.time, s.feeErr
+}
+
+func l1Validator(nodeID byte, balance uint64) platformvm.ClientPermissionlessValidator {
+	validationID := ids.ID{nodeID}
+	return platformvm.ClientPermissionlessValidator{
+		ClientStaker:      platformvm.ClientStaker{NodeID: ids.NodeID{nodeID}, Weight: 100},
+		ClientL1Validator: platformvm.ClientL1Validator{ValidationID: &validationID, Balance: &balance},
+	}
+}
+
+func TestGetL1ValidatorBalances(t *testing.T) {
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	subnetID := ids.GenerateTestID()
+	client := &stubL1BalanceClient{
+		stubCurrentValidatorsGetter: stubCurrentValidatorsGetter{validators: []platformvm.ClientPermissionlessValidator{
+			l1Validator(0x01, 86_400_000),
+			l1Validator(0x02, 3_600_000),
+			l1Validator(0x03, 0),
+		}},
+		price: 1_000,
+		time:  at,
+	}
+
+	got, err := getL1ValidatorBalances(context.Background(), client, subnetID)
+	if err != nil {
+		t.Fatalf("getL1ValidatorBalances() error = %v", err)
+	}
+	if client.gotSubnetID != subnetID {
+		t.Errorf("queried subnet %s, want %s", client.gotSubnetID, subnetID)
+	}
+	if got.FeePerSecond != 1_000 || !got.Time.Equal(at) {
+		t.Errorf("fee = %d at %s, want 1000 at %s", got.FeePerSecond, got.Time, at)
+	}
+
+	want := []struct {
+		nodeID  ids.NodeID
+		seconds uint64
+	}{
+		{ids.NodeID{0x03}, 0},
+		{ids.NodeID{0x02}, 3_600},
+		{ids.NodeID{0x01}, 86_400},
+	}
+	if len(got.Validators) != len(want) {
+		t.Fatalf("got %d validators, want %d", len(got.Validators), len(want))
+	}
+	for i, w := range want {
+		v := got.Validators[i]
+		if v.NodeID != w.nodeID || v.SecondsLeft == nil || *v.SecondsLeft != w.seconds {
+			t.Errorf("validator %d = %+v, want node %s with %d seconds left", i, v, w.nodeID, w.seconds)
+			continue
+		}
+		if wantAt := at.Add(time.Duration(w.seconds) * time.Second); !v.DepletesAt.Equal(wantAt) {
+			t.Errorf("validator %d depletes at %s, want %s", i, v.DepletesAt, wantAt)
+		}
+	}
+}
+
+func TestGetL1ValidatorBalancesZeroFee(t *testing.T) {
+	client := &stubL1BalanceClient{stubCurrentValidatorsGetter: stubCurrentValidatorsGetter{
+		validators: []platformvm.ClientPermissionlessValidator{l1Validator(0x01, 1_000)},
+	}}
+	got, err := getL1ValidatorBalances(context.Background(), client, ids.GenerateTestID())
+	if err != nil {
+		t.Fatalf("getL1ValidatorBalances() error = %v", err)
+	}
+	if v := got.Validators[0]; v.SecondsLeft != nil || v.DepletesAt != nil {
+		t.Errorf("with no fee, validator = %+v, want no depletion estimate", v)
+	}
+}
+
+func TestGetL1ValidatorBalancesErrors(t *testing.T) {
+	legacy := platformvm.ClientPermissionlessValidator{ClientStaker: platformvm.ClientStaker{NodeID: ids.NodeID{0x01}}}
+	tests := []struct {
+		name   string
+		client *stubL1BalanceClient
+	}{
+		{
+			name:   "validators error",
+			client: &stubL1BalanceClient{stubCurrentValidatorsGetter: stubCurrentValidatorsGetter{err: errors.New("boom")}},
+		},
+		{
+			name:   "fee state error",
+			client: &stubL1BalanceClient{feeErr: errors.New("boom")},
+		},
+		{
+			name: "not an L1",
+			client: &stubL1BalanceClient{
+				stubCurrentValidatorsGetter: stubCurrentValidatorsGetter{validators: []platformvm.ClientPermissionlessValidator{legacy}},
+				price:                       1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := getL1ValidatorBalances(context.Background(), tt.client, ids.GenerateTestID()); err == nil {
+				t.Fatal("getL1ValidatorBalances() expected error")
+			}
+		})
+	}
+}
+
+func TestGetValidatorFeePerSecond(t *testing.T) {
+	got, err := getValidatorFeePerSecond(context.Background(), &stubL1BalanceClient{price: 512})
+	if err != nil || got != 512 {
+		t.Fatalf("getValidatorFeePerSecond() = %d, %v; want 512, nil", got, err)
+	}
+
+	_, err = getValidatorFeePerSecond(context.Background(), &stubL1BalanceClient{feeErr: errors.New("method not found")})
+	if !errors.Is(err, ErrDynamicFeesUnavailable) {
+		t.Fatalf("getValidatorFeePerSecond() error = %v, want ErrDynamicFeesUnavailable", err)
+	}
+}
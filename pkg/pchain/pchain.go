@@ -3,19 +3,29 @@ package pchain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
 	"github.com/ava-labs/platform-cli/pkg/wallet"
 )
 
@@ -33,6 +43,11 @@ type baseTxIssuer interface {
 	IssueBaseTx(outputs []*avax.TransferableOutput, options ...common.Option) (*txs.Tx, error)
 }
 
+// baseTxBuilder builds an unsigned P-Chain BaseTx without issuing it.
+type baseTxBuilder interface {
+	NewBaseTx(outputs []*avax.TransferableOutput, options ...common.Option) (*txs.BaseTx, error)
+}
+
 // exportTxIssuer issues a P-Chain ExportTx.
 type exportTxIssuer interface {
 	IssueExportTx(chainID ids.ID, outputs []*avax.TransferableOutput, options ...common.Option) (*txs.Tx, error)
@@ -94,29 +109,93 @@ type createChainTxIssuer interface {
 
 // Send sends AVAX on the P-Chain (IssueBaseTx).
 func Send(ctx context.Context, w *wallet.Wallet, to ids.ShortID, amountNAVAX uint64) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
 	return issueSendTx(w.PWallet(), avaxAssetID, to, amountNAVAX, common.WithContext(ctx))
 }
 
 // Export exports AVAX from P-Chain to another chain (IssueExportTx).
 func Export(ctx context.Context, w *wallet.Wallet, destChainID ids.ID, amountNAVAX uint64) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
 	return issueExportTx(w.PWallet(), destChainID, avaxAssetID, w.PChainAddress(), amountNAVAX, common.WithContext(ctx))
 }
 
 // Import imports AVAX to P-Chain from another chain (IssueImportTx).
 func Import(ctx context.Context, w *wallet.Wallet, sourceChainID ids.ID) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueImportTx(w.PWallet(), sourceChainID, w.PChainAddress(), common.WithContext(ctx))
 }
 
-func issueSendTx(
-	issuer baseTxIssuer,
-	avaxAssetID ids.ID,
-	to ids.ShortID,
-	amountNAVAX uint64,
-	options ...common.Option,
-) (ids.ID, error) {
-	tx, err := issuer.IssueBaseTx([]*avax.TransferableOutput{{
+// EstimateSendFee returns the fee, in nAVAX, that Send would currently pay to
+// transfer amountNAVAX to the given address. The transaction is built from the
+// wallet's UTXOs but neither signed nor issued.
+func EstimateSendFee(ctx context.Context, w *wallet.Wallet, to ids.ShortID, amountNAVAX uint64) (uint64, error) {
+	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
+	return estimateSendFee(w.PWallet().Builder(), avaxAssetID, to, amountNAVAX, common.WithContext(ctx))
+}
+
+// maxSendFeeRounds bounds how many times SendAmountAfterFee re-estimates the
+// fee while settling the amount sent.
+const maxSendFeeRounds = 8
+
+// ErrFeeExceedsAmount is returned by SendAmountAfterFee when the fee alone
+// would use up the whole amount.
+var ErrFeeExceedsAmount = errors.New("fee exceeds amount")
+
+// SendAmountAfterFee returns the largest amount, in nAVAX, that Send can
+// transfer to the given address while spending no more than totalNAVAX
+// including the fee, along with the estimated fee for that transfer.
+func SendAmountAfterFee(ctx context.Context, w *wallet.Wallet, to ids.ShortID, totalNAVAX uint64) (amount uint64, fee uint64, err error) {
+	builder := w.PWallet().Builder()
+	avaxAssetID := builder.Context().AVAXAssetID
+	return sendAmountAfterFee(totalNAVAX, func(amount uint64) (uint64, error) {
+		return estimateSendFee(builder, avaxAssetID, to, amount, common.WithContext(ctx))
+	})
+}
+
+// sendAmountAfterFee settles amount + feeFor(amount) <= total. The fee can
+// grow with the amount when more UTXOs are needed to cover it, so the estimate
+// is repeated with each new fee until it stops changing.
+func sendAmountAfterFee(total uint64, feeFor func(amount uint64) (uint64, error)) (uint64, uint64, error) {
+	fee, err := feeFor(1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var best, bestFee uint64
+	for range maxSendFeeRounds {
+		if fee >= total {
+			break
+		}
+		amount := total - fee
+		got, err := feeFor(amount)
+		if err != nil {
+			return 0, 0, err
+		}
+		if got <= fee && amount > best {
+			best, bestFee = amount, got
+		}
+		if got == fee {
+			break
+		}
+		fee = got
+	}
+	if best == 0 {
+		return 0, 0, fmt.Errorf("%w: a fee of %s AVAX leaves nothing of %s AVAX to send",
+			ErrFeeExceedsAmount, FormatAVAX(fee), FormatAVAX(total))
+	}
+	return best, bestFee, nil
+}
+
+func sendOutputs(avaxAssetID ids.ID, to ids.ShortID, amountNAVAX uint64) []*avax.TransferableOutput {
+	return []*avax.TransferableOutput{{
 		Asset: avax.Asset{ID: avaxAssetID},
 		Out: &secp256k1fx.TransferOutput{
 			Amt: amountNAVAX,
@@ -125,13 +204,63 @@ func issueSendTx(
 				Addrs:     []ids.ShortID{to},
 			},
 		},
-	}}, options...)
+	}}
+}
+
+func issueSendTx(
+	issuer baseTxIssuer,
+	avaxAssetID ids.ID,
+	to ids.ShortID,
+	amountNAVAX uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	tx, err := issuer.IssueBaseTx(sendOutputs(avaxAssetID, to, amountNAVAX), options...)
 	if err != nil {
 		return ids.Empty, fmt.Errorf("failed to issue BaseTx: %w", err)
 	}
 	return tx.ID(), nil
 }
 
+func estimateSendFee(
+	builder baseTxBuilder,
+	avaxAssetID ids.ID,
+	to ids.ShortID,
+	amountNAVAX uint64,
+	options ...common.Option,
+) (uint64, error) {
+	return estimateOutputsFee(builder, avaxAssetID, sendOutputs(avaxAssetID, to, amountNAVAX), options...)
+}
+
+// estimateOutputsFee builds, without signing, a BaseTx paying outputs and
+// returns the AVAX it burns.
+func estimateOutputsFee(
+	builder baseTxBuilder,
+	avaxAssetID ids.ID,
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
+) (uint64, error) {
+	utx, err := builder.NewBaseTx(outputs, options...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build BaseTx: %w", err)
+	}
+
+	var consumed, produced uint64
+	for _, in := range utx.Ins {
+		if in.AssetID() == avaxAssetID {
+			consumed += in.In.Amount()
+		}
+	}
+	for _, out := range utx.Outs {
+		if out.AssetID() == avaxAssetID {
+			produced += out.Out.Amount()
+		}
+	}
+	if produced > consumed {
+		return 0, fmt.Errorf("built BaseTx produces more AVAX (%d) than it consumes (%d)", produced, consumed)
+	}
+	return consumed - produced, nil
+}
+
 func issueExportTx(
 	issuer exportTxIssuer,
 	destChainID ids.ID,
@@ -180,6 +309,52 @@ func issueImportTx(
 // Primary Network Staking
 // =============================================================================
 
+var (
+	// ErrStakeTooSmall is returned when a stake is below the network minimum.
+	ErrStakeTooSmall = errors.New("stake amount too small")
+	// ErrStakeTooLarge is returned when a stake exceeds the maximum validator
+	// weight.
+	ErrStakeTooLarge = errors.New("stake amount too large")
+	// ErrMissingBLSSigner is returned when a primary network validator is
+	// added without a BLS proof of possession.
+	ErrMissingBLSSigner = errors.New("BLS proof of possession is required for primary network validators")
+)
+
+// ValidateValidatorStake checks a primary network validator stake (in nAVAX)
+// against config's bounds, so out-of-range stakes fail before a tx is built
+// rather than being rejected by the network after fees are computed.
+func ValidateValidatorStake(config network.Config, stake uint64) error {
+	return checkStakeBounds(config, "validator", stake, config.MinValidatorStake)
+}
+
+// ValidateDelegatorStake checks a primary network delegation (in nAVAX)
+// against config's bounds. A delegation can never exceed the maximum validator
+// weight; the remaining capacity of a specific validator is not checked.
+func ValidateDelegatorStake(config network.Config, stake uint64) error {
+	return checkStakeBounds(config, "delegator", stake, config.MinDelegatorStake)
+}
+
+// requireBLSSigner rejects a nil proof of possession before it is passed to
+// the wallet as a non-nil signer.Signer interface.
+func requireBLSSigner(pop *signer.ProofOfPossession) error {
+	if pop == nil {
+		return ErrMissingBLSSigner
+	}
+	return nil
+}
+
+func checkStakeBounds(config network.Config, role string, stake, minStake uint64) error {
+	if stake < minStake {
+		return fmt.Errorf("%w: %s stake of %s AVAX is below the %s minimum of %s AVAX",
+			ErrStakeTooSmall, role, FormatAVAX(stake), config.Name, FormatAVAX(minStake))
+	}
+	if config.MaxValidatorStake != 0 && stake > config.MaxValidatorStake {
+		return fmt.Errorf("%w: %s stake of %s AVAX is above the %s maximum of %s AVAX",
+			ErrStakeTooLarge, role, FormatAVAX(stake), config.Name, FormatAVAX(config.MaxValidatorStake))
+	}
+	return nil
+}
+
 // AddValidatorConfig holds configuration for adding a primary network validator.
 type AddValidatorConfig struct {
 	NodeID        ids.NodeID
@@ -191,9 +366,17 @@ type AddValidatorConfig struct {
 }
 
 // AddValidator adds a validator to the primary network (IssueAddValidatorTx).
-// NOTE: This uses the legacy AddValidatorTx which is deprecated post-Etna.
-// Use AddPermissionlessValidator for post-Etna networks.
+// It is kept for historical/replay use only; the CLI never calls it.
+//
+// Deprecated: AddValidatorTx is rejected post-Etna. Use
+// AddPermissionlessValidator.
 func AddValidator(ctx context.Context, w *wallet.Wallet, cfg AddValidatorConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	if err := ValidateValidatorStake(w.Config(), cfg.StakeAmt); err != nil {
+		return ids.Empty, err
+	}
 	rewardsOwner := &secp256k1fx.OutputOwners{
 		Threshold: 1,
 		Addrs:     []ids.ShortID{cfg.RewardAddr},
@@ -230,6 +413,15 @@ type AddPermissionlessValidatorConfig struct {
 // AddPermissionlessValidator adds a permissionless validator to the primary network.
 // This is the post-Etna method for staking on the primary network.
 func AddPermissionlessValidator(ctx context.Context, w *wallet.Wallet, cfg AddPermissionlessValidatorConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	if err := requireBLSSigner(cfg.BLSSigner); err != nil {
+		return ids.Empty, err
+	}
+	if err := ValidateValidatorStake(w.Config(), cfg.StakeAmt); err != nil {
+		return ids.Empty, err
+	}
 	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
 	return issueAddPermissionlessValidatorTx(
 		w.PWallet(),
@@ -292,6 +484,15 @@ type AddAutoRenewedValidatorConfig struct {
 
 // AddAutoRenewedValidator adds an auto-renewed validator to the primary network.
 func AddAutoRenewedValidator(ctx context.Context, w *wallet.Wallet, cfg AddAutoRenewedValidatorConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	if err := requireBLSSigner(cfg.BLSSigner); err != nil {
+		return ids.Empty, err
+	}
+	if err := ValidateValidatorStake(w.Config(), cfg.StakeAmt); err != nil {
+		return ids.Empty, err
+	}
 	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
 	return issueAddAutoRenewedValidatorTx(w.PWallet(), avaxAssetID, cfg, common.WithContext(ctx))
 }
@@ -351,6 +552,9 @@ type SetAutoRenewedValidatorConfigTxConfig struct {
 // builder resolves the authorizing owner from the wallet backend's owners map
 // (builder.authorize -> backend.GetOwner) rather than from chain state.
 func SetAutoRenewedValidatorConfig(ctx context.Context, w *wallet.Wallet, cfg SetAutoRenewedValidatorConfigTxConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueSetAutoRenewedValidatorConfigTx(w.PWallet(), cfg, common.WithContext(ctx))
 }
 
@@ -384,7 +588,13 @@ func issueSetAutoRenewedValidatorConfigTx(
 // fetch. The typed client does not yet surface validatorAuthority, so the
 // reply is decoded with purpose-built structs.
 func GetAutoRenewedValidatorAuthority(ctx context.Context, rpcURL string, nodeID ids.NodeID, txID ids.ID) (*secp256k1fx.OutputOwners, error) {
-	client := platformvm.NewClient(rpcURL)
+	return GetAutoRenewedValidatorAuthorityWithConfig(ctx, network.Config{RPCURL: rpcURL}, nodeID, txID)
+}
+
+// GetAutoRenewedValidatorAuthorityWithConfig is
+// GetAutoRenewedValidatorAuthority for the node of config.
+func GetAutoRenewedValidatorAuthorityWithConfig(ctx context.Context, config network.Config, nodeID ids.NodeID, txID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	client := network.NewPChainClient(ctx, config)
 	args := &platformvm.GetCurrentValidatorsArgs{}
 	if nodeID != ids.EmptyNodeID {
 		args.NodeIDs = []ids.NodeID{nodeID}
@@ -464,9 +674,17 @@ type AddDelegatorConfig struct {
 }
 
 // AddDelegator adds a delegator to the primary network (IssueAddDelegatorTx).
-// NOTE: This uses the legacy AddDelegatorTx which is deprecated post-Etna.
-// Use AddPermissionlessDelegator for post-Etna networks.
+// It is kept for historical/replay use only; the CLI never calls it.
+//
+// Deprecated: AddDelegatorTx is rejected post-Etna. Use
+// AddPermissionlessDelegator.
 func AddDelegator(ctx context.Context, w *wallet.Wallet, cfg AddDelegatorConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	if err := ValidateDelegatorStake(w.Config(), cfg.StakeAmt); err != nil {
+		return ids.Empty, err
+	}
 	rewardsOwner := &secp256k1fx.OutputOwners{
 		Threshold: 1,
 		Addrs:     []ids.ShortID{cfg.RewardAddr},
@@ -500,6 +718,12 @@ type AddPermissionlessDelegatorConfig struct {
 // AddPermissionlessDelegator adds a permissionless delegator to the primary network.
 // This is the post-Etna method for delegating on the primary network.
 func AddPermissionlessDelegator(ctx context.Context, w *wallet.Wallet, cfg AddPermissionlessDelegatorConfig) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	if err := ValidateDelegatorStake(w.Config(), cfg.StakeAmt); err != nil {
+		return ids.Empty, err
+	}
 	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
 	return issueAddPermissionlessDelegatorTx(
 		w.PWallet(),
@@ -540,23 +764,233 @@ func issueAddPermissionlessDelegatorTx(
 	return tx.ID(), nil
 }
 
+// =============================================================================
+// Validator Queries
+// =============================================================================
+
+// ValidatorSummary is a condensed view of a current validator.
+type ValidatorSummary struct {
+	NodeID         ids.NodeID `json:"nodeID"`
+	TxID           ids.ID     `json:"txID"`
+	Weight         uint64     `json:"weight"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        time.Time  `json:"endTime"`
+	DelegationFee  float32    `json:"delegationFee"`
+	DelegatorCount uint64     `json:"delegatorCount"`
+}
+
+// currentValidatorsGetter fetches the current validator set of a subnet.
+// platformvm.Client satisfies it.
+type currentValidatorsGetter interface {
+	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]platformvm.ClientPermissionlessValidator, error)
+}
+
+// GetCurrentValidators returns the current validators of subnetID (use
+// constants.PrimaryNetworkID for the primary network), sorted by node ID and
+// then tx ID so that repeated calls page deterministically.
+func GetCurrentValidators(ctx context.Context, rpcURL string, subnetID ids.ID) ([]ValidatorSummary, error) {
+	return GetCurrentValidatorsWithConfig(ctx, network.Config{RPCURL: rpcURL}, subnetID)
+}
+
+// GetCurrentValidatorsWithConfig is GetCurrentValidators for the node of
+// config.
+func GetCurrentValidatorsWithConfig(ctx context.Context, config network.Config, subnetID ids.ID) ([]ValidatorSummary, error) {
+	return getCurrentValidators(ctx, network.NewPChainClient(ctx, config), subnetID)
+}
+
+func getCurrentValidators(ctx context.Context, client currentValidatorsGetter, subnetID ids.ID) ([]ValidatorSummary, error) {
+	validators, err := client.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current validators: %w", err)
+	}
+
+	summaries := make([]ValidatorSummary, 0, len(validators))
+	for _, v := range validators {
+		summary := ValidatorSummary{
+			NodeID:        v.NodeID,
+			TxID:          v.TxID,
+			Weight:        v.Weight,
+			StartTime:     time.Unix(int64(v.StartTime), 0).UTC(),
+			EndTime:       time.Unix(int64(v.EndTime), 0).UTC(),
+			DelegationFee: v.DelegationFee,
+		}
+		if v.DelegatorCount != nil {
+			summary.DelegatorCount = *v.DelegatorCount
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if c := summaries[i].NodeID.Compare(summaries[j].NodeID); c != 0 {
+			return c < 0
+		}
+		return summaries[i].TxID.Compare(summaries[j].TxID) < 0
+	})
+	return summaries, nil
+}
+
+// Stake kinds reported by GetStakesRewardedTo.
+const (
+	StakeValidator = "validator"
+	StakeDelegator = "delegator"
+)
+
+// Stake is a primary network validation or delegation whose rewards go to a
+// given address.
+type Stake struct {
+	Kind      string     `json:"kind"`
+	NodeID    ids.NodeID `json:"nodeID"`
+	TxID      ids.ID     `json:"txID"`
+	Weight    uint64     `json:"weight"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   time.Time  `json:"endTime"`
+}
+
+// GetStakesRewardedTo returns the current primary network validators whose
+// validation rewards go to addr, and the delegations to nodeIDs whose rewards
+// go to addr, sorted by end time. The P-Chain lists delegators only for
+// validators requested by node ID, so delegations to other nodes are not
+// found. Validators are searched on every node, whatever nodeIDs holds.
+//
+// There is no pending set to query: since Durango a staker starts when its
+// transaction is accepted, so every accepted stake is current.
+func GetStakesRewardedTo(ctx context.Context, rpcURL string, addr ids.ShortID, nodeIDs []ids.NodeID) ([]Stake, error) {
+	return GetStakesRewardedToWithConfig(ctx, network.Config{RPCURL: rpcURL}, addr, nodeIDs)
+}
+
+// GetStakesRewardedToWithConfig is GetStakesRewardedTo for the node of config.
+func GetStakesRewardedToWithConfig(ctx context.Context, config network.Config, addr ids.ShortID, nodeIDs []ids.NodeID) ([]Stake, error) {
+	return getStakesRewardedTo(ctx, network.NewPChainClient(ctx, config), addr, nodeIDs)
+}
+
+func getStakesRewardedTo(ctx context.Context, client currentValidatorsGetter, addr ids.ShortID, nodeIDs []ids.NodeID) ([]Stake, error) {
+	// Filtering by nodeIDs would hide the validators on other nodes, so the
+	// full set is fetched for them and nodeIDs only for their delegators.
+	validators, err := client.GetCurrentValidators(ctx, constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current validators: %w", err)
+	}
+	var delegated []platformvm.ClientPermissionlessValidator
+	if len(nodeIDs) > 0 {
+		delegated, err = client.GetCurrentValidators(ctx, constants.PrimaryNetworkID, nodeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch delegators: %w", err)
+		}
+	}
+
+	var stakes []Stake
+	seen := set.Set[ids.ID]{}
+	for _, v := range validators {
+		if ownerIncludes(v.ValidationRewardOwner, addr) {
+			stakes = append(stakes, newStake(StakeValidator, v.ClientStaker))
+		}
+	}
+	for _, v := range slices.Concat(validators, delegated) {
+		for _, d := range v.Delegators {
+			if ownerIncludes(d.RewardOwner, addr) && !seen.Contains(d.TxID) {
+				seen.Add(d.TxID)
+				stakes = append(stakes, newStake(StakeDelegator, d.ClientStaker))
+			}
+		}
+	}
+	sort.SliceStable(stakes, func(i, j int) bool {
+		return stakes[i].EndTime.Before(stakes[j].EndTime)
+	})
+	return stakes, nil
+}
+
+func newStake(kind string, s platformvm.ClientStaker) Stake {
+	return Stake{
+		Kind:      kind,
+		NodeID:    s.NodeID,
+		TxID:      s.TxID,
+		Weight:    s.Weight,
+		StartTime: time.Unix(int64(s.StartTime), 0).UTC(),
+		EndTime:   time.Unix(int64(s.EndTime), 0).UTC(),
+	}
+}
+
+func ownerIncludes(owner *platformvm.ClientOwner, addr ids.ShortID) bool {
+	return owner != nil && slices.Contains(owner.Addresses, addr)
+}
+
 // =============================================================================
 // Subnet Management
 // =============================================================================
 
-// CreateSubnet creates a new subnet (IssueCreateSubnetTx).
+// OutputOwnerSpec describes a threshold owner: any Threshold of Addrs must
+// sign to act for it.
+type OutputOwnerSpec struct {
+	Addrs     []ids.ShortID
+	Threshold uint32
+}
+
+// SingleOwner is the spec for addr alone, threshold 1.
+func SingleOwner(addr ids.ShortID) OutputOwnerSpec {
+	return OutputOwnerSpec{Addrs: []ids.ShortID{addr}, Threshold: 1}
+}
+
+// OutputOwners validates the spec and returns it as secp256k1fx owners, with
+// the addresses sorted as the P-Chain requires.
+func (s OutputOwnerSpec) OutputOwners() (*secp256k1fx.OutputOwners, error) {
+	if len(s.Addrs) == 0 {
+		return nil, fmt.Errorf("owner needs at least one address")
+	}
+	if s.Threshold == 0 || int(s.Threshold) > len(s.Addrs) {
+		return nil, fmt.Errorf("threshold must be between 1 and the number of owner addresses (%d), got %d", len(s.Addrs), s.Threshold)
+	}
+	addrs := slices.Clone(s.Addrs)
+	slices.SortFunc(addrs, ids.ShortID.Compare)
+	for i := 1; i < len(addrs); i++ {
+		if addrs[i] == addrs[i-1] {
+			return nil, fmt.Errorf("duplicate owner address %s", addrs[i])
+		}
+	}
+	return &secp256k1fx.OutputOwners{Threshold: s.Threshold, Addrs: addrs}, nil
+}
+
+// CreateSubnet creates a new subnet (IssueCreateSubnetTx) owned by the
+// wallet's own address.
 func CreateSubnet(ctx context.Context, w *wallet.Wallet) (ids.ID, error) {
-	return issueCreateSubnetTx(w.PWallet(), w.PChainAddress(), common.WithContext(ctx))
+	return CreateSubnetWithOwner(ctx, w, SingleOwner(w.PChainAddress()))
+}
+
+// CreateSubnetWithOwner creates a new subnet owned by owner, which may be
+// another address or a multisig.
+func CreateSubnetWithOwner(ctx context.Context, w *wallet.Wallet, owner OutputOwnerSpec) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	subnetID, err := issueCreateSubnetTx(w.PWallet(), owner, common.WithContext(ctx))
+	if err != nil {
+		return ids.Empty, err
+	}
+	w.RecordCreatedSubnet(subnetID)
+	return subnetID, nil
+}
+
+// ErrSubnetNotTracked is returned when a subnet-scoped operation is attempted
+// on a wallet that does not track the subnet, so the builder could not
+// resolve the subnet's owner for subnet auth.
+var ErrSubnetNotTracked = errors.New("wallet does not track subnet")
+
+// requireTrackedSubnet returns ErrSubnetNotTracked unless w can sign subnet
+// auth for subnetID.
+func requireTrackedSubnet(w *wallet.Wallet, subnetID ids.ID) error {
+	if w.TracksSubnet(subnetID) {
+		return nil
+	}
+	return fmt.Errorf("%w %s: load the wallet with wallet.NewWalletWithSubnet or call TrackSubnet first", ErrSubnetNotTracked, subnetID)
 }
 
 func issueCreateSubnetTx(
 	issuer createSubnetTxIssuer,
-	ownerAddr ids.ShortID,
+	ownerSpec OutputOwnerSpec,
 	options ...common.Option,
 ) (ids.ID, error) {
-	owner := &secp256k1fx.OutputOwners{
-		Threshold: 1,
-		Addrs:     []ids.ShortID{ownerAddr},
+	owner, err := ownerSpec.OutputOwners()
+	if err != nil {
+		return ids.Empty, fmt.Errorf("invalid subnet owner: %w", err)
 	}
 
 	tx, err := issuer.IssueCreateSubnetTx(owner, options...)
@@ -568,6 +1002,12 @@ func issueCreateSubnetTx(
 
 // TransferSubnetOwnership transfers subnet ownership (IssueTransferSubnetOwnershipTx).
 func TransferSubnetOwnership(ctx context.Context, w *wallet.Wallet, subnetID ids.ID, newOwner ids.ShortID) (ids.ID, error) {
+	if err := requireTrackedSubnet(w, subnetID); err != nil {
+		return ids.Empty, err
+	}
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueTransferSubnetOwnershipTx(w.PWallet(), subnetID, newOwner, common.WithContext(ctx))
 }
 
@@ -589,8 +1029,33 @@ func issueTransferSubnetOwnershipTx(
 	return tx.ID(), nil
 }
 
+// MaxTxSize is the largest transaction, in bytes, that nodes admit to their
+// mempool. Larger transactions are dropped before they reach a block.
+const MaxTxSize = mempool.MaxTxSize
+
+// convertTxOverhead approximates the bytes of a signed ConvertSubnetToL1Tx
+// outside its validator list: fee inputs, change, subnet auth and credentials.
+const convertTxOverhead = units.KiB
+
+// EstimateConvertSubnetToL1TxSize returns the approximate signed size, in
+// bytes, of a ConvertSubnetToL1Tx carrying validators. The validators are
+// sized exactly; the rest of the transaction is a fixed allowance.
+func EstimateConvertSubnetToL1TxSize(validators []*txs.ConvertSubnetToL1Validator) (int, error) {
+	size, err := txs.Codec.Size(txs.CodecVersion, validators)
+	if err != nil {
+		return 0, fmt.Errorf("failed to size validators: %w", err)
+	}
+	return size + convertTxOverhead, nil
+}
+
 // ConvertSubnetToL1 converts a subnet to L1 (IssueConvertSubnetToL1Tx).
 func ConvertSubnetToL1(ctx context.Context, w *wallet.Wallet, subnetID, chainID ids.ID, managerAddr []byte, validators []*txs.ConvertSubnetToL1Validator) (ids.ID, error) {
+	if err := requireTrackedSubnet(w, subnetID); err != nil {
+		return ids.Empty, err
+	}
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueConvertSubnetToL1Tx(w.PWallet(), subnetID, chainID, managerAddr, validators, common.WithContext(ctx))
 }
 
@@ -609,6 +1074,39 @@ func issueConvertSubnetToL1Tx(
 	return tx.ID(), nil
 }
 
+// L1ValidatorID pairs an L1 validator's node with its validation ID, the ID
+// that l1 increase-validator-balance and l1 disable-validator take.
+type L1ValidatorID struct {
+	NodeID       ids.NodeID `json:"nodeID"`
+	ValidationID ids.ID     `json:"validationID"`
+}
+
+// ComputeValidationID returns the validation ID the P-Chain assigns to the
+// validator at index in a ConvertSubnetToL1Tx for subnetID: the subnet ID
+// with the index appended. Validators registered later get the hash of their
+// RegisterL1Validator message instead (RegisterL1ValidatorInfo.ValidationID).
+func ComputeValidationID(subnetID ids.ID, index uint32) ids.ID {
+	return subnetID.Append(index)
+}
+
+// ConvertedValidationIDs returns the validation ID of each initial validator
+// of a ConvertSubnetToL1Tx, in node ID order. That is the order the
+// transaction lists them in, and so the index each ID is derived from.
+func ConvertedValidationIDs(subnetID ids.ID, validators []*txs.ConvertSubnetToL1Validator) ([]L1ValidatorID, error) {
+	sorted := slices.Clone(validators)
+	slices.SortFunc(sorted, (*txs.ConvertSubnetToL1Validator).Compare)
+
+	result := make([]L1ValidatorID, len(sorted))
+	for i, v := range sorted {
+		nodeID, err := ids.ToNodeID(v.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node ID for validator %d: %w", i, err)
+		}
+		result[i] = L1ValidatorID{NodeID: nodeID, ValidationID: ComputeValidationID(subnetID, uint32(i))}
+	}
+	return result, nil
+}
+
 // AddSubnetValidatorConfig holds configuration for adding a validator to a
 // permissioned subnet.
 type AddSubnetValidatorConfig struct {
@@ -624,6 +1122,12 @@ type AddSubnetValidatorConfig struct {
 // network, and the subnet owner authorizes the tx via subnet auth (resolved by
 // the wallet backend, so the wallet must track the subnet).
 func AddSubnetValidator(ctx context.Context, w *wallet.Wallet, cfg AddSubnetValidatorConfig) (ids.ID, error) {
+	if err := requireTrackedSubnet(w, cfg.SubnetID); err != nil {
+		return ids.Empty, err
+	}
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueAddSubnetValidatorTx(w.PWallet(), cfg, common.WithContext(ctx))
 }
 
@@ -656,6 +1160,9 @@ func issueAddSubnetValidatorTx(
 
 // RegisterL1Validator registers a new L1 validator (IssueRegisterL1ValidatorTx).
 func RegisterL1Validator(ctx context.Context, w *wallet.Wallet, balance uint64, pop [bls.SignatureLen]byte, message []byte) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	tx, err := w.PWallet().IssueRegisterL1ValidatorTx(balance, pop, message, common.WithContext(ctx))
 	if err != nil {
 		return ids.Empty, fmt.Errorf("failed to issue RegisterL1ValidatorTx: %w", err)
@@ -665,6 +1172,9 @@ func RegisterL1Validator(ctx context.Context, w *wallet.Wallet, balance uint64,
 
 // SetL1ValidatorWeight sets the weight of an L1 validator (IssueSetL1ValidatorWeightTx).
 func SetL1ValidatorWeight(ctx context.Context, w *wallet.Wallet, message []byte) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	tx, err := w.PWallet().IssueSetL1ValidatorWeightTx(message, common.WithContext(ctx))
 	if err != nil {
 		return ids.Empty, fmt.Errorf("failed to issue SetL1ValidatorWeightTx: %w", err)
@@ -674,6 +1184,9 @@ func SetL1ValidatorWeight(ctx context.Context, w *wallet.Wallet, message []byte)
 
 // IncreaseL1ValidatorBalance increases the balance of an L1 validator (IssueIncreaseL1ValidatorBalanceTx).
 func IncreaseL1ValidatorBalance(ctx context.Context, w *wallet.Wallet, validationID ids.ID, amount uint64) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	tx, err := w.PWallet().IssueIncreaseL1ValidatorBalanceTx(validationID, amount, common.WithContext(ctx))
 	if err != nil {
 		return ids.Empty, fmt.Errorf("failed to issue IncreaseL1ValidatorBalanceTx: %w", err)
@@ -683,6 +1196,9 @@ func IncreaseL1ValidatorBalance(ctx context.Context, w *wallet.Wallet, validatio
 
 // DisableL1Validator disables an L1 validator (IssueDisableL1ValidatorTx).
 func DisableL1Validator(ctx context.Context, w *wallet.Wallet, validationID ids.ID) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	tx, err := w.PWallet().IssueDisableL1ValidatorTx(validationID, common.WithContext(ctx))
 	if err != nil {
 		return ids.Empty, fmt.Errorf("failed to issue DisableL1ValidatorTx: %w", err)
@@ -705,14 +1221,48 @@ type CreateChainConfig struct {
 
 // CreateChain creates a new chain on a subnet (IssueCreateChainTx).
 func CreateChain(ctx context.Context, w *wallet.Wallet, cfg CreateChainConfig) (ids.ID, error) {
+	if err := requireTrackedSubnet(w, cfg.SubnetID); err != nil {
+		return ids.Empty, err
+	}
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
 	return issueCreateChainTx(w.PWallet(), cfg, common.WithContext(ctx))
 }
 
+var (
+	// ErrGenesisTooLarge is returned by CreateChain when the genesis makes
+	// the CreateChainTx larger than nodes accept.
+	ErrGenesisTooLarge = errors.New("genesis too large")
+
+	// ErrVMNotRegistered is returned by CheckVMRegistered when a node does
+	// not have the chain's VM installed.
+	ErrVMNotRegistered = errors.New("VM not registered")
+)
+
+// genesisTooLargeHint is the guidance attached to ErrGenesisTooLarge.
+const genesisTooLargeHint = "reduce the genesis size, for example by funding fewer alloc accounts or deploying large contracts after the chain starts instead of embedding their bytecode"
+
+// createChainTxOverhead approximates the bytes of a signed CreateChainTx
+// outside its genesis, name and fx IDs: fee inputs, change, subnet auth and
+// credentials.
+const createChainTxOverhead = units.KiB
+
+// EstimateCreateChainTxSize returns the approximate signed size, in bytes, of
+// a CreateChainTx for cfg.
+func EstimateCreateChainTxSize(cfg CreateChainConfig) int {
+	return len(cfg.Genesis) + len(cfg.ChainName) + ids.IDLen*(len(cfg.FxIDs)+1) + createChainTxOverhead
+}
+
 func issueCreateChainTx(
 	issuer createChainTxIssuer,
 	cfg CreateChainConfig,
 	options ...common.Option,
 ) (ids.ID, error) {
+	if size := EstimateCreateChainTxSize(cfg); size > MaxTxSize {
+		return ids.Empty, fmt.Errorf("%w: the %d-byte genesis makes the CreateChainTx about %d bytes, over the %d-byte limit nodes accept; %s",
+			ErrGenesisTooLarge, len(cfg.Genesis), size, MaxTxSize, genesisTooLargeHint)
+	}
 	tx, err := issuer.IssueCreateChainTx(
 		cfg.SubnetID,
 		cfg.Genesis,
@@ -722,7 +1272,156 @@ func issueCreateChainTx(
 		options...,
 	)
 	if err != nil {
+		if isTxTooLargeError(err) {
+			return ids.Empty, fmt.Errorf("failed to issue CreateChainTx: %w: %s (%w)", ErrGenesisTooLarge, genesisTooLargeHint, err)
+		}
 		return ids.Empty, fmt.Errorf("failed to issue CreateChainTx: %w", err)
 	}
 	return tx.ID(), nil
 }
+
+// isTxTooLargeError reports whether err is a node rejecting a transaction
+// for its size. The node's error arrives as JSON-RPC text, so it is matched
+// by message.
+func isTxTooLargeError(err error) bool {
+	msg := err.Error()
+	return errors.Is(err, mempool.ErrTxTooLarge) ||
+		strings.Contains(msg, mempool.ErrTxTooLarge.Error()) ||
+		strings.Contains(msg, "genesis too long")
+}
+
+// vmLister lists the VMs a node has installed. info.Client satisfies it.
+type vmLister interface {
+	GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error)
+}
+
+// CheckVMRegistered reports whether the node at rpcURL has vmID installed.
+// It fails with ErrVMNotRegistered when the node lists its VMs and vmID is
+// not among them. The P-Chain accepts a CreateChainTx for any VM ID, so a
+// chain whose VM the validators lack is created but never starts.
+func CheckVMRegistered(ctx context.Context, rpcURL string, vmID ids.ID) error {
+	return CheckVMRegisteredWithConfig(ctx, network.Config{RPCURL: rpcURL}, vmID)
+}
+
+// CheckVMRegisteredWithConfig is CheckVMRegistered for the node of config.
+func CheckVMRegisteredWithConfig(ctx context.Context, config network.Config, vmID ids.ID) error {
+	return checkVMRegistered(ctx, network.NewInfoClient(ctx, config), config.RPCURL, vmID)
+}
+
+func checkVMRegistered(ctx context.Context, client vmLister, rpcURL string, vmID ids.ID) error {
+	vms, err := client.GetVMs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list VMs on %s: %w", rpcURL, err)
+	}
+	if _, ok := vms[vmID]; !ok {
+		return fmt.Errorf("%w: %s does not have VM %s installed; every validator of the subnet must run the VM binary (in its plugins directory, named by the VM ID) for the chain to start",
+			ErrVMNotRegistered, rpcURL, vmID)
+	}
+	return nil
+}
+
+// ErrSubnetAuthInsufficient is returned by CheckSubnetAuth when the given
+// signers cannot authorize changes to a subnet.
+var ErrSubnetAuthInsufficient = errors.New("insufficient subnet authority")
+
+// subnetGetter fetches a subnet's ownership details.
+// platformvm.Client satisfies it.
+type subnetGetter interface {
+	GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (platformvm.GetSubnetClientResponse, error)
+}
+
+// CheckSubnetAuth reports whether signers can authorize subnet transactions
+// (CreateChainTx, AddSubnetValidatorTx) on subnetID. It fails with
+// ErrSubnetAuthInsufficient when fewer than the owner threshold of the
+// control keys are among signers, when the owner is still time-locked, or
+// when the subnet has been transformed or converted to an L1 and no longer
+// accepts owner-authorized changes. Checking first avoids paying fees for a
+// transaction the P-Chain would reject.
+func CheckSubnetAuth(ctx context.Context, rpcURL string, subnetID ids.ID, signers []ids.ShortID) error {
+	return CheckSubnetAuthWithConfig(ctx, network.Config{RPCURL: rpcURL}, subnetID, signers)
+}
+
+// CheckSubnetAuthWithConfig is CheckSubnetAuth for the node of config.
+func CheckSubnetAuthWithConfig(ctx context.Context, config network.Config, subnetID ids.ID, signers []ids.ShortID) error {
+	return checkSubnetAuth(ctx, network.NewPChainClient(ctx, config), subnetID, signers, time.Now())
+}
+
+func checkSubnetAuth(ctx context.Context, client subnetGetter, subnetID ids.ID, signers []ids.ShortID, now time.Time) error {
+	subnet, err := client.GetSubnet(ctx, subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnet %s: %w", subnetID, err)
+	}
+	if subnet.ConversionID != ids.Empty {
+		return fmt.Errorf("%w: subnet %s has been converted to an L1; its owner can no longer authorize changes", ErrSubnetAuthInsufficient, subnetID)
+	}
+	if !subnet.IsPermissioned {
+		return fmt.Errorf("%w: subnet %s is permissionless; its owner can no longer authorize changes", ErrSubnetAuthInsufficient, subnetID)
+	}
+	if subnet.Locktime > uint64(now.Unix()) {
+		return fmt.Errorf("%w: subnet %s owner is locked until %s", ErrSubnetAuthInsufficient, subnetID, time.Unix(int64(subnet.Locktime), 0).UTC().Format(time.RFC3339))
+	}
+
+	var held uint32
+	for _, key := range subnet.ControlKeys {
+		if slices.Contains(signers, key) {
+			held++
+		}
+	}
+	if held < subnet.Threshold {
+		return fmt.Errorf("%w: subnet %s needs %d of its %d control keys to sign, but this wallet holds %d",
+			ErrSubnetAuthInsufficient, subnetID, subnet.Threshold, len(subnet.ControlKeys), held)
+	}
+	return nil
+}
+
+// =============================================================================
+// Broadcast
+// =============================================================================
+
+// signedTxClient submits signed transactions and reports their status.
+// platformvm.Client satisfies it.
+type signedTxClient interface {
+	TxStatusClient
+	IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error)
+}
+
+// ParseSignedTx decodes signed P-Chain transaction bytes, rejecting bytes that
+// do not decode to a known transaction type or carry no credentials.
+func ParseSignedTx(txBytes []byte) (*txs.Tx, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode P-Chain transaction: %w", err)
+	}
+	if len(tx.Creds) == 0 {
+		return nil, fmt.Errorf("transaction %s is not signed", tx.ID())
+	}
+	return tx, nil
+}
+
+// BroadcastSignedTx submits a signed P-Chain transaction (as produced with
+// --broadcast=false) to the node at rpcURL. With a non-nil wait it then polls,
+// per wait, until the transaction is accepted.
+func BroadcastSignedTx(ctx context.Context, rpcURL string, tx *txs.Tx, wait *WaitConfig) (ids.ID, error) {
+	return BroadcastSignedTxWithConfig(ctx, network.Config{RPCURL: rpcURL}, tx, wait)
+}
+
+// BroadcastSignedTxWithConfig is BroadcastSignedTx for the node of config.
+func BroadcastSignedTxWithConfig(ctx context.Context, config network.Config, tx *txs.Tx, wait *WaitConfig) (ids.ID, error) {
+	return broadcastSignedTx(ctx, network.NewPChainClient(ctx, config), tx, wait)
+}
+
+func broadcastSignedTx(ctx context.Context, client signedTxClient, tx *txs.Tx, wait *WaitConfig) (ids.ID, error) {
+	txID, err := client.IssueTx(ctx, tx.Bytes())
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to issue transaction: %w", err)
+	}
+	if txID != tx.ID() {
+		return ids.Empty, fmt.Errorf("node returned tx ID %s, expected %s", txID, tx.ID())
+	}
+	if wait != nil {
+		if err := WaitForTxAcceptance(ctx, client, txID, *wait); err != nil {
+			return txID, fmt.Errorf("transaction %s issued but not confirmed: %w", txID, err)
+		}
+	}
+	return txID, nil
+}
@@ -11,7 +11,9 @@ package pchain
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -111,16 +113,69 @@ func (tn *testNetwork) getTestWallet(ctx context.Context) (*wallet.Wallet, error
 	return wallet.NewWallet(ctx, key, config)
 }
 
-// getTestWalletWithSubnet creates a wallet that tracks a specific subnet.
-func (tn *testNetwork) getTestWalletWithSubnet(ctx context.Context, subnetID ids.ID) (*wallet.Wallet, error) {
-	if len(tn.network.PreFundedKeys) == 0 {
-		tn.t.Fatal("no pre-funded keys available")
+// walletFixture is a wallet for one pre-funded key that is reused across
+// the steps of a test. After each step it is refreshed from the node, so the
+// next step sees the UTXOs and subnets the previous one created without the
+// network or the wallet being rebuilt.
+type walletFixture struct {
+	t      *testing.T
+	ctx    context.Context
+	wallet *wallet.Wallet
+}
+
+// newWalletFixtures creates one fixture per pre-funded key, up to n, loading
+// the wallets concurrently. All fixtures share ctx.
+func (tn *testNetwork) newWalletFixtures(ctx context.Context, n int) []*walletFixture {
+	tn.t.Helper()
+	if n > len(tn.network.PreFundedKeys) {
+		tn.t.Fatalf("need %d pre-funded keys, network has %d", n, len(tn.network.PreFundedKeys))
 	}
 
-	key := tn.network.PreFundedKeys[0]
 	config := tn.getNetworkConfig()
+	fixtures := make([]*walletFixture, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w, err := wallet.NewWallet(ctx, tn.network.PreFundedKeys[i], config)
+			if err != nil {
+				errs[i] = fmt.Errorf("pre-funded key %d: %w", i, err)
+				return
+			}
+			fixtures[i] = &walletFixture{t: tn.t, ctx: ctx, wallet: w}
+		}()
+	}
+	wg.Wait()
 
-	return wallet.NewWalletWithSubnet(ctx, key, config, subnetID)
+	for _, err := range errs {
+		if err != nil {
+			tn.t.Fatalf("failed to create wallet: %v", err)
+		}
+	}
+	return fixtures
+}
+
+// step runs op with the fixture's wallet and refreshes the wallet afterwards.
+// A failing op or refresh fails the test.
+func (f *walletFixture) step(name string, op func(ctx context.Context, w *wallet.Wallet) error) {
+	f.t.Helper()
+	if err := op(f.ctx, f.wallet); err != nil {
+		f.t.Fatalf("%s failed: %v", name, err)
+	}
+	if err := f.wallet.Refresh(f.ctx); err != nil {
+		f.t.Fatalf("%s: %v", name, err)
+	}
+}
+
+// trackSubnet makes the fixture's wallet able to sign for subnetID, e.g.
+// right after creating it.
+func (f *walletFixture) trackSubnet(subnetID ids.ID) {
+	f.t.Helper()
+	if err := f.wallet.TrackSubnet(f.ctx, subnetID); err != nil {
+		f.t.Fatalf("failed to track subnet %s: %v", subnetID, err)
+	}
 }
 
 func TestIntegration_Send(t *testing.T) {
@@ -205,10 +260,9 @@ func TestIntegration_TransferSubnetOwnership(t *testing.T) {
 		newOwner = tn.network.PreFundedKeys[1].Address()
 	}
 
-	// Need to refresh the wallet to include the new subnet
-	w, err = tn.getTestWalletWithSubnet(ctx, subnetID)
-	if err != nil {
-		t.Fatalf("failed to recreate wallet: %v", err)
+	// Refresh the wallet so it knows the new subnet's owner
+	if err := w.TrackSubnet(ctx, subnetID); err != nil {
+		t.Fatalf("failed to track subnet: %v", err)
 	}
 
 	// Transfer ownership
@@ -242,10 +296,9 @@ func TestIntegration_CreateChain(t *testing.T) {
 		t.Fatalf("CreateSubnet failed: %v", err)
 	}
 
-	// Need to refresh the wallet to include the new subnet
-	w, err = tn.getTestWalletWithSubnet(ctx, subnetID)
-	if err != nil {
-		t.Fatalf("failed to recreate wallet: %v", err)
+	// Refresh the wallet so it knows the new subnet's owner
+	if err := w.TrackSubnet(ctx, subnetID); err != nil {
+		t.Fatalf("failed to track subnet: %v", err)
 	}
 
 	// Create a simple chain with minimal genesis
@@ -307,3 +360,56 @@ func TestIntegration_ExportImport(t *testing.T) {
 
 	t.Logf("Export TX ID: %s (network ID: %d)", txID, networkID)
 }
+
+// TestIntegration_SharedWallets runs a sequence of dependent operations on
+// one network, with wallets that are refreshed between steps rather than
+// recreated.
+func TestIntegration_SharedWallets(t *testing.T) {
+	tn, cleanup := setupTestNetwork(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	fixtures := tn.newWalletFixtures(ctx, min(2, len(tn.network.PreFundedKeys)))
+	owner := fixtures[0]
+	other := fixtures[len(fixtures)-1]
+
+	var subnetID ids.ID
+	owner.step("CreateSubnet", func(ctx context.Context, w *wallet.Wallet) error {
+		var err error
+		subnetID, err = CreateSubnet(ctx, w)
+		return err
+	})
+	owner.trackSubnet(subnetID)
+
+	owner.step("TransferSubnetOwnership", func(ctx context.Context, w *wallet.Wallet) error {
+		_, err := TransferSubnetOwnership(ctx, w, subnetID, other.wallet.PChainAddress())
+		return err
+	})
+
+	// Both wallets keep issuing after the ownership change.
+	var wg sync.WaitGroup
+	errs := make([]error, len(fixtures))
+	for i, f := range fixtures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dest := fixtures[(i+1)%len(fixtures)].wallet.PChainAddress()
+			_, errs[i] = Send(ctx, f.wallet, dest, 100)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Send from wallet %d failed: %v", i, err)
+		}
+	}
+
+	// The new owner can sign for the subnet once its wallet tracks it.
+	other.trackSubnet(subnetID)
+	other.step("TransferSubnetOwnership back", func(ctx context.Context, w *wallet.Wallet) error {
+		_, err := TransferSubnetOwnership(ctx, w, subnetID, owner.wallet.PChainAddress())
+		return err
+	})
+}
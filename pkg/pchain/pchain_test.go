@@ -3,16 +3,27 @@ package pchain
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
 )
 
 type testContextKey string
@@ -37,6 +48,19 @@ func (s *stubBaseTxIssuer) IssueBaseTx(outputs []*avax.TransferableOutput, optio
 	return s.tx, s.err
 }
 
+// stubBaseTxBuilder implements baseTxBuilder.
+type stubBaseTxBuilder struct {
+	utx *txs.BaseTx
+	err error
+
+	gotOutputs []*avax.TransferableOutput
+}
+
+func (s *stubBaseTxBuilder) NewBaseTx(outputs []*avax.TransferableOutput, _ ...common.Option) (*txs.BaseTx, error) {
+	s.gotOutputs = outputs
+	return s.utx, s.err
+}
+
 // stubExportTxIssuer implements exportTxIssuer.
 type stubExportTxIssuer struct {
 	tx  *txs.Tx
@@ -186,6 +210,16 @@ func (s *stubCreateChainTxIssuer) IssueCreateChainTx(subnetID ids.ID, genesis []
 	return s.tx, s.err
 }
 
+// stubVMLister implements vmLister.
+type stubVMLister struct {
+	vms map[ids.ID][]string
+	err error
+}
+
+func (s stubVMLister) GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error) {
+	return s.vms, s.err
+}
+
 // =============================================================================
 // Tests
 // =============================================================================
@@ -263,6 +297,46 @@ func TestIssueSendTxError(t *testing.T) {
 	}
 }
 
+func TestEstimateSendFee(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	dest := ids.GenerateTestShortID()
+
+	in := func(asset ids.ID, amt uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{Asset: avax.Asset{ID: asset}, In: &secp256k1fx.TransferInput{Amt: amt}}
+	}
+	out := func(asset ids.ID, amt uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{Asset: avax.Asset{ID: asset}, Out: &secp256k1fx.TransferOutput{Amt: amt}}
+	}
+
+	utx := &txs.BaseTx{}
+	utx.Ins = []*avax.TransferableInput{in(assetID, 3_000), in(assetID, 2_000), in(otherAssetID, 50)}
+	utx.Outs = []*avax.TransferableOutput{out(assetID, 4_000), out(assetID, 900), out(otherAssetID, 50)}
+
+	builder := &stubBaseTxBuilder{utx: utx}
+	fee, err := estimateSendFee(builder, assetID, dest, 4_000)
+	if err != nil {
+		t.Fatalf("estimateSendFee() returned error: %v", err)
+	}
+	if fee != 100 {
+		t.Fatalf("estimateSendFee() = %d, want 100", fee)
+	}
+	if len(builder.gotOutputs) != 1 || builder.gotOutputs[0].Out.Amount() != 4_000 {
+		t.Fatalf("estimateSendFee() built outputs = %v, want single 4000 output", builder.gotOutputs)
+	}
+}
+
+func TestEstimateSendFeeError(t *testing.T) {
+	builder := &stubBaseTxBuilder{err: errors.New("insufficient funds")}
+	_, err := estimateSendFee(builder, ids.GenerateTestID(), ids.GenerateTestShortID(), 1)
+	if err == nil {
+		t.Fatal("estimateSendFee() expected error")
+	}
+	if !strings.Contains(err.Error(), "failed to build BaseTx") {
+		t.Fatalf("estimateSendFee() error = %v, want wrapped BaseTx message", err)
+	}
+}
+
 func TestIssueExportTx(t *testing.T) {
 	destChainID := ids.GenerateTestID()
 	assetID := ids.GenerateTestID()
@@ -447,6 +521,51 @@ func TestIssueAddPermissionlessDelegatorTx(t *testing.T) {
 	}
 }
 
+func TestValidateStake(t *testing.T) {
+	cfg := network.Mainnet
+
+	tests := []struct {
+		name     string
+		validate func(network.Config, uint64) error
+		stake    uint64
+		wantErr  error
+	}{
+		{"validator at minimum", ValidateValidatorStake, cfg.MinValidatorStake, nil},
+		{"validator below minimum", ValidateValidatorStake, cfg.MinValidatorStake - 1, ErrStakeTooSmall},
+		{"validator at maximum", ValidateValidatorStake, cfg.MaxValidatorStake, nil},
+		{"validator above maximum", ValidateValidatorStake, cfg.MaxValidatorStake + 1, ErrStakeTooLarge},
+		{"delegator at minimum", ValidateDelegatorStake, cfg.MinDelegatorStake, nil},
+		{"delegator below minimum", ValidateDelegatorStake, cfg.MinDelegatorStake - 1, ErrStakeTooSmall},
+		{"delegator above maximum", ValidateDelegatorStake, cfg.MaxValidatorStake + 1, ErrStakeTooLarge},
+		{"zero stake", ValidateDelegatorStake, 0, ErrStakeTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(cfg, tt.stake)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validate(%d) error = %v, want %v", tt.stake, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateStakeNoMaximum(t *testing.T) {
+	cfg := network.Config{Name: "custom", MinValidatorStake: 1}
+	if err := ValidateValidatorStake(cfg, ^uint64(0)); err != nil {
+		t.Fatalf("ValidateValidatorStake() with no maximum error = %v", err)
+	}
+}
+
+func TestRequireBLSSigner(t *testing.T) {
+	if err := requireBLSSigner(nil); !errors.Is(err, ErrMissingBLSSigner) {
+		t.Fatalf("requireBLSSigner(nil) error = %v, want %v", err, ErrMissingBLSSigner)
+	}
+	if err := requireBLSSigner(&signer.ProofOfPossession{}); err != nil {
+		t.Fatalf("requireBLSSigner() error = %v", err)
+	}
+}
+
 func TestIssueCreateSubnetTx(t *testing.T) {
 	owner := ids.GenerateTestShortID()
 	txID := ids.GenerateTestID()
@@ -454,7 +573,7 @@ func TestIssueCreateSubnetTx(t *testing.T) {
 	issuer := &stubCreateSubnetTxIssuer{tx: &txs.Tx{TxID: txID}}
 	gotTxID, err := issueCreateSubnetTx(
 		issuer,
-		owner,
+		SingleOwner(owner),
 	)
 	if err != nil {
 		t.Fatalf("issueCreateSubnetTx() returned error: %v", err)
@@ -462,8 +581,89 @@ func TestIssueCreateSubnetTx(t *testing.T) {
 	if gotTxID != txID {
 		t.Fatalf("issueCreateSubnetTx() txID = %s, want %s", gotTxID, txID)
 	}
-	if issuer.gotOwner == nil || len(issuer.gotOwner.Addrs) != 1 || issuer.gotOwner.Addrs[0] != owner {
-		t.Fatalf("issueCreateSubnetTx() owner addrs = %#v, want [%s]", issuer.gotOwner, owner)
+	if issuer.gotOwner == nil || issuer.gotOwner.Threshold != 1 || len(issuer.gotOwner.Addrs) != 1 || issuer.gotOwner.Addrs[0] != owner {
+		t.Fatalf("issueCreateSubnetTx() owner = %#v, want 1-of-[%s]", issuer.gotOwner, owner)
+	}
+}
+
+func TestIssueCreateSubnetTx_Owner(t *testing.T) {
+	a, b, c := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+
+	tests := []struct {
+		name      string
+		spec      OutputOwnerSpec
+		wantAddrs []ids.ShortID
+		wantErr   bool
+	}{
+		{
+			name:      "2-of-3 multisig, sorted",
+			spec:      OutputOwnerSpec{Addrs: []ids.ShortID{c, a, b}, Threshold: 2},
+			wantAddrs: []ids.ShortID{a, b, c},
+		},
+		{name: "threshold above owner count", spec: OutputOwnerSpec{Addrs: []ids.ShortID{a, b}, Threshold: 3}, wantErr: true},
+		{name: "zero threshold", spec: OutputOwnerSpec{Addrs: []ids.ShortID{a}}, wantErr: true},
+		{name: "no addresses", spec: OutputOwnerSpec{Threshold: 1}, wantErr: true},
+		{name: "duplicate address", spec: OutputOwnerSpec{Addrs: []ids.ShortID{a, a}, Threshold: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := &stubCreateSubnetTxIssuer{tx: &txs.Tx{TxID: ids.GenerateTestID()}}
+			_, err := issueCreateSubnetTx(issuer, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("issueCreateSubnetTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if issuer.gotOwner != nil {
+					t.Fatal("issueCreateSubnetTx() issued a tx for an invalid owner")
+				}
+				return
+			}
+			want := &secp256k1fx.OutputOwners{Threshold: tt.spec.Threshold, Addrs: tt.wantAddrs}
+			if !reflect.DeepEqual(issuer.gotOwner, want) {
+				t.Fatalf("issueCreateSubnetTx() owner = %#v, want %#v", issuer.gotOwner, want)
+			}
+			if err := issuer.gotOwner.Verify(); err != nil {
+				t.Fatalf("owner.Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSubnetOperationsRequireTrackedSubnet(t *testing.T) {
+	ctx := context.Background()
+	subnetID := ids.GenerateTestID()
+	// The wallet tracks no subnets, so every subnet-scoped operation must be
+	// refused before a transaction is built.
+	w := &wallet.Wallet{}
+
+	ops := map[string]func() error{
+		"TransferSubnetOwnership": func() error {
+			_, err := TransferSubnetOwnership(ctx, w, subnetID, ids.GenerateTestShortID())
+			return err
+		},
+		"ConvertSubnetToL1": func() error {
+			_, err := ConvertSubnetToL1(ctx, w, subnetID, ids.GenerateTestID(), nil, nil)
+			return err
+		},
+		"AddSubnetValidator": func() error {
+			_, err := AddSubnetValidator(ctx, w, AddSubnetValidatorConfig{SubnetID: subnetID})
+			return err
+		},
+		"CreateChain": func() error {
+			_, err := CreateChain(ctx, w, CreateChainConfig{SubnetID: subnetID})
+			return err
+		},
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			err := op()
+			if !errors.Is(err, ErrSubnetNotTracked) {
+				t.Fatalf("%s() error = %v, want %v", name, err, ErrSubnetNotTracked)
+			}
+			if !strings.Contains(err.Error(), subnetID.String()) {
+				t.Errorf("%s() error %q does not name the subnet", name, err)
+			}
+		})
 	}
 }
 
@@ -601,3 +801,494 @@ func TestIssueCreateChainTx(t *testing.T) {
 		t.Fatalf("issueCreateChainTx() fxIDs mismatch: got %#v, want %#v", gotCfg.FxIDs, cfg.FxIDs)
 	}
 }
+
+func TestIssueCreateChainTx_GenesisTooLarge(t *testing.T) {
+	issuer := &stubCreateChainTxIssuer{tx: &txs.Tx{TxID: ids.GenerateTestID()}}
+	cfg := CreateChainConfig{SubnetID: ids.GenerateTestID(), Genesis: make([]byte, MaxTxSize), ChainName: "big"}
+	if _, err := issueCreateChainTx(issuer, cfg); !errors.Is(err, ErrGenesisTooLarge) {
+		t.Fatalf("issueCreateChainTx() error = %v, want %v", err, ErrGenesisTooLarge)
+	}
+	if issuer.gotCfg.Genesis != nil {
+		t.Fatal("issueCreateChainTx() issued a transaction over the size limit")
+	}
+
+	// A node rejecting the transaction for its size is reported the same way.
+	issuer.err = fmt.Errorf("failed to issue tx: %s", mempool.ErrTxTooLarge)
+	cfg.Genesis = []byte("{}")
+	if _, err := issueCreateChainTx(issuer, cfg); !errors.Is(err, ErrGenesisTooLarge) {
+		t.Fatalf("issueCreateChainTx() error = %v, want %v", err, ErrGenesisTooLarge)
+	}
+
+	issuer.err = errors.New("insufficient funds")
+	if _, err := issueCreateChainTx(issuer, cfg); err == nil || errors.Is(err, ErrGenesisTooLarge) {
+		t.Fatalf("issueCreateChainTx() error = %v, want an error other than %v", err, ErrGenesisTooLarge)
+	}
+}
+
+func TestCheckVMRegistered(t *testing.T) {
+	vmID := ids.GenerateTestID()
+	tests := []struct {
+		name    string
+		client  stubVMLister
+		wantErr error
+	}{
+		{"installed", stubVMLister{vms: map[ids.ID][]string{vmID: {"subnetevm"}}}, nil},
+		{"missing", stubVMLister{vms: map[ids.ID][]string{ids.GenerateTestID(): nil}}, ErrVMNotRegistered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVMRegistered(context.Background(), tt.client, "http://127.0.0.1:9650", vmID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkVMRegistered() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := checkVMRegistered(context.Background(), stubVMLister{err: errors.New("method not found")}, "http://127.0.0.1:9650", vmID)
+	if err == nil || errors.Is(err, ErrVMNotRegistered) {
+		t.Fatalf("checkVMRegistered() error = %v, want a query error", err)
+	}
+}
+
+// =============================================================================
+// Broadcast
+// =============================================================================
+
+// stubSignedTxClient implements signedTxClient. Its transactions are
+// committed on the first status check.
+type stubSignedTxClient struct {
+	issueID  ids.ID
+	issueErr error
+	awaitErr error
+
+	gotBytes  []byte
+	awaitedID ids.ID
+}
+
+func (s *stubSignedTxClient) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	s.gotBytes = txBytes
+	return s.issueID, s.issueErr
+}
+
+func (s *stubSignedTxClient) GetTxStatus(_ context.Context, txID ids.ID, _ ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	s.awaitedID = txID
+	return &platformvm.GetTxStatusResponse{Status: status.Committed}, s.awaitErr
+}
+
+func newSignedTestTx(t *testing.T) *txs.Tx {
+	t.Helper()
+	key, err := secp256k1.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() error = %v", err)
+	}
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    5,
+		BlockchainID: ids.Empty,
+	}}}
+	if err := tx.Sign(txs.Codec, [][]*secp256k1.PrivateKey{{key}}); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return tx
+}
+
+func TestParseSignedTx(t *testing.T) {
+	tx := newSignedTestTx(t)
+
+	parsed, err := ParseSignedTx(tx.Bytes())
+	if err != nil {
+		t.Fatalf("ParseSignedTx() returned error: %v", err)
+	}
+	if parsed.ID() != tx.ID() {
+		t.Fatalf("ParseSignedTx() ID = %s, want %s", parsed.ID(), tx.ID())
+	}
+
+	if _, err := ParseSignedTx([]byte{0x00, 0x01, 0x02}); err == nil {
+		t.Fatal("ParseSignedTx() expected error for garbage bytes")
+	}
+
+	unsigned := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: 5}}}
+	if err := unsigned.Initialize(txs.Codec); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if _, err := ParseSignedTx(unsigned.Bytes()); err == nil {
+		t.Fatal("ParseSignedTx() expected error for tx without credentials")
+	}
+}
+
+func TestBroadcastSignedTx(t *testing.T) {
+	tx := newSignedTestTx(t)
+
+	client := &stubSignedTxClient{issueID: tx.ID()}
+	wait := DefaultWaitConfig()
+	gotID, err := broadcastSignedTx(context.Background(), client, tx, &wait)
+	if err != nil {
+		t.Fatalf("broadcastSignedTx() returned error: %v", err)
+	}
+	if gotID != tx.ID() {
+		t.Fatalf("broadcastSignedTx() txID = %s, want %s", gotID, tx.ID())
+	}
+	if string(client.gotBytes) != string(tx.Bytes()) {
+		t.Fatal("broadcastSignedTx() did not submit the signed tx bytes")
+	}
+	if client.awaitedID != tx.ID() {
+		t.Fatalf("broadcastSignedTx() awaited %s, want %s", client.awaitedID, tx.ID())
+	}
+
+	noWait := &stubSignedTxClient{issueID: tx.ID()}
+	if _, err := broadcastSignedTx(context.Background(), noWait, tx, nil); err != nil {
+		t.Fatalf("broadcastSignedTx(wait=nil) returned error: %v", err)
+	}
+	if noWait.awaitedID != ids.Empty {
+		t.Fatal("broadcastSignedTx(wait=nil) should not poll for acceptance")
+	}
+}
+
+func TestBroadcastSignedTxErrors(t *testing.T) {
+	tx := newSignedTestTx(t)
+
+	tests := []struct {
+		name    string
+		client  *stubSignedTxClient
+		wantErr string
+	}{
+		{"issue fails", &stubSignedTxClient{issueErr: errors.New("boom")}, "failed to issue transaction"},
+		{"id mismatch", &stubSignedTxClient{issueID: ids.GenerateTestID()}, "expected"},
+		{"await fails", &stubSignedTxClient{issueID: tx.ID(), awaitErr: errors.New("timeout")}, "not confirmed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait := DefaultWaitConfig()
+			_, err := broadcastSignedTx(context.Background(), tt.client, tx, &wait)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("broadcastSignedTx() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Validator Queries
+// =============================================================================
+
+// stubCurrentValidatorsGetter implements currentValidatorsGetter.
+type stubCurrentValidatorsGetter struct {
+	validators []platformvm.ClientPermissionlessValidator
+	err        error
+	// delegatorsByNodeID lists delegators only for validators requested by
+	// node ID, as the P-Chain does.
+	delegatorsByNodeID bool
+
+	gotSubnetID ids.ID
+}
+
+func (s *stubCurrentValidatorsGetter) GetCurrentValidators(_ context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, _ ...rpc.Option) ([]platformvm.ClientPermissionlessValidator, error) {
+	s.gotSubnetID = subnetID
+	if len(nodeIDs) == 0 && !s.delegatorsByNodeID {
+		return s.validators, s.err
+	}
+	var validators []platformvm.ClientPermissionlessValidator
+	for _, v := range s.validators {
+		switch {
+		case len(nodeIDs) == 0:
+			v.Delegators = nil
+		case !slices.Contains(nodeIDs, v.NodeID):
+			continue
+		}
+		validators = append(validators, v)
+	}
+	return validators, s.err
+}
+
+func TestGetCurrentValidatorsSortsDeterministically(t *testing.T) {
+	nodeA := ids.NodeID{0x01}
+	nodeB := ids.NodeID{0x02}
+	txLow := ids.ID{0x01}
+	txHigh := ids.ID{0x02}
+	delegators := uint64(3)
+
+	staker := func(nodeID ids.NodeID, txID ids.ID) platformvm.ClientPermissionlessValidator {
+		return platformvm.ClientPermissionlessValidator{
+			ClientStaker: platformvm.ClientStaker{NodeID: nodeID, TxID: txID, Weight: 10, StartTime: 100, EndTime: 200},
+		}
+	}
+	withDelegators := staker(nodeA, txHigh)
+	withDelegators.DelegatorCount = &delegators
+
+	subnetID := ids.GenerateTestID()
+	client := &stubCurrentValidatorsGetter{validators: []platformvm.ClientPermissionlessValidator{
+		staker(nodeB, txLow),
+		withDelegators,
+		staker(nodeA, txLow),
+	}}
+
+	got, err := getCurrentValidators(context.Background(), client, subnetID)
+	if err != nil {
+		t.Fatalf("getCurrentValidators() returned error: %v", err)
+	}
+	if client.gotSubnetID != subnetID {
+		t.Fatalf("getCurrentValidators() subnetID = %s, want %s", client.gotSubnetID, subnetID)
+	}
+
+	want := []struct {
+		nodeID ids.NodeID
+		txID   ids.ID
+	}{{nodeA, txLow}, {nodeA, txHigh}, {nodeB, txLow}}
+	if len(got) != len(want) {
+		t.Fatalf("getCurrentValidators() returned %d validators, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].NodeID != w.nodeID || got[i].TxID != w.txID {
+			t.Fatalf("getCurrentValidators()[%d] = (%s, %s), want (%s, %s)", i, got[i].NodeID, got[i].TxID, w.nodeID, w.txID)
+		}
+	}
+	if got[1].DelegatorCount != delegators {
+		t.Fatalf("getCurrentValidators()[1].DelegatorCount = %d, want %d", got[1].DelegatorCount, delegators)
+	}
+	if !got[0].EndTime.Equal(time.Unix(200, 0)) {
+		t.Fatalf("getCurrentValidators()[0].EndTime = %v, want unix 200", got[0].EndTime)
+	}
+}
+
+func TestGetCurrentValidatorsError(t *testing.T) {
+	client := &stubCurrentValidatorsGetter{err: errors.New("boom")}
+	if _, err := getCurrentValidators(context.Background(), client, ids.Empty); err == nil {
+		t.Fatal("getCurrentValidators() expected error")
+	}
+}
+
+func TestGetStakesRewardedTo(t *testing.T) {
+	me, other := ids.ShortID{0x01}, ids.ShortID{0x02}
+	owner := func(addrs ...ids.ShortID) *platformvm.ClientOwner {
+		return &platformvm.ClientOwner{Threshold: 1, Addresses: addrs}
+	}
+	staker := func(txID byte, end uint64) platformvm.ClientStaker {
+		return platformvm.ClientStaker{NodeID: ids.NodeID{txID}, TxID: ids.ID{txID}, Weight: 10, StartTime: 100, EndTime: end}
+	}
+
+	client := &stubCurrentValidatorsGetter{validators: []platformvm.ClientPermissionlessValidator{
+		{
+			ClientStaker:          staker(0x01, 500),
+			ValidationRewardOwner: owner(other, me),
+			Delegators: []platformvm.ClientDelegator{
+				{ClientStaker: staker(0x02, 300), RewardOwner: owner(me)},
+				{ClientStaker: staker(0x03, 200), RewardOwner: owner(other)},
+			},
+		},
+		{ClientStaker: staker(0x04, 400), ValidationRewardOwner: owner(other)},
+		{ClientStaker: staker(0x05, 100)},
+	}}
+
+	got, err := getStakesRewardedTo(context.Background(), client, me, nil)
+	if err != nil {
+		t.Fatalf("getStakesRewardedTo() error = %v", err)
+	}
+	if client.gotSubnetID != constants.PrimaryNetworkID {
+		t.Fatalf("getStakesRewardedTo() queried subnet %s, want the primary network", client.gotSubnetID)
+	}
+	want := []struct {
+		kind string
+		txID ids.ID
+	}{{StakeDelegator, ids.ID{0x02}}, {StakeValidator, ids.ID{0x01}}}
+	if len(got) != len(want) {
+		t.Fatalf("getStakesRewardedTo() = %+v, want %d stakes", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].TxID != w.txID {
+			t.Fatalf("getStakesRewardedTo()[%d] = %s %s, want %s %s", i, got[i].Kind, got[i].TxID, w.kind, w.txID)
+		}
+	}
+
+	client.err = errors.New("boom")
+	if _, err := getStakesRewardedTo(context.Background(), client, me, nil); err == nil {
+		t.Fatal("getStakesRewardedTo() expected error")
+	}
+}
+
+func TestGetStakesRewardedToUnlistedNode(t *testing.T) {
+	me, other := ids.ShortID{0x01}, ids.ShortID{0x02}
+	owner := func(addrs ...ids.ShortID) *platformvm.ClientOwner {
+		return &platformvm.ClientOwner{Threshold: 1, Addresses: addrs}
+	}
+	mine, delegatedTo := ids.NodeID{0x01}, ids.NodeID{0x02}
+
+	client := &stubCurrentValidatorsGetter{delegatorsByNodeID: true, validators: []platformvm.ClientPermissionlessValidator{
+		{
+			ClientStaker:          platformvm.ClientStaker{NodeID: mine, TxID: ids.ID{0x01}, EndTime: 300},
+			ValidationRewardOwner: owner(me),
+		},
+		{
+			ClientStaker:          platformvm.ClientStaker{NodeID: delegatedTo, TxID: ids.ID{0x02}, EndTime: 400},
+			ValidationRewardOwner: owner(other),
+			Delegators: []platformvm.ClientDelegator{
+				{ClientStaker: platformvm.ClientStaker{NodeID: delegatedTo, TxID: ids.ID{0x03}, EndTime: 200}, RewardOwner: owner(me)},
+			},
+		},
+	}}
+
+	// The wallet validates on a node that is not among those listed for
+	// delegations; it must still be found, and the delegation only once.
+	got, err := getStakesRewardedTo(context.Background(), client, me, []ids.NodeID{delegatedTo})
+	if err != nil {
+		t.Fatalf("getStakesRewardedTo() error = %v", err)
+	}
+	want := []struct {
+		kind string
+		txID ids.ID
+	}{{StakeDelegator, ids.ID{0x03}}, {StakeValidator, ids.ID{0x01}}}
+	if len(got) != len(want) {
+		t.Fatalf("getStakesRewardedTo() = %+v, want %d stakes", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].TxID != w.txID {
+			t.Fatalf("getStakesRewardedTo()[%d] = %s %s, want %s %s", i, got[i].Kind, got[i].TxID, w.kind, w.txID)
+		}
+	}
+}
+
+// stubSubnetGetter implements subnetGetter.
+type stubSubnetGetter struct {
+	subnet platformvm.GetSubnetClientResponse
+	err    error
+}
+
+func (s *stubSubnetGetter) GetSubnet(context.Context, ids.ID, ...rpc.Option) (platformvm.GetSubnetClientResponse, error) {
+	return s.subnet, s.err
+}
+
+func TestCheckSubnetAuth(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	alice, bob, carol := ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	owned := func(threshold uint32, keys ...ids.ShortID) platformvm.GetSubnetClientResponse {
+		return platformvm.GetSubnetClientResponse{IsPermissioned: true, ControlKeys: keys, Threshold: threshold}
+	}
+
+	tests := []struct {
+		name    string
+		client  *stubSubnetGetter
+		signers []ids.ShortID
+		wantErr error
+	}{
+		{name: "sole owner", client: &stubSubnetGetter{subnet: owned(1, alice)}, signers: []ids.ShortID{alice}},
+		{name: "one of several", client: &stubSubnetGetter{subnet: owned(1, bob, alice)}, signers: []ids.ShortID{alice}},
+		{name: "not an owner", client: &stubSubnetGetter{subnet: owned(1, bob)}, signers: []ids.ShortID{alice}, wantErr: ErrSubnetAuthInsufficient},
+		{name: "below threshold", client: &stubSubnetGetter{subnet: owned(2, alice, bob, carol)}, signers: []ids.ShortID{alice}, wantErr: ErrSubnetAuthInsufficient},
+		{name: "meets threshold", client: &stubSubnetGetter{subnet: owned(2, alice, bob, carol)}, signers: []ids.ShortID{carol, alice}},
+		{
+			name: "time-locked",
+			client: &stubSubnetGetter{subnet: platformvm.GetSubnetClientResponse{
+				IsPermissioned: true, ControlKeys: []ids.ShortID{alice}, Threshold: 1, Locktime: uint64(now.Unix()) + 1,
+			}},
+			signers: []ids.ShortID{alice},
+			wantErr: ErrSubnetAuthInsufficient,
+		},
+		{
+			name: "converted to L1",
+			client: &stubSubnetGetter{subnet: platformvm.GetSubnetClientResponse{
+				ControlKeys: []ids.ShortID{alice}, Threshold: 1, ConversionID: ids.GenerateTestID(),
+			}},
+			signers: []ids.ShortID{alice},
+			wantErr: ErrSubnetAuthInsufficient,
+		},
+		{name: "lookup fails", client: &stubSubnetGetter{err: errors.New("not found")}, signers: []ids.ShortID{alice}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSubnetAuth(context.Background(), tt.client, ids.GenerateTestID(), tt.signers, now)
+			if tt.client.err != nil {
+				if err == nil {
+					t.Fatal("checkSubnetAuth() expected error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkSubnetAuth() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertedValidationIDs(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	nodeA, nodeB, nodeC := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	sortedNodes := []ids.NodeID{nodeA, nodeB, nodeC}
+	slices.SortFunc(sortedNodes, ids.NodeID.Compare)
+
+	// Listed out of order: the IDs follow node ID order, as in the tx.
+	validators := []*txs.ConvertSubnetToL1Validator{
+		{NodeID: sortedNodes[2].Bytes(), Weight: 1},
+		{NodeID: sortedNodes[0].Bytes(), Weight: 1},
+		{NodeID: sortedNodes[1].Bytes(), Weight: 1},
+	}
+
+	got, err := ConvertedValidationIDs(subnetID, validators)
+	if err != nil {
+		t.Fatalf("ConvertedValidationIDs() error = %v", err)
+	}
+	if len(got) != len(sortedNodes) {
+		t.Fatalf("ConvertedValidationIDs() returned %d IDs, want %d", len(got), len(sortedNodes))
+	}
+	for i, nodeID := range sortedNodes {
+		want := L1ValidatorID{NodeID: nodeID, ValidationID: subnetID.Append(uint32(i))}
+		if got[i] != want {
+			t.Errorf("ConvertedValidationIDs()[%d] = %+v, want %+v", i, got[i], want)
+		}
+	}
+	if validators[0].NodeID[0] != sortedNodes[2].Bytes()[0] {
+		t.Error("ConvertedValidationIDs() reordered the caller's validators")
+	}
+
+	if _, err := ConvertedValidationIDs(subnetID, []*txs.ConvertSubnetToL1Validator{{NodeID: []byte{0x01}}}); err == nil {
+		t.Error("ConvertedValidationIDs() with a short node ID expected error")
+	}
+}
+
+func TestSendAmountAfterFee(t *testing.T) {
+	// Amounts above 1000 nAVAX need a second UTXO, which raises the fee.
+	twoInputs := func(amount uint64) (uint64, error) {
+		if amount > 1_000 {
+			return 20, nil
+		}
+		return 10, nil
+	}
+	tests := []struct {
+		name      string
+		total     uint64
+		feeFor    func(uint64) (uint64, error)
+		wantSent  uint64
+		wantFee   uint64
+		wantErrIs error
+	}{
+		{name: "flat fee", total: 500, feeFor: twoInputs, wantSent: 490, wantFee: 10},
+		{name: "fee grows with amount", total: 5_000, feeFor: twoInputs, wantSent: 4_980, wantFee: 20},
+		{name: "fee shrinks below boundary", total: 1_015, feeFor: twoInputs, wantSent: 995, wantFee: 10},
+		{name: "fee equals total", total: 10, feeFor: twoInputs, wantErrIs: ErrFeeExceedsAmount},
+		{name: "fee exceeds total", total: 3, feeFor: twoInputs, wantErrIs: ErrFeeExceedsAmount},
+		{
+			name:      "estimate error",
+			total:     500,
+			feeFor:    func(uint64) (uint64, error) { return 0, ErrInsufficientFunds },
+			wantErrIs: ErrInsufficientFunds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent, fee, err := sendAmountAfterFee(tt.total, tt.feeFor)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("sendAmountAfterFee() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sendAmountAfterFee() error = %v", err)
+			}
+			if sent != tt.wantSent || fee != tt.wantFee {
+				t.Errorf("sendAmountAfterFee() = (%d, %d), want (%d, %d)", sent, fee, tt.wantSent, tt.wantFee)
+			}
+			if sent+fee > tt.total {
+				t.Errorf("sent %d + fee %d exceeds total %d", sent, fee, tt.total)
+			}
+		})
+	}
+}
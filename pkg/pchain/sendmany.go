@@ -0,0 +1,237 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/platform-cli/pkg/wallet"
+)
+
+// ErrInsufficientFunds is matched by InsufficientFundsError.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// InsufficientFundsError reports what a send needs against what the wallet
+// holds. Amounts are in nAVAX.
+type InsufficientFundsError struct {
+	// Amount is the total paid to recipients, staked or locked by the tx.
+	Amount uint64
+	// Purpose describes Amount in the message, e.g. "staked"; it defaults to
+	// "to recipients".
+	Purpose string
+	// Fee is the estimated fee; it is zero when FeeUnknown.
+	Fee uint64
+	// FeeUnknown is set when the balance could not even cover a fee estimate.
+	FeeUnknown bool
+	Have       uint64
+}
+
+// Need returns the amount plus the estimated fee.
+func (e *InsufficientFundsError) Need() uint64 {
+	return e.Amount + e.Fee
+}
+
+func (e *InsufficientFundsError) Error() string {
+	if e.FeeUnknown && e.Amount == 0 {
+		return fmt.Sprintf("insufficient funds: cannot cover the fee, have %s AVAX", FormatAVAX(e.Have))
+	}
+	if e.FeeUnknown {
+		return fmt.Sprintf("insufficient funds: need %s AVAX plus fees, have %s AVAX",
+			FormatAVAX(e.Amount), FormatAVAX(e.Have))
+	}
+	if e.Amount == 0 {
+		return fmt.Sprintf("insufficient funds: need ~%s AVAX for the fee, have %s AVAX (short %s AVAX)",
+			FormatAVAX(e.Fee), FormatAVAX(e.Have), FormatAVAX(e.Need()-e.Have))
+	}
+	purpose := e.Purpose
+	if purpose == "" {
+		purpose = "to recipients"
+	}
+	return fmt.Sprintf("insufficient funds: need %s AVAX (%s %s + ~%s fee), have %s AVAX (short %s AVAX)",
+		FormatAVAX(e.Need()), FormatAVAX(e.Amount), purpose, FormatAVAX(e.Fee), FormatAVAX(e.Have), FormatAVAX(e.Need()-e.Have))
+}
+
+// Is makes errors.Is(err, ErrInsufficientFunds) match.
+func (e *InsufficientFundsError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// Payment is one recipient of a SendMany.
+type Payment struct {
+	To          ids.ShortID
+	AmountNAVAX uint64
+}
+
+// fundedBaseTxBuilder builds BaseTxs and reports the balance it can spend.
+// The avalanchego P-Chain builder satisfies it.
+type fundedBaseTxBuilder interface {
+	baseTxBuilder
+	GetBalance(options ...common.Option) (map[ids.ID]uint64, error)
+}
+
+// SendMany pays every recipient in a single BaseTx, so either all payments
+// are made or none are. Before signing it checks that the wallet covers the
+// payments plus the fee, returning an *InsufficientFundsError otherwise.
+func SendMany(ctx context.Context, w *wallet.Wallet, payments []Payment) (ids.ID, error) {
+	if err := w.RefreshBeforeIssue(ctx); err != nil {
+		return ids.Empty, err
+	}
+	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
+	if _, err := checkSendManyFunds(w.PWallet().Builder(), avaxAssetID, payments, common.WithContext(ctx)); err != nil {
+		return ids.Empty, err
+	}
+	tx, err := w.PWallet().IssueBaseTx(paymentOutputs(avaxAssetID, payments), common.WithContext(ctx))
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to issue BaseTx: %w", err)
+	}
+	return tx.ID(), nil
+}
+
+// EstimateSendManyFee returns the fee SendMany would currently pay, after the
+// same funds check.
+func EstimateSendManyFee(ctx context.Context, w *wallet.Wallet, payments []Payment) (uint64, error) {
+	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
+	return checkSendManyFunds(w.PWallet().Builder(), avaxAssetID, payments, common.WithContext(ctx))
+}
+
+// PChainSpender is a wallet that spends P-Chain UTXOs. *wallet.Wallet and
+// *wallet.FullWallet satisfy it.
+type PChainSpender interface {
+	PWallet() pwallet.Wallet
+	PChainAddress() ids.ShortID
+}
+
+// CheckFunds checks, before a transaction is built, that the wallet holds
+// amountNAVAX plus a fee, returning an *InsufficientFundsError with the
+// shortfall otherwise. purpose describes the amount in that error (e.g.
+// "staked"). The fee is priced as a BaseTx moving amountNAVAX, so it
+// underestimates larger transactions; those can still fail when built, with
+// the builder's own insufficient funds error.
+func CheckFunds(ctx context.Context, w PChainSpender, amountNAVAX uint64, purpose string) error {
+	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
+	var payments []Payment
+	if amountNAVAX > 0 {
+		payments = []Payment{{To: w.PChainAddress(), AmountNAVAX: amountNAVAX}}
+	}
+	_, err := checkOutputsFunds(w.PWallet().Builder(), avaxAssetID, amountNAVAX, payments, common.WithContext(ctx))
+	var insufficient *InsufficientFundsError
+	if errors.As(err, &insufficient) {
+		insufficient.Purpose = purpose
+	}
+	return err
+}
+
+// CheckStakeFunds is CheckFunds for a stake of stakeNAVAX. Stakeable-locked
+// funds can be staked, so they count towards the stake, but the fee must
+// still come from unlocked funds.
+func CheckStakeFunds(ctx context.Context, w PChainSpender, stakeNAVAX uint64) error {
+	avaxAssetID := w.PWallet().Builder().Context().AVAXAssetID
+	_, err := checkStakeFunds(w.PWallet().Builder(), avaxAssetID, stakeNAVAX, w.PChainAddress(), common.WithContext(ctx))
+	var insufficient *InsufficientFundsError
+	if errors.As(err, &insufficient) {
+		insufficient.Purpose = "staked"
+	}
+	return err
+}
+
+// SumPayments totals the payment amounts, rejecting empty batches, zero
+// amounts and overflow.
+func SumPayments(payments []Payment) (uint64, error) {
+	if len(payments) == 0 {
+		return 0, fmt.Errorf("at least one payment is required")
+	}
+	var total uint64
+	for i, p := range payments {
+		if p.AmountNAVAX == 0 {
+			return 0, fmt.Errorf("payment %d to %s: amount must be positive", i+1, p.To)
+		}
+		if p.AmountNAVAX > math.MaxUint64-total {
+			return 0, fmt.Errorf("payment total overflows uint64 nAVAX")
+		}
+		total += p.AmountNAVAX
+	}
+	return total, nil
+}
+
+func paymentOutputs(avaxAssetID ids.ID, payments []Payment) []*avax.TransferableOutput {
+	outputs := make([]*avax.TransferableOutput, 0, len(payments))
+	for _, p := range payments {
+		outputs = append(outputs, sendOutputs(avaxAssetID, p.To, p.AmountNAVAX)...)
+	}
+	return outputs
+}
+
+// checkSendManyFunds returns the fee of paying every recipient, or an
+// *InsufficientFundsError if the builder's balance cannot cover the payments
+// and fee. When the real outputs cannot be built for lack of funds, the fee
+// is priced on the same outputs at 1 nAVAX each to state the shortfall.
+func checkSendManyFunds(builder fundedBaseTxBuilder, avaxAssetID ids.ID, payments []Payment, options ...common.Option) (uint64, error) {
+	total, err := SumPayments(payments)
+	if err != nil {
+		return 0, err
+	}
+	return checkOutputsFunds(builder, avaxAssetID, total, payments, options...)
+}
+
+// checkStakeFunds returns the fee of staking stake from the builder's
+// balance, stakeable-locked funds included, or an *InsufficientFundsError.
+// Locked funds cannot be sent, so the fee is priced on a 1 nAVAX output to
+// to, which the unlocked funds must cover.
+func checkStakeFunds(builder fundedBaseTxBuilder, avaxAssetID ids.ID, stake uint64, to ids.ShortID, options ...common.Option) (uint64, error) {
+	balances, err := builder.GetBalance(append(options, common.WithStakeableLocked())...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	balance := balances[avaxAssetID]
+
+	fee, err := estimateOutputsFee(builder, avaxAssetID, sendOutputs(avaxAssetID, to, 1), options...)
+	switch {
+	case err != nil && balance < stake:
+		return 0, &InsufficientFundsError{Amount: stake, FeeUnknown: true, Have: balance}
+	case err != nil:
+		return 0, err
+	case fee > math.MaxUint64-stake || balance < stake+fee:
+		return 0, &InsufficientFundsError{Amount: stake, Fee: fee, Have: balance}
+	}
+	return fee, nil
+}
+
+// checkOutputsFunds is checkSendManyFunds for payments already totalling
+// total. An empty payments list checks that the balance covers a fee.
+func checkOutputsFunds(builder fundedBaseTxBuilder, avaxAssetID ids.ID, total uint64, payments []Payment, options ...common.Option) (uint64, error) {
+	balances, err := builder.GetBalance(options...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	balance := balances[avaxAssetID]
+
+	fee, buildErr := estimateOutputsFee(builder, avaxAssetID, paymentOutputs(avaxAssetID, payments), options...)
+	if buildErr != nil {
+		probe := make([]Payment, len(payments))
+		for i, p := range payments {
+			probe[i] = Payment{To: p.To, AmountNAVAX: 1}
+		}
+		probeFee, err := estimateOutputsFee(builder, avaxAssetID, paymentOutputs(avaxAssetID, probe), options...)
+		switch {
+		case err == nil:
+			fee = probeFee
+		case balance < total || len(payments) == 0:
+			return 0, &InsufficientFundsError{Amount: total, FeeUnknown: true, Have: balance}
+		default:
+			return 0, buildErr
+		}
+	}
+
+	if fee > math.MaxUint64-total || balance < total+fee {
+		return 0, &InsufficientFundsError{Amount: total, Fee: fee, Have: balance}
+	}
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return fee, nil
+}
@@ -0,0 +1,196 @@
+package pchain
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+// stubFundedBuilder implements fundedBaseTxBuilder for a wallet holding
+// balance nAVAX, plus locked stakeable-locked nAVAX, charging perOutputFee
+// for each output. Like the real builder, it fails to build a tx the
+// unlocked balance cannot cover.
+type stubFundedBuilder struct {
+	assetID      ids.ID
+	balance      uint64
+	locked       uint64
+	perOutputFee uint64
+}
+
+func (s *stubFundedBuilder) GetBalance(options ...common.Option) (map[ids.ID]uint64, error) {
+	if common.NewOptions(options).AllowStakeableLocked() {
+		return map[ids.ID]uint64{s.assetID: s.balance + s.locked}, nil
+	}
+	return map[ids.ID]uint64{s.assetID: s.balance}, nil
+}
+
+func (s *stubFundedBuilder) NewBaseTx(outputs []*avax.TransferableOutput, _ ...common.Option) (*txs.BaseTx, error) {
+	var produced uint64
+	for _, out := range outputs {
+		produced += out.Out.Amount()
+	}
+	consumed := produced + s.perOutputFee*uint64(len(outputs))
+	if consumed > s.balance {
+		return nil, errors.New("insufficient funds")
+	}
+	utx := &txs.BaseTx{}
+	utx.Ins = []*avax.TransferableInput{{
+		Asset: avax.Asset{ID: s.assetID},
+		In:    &secp256k1fx.TransferInput{Amt: consumed},
+	}}
+	utx.Outs = outputs
+	return utx, nil
+}
+
+func TestCheckSendManyFunds(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	payments := []Payment{
+		{To: ids.GenerateTestShortID(), AmountNAVAX: 3_000},
+		{To: ids.GenerateTestShortID(), AmountNAVAX: 2_000},
+	}
+
+	t.Run("covered", func(t *testing.T) {
+		builder := &stubFundedBuilder{assetID: assetID, balance: 10_000, perOutputFee: 100}
+		fee, err := checkSendManyFunds(builder, assetID, payments)
+		if err != nil {
+			t.Fatalf("checkSendManyFunds() error = %v", err)
+		}
+		if fee != 200 {
+			t.Fatalf("checkSendManyFunds() fee = %d, want 200", fee)
+		}
+	})
+
+	t.Run("short", func(t *testing.T) {
+		builder := &stubFundedBuilder{assetID: assetID, balance: 5_100, perOutputFee: 100}
+		_, err := checkSendManyFunds(builder, assetID, payments)
+		var short *InsufficientFundsError
+		if !errors.As(err, &short) {
+			t.Fatalf("checkSendManyFunds() error = %v, want *InsufficientFundsError", err)
+		}
+		if short.Need() != 5_200 || short.Fee != 200 || short.Have != 5_100 {
+			t.Fatalf("shortfall = %+v, want need 5200 (fee 200), have 5100", short)
+		}
+		if !errors.Is(err, ErrInsufficientFunds) {
+			t.Fatal("errors.Is(err, ErrInsufficientFunds) = false")
+		}
+	})
+
+	t.Run("cannot cover even the fee", func(t *testing.T) {
+		builder := &stubFundedBuilder{assetID: assetID, balance: 1, perOutputFee: 100}
+		_, err := checkSendManyFunds(builder, assetID, payments)
+		var short *InsufficientFundsError
+		if !errors.As(err, &short) || !short.FeeUnknown || short.Amount != 5_000 {
+			t.Fatalf("checkSendManyFunds() error = %v, want shortfall of 5000 plus unknown fee", err)
+		}
+	})
+}
+
+func TestSumPayments(t *testing.T) {
+	to := ids.GenerateTestShortID()
+	tests := []struct {
+		name     string
+		payments []Payment
+		want     uint64
+		wantErr  bool
+	}{
+		{name: "sum", payments: []Payment{{To: to, AmountNAVAX: 1}, {To: to, AmountNAVAX: 2}}, want: 3},
+		{name: "empty", wantErr: true},
+		{name: "zero amount", payments: []Payment{{To: to}}, wantErr: true},
+		{name: "overflow", payments: []Payment{{To: to, AmountNAVAX: math.MaxUint64}, {To: to, AmountNAVAX: 1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SumPayments(tt.payments)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SumPayments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("SumPayments() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOutputsFundsFeeOnly(t *testing.T) {
+	assetID := ids.GenerateTestID()
+
+	// The stub charges per output, so a check with no outputs is free.
+	builder := &stubFundedBuilder{assetID: assetID, perOutputFee: 100}
+	if _, err := checkOutputsFunds(builder, assetID, 0, nil); err != nil {
+		t.Fatalf("checkOutputsFunds(fee only) error = %v", err)
+	}
+
+	staked := []Payment{{To: ids.GenerateTestShortID(), AmountNAVAX: 2_000}}
+	builder.balance = 1_500
+	_, err := checkOutputsFunds(builder, assetID, 2_000, staked)
+	var short *InsufficientFundsError
+	if !errors.As(err, &short) {
+		t.Fatalf("checkOutputsFunds() error = %v, want *InsufficientFundsError", err)
+	}
+	short.Purpose = "staked"
+	if want := "insufficient funds: need 0.0000021 AVAX (0.000002 staked + ~0.0000001 fee), have 0.0000015 AVAX (short 0.0000006 AVAX)"; short.Error() != want {
+		t.Fatalf("Error() = %q, want %q", short.Error(), want)
+	}
+}
+
+func TestCheckStakeFunds(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	to := ids.GenerateTestShortID()
+
+	tests := []struct {
+		name     string
+		balance  uint64
+		locked   uint64
+		wantFee  uint64
+		wantHave uint64 // non-zero when an *InsufficientFundsError is expected
+		wantErr  bool
+	}{
+		{name: "unlocked", balance: 3_000, wantFee: 100},
+		{name: "mostly stakeable-locked", balance: 500, locked: 5_000, wantFee: 100},
+		{name: "short with locked", balance: 500, locked: 1_000, wantHave: 1_500},
+		{name: "locked cannot pay the fee", balance: 50, locked: 5_000, wantErr: true},
+		{name: "fee unknown", balance: 50, locked: 1_000, wantHave: 1_050},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &stubFundedBuilder{assetID: assetID, balance: tt.balance, locked: tt.locked, perOutputFee: 100}
+			fee, err := checkStakeFunds(builder, assetID, 2_000, to)
+			var short *InsufficientFundsError
+			switch {
+			case tt.wantHave != 0:
+				if !errors.As(err, &short) || short.Have != tt.wantHave || short.Amount != 2_000 {
+					t.Fatalf("checkStakeFunds() error = %v, want *InsufficientFundsError having %d", err, tt.wantHave)
+				}
+			case tt.wantErr:
+				if err == nil || errors.As(err, &short) {
+					t.Fatalf("checkStakeFunds() error = %v, want the builder's error", err)
+				}
+			case err != nil:
+				t.Fatalf("checkStakeFunds() error = %v", err)
+			case fee != tt.wantFee:
+				t.Fatalf("checkStakeFunds() fee = %d, want %d", fee, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestInsufficientFundsErrorFeeOnly(t *testing.T) {
+	tests := []struct {
+		err  *InsufficientFundsError
+		want string
+	}{
+		{&InsufficientFundsError{Fee: 100, Have: 40}, "insufficient funds: need ~0.0000001 AVAX for the fee, have 0.00000004 AVAX (short 0.00000006 AVAX)"},
+		{&InsufficientFundsError{FeeUnknown: true, Have: 40}, "insufficient funds: cannot cover the fee, have 0.00000004 AVAX"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package pchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+const (
+	// DefaultPollInterval is the initial delay between acceptance checks.
+	DefaultPollInterval = 500 * time.Millisecond
+	// DefaultMaxPollInterval caps the backed-off delay between checks.
+	DefaultMaxPollInterval = 5 * time.Second
+	// pollBackoffFactor multiplies the delay after each pending check.
+	pollBackoffFactor = 2
+)
+
+// WaitConfig controls how WaitForTxAcceptance polls a transaction's status.
+type WaitConfig struct {
+	// PollInterval is the delay before the second status check. It grows by
+	// pollBackoffFactor after each check that finds the tx still pending.
+	PollInterval time.Duration
+	// MaxInterval caps the delay between checks.
+	MaxInterval time.Duration
+	// MaxWait bounds the whole wait; zero waits until ctx is done.
+	MaxWait time.Duration
+}
+
+// DefaultWaitConfig returns the polling schedule used when none is given.
+func DefaultWaitConfig() WaitConfig {
+	return WaitConfig{
+		PollInterval: DefaultPollInterval,
+		MaxInterval:  DefaultMaxPollInterval,
+	}
+}
+
+// TxStatusClient reports the status of P-Chain transactions.
+// platformvm.Client satisfies it.
+type TxStatusClient interface {
+	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*platformvm.GetTxStatusResponse, error)
+}
+
+// WaitForTxAcceptance polls txID until it is committed. An aborted or dropped
+// tx is an error, as is running past cfg.MaxWait or ctx's deadline.
+func WaitForTxAcceptance(ctx context.Context, client TxStatusClient, txID ids.ID, cfg WaitConfig) error {
+	return waitForTxAcceptance(ctx, client, txID, cfg, sleepContext)
+}
+
+func waitForTxAcceptance(
+	ctx context.Context,
+	client TxStatusClient,
+	txID ids.ID,
+	cfg WaitConfig,
+	sleep func(context.Context, time.Duration) error,
+) error {
+	if cfg.PollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive (got %s)", cfg.PollInterval)
+	}
+	if cfg.MaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.MaxWait)
+		defer cancel()
+	}
+	maxInterval := max(cfg.MaxInterval, cfg.PollInterval)
+
+	delay := cfg.PollInterval
+	for {
+		resp, err := client.GetTxStatus(ctx, txID)
+		if err != nil {
+			return fmt.Errorf("failed to get status of %s: %w", txID, err)
+		}
+		switch resp.Status {
+		case status.Committed:
+			return nil
+		case status.Aborted:
+			return fmt.Errorf("transaction %s was aborted", txID)
+		case status.Dropped:
+			return fmt.Errorf("transaction %s was dropped: %s", txID, resp.Reason)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return fmt.Errorf("transaction %s still %s: %w", txID, resp.Status, err)
+		}
+		delay = min(delay*pollBackoffFactor, maxInterval)
+	}
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx ends.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
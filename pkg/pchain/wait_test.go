@@ -0,0 +1,105 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+// scriptedStatusClient implements TxStatusClient, returning statuses in
+// order and repeating the last one.
+type scriptedStatusClient struct {
+	statuses []status.Status
+	reason   string
+	calls    int
+}
+
+func (s *scriptedStatusClient) GetTxStatus(context.Context, ids.ID, ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	st := s.statuses[min(s.calls, len(s.statuses)-1)]
+	s.calls++
+	return &platformvm.GetTxStatusResponse{Status: st, Reason: s.reason}, nil
+}
+
+// recordingSleep records requested delays instead of sleeping.
+type recordingSleep struct {
+	delays []time.Duration
+	err    error
+}
+
+func (r *recordingSleep) sleep(_ context.Context, d time.Duration) error {
+	r.delays = append(r.delays, d)
+	return r.err
+}
+
+func TestWaitForTxAcceptance_Backoff(t *testing.T) {
+	client := &scriptedStatusClient{statuses: []status.Status{
+		status.Processing, status.Processing, status.Processing, status.Processing, status.Committed,
+	}}
+	clock := &recordingSleep{}
+	cfg := WaitConfig{PollInterval: 100 * time.Millisecond, MaxInterval: 300 * time.Millisecond}
+
+	if err := waitForTxAcceptance(context.Background(), client, ids.GenerateTestID(), cfg, clock.sleep); err != nil {
+		t.Fatalf("waitForTxAcceptance() error = %v", err)
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	if len(clock.delays) != len(want) {
+		t.Fatalf("delays = %v, want %v", clock.delays, want)
+	}
+	for i := range want {
+		if clock.delays[i] != want[i] {
+			t.Fatalf("delays = %v, want %v", clock.delays, want)
+		}
+	}
+	if client.calls != 5 {
+		t.Fatalf("status checks = %d, want 5", client.calls)
+	}
+}
+
+func TestWaitForTxAcceptance_Outcomes(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []status.Status
+		sleepErr error
+		cfg      WaitConfig
+		wantErr  string
+	}{
+		{name: "committed", statuses: []status.Status{status.Committed}, cfg: DefaultWaitConfig()},
+		{name: "aborted", statuses: []status.Status{status.Processing, status.Aborted}, cfg: DefaultWaitConfig(), wantErr: "aborted"},
+		{name: "dropped", statuses: []status.Status{status.Dropped}, cfg: DefaultWaitConfig(), wantErr: "dropped: insufficient funds"},
+		{name: "deadline", statuses: []status.Status{status.Processing}, sleepErr: context.DeadlineExceeded, cfg: DefaultWaitConfig(), wantErr: "still Processing"},
+		{name: "zero interval", statuses: []status.Status{status.Processing}, cfg: WaitConfig{}, wantErr: "poll interval must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &scriptedStatusClient{statuses: tt.statuses, reason: "insufficient funds"}
+			clock := &recordingSleep{err: tt.sleepErr}
+			err := waitForTxAcceptance(context.Background(), client, ids.GenerateTestID(), tt.cfg, clock.sleep)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("waitForTxAcceptance() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("waitForTxAcceptance() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForTxAcceptance_MaxWait(t *testing.T) {
+	client := &scriptedStatusClient{statuses: []status.Status{status.Processing}}
+	cfg := WaitConfig{PollInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxWait: 20 * time.Millisecond}
+
+	err := WaitForTxAcceptance(context.Background(), client, ids.GenerateTestID(), cfg)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForTxAcceptance() error = %v, want context.DeadlineExceeded", err)
+	}
+}
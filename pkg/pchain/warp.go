@@ -0,0 +1,242 @@
+package pchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// RegisterExpiryWindow is how far ahead of the P-Chain's clock a
+// RegisterL1Validator message may expire and still be accepted.
+const RegisterExpiryWindow = executor.RegisterL1ValidatorTxExpiryWindow * time.Second
+
+// ErrUnexpectedWarpPayload is returned when a Warp message is well formed but
+// carries a different kind of payload than the transaction needs.
+var ErrUnexpectedWarpPayload = errors.New("unexpected Warp message payload")
+
+// RegisterL1ValidatorMessage summarizes the RegisterL1Validator payload of a
+// signed Warp message.
+type RegisterL1ValidatorMessage struct {
+	SourceChainID ids.ID
+	SubnetID      ids.ID
+	NodeID        ids.NodeID
+	ValidationID  ids.ID
+	Weight        uint64
+	Expiry        time.Time
+	BLSPublicKey  []byte
+}
+
+// L1ValidatorWeightMessage summarizes the L1ValidatorWeight payload of a
+// signed Warp message.
+type L1ValidatorWeightMessage struct {
+	SourceChainID ids.ID
+	ValidationID  ids.ID
+	Nonce         uint64
+	Weight        uint64
+}
+
+// ParseRegisterL1ValidatorMessage decodes a signed Warp message for
+// RegisterL1ValidatorTx and applies the checks the P-Chain makes without
+// chain state: the network ID, the payload type and its contents, and that
+// the expiry lies within RegisterExpiryWindow of now.
+func ParseRegisterL1ValidatorMessage(b []byte, networkID uint32, now time.Time) (*RegisterL1ValidatorMessage, error) {
+	msg, inner, err := parseL1ValidatorWarpMessage(b, networkID)
+	if err != nil {
+		return nil, err
+	}
+	register, ok := inner.(*message.RegisterL1Validator)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %s, want RegisterL1Validator", ErrUnexpectedWarpPayload, payloadName(inner))
+	}
+	if err := register.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid RegisterL1Validator payload: %w", err)
+	}
+	nodeID, err := ids.ToNodeID(register.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RegisterL1Validator node ID: %w", err)
+	}
+
+	expiry := time.Unix(int64(register.Expiry), 0).UTC()
+	if !expiry.After(now) {
+		return nil, fmt.Errorf("warp message expired at %s", expiry.Format(time.RFC3339))
+	}
+	if expiry.Sub(now) > RegisterExpiryWindow {
+		return nil, fmt.Errorf("warp message expiry %s is more than %s ahead; the P-Chain will not accept it yet",
+			expiry.Format(time.RFC3339), RegisterExpiryWindow)
+	}
+
+	return &RegisterL1ValidatorMessage{
+		SourceChainID: msg.SourceChainID,
+		SubnetID:      register.SubnetID,
+		NodeID:        nodeID,
+		ValidationID:  register.ValidationID(),
+		Weight:        register.Weight,
+		Expiry:        expiry,
+		BLSPublicKey:  register.BLSPublicKey[:],
+	}, nil
+}
+
+// ParseL1ValidatorWeightMessage decodes a signed Warp message for
+// SetL1ValidatorWeightTx, checking the network ID, the payload type and its
+// contents.
+func ParseL1ValidatorWeightMessage(b []byte, networkID uint32) (*L1ValidatorWeightMessage, error) {
+	msg, inner, err := parseL1ValidatorWarpMessage(b, networkID)
+	if err != nil {
+		return nil, err
+	}
+	weight, ok := inner.(*message.L1ValidatorWeight)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %s, want L1ValidatorWeight", ErrUnexpectedWarpPayload, payloadName(inner))
+	}
+	if err := weight.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid L1ValidatorWeight payload: %w", err)
+	}
+	return &L1ValidatorWeightMessage{
+		SourceChainID: msg.SourceChainID,
+		ValidationID:  weight.ValidationID,
+		Nonce:         weight.Nonce,
+		Weight:        weight.Weight,
+	}, nil
+}
+
+// parseL1ValidatorWarpMessage unwraps a signed Warp message down to its
+// L1 validator payload: message, then AddressedCall, then payload.
+func parseL1ValidatorWarpMessage(b []byte, networkID uint32) (*warp.Message, message.Payload, error) {
+	msg, err := warp.ParseMessage(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Warp message: %w", err)
+	}
+	if msg.NetworkID != networkID {
+		return nil, nil, fmt.Errorf("warp message is for network ID %d, but the selected network is %d", msg.NetworkID, networkID)
+	}
+	call, err := payload.ParseAddressedCall(msg.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Warp message payload: not an AddressedCall: %w", err)
+	}
+	inner, err := message.Parse(call.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Warp message payload: %w", err)
+	}
+	return msg, inner, nil
+}
+
+// payloadName names a Warp payload type for error messages.
+func payloadName(p message.Payload) string {
+	switch p.(type) {
+	case *message.RegisterL1Validator:
+		return "RegisterL1Validator"
+	case *message.L1ValidatorWeight:
+		return "L1ValidatorWeight"
+	case *message.L1ValidatorRegistration:
+		return "L1ValidatorRegistration"
+	case *message.SubnetToL1Conversion:
+		return "SubnetToL1Conversion"
+	default:
+		return fmt.Sprintf("%T", p)
+	}
+}
+
+// L1Manager identifies an L1's validator manager: the chain it runs on and
+// its contract address. The P-Chain only accepts validator-set Warp messages
+// sent from this chain and address.
+type L1Manager struct {
+	ChainID ids.ID
+	Address []byte
+}
+
+// RegisterL1ValidatorRequest holds the fields of a RegisterL1Validator
+// payload.
+type RegisterL1ValidatorRequest struct {
+	SubnetID              ids.ID
+	NodeID                ids.NodeID
+	BLSPublicKey          [bls.PublicKeyLen]byte
+	Expiry                time.Time
+	RemainingBalanceOwner message.PChainOwner
+	DisableOwner          message.PChainOwner
+	Weight                uint64
+}
+
+// GetL1Manager looks up the validator manager recorded when subnetID was
+// converted to an L1.
+func GetL1Manager(ctx context.Context, rpcURL string, subnetID ids.ID) (L1Manager, error) {
+	return GetL1ManagerWithConfig(ctx, network.Config{RPCURL: rpcURL}, subnetID)
+}
+
+// GetL1ManagerWithConfig is GetL1Manager for the node of config.
+func GetL1ManagerWithConfig(ctx context.Context, config network.Config, subnetID ids.ID) (L1Manager, error) {
+	return getL1Manager(ctx, network.NewPChainClient(ctx, config), subnetID)
+}
+
+func getL1Manager(ctx context.Context, client subnetGetter, subnetID ids.ID) (L1Manager, error) {
+	subnet, err := client.GetSubnet(ctx, subnetID)
+	if err != nil {
+		return L1Manager{}, fmt.Errorf("failed to fetch subnet %s: %w", subnetID, err)
+	}
+	if subnet.ConversionID == ids.Empty {
+		return L1Manager{}, fmt.Errorf("subnet %s has not been converted to an L1", subnetID)
+	}
+	return L1Manager{ChainID: subnet.ManagerChainID, Address: subnet.ManagerAddress}, nil
+}
+
+// NewRegisterL1ValidatorMessage builds the unsigned Warp message the
+// validator manager sends to register a validator, and returns it together
+// with the validation ID the P-Chain will assign. The message still has to
+// be signed by the L1's validators before RegisterL1ValidatorTx accepts it.
+func NewRegisterL1ValidatorMessage(networkID uint32, manager L1Manager, req RegisterL1ValidatorRequest) (*warp.UnsignedMessage, ids.ID, error) {
+	if len(manager.Address) == 0 {
+		return nil, ids.Empty, errors.New("validator manager address is required")
+	}
+	register, err := message.NewRegisterL1Validator(
+		req.SubnetID,
+		req.NodeID,
+		req.BLSPublicKey,
+		uint64(req.Expiry.Unix()),
+		req.RemainingBalanceOwner,
+		req.DisableOwner,
+		req.Weight,
+	)
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("failed to build RegisterL1Validator payload: %w", err)
+	}
+	if err := register.Verify(); err != nil {
+		return nil, ids.Empty, fmt.Errorf("invalid RegisterL1Validator payload: %w", err)
+	}
+	call, err := payload.NewAddressedCall(manager.Address, register.Bytes())
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("failed to build AddressedCall: %w", err)
+	}
+	unsigned, err := warp.NewUnsignedMessage(networkID, manager.ChainID, call.Bytes())
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("failed to build Warp message: %w", err)
+	}
+	return unsigned, register.ValidationID(), nil
+}
+
+// SignWarpMessage attaches an aggregate BLS signature to unsigned. signers
+// is the bit set of validator indices (in the canonical validator order)
+// that contributed to signature, in its minimal big-endian encoding.
+func SignWarpMessage(unsigned *warp.UnsignedMessage, signers []byte, signature [bls.SignatureLen]byte) ([]byte, error) {
+	bits := set.BitsFromBytes(signers)
+	if bits.Len() == 0 {
+		return nil, errors.New("signer bit set is empty")
+	}
+	if !bytes.Equal(bits.Bytes(), signers) {
+		return nil, errors.New("signer bit set is not minimally encoded (remove leading zero bytes)")
+	}
+	msg, err := warp.NewMessage(unsigned, &warp.BitSetSignature{Signers: signers, Signature: signature})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed Warp message: %w", err)
+	}
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,193 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+const testWarpNetworkID = 5
+
+// signedWarpMessage wraps p in an AddressedCall and an (unsigned-for-test)
+// Warp message for networkID.
+func signedWarpMessage(t *testing.T, networkID uint32, p message.Payload) []byte {
+	t.Helper()
+	call, err := payload.NewAddressedCall([]byte{0x01}, p.Bytes())
+	if err != nil {
+		t.Fatalf("NewAddressedCall() error = %v", err)
+	}
+	unsigned, err := warp.NewUnsignedMessage(networkID, ids.GenerateTestID(), call.Bytes())
+	if err != nil {
+		t.Fatalf("NewUnsignedMessage() error = %v", err)
+	}
+	msg, err := warp.NewMessage(unsigned, &warp.BitSetSignature{})
+	if err != nil {
+		t.Fatalf("NewMessage() error = %v", err)
+	}
+	return msg.Bytes()
+}
+
+func newRegisterPayload(t *testing.T, expiry time.Time, weight uint64) *message.RegisterL1Validator {
+	t.Helper()
+	p, err := message.NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		[bls.PublicKeyLen]byte{},
+		uint64(expiry.Unix()),
+		message.PChainOwner{},
+		message.PChainOwner{},
+		weight,
+	)
+	if err != nil {
+		t.Fatalf("NewRegisterL1Validator() error = %v", err)
+	}
+	return p
+}
+
+func TestParseRegisterL1ValidatorMessage(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	register := newRegisterPayload(t, now.Add(time.Hour), 20)
+	weight, err := message.NewL1ValidatorWeight(ids.GenerateTestID(), 1, 5)
+	if err != nil {
+		t.Fatalf("NewL1ValidatorWeight() error = %v", err)
+	}
+
+	got, err := ParseRegisterL1ValidatorMessage(signedWarpMessage(t, testWarpNetworkID, register), testWarpNetworkID, now)
+	if err != nil {
+		t.Fatalf("ParseRegisterL1ValidatorMessage() error = %v", err)
+	}
+	if got.SubnetID != register.SubnetID || got.ValidationID != register.ValidationID() || got.Weight != 20 {
+		t.Fatalf("ParseRegisterL1ValidatorMessage() = %+v", got)
+	}
+
+	tests := []struct {
+		name    string
+		msg     []byte
+		wantErr error
+	}{
+		{name: "not a warp message", msg: []byte{0xde, 0xad}},
+		{name: "wrong network", msg: signedWarpMessage(t, 1, register)},
+		{name: "weight payload", msg: signedWarpMessage(t, testWarpNetworkID, weight), wantErr: ErrUnexpectedWarpPayload},
+		{name: "expired", msg: signedWarpMessage(t, testWarpNetworkID, newRegisterPayload(t, now, 20))},
+		{name: "expiry too far ahead", msg: signedWarpMessage(t, testWarpNetworkID, newRegisterPayload(t, now.Add(RegisterExpiryWindow+time.Second), 20))},
+		{name: "zero weight", msg: signedWarpMessage(t, testWarpNetworkID, newRegisterPayload(t, now.Add(time.Hour), 0))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRegisterL1ValidatorMessage(tt.msg, testWarpNetworkID, now)
+			if err == nil {
+				t.Fatal("ParseRegisterL1ValidatorMessage() expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRegisterL1ValidatorMessage() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseL1ValidatorWeightMessage(t *testing.T) {
+	validationID := ids.GenerateTestID()
+	weight, err := message.NewL1ValidatorWeight(validationID, 3, 50)
+	if err != nil {
+		t.Fatalf("NewL1ValidatorWeight() error = %v", err)
+	}
+
+	got, err := ParseL1ValidatorWeightMessage(signedWarpMessage(t, testWarpNetworkID, weight), testWarpNetworkID)
+	if err != nil {
+		t.Fatalf("ParseL1ValidatorWeightMessage() error = %v", err)
+	}
+	want := L1ValidatorWeightMessage{SourceChainID: got.SourceChainID, ValidationID: validationID, Nonce: 3, Weight: 50}
+	if *got != want {
+		t.Fatalf("ParseL1ValidatorWeightMessage() = %+v, want %+v", *got, want)
+	}
+
+	register := newRegisterPayload(t, time.Now().Add(time.Hour), 20)
+	_, err = ParseL1ValidatorWeightMessage(signedWarpMessage(t, testWarpNetworkID, register), testWarpNetworkID)
+	if !errors.Is(err, ErrUnexpectedWarpPayload) {
+		t.Fatalf("ParseL1ValidatorWeightMessage(register payload) error = %v, want ErrUnexpectedWarpPayload", err)
+	}
+
+	if _, err := ParseL1ValidatorWeightMessage(signedWarpMessage(t, 1, weight), testWarpNetworkID); err == nil {
+		t.Fatal("ParseL1ValidatorWeightMessage() expected error for the wrong network")
+	}
+}
+
+func TestGetL1Manager(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+
+	got, err := getL1Manager(context.Background(), &stubSubnetGetter{subnet: platformvm.GetSubnetClientResponse{
+		ConversionID:   ids.GenerateTestID(),
+		ManagerChainID: chainID,
+		ManagerAddress: []byte{0xaa},
+	}}, subnetID)
+	if err != nil {
+		t.Fatalf("getL1Manager() error = %v", err)
+	}
+	if got.ChainID != chainID || len(got.Address) != 1 || got.Address[0] != 0xaa {
+		t.Fatalf("getL1Manager() = %+v", got)
+	}
+
+	if _, err := getL1Manager(context.Background(), &stubSubnetGetter{subnet: platformvm.GetSubnetClientResponse{IsPermissioned: true}}, subnetID); err == nil {
+		t.Fatal("getL1Manager() expected error for an unconverted subnet")
+	}
+	if _, err := getL1Manager(context.Background(), &stubSubnetGetter{err: errors.New("boom")}, subnetID); err == nil {
+		t.Fatal("getL1Manager() expected error when the lookup fails")
+	}
+}
+
+func TestBuildAndSignRegisterL1ValidatorMessage(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	manager := L1Manager{ChainID: ids.GenerateTestID(), Address: []byte{0x01, 0x02}}
+	req := RegisterL1ValidatorRequest{
+		SubnetID: ids.GenerateTestID(),
+		NodeID:   ids.GenerateTestNodeID(),
+		Expiry:   now.Add(time.Hour),
+		Weight:   20,
+	}
+
+	unsigned, validationID, err := NewRegisterL1ValidatorMessage(testWarpNetworkID, manager, req)
+	if err != nil {
+		t.Fatalf("NewRegisterL1ValidatorMessage() error = %v", err)
+	}
+	signed, err := SignWarpMessage(unsigned, []byte{0x05}, [bls.SignatureLen]byte{})
+	if err != nil {
+		t.Fatalf("SignWarpMessage() error = %v", err)
+	}
+
+	got, err := ParseRegisterL1ValidatorMessage(signed, testWarpNetworkID, now)
+	if err != nil {
+		t.Fatalf("ParseRegisterL1ValidatorMessage() error = %v", err)
+	}
+	if got.SourceChainID != manager.ChainID || got.SubnetID != req.SubnetID || got.NodeID != req.NodeID ||
+		got.ValidationID != validationID || got.Weight != req.Weight || !got.Expiry.Equal(req.Expiry) {
+		t.Fatalf("round trip = %+v, want %+v", got, req)
+	}
+
+	if _, _, err := NewRegisterL1ValidatorMessage(testWarpNetworkID, L1Manager{ChainID: manager.ChainID}, req); err == nil {
+		t.Fatal("NewRegisterL1ValidatorMessage() expected error without a manager address")
+	}
+	zeroWeight := req
+	zeroWeight.Weight = 0
+	if _, _, err := NewRegisterL1ValidatorMessage(testWarpNetworkID, manager, zeroWeight); err == nil {
+		t.Fatal("NewRegisterL1ValidatorMessage() expected error for zero weight")
+	}
+
+	for name, signers := range map[string][]byte{
+		"empty":          nil,
+		"no bits set":    {0x00},
+		"leading zeroes": {0x00, 0x01},
+	} {
+		if _, err := SignWarpMessage(unsigned, signers, [bls.SignatureLen]byte{}); err == nil {
+			t.Fatalf("SignWarpMessage(%s) expected error", name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//go:build qr
+
+// Package qr renders short strings, such as addresses, as QR codes. It is
+// compiled in only with the qr build tag, so default builds do not carry the
+// QR encoder.
+package qr
+
+import (
+	"fmt"
+	"os"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Enabled indicates whether QR support is compiled in.
+const Enabled = true
+
+// level is the error-correction level; medium tolerates some glare or
+// smudging on a phone screen without making the code much denser.
+const level = qrcode.Medium
+
+// Terminal renders content as a QR code drawn with Unicode half blocks, for
+// printing to a terminal.
+func Terminal(content string) (string, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return code.ToSmallString(false), nil
+}
+
+// pngFilePerm is the mode of written PNG files. An address is not secret,
+// but only the owner should be able to swap it for another one.
+const pngFilePerm = 0o600
+
+// WritePNG writes content as a size x size pixel PNG QR code to path. It
+// fails rather than overwrite an existing file.
+func WritePNG(content, path string, size int) error {
+	png, err := qrcode.Encode(content, level, size)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, pngFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create QR code file: %w", err)
+	}
+	if _, err := file.Write(png); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write QR code to %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write QR code to %s: %w", path, err)
+	}
+	return nil
+}
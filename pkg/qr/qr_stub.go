@@ -0,0 +1,22 @@
+//go:build !qr
+
+package qr
+
+import "fmt"
+
+// Enabled indicates whether QR support is compiled in.
+const Enabled = false
+
+// errNotCompiled is returned by every function when QR support is not
+// compiled in.
+var errNotCompiled = fmt.Errorf("QR code support not compiled. Rebuild with: go build -tags qr")
+
+// Terminal returns error for stub.
+func Terminal(content string) (string, error) {
+	return "", errNotCompiled
+}
+
+// WritePNG returns error for stub.
+func WritePNG(content, path string, size int) error {
+	return errNotCompiled
+}
@@ -0,0 +1,50 @@
+//go:build qr
+
+package qr
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTerminal(t *testing.T) {
+	got, err := Terminal("P-fuji1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq")
+	if err != nil {
+		t.Fatalf("Terminal() error = %v", err)
+	}
+	if lines := strings.Count(got, "\n"); lines < 10 {
+		t.Fatalf("Terminal() rendered %d lines, want a full code", lines)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addr.png")
+	if err := WritePNG("0x0000000000000000000000000000000000000000", path, 256); err != nil {
+		t.Fatalf("WritePNG() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("\x89PNG")) {
+		t.Fatal("WritePNG() did not write a PNG")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != pngFilePerm {
+		t.Fatalf("WritePNG() mode = %o, want %o", perm, pngFilePerm)
+	}
+
+	// An existing file is never overwritten.
+	if err := WritePNG("0x1111111111111111111111111111111111111111", path, 256); err == nil {
+		t.Fatal("WritePNG() expected error for an existing file")
+	}
+	if again, _ := os.ReadFile(path); !bytes.Equal(again, data) {
+		t.Fatal("WritePNG() changed the existing file")
+	}
+}
@@ -0,0 +1,98 @@
+// Package retry bounds the total time a command spends waiting between
+// retries, across every retry loop that runs under the same context.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deadlineShare is the divisor applied to the time left before a context's
+// deadline to size the budget installed by WithDeadlineBudget. Half the
+// remaining time may be spent backing off; the rest is left for the calls.
+const deadlineShare = 2
+
+// ErrBudgetExhausted is returned by Wait when another retry would overrun the
+// shared retry budget or the context deadline.
+var ErrBudgetExhausted = errors.New("retry budget exhausted")
+
+// Budget is the total backoff time that retry loops sharing it may spend.
+// It is safe for concurrent use.
+type Budget struct {
+	mu        sync.Mutex
+	remaining time.Duration
+}
+
+// NewBudget returns a budget allowing total worth of retry waits.
+func NewBudget(total time.Duration) *Budget {
+	return &Budget{remaining: total}
+}
+
+// Remaining returns the wait time left in the budget.
+func (b *Budget) Remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining
+}
+
+// take reserves d from the budget, reporting whether enough was left.
+func (b *Budget) take(d time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d > b.remaining {
+		return false
+	}
+	b.remaining -= d
+	return true
+}
+
+type budgetKey struct{}
+
+// WithBudget returns a context carrying b. Wait calls under the returned
+// context draw from b.
+func WithBudget(ctx context.Context, b *Budget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// WithDeadlineBudget returns a context carrying a budget sized from ctx's
+// deadline. A context without a deadline is returned unchanged.
+func WithDeadlineBudget(ctx context.Context) context.Context {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx
+	}
+	return WithBudget(ctx, NewBudget(time.Until(deadline)/deadlineShare))
+}
+
+// FromContext returns the budget carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Budget {
+	b, _ := ctx.Value(budgetKey{}).(*Budget)
+	return b
+}
+
+// Wait sleeps for delay before a retry. It returns ctx.Err() if ctx ends
+// first, and an error wrapping ErrBudgetExhausted, without sleeping, if the
+// wait would pass ctx's deadline or exceed the budget carried by ctx.
+func Wait(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return fmt.Errorf("%w: waiting %s would pass the operation deadline", ErrBudgetExhausted, delay)
+	}
+	if b := FromContext(ctx); b != nil && !b.take(delay) {
+		return fmt.Errorf("%w: waiting %s would exceed the %s left for retries", ErrBudgetExhausted, delay, b.Remaining())
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWait_SharedBudget(t *testing.T) {
+	budget := NewBudget(30 * time.Millisecond)
+	ctx := WithBudget(context.Background(), budget)
+
+	if err := Wait(ctx, 20*time.Millisecond); err != nil {
+		t.Fatalf("first Wait() error = %v", err)
+	}
+	if got := budget.Remaining(); got != 10*time.Millisecond {
+		t.Fatalf("Remaining() = %s, want 10ms", got)
+	}
+
+	// A second loop drawing on the same budget is refused without sleeping.
+	start := time.Now()
+	err := Wait(ctx, 20*time.Millisecond)
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("second Wait() error = %v, want ErrBudgetExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 20*time.Millisecond {
+		t.Fatalf("refused Wait() slept %s", elapsed)
+	}
+}
+
+func TestWait_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := Wait(ctx, time.Second); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Wait() past deadline error = %v, want ErrBudgetExhausted", err)
+	}
+}
+
+func TestWait_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := Wait(ctx, time.Millisecond); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() on cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestWithDeadlineBudget(t *testing.T) {
+	if b := FromContext(WithDeadlineBudget(context.Background())); b != nil {
+		t.Fatalf("budget without deadline = %s, want none", b.Remaining())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	b := FromContext(WithDeadlineBudget(ctx))
+	if b == nil {
+		t.Fatal("WithDeadlineBudget() installed no budget")
+	}
+	if got := b.Remaining(); got <= 0 || got > time.Minute/deadlineShare {
+		t.Fatalf("Remaining() = %s, want (0, %s]", got, time.Minute/deadlineShare)
+	}
+}
@@ -0,0 +1,228 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethclient"
+	"github.com/ava-labs/libevm/params"
+	"github.com/ava-labs/libevm/rpc"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// cChainRPCPath is the C-Chain EVM JSON-RPC endpoint, relative to the node URL.
+const cChainRPCPath = "/ext/bc/C/rpc"
+
+// weiPerNAVAX converts C-Chain balances (18 decimals) to nAVAX (9 decimals).
+var weiPerNAVAX = big.NewInt(params.GWei)
+
+// ErrNotContractCreation is returned when a transaction did not deploy a
+// contract.
+var ErrNotContractCreation = errors.New("transaction is not a successful contract creation")
+
+// DialCChain connects to the C-Chain JSON-RPC endpoint of config's node,
+// sending requests with network.HTTPClient(ctx). Callers must Close the
+// returned client.
+func DialCChain(ctx context.Context, config network.Config) (*ethclient.Client, error) {
+	client, err := rpc.DialOptions(ctx, config.RPCURL+cChainRPCPath, rpc.WithHTTPClient(network.HTTPClient(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to C-Chain: %w", err)
+	}
+	return ethclient.NewClient(client), nil
+}
+
+// GetCChainBalance returns the C-Chain AVAX balance of addr in nAVAX, rounded
+// down. It only talks to the C-Chain endpoint, so it works when the P-Chain
+// is unreachable and vice versa.
+func GetCChainBalance(ctx context.Context, config network.Config, addr common.Address) (uint64, error) {
+	client, err := DialCChain(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	wei, err := client.BalanceAt(ctx, addr, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get C-Chain balance: %w", err)
+	}
+	return balanceToNAVAX(wei)
+}
+
+// balanceToNAVAX converts a wei balance to nAVAX, rounding down.
+func balanceToNAVAX(wei *big.Int) (uint64, error) {
+	nAVAX := new(big.Int).Quo(wei, weiPerNAVAX)
+	if !nAVAX.IsUint64() {
+		return 0, fmt.Errorf("C-Chain balance %s wei overflows nAVAX", wei)
+	}
+	return nAVAX.Uint64(), nil
+}
+
+// ErrHistoricalStateUnavailable is returned when a node cannot serve C-Chain
+// state at a past block, because it prunes old state (it is not an archive
+// node).
+var ErrHistoricalStateUnavailable = errors.New("node does not serve historical C-Chain state (an archive node is required)")
+
+// prunedStateErrors are fragments of the errors nodes return when asked for
+// state they no longer keep.
+var prunedStateErrors = []string{"missing trie node", "historical state", "state not available", "pruned"}
+
+// cChainHistoryReader reads C-Chain headers and balances at a given block.
+// ethclient.Client satisfies it.
+type cChainHistoryReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// GetCChainBalanceAt returns the C-Chain AVAX balance of addr in nAVAX as of
+// block height, rounded down. Only archive nodes keep the state this needs;
+// other nodes fail with ErrHistoricalStateUnavailable.
+func GetCChainBalanceAt(ctx context.Context, config network.Config, addr common.Address, height uint64) (uint64, error) {
+	client, err := DialCChain(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	return cChainBalanceAt(ctx, client, addr, height)
+}
+
+func cChainBalanceAt(ctx context.Context, client cChainHistoryReader, addr common.Address, height uint64) (uint64, error) {
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get C-Chain head: %w", err)
+	}
+	if height > head.Number.Uint64() {
+		return 0, fmt.Errorf("height %d is past the C-Chain head (%d)", height, head.Number.Uint64())
+	}
+
+	wei, err := client.BalanceAt(ctx, addr, new(big.Int).SetUint64(height))
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		for _, fragment := range prunedStateErrors {
+			if strings.Contains(msg, fragment) {
+				return 0, fmt.Errorf("%w: %v", ErrHistoricalStateUnavailable, err)
+			}
+		}
+		return 0, fmt.Errorf("failed to get C-Chain balance at height %d: %w", height, err)
+	}
+	return balanceToNAVAX(wei)
+}
+
+// CChainHeightAt returns the height of the last C-Chain block produced at or
+// before t, found by binary search over block timestamps.
+func CChainHeightAt(ctx context.Context, config network.Config, t time.Time) (uint64, error) {
+	client, err := DialCChain(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	return cChainHeightAt(ctx, client, t)
+}
+
+func cChainHeightAt(ctx context.Context, client cChainHistoryReader, t time.Time) (uint64, error) {
+	header := func(height *big.Int) (*types.Header, error) {
+		h, err := client.HeaderByNumber(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get C-Chain block header: %w", err)
+		}
+		return h, nil
+	}
+
+	head, err := header(nil)
+	if err != nil {
+		return 0, err
+	}
+	if t.Unix() >= 0 && head.Time <= uint64(t.Unix()) {
+		return head.Number.Uint64(), nil
+	}
+	genesis, err := header(new(big.Int))
+	if err != nil {
+		return 0, err
+	}
+	if t.Unix() < 0 || genesis.Time > uint64(t.Unix()) {
+		return 0, fmt.Errorf("%s is before the C-Chain genesis", t.UTC().Format(time.RFC3339))
+	}
+
+	// Invariant: block lo is at or before t, block hi is after it.
+	lo, hi := uint64(0), head.Number.Uint64()
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		h, err := header(new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+		if h.Time <= uint64(t.Unix()) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// NAVAXToWei converts a C-Chain amount or gas price from nAVAX to wei.
+func NAVAXToWei(nAVAX uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(nAVAX), weiPerNAVAX)
+}
+
+// GetCChainBaseFee returns the C-Chain's current base fee in nAVAX per gas,
+// rounded up so that it is always enough to be accepted at that moment.
+func GetCChainBaseFee(ctx context.Context, config network.Config) (uint64, error) {
+	client, err := DialCChain(ctx, config)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	var baseFee hexutil.Big
+	if err := client.Client().CallContext(ctx, &baseFee, "eth_baseFee"); err != nil {
+		return 0, fmt.Errorf("failed to get C-Chain base fee: %w", err)
+	}
+	wei := baseFee.ToInt()
+	nAVAX, rem := new(big.Int).QuoRem(wei, weiPerNAVAX, new(big.Int))
+	if rem.Sign() != 0 {
+		nAVAX.Add(nAVAX, big.NewInt(1))
+	}
+	if !nAVAX.IsUint64() {
+		return 0, fmt.Errorf("C-Chain base fee %s wei overflows nAVAX", wei)
+	}
+	return nAVAX.Uint64(), nil
+}
+
+// ContractReceiptClient reads transaction receipts and contract code.
+// ethclient.Client satisfies it.
+type ContractReceiptClient interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// DeployedContractAddress returns the address of the contract deployed by
+// txHash. It fails with ErrNotContractCreation if the transaction reverted,
+// deployed nothing, or left no code at the address.
+func DeployedContractAddress(ctx context.Context, client ContractReceiptClient, txHash common.Hash) (common.Address, error) {
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get receipt for %s: %w", txHash.Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, fmt.Errorf("%w: %s reverted", ErrNotContractCreation, txHash.Hex())
+	}
+	if receipt.ContractAddress == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: %s has no contract address", ErrNotContractCreation, txHash.Hex())
+	}
+
+	code, err := client.CodeAt(ctx, receipt.ContractAddress, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get code at %s: %w", receipt.ContractAddress.Hex(), err)
+	}
+	if len(code) == 0 {
+		return common.Address{}, fmt.Errorf("%w: no code at %s", ErrNotContractCreation, receipt.ContractAddress.Hex())
+	}
+	return receipt.ContractAddress, nil
+}
@@ -0,0 +1,247 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// newEthRPCServer answers method on the C-Chain endpoint with result.
+func newEthRPCServer(t *testing.T, method string, result any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != cChainRPCPath {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		if req.Method != method {
+			t.Errorf("method = %q, want %s", req.Method, method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newEthBalanceServer serves eth_getBalance with the given hex-encoded wei
+// balance.
+func newEthBalanceServer(t *testing.T, weiHex string) *httptest.Server {
+	t.Helper()
+	return newEthRPCServer(t, "eth_getBalance", weiHex)
+}
+
+func TestGetCChainBalance(t *testing.T) {
+	tests := []struct {
+		name   string
+		weiHex string
+		want   uint64
+	}{
+		{name: "zero", weiHex: "0x0", want: 0},
+		{name: "whole AVAX", weiHex: "0xde0b6b3a7640000", want: 1_000_000_000}, // 1e18 wei
+		{name: "sub-nAVAX dust rounds down", weiHex: "0x773593ff", want: 1},    // 2e9-1 wei
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newEthBalanceServer(t, tt.weiHex)
+			got, err := GetCChainBalance(context.Background(), network.Config{RPCURL: srv.URL}, common.Address{})
+			if err != nil {
+				t.Fatalf("GetCChainBalance() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("GetCChainBalance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCChainBaseFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		weiHex string
+		want   uint64
+	}{
+		{name: "whole nAVAX", weiHex: "0x5d21dba00", want: 25},      // 25 gwei
+		{name: "fraction rounds up", weiHex: "0x3b9aca01", want: 2}, // 1 gwei + 1 wei
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newEthRPCServer(t, "eth_baseFee", tt.weiHex)
+			got, err := GetCChainBaseFee(context.Background(), network.Config{RPCURL: srv.URL})
+			if err != nil {
+				t.Fatalf("GetCChainBaseFee() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("GetCChainBaseFee() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// stubReceiptClient implements ContractReceiptClient.
+type stubReceiptClient struct {
+	receipt    *types.Receipt
+	receiptErr error
+	code       []byte
+}
+
+func (s *stubReceiptClient) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return s.receipt, s.receiptErr
+}
+
+func (s *stubReceiptClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return s.code, nil
+}
+
+func TestDeployedContractAddress(t *testing.T) {
+	deployed := common.HexToAddress("0x0feedc0de0000000000000000000000000000000")
+	tests := []struct {
+		name            string
+		client          *stubReceiptClient
+		want            common.Address
+		wantErr         bool
+		wantNotCreation bool
+	}{
+		{
+			name: "contract creation",
+			client: &stubReceiptClient{
+				receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, ContractAddress: deployed},
+				code:    []byte{0x60, 0x80},
+			},
+			want: deployed,
+		},
+		{
+			name:            "plain call",
+			client:          &stubReceiptClient{receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful}},
+			wantErr:         true,
+			wantNotCreation: true,
+		},
+		{
+			name: "reverted deployment",
+			client: &stubReceiptClient{
+				receipt: &types.Receipt{Status: types.ReceiptStatusFailed, ContractAddress: deployed},
+			},
+			wantErr:         true,
+			wantNotCreation: true,
+		},
+		{
+			name: "no code",
+			client: &stubReceiptClient{
+				receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, ContractAddress: deployed},
+			},
+			wantErr:         true,
+			wantNotCreation: true,
+		},
+		{
+			name:    "receipt not found",
+			client:  &stubReceiptClient{receiptErr: errors.New("not found")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeployedContractAddress(context.Background(), tt.client, common.Hash{1})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeployedContractAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrNotContractCreation); got != tt.wantNotCreation {
+				t.Fatalf("errors.Is(err, ErrNotContractCreation) = %v, want %v (err: %v)", got, tt.wantNotCreation, err)
+			}
+			if got != tt.want {
+				t.Fatalf("DeployedContractAddress() = %s, want %s", got.Hex(), tt.want.Hex())
+			}
+		})
+	}
+}
+
+// stubHistory implements cChainHistoryReader over a chain of blocks whose
+// timestamps are given by times (index = height).
+type stubHistory struct {
+	times      []uint64
+	balanceErr error
+}
+
+func (s *stubHistory) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	height := uint64(len(s.times) - 1)
+	if number != nil {
+		height = number.Uint64()
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(height), Time: s.times[height]}, nil
+}
+
+func (s *stubHistory) BalanceAt(_ context.Context, _ common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if s.balanceErr != nil {
+		return nil, s.balanceErr
+	}
+	// One AVAX per block of history.
+	return new(big.Int).Mul(blockNumber, big.NewInt(1e18)), nil
+}
+
+func TestCChainBalanceAt(t *testing.T) {
+	history := &stubHistory{times: []uint64{100, 110, 120}}
+	got, err := cChainBalanceAt(context.Background(), history, common.Address{}, 2)
+	if err != nil {
+		t.Fatalf("cChainBalanceAt() error = %v", err)
+	}
+	if got != 2_000_000_000 {
+		t.Fatalf("cChainBalanceAt() = %d, want 2000000000", got)
+	}
+
+	if _, err := cChainBalanceAt(context.Background(), history, common.Address{}, 3); err == nil {
+		t.Fatal("cChainBalanceAt() expected error past the head")
+	}
+
+	history.balanceErr = errors.New("missing trie node 1a2b (path )")
+	if _, err := cChainBalanceAt(context.Background(), history, common.Address{}, 1); !errors.Is(err, ErrHistoricalStateUnavailable) {
+		t.Fatalf("cChainBalanceAt() error = %v, want ErrHistoricalStateUnavailable", err)
+	}
+
+	history.balanceErr = errors.New("connection refused")
+	if _, err := cChainBalanceAt(context.Background(), history, common.Address{}, 1); err == nil || errors.Is(err, ErrHistoricalStateUnavailable) {
+		t.Fatalf("cChainBalanceAt() error = %v, want a plain failure", err)
+	}
+}
+
+func TestCChainHeightAt(t *testing.T) {
+	history := &stubHistory{times: []uint64{100, 110, 110, 120, 135, 150}}
+	tests := []struct {
+		unix    int64
+		want    uint64
+		wantErr bool
+	}{
+		{unix: 99, wantErr: true},
+		{unix: 100, want: 0},
+		{unix: 109, want: 0},
+		{unix: 110, want: 2},
+		{unix: 134, want: 3},
+		{unix: 135, want: 4},
+		{unix: 150, want: 5},
+		{unix: 1_000, want: 5},
+	}
+	for _, tt := range tests {
+		got, err := cChainHeightAt(context.Background(), history, time.Unix(tt.unix, 0))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("cChainHeightAt(%d) error = %v, wantErr %v", tt.unix, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("cChainHeightAt(%d) = %d, want %d", tt.unix, got, tt.want)
+		}
+	}
+}
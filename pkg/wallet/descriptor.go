@@ -0,0 +1,134 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+const (
+	// DescriptorVersion is the current descriptor format version.
+	DescriptorVersion = 1
+
+	// DescriptorTypeSecp256k1 describes a single secp256k1 key. Future types
+	// (an HD account or a Ledger address chain) will carry an extended public
+	// key and use DerivationPath as the account path.
+	DescriptorTypeSecp256k1 = "secp256k1"
+)
+
+// Descriptor is the public, watch-only description of a wallet. It holds
+// everything needed to derive the wallet's addresses and nothing that can
+// sign.
+type Descriptor struct {
+	Version int    `json:"version"`
+	Type    string `json:"type"`
+	// PublicKey is the hex-encoded compressed public key.
+	PublicKey string `json:"publicKey"`
+	// DerivationPath is the BIP44 path the key was derived from, if known.
+	DerivationPath string `json:"derivationPath,omitempty"`
+	// PChainAddress (CB58 short ID) and EVMAddress are informational and
+	// are checked against PublicKey when the descriptor is parsed.
+	PChainAddress string `json:"pChainAddress"`
+	EVMAddress    string `json:"evmAddress"`
+}
+
+// NewDescriptor builds a descriptor for a single secp256k1 public key.
+func NewDescriptor(pub *secp256k1.PublicKey, derivationPath string) Descriptor {
+	return Descriptor{
+		Version:        DescriptorVersion,
+		Type:           DescriptorTypeSecp256k1,
+		PublicKey:      "0x" + hex.EncodeToString(pub.Bytes()),
+		DerivationPath: derivationPath,
+		PChainAddress:  pub.Address().String(),
+		EVMAddress:     pub.EthAddress().Hex(),
+	}
+}
+
+// ParseDescriptor decodes a JSON descriptor and returns its public key. It
+// rejects unknown versions and types, and addresses that do not match the key.
+func ParseDescriptor(data []byte) (Descriptor, *secp256k1.PublicKey, error) {
+	var d Descriptor
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Descriptor{}, nil, fmt.Errorf("failed to decode descriptor: %w", err)
+	}
+	if d.Version != DescriptorVersion {
+		return Descriptor{}, nil, fmt.Errorf("unsupported descriptor version %d (expected %d)", d.Version, DescriptorVersion)
+	}
+	if d.Type != DescriptorTypeSecp256k1 {
+		return Descriptor{}, nil, fmt.Errorf("unsupported descriptor type %q", d.Type)
+	}
+
+	pubBytes, err := hex.DecodeString(strings.TrimPrefix(d.PublicKey, "0x"))
+	if err != nil {
+		return Descriptor{}, nil, fmt.Errorf("invalid descriptor public key: %w", err)
+	}
+	pub, err := secp256k1.ToPublicKey(pubBytes)
+	if err != nil {
+		return Descriptor{}, nil, fmt.Errorf("invalid descriptor public key: %w", err)
+	}
+
+	if d.PChainAddress != "" && d.PChainAddress != pub.Address().String() {
+		return Descriptor{}, nil, fmt.Errorf("descriptor P-Chain address %s does not match its public key", d.PChainAddress)
+	}
+	if d.EVMAddress != "" && !strings.EqualFold(d.EVMAddress, pub.EthAddress().Hex()) {
+		return Descriptor{}, nil, fmt.Errorf("descriptor EVM address %s does not match its public key", d.EVMAddress)
+	}
+	return d, pub, nil
+}
+
+// ReadOnlyWallet watches the P-Chain addresses of a set of public keys. It
+// cannot sign or issue transactions.
+type ReadOnlyWallet struct {
+	addresses []ids.ShortID
+	config    network.Config
+}
+
+// NewReadOnlyWalletFromPublicKey creates a watch-only wallet for the given
+// public keys.
+func NewReadOnlyWalletFromPublicKey(config network.Config, pubs ...*secp256k1.PublicKey) (*ReadOnlyWallet, error) {
+	if len(pubs) == 0 {
+		return nil, fmt.Errorf("at least one public key is required")
+	}
+	addresses := make([]ids.ShortID, len(pubs))
+	for i, pub := range pubs {
+		addresses[i] = pub.Address()
+	}
+	return &ReadOnlyWallet{addresses: addresses, config: config}, nil
+}
+
+// Addresses returns the watched P-Chain addresses.
+func (w *ReadOnlyWallet) Addresses() []ids.ShortID {
+	return append([]ids.ShortID(nil), w.addresses...)
+}
+
+// FormattedPChainAddresses returns the watched addresses formatted for the
+// wallet's network.
+func (w *ReadOnlyWallet) FormattedPChainAddresses() []string {
+	formatted := make([]string, len(w.addresses))
+	for i, addr := range w.addresses {
+		formatted[i] = FormatPChainAddress(addr, w.config.NetworkID)
+	}
+	return formatted
+}
+
+// GetPChainBalance returns the combined unlocked P-Chain balance, in nAVAX,
+// of the watched addresses.
+func (w *ReadOnlyWallet) GetPChainBalance(ctx context.Context) (uint64, error) {
+	client := network.NewPChainClient(ctx, w.config)
+	resp, err := client.GetBalance(ctx, w.addresses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return uint64(resp.Unlocked), nil
+}
+
+// Config returns the network configuration.
+func (w *ReadOnlyWallet) Config() network.Config {
+	return w.config
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+func testPublicKey(t *testing.T) *secp256k1.PublicKey {
+	t.Helper()
+	key, err := ToPrivateKey(testKeyBytes)
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+	return key.PublicKey()
+}
+
+func TestDescriptorRoundTrip(t *testing.T) {
+	pub := testPublicKey(t)
+	data, err := json.Marshal(NewDescriptor(pub, ""))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "derivationPath") {
+		t.Errorf("descriptor without a path should omit derivationPath: %s", data)
+	}
+
+	d, got, err := ParseDescriptor(data)
+	if err != nil {
+		t.Fatalf("ParseDescriptor() error = %v", err)
+	}
+	if got.Address() != pub.Address() {
+		t.Fatalf("ParseDescriptor() address = %s, want %s", got.Address(), pub.Address())
+	}
+	// ewoq's well-known P-Chain short ID
+	if d.PChainAddress != "6Y3kysjF9jnHnYkdS9yGAuoHyae2eNmeV" {
+		t.Errorf("PChainAddress = %s, want ewoq address", d.PChainAddress)
+	}
+}
+
+func TestParseDescriptorErrors(t *testing.T) {
+	valid := NewDescriptor(testPublicKey(t), "")
+	otherKey, err := secp256k1.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(d *Descriptor)
+	}{
+		{"unknown version", func(d *Descriptor) { d.Version = 99 }},
+		{"unknown type", func(d *Descriptor) { d.Type = "bip32" }},
+		{"bad public key", func(d *Descriptor) { d.PublicKey = "0x1234" }},
+		{"p-chain mismatch", func(d *Descriptor) { d.PChainAddress = otherKey.Address().String() }},
+		{"evm mismatch", func(d *Descriptor) { d.EVMAddress = otherKey.PublicKey().EthAddress().Hex() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid
+			tt.mutate(&d)
+			data, err := json.Marshal(d)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if _, _, err := ParseDescriptor(data); err == nil {
+				t.Fatal("ParseDescriptor() expected error")
+			}
+		})
+	}
+}
+
+func TestNewReadOnlyWalletFromPublicKey(t *testing.T) {
+	if _, err := NewReadOnlyWalletFromPublicKey(network.Config{}); err == nil {
+		t.Fatal("NewReadOnlyWalletFromPublicKey() expected error without keys")
+	}
+
+	pub := testPublicKey(t)
+	w, err := NewReadOnlyWalletFromPublicKey(network.Config{NetworkID: 5}, pub)
+	if err != nil {
+		t.Fatalf("NewReadOnlyWalletFromPublicKey() error = %v", err)
+	}
+	addrs := w.Addresses()
+	if len(addrs) != 1 || addrs[0] != pub.Address() {
+		t.Fatalf("Addresses() = %v, want [%s]", addrs, pub.Address())
+	}
+	if got := w.FormattedPChainAddresses(); len(got) != 1 || !strings.HasPrefix(got[0], "P-fuji1") {
+		t.Fatalf("FormattedPChainAddresses() = %v, want a fuji address", got)
+	}
+}
@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -8,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/cb58"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/mr-tron/base58"
 )
 
 // DeriveAddressesFormatted derives both P-Chain and EVM addresses from a private key,
@@ -23,12 +26,33 @@ func DeriveAddressesFormatted(keyBytes []byte, networkID uint32) (pAddr string,
 	return pAddr, evmAddr
 }
 
+// WIF (Bitcoin wallet import format) layout: a version byte, the 32-byte key,
+// an optional compressed-public-key flag, and a double-SHA256 checksum.
+const (
+	wifMainnetVersion = 0x80
+	wifTestnetVersion = 0xef
+	wifCompressedFlag = 0x01
+	wifChecksumLen    = 4
+)
+
+const (
+	privateKeyLen = secp256k1.PrivateKeyLen
+	// uncompressedPairLen is the length of an uncompressed public key, or of
+	// a private key followed by its public key; both are refused as ambiguous.
+	uncompressedPairLen = 2 * privateKeyLen
+)
+
 // ParsePrivateKey parses a private key from various formats.
 // Supported formats:
 //   - PrivateKey-... (Avalanche CB58 format)
 //   - 0x... (hex format)
 //   - Raw CB58 string
+//   - WIF (Bitcoin wallet import format, mainnet or testnet)
 //   - Raw hex string
+//
+// A 64-byte value is rejected: it could be an uncompressed public key or a
+// private key followed by its public key, and guessing wrong would import
+// the wrong key.
 func ParsePrivateKey(keyStr string) ([]byte, error) {
 	keyStr = strings.TrimSpace(keyStr)
 
@@ -50,20 +74,65 @@ func ParsePrivateKey(keyStr string) ([]byte, error) {
 			return nil, fmt.Errorf("failed to decode CB58 private key: %w", err)
 		}
 	} else {
-		// Try CB58 without prefix
-		keyBytes, err = cb58.Decode(keyStr)
+		keyBytes, err = decodeUnprefixedKey(keyStr)
 		if err != nil {
-			// Try hex without prefix
-			keyBytes, err = hex.DecodeString(keyStr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode private key (tried CB58 and hex): %w", err)
-			}
+			return nil, err
 		}
 	}
 
+	if len(keyBytes) == uncompressedPairLen {
+		return nil, fmt.Errorf("got %d bytes, which is ambiguous (an uncompressed public key, or a private key followed by its public key): provide only the %d-byte private key", len(keyBytes), privateKeyLen)
+	}
 	return keyBytes, nil
 }
 
+// decodeUnprefixedKey tries CB58, then WIF, then hex. The checksums keep
+// CB58 and WIF from matching each other's strings.
+func decodeUnprefixedKey(keyStr string) ([]byte, error) {
+	if keyBytes, err := cb58.Decode(keyStr); err == nil {
+		return keyBytes, nil
+	}
+	if keyBytes, err := decodeWIF(keyStr); err == nil {
+		return keyBytes, nil
+	}
+	keyBytes, err := hex.DecodeString(keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key (tried CB58, WIF and hex): %w", err)
+	}
+	return keyBytes, nil
+}
+
+// decodeWIF decodes a WIF private key. The checksum and version byte must
+// match; compressed and uncompressed forms give the same key bytes. The
+// decoded buffer is zeroed once the key is copied out of it.
+func decodeWIF(s string) ([]byte, error) {
+	decoded, err := base58.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base58: %w", err)
+	}
+	defer clear(decoded)
+	if len(decoded) != 1+privateKeyLen+wifChecksumLen && len(decoded) != 1+privateKeyLen+1+wifChecksumLen {
+		return nil, fmt.Errorf("invalid WIF length %d", len(decoded))
+	}
+	payload, checksum := decoded[:len(decoded)-wifChecksumLen], decoded[len(decoded)-wifChecksumLen:]
+	if !bytes.Equal(wifChecksum(payload), checksum) {
+		return nil, fmt.Errorf("invalid WIF checksum")
+	}
+	if payload[0] != wifMainnetVersion && payload[0] != wifTestnetVersion {
+		return nil, fmt.Errorf("unsupported WIF version byte 0x%02x", payload[0])
+	}
+	if len(payload) == 1+privateKeyLen+1 && payload[len(payload)-1] != wifCompressedFlag {
+		return nil, fmt.Errorf("invalid WIF compression flag 0x%02x", payload[len(payload)-1])
+	}
+	return bytes.Clone(payload[1 : 1+privateKeyLen]), nil
+}
+
+func wifChecksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:wifChecksumLen]
+}
+
 // ToPrivateKey converts raw key bytes to a secp256k1 private key.
 func ToPrivateKey(keyBytes []byte) (*secp256k1.PrivateKey, error) {
 	key, err := secp256k1.ToPrivateKey(keyBytes)
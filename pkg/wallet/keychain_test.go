@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/mr-tron/base58"
 )
 
 // testKeyBytes is the well-known ewoq test key.
@@ -287,3 +289,89 @@ func TestParseAndDeriveConsistency(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePrivateKey_WIF(t *testing.T) {
+	// Test vectors from the Bitcoin wiki's WIF page.
+	wantHex := "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"
+	tests := []struct {
+		name string
+		wif  string
+	}{
+		{"uncompressed", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+		{"compressed", "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyBytes, err := ParsePrivateKey(tt.wif)
+			if err != nil {
+				t.Fatalf("ParsePrivateKey() error = %v", err)
+			}
+			if got := hex.EncodeToString(keyBytes); got != wantHex {
+				t.Errorf("ParsePrivateKey() = %s, want %s", got, wantHex)
+			}
+		})
+	}
+}
+
+// keyToWIF converts a private key to mainnet WIF with the compressed flag,
+// the form Avalanche addresses are derived from.
+func keyToWIF(key *secp256k1.PrivateKey) string {
+	payload := make([]byte, 0, 1+privateKeyLen+1+wifChecksumLen)
+	payload = append(payload, wifMainnetVersion)
+	payload = append(payload, key.Bytes()...)
+	payload = append(payload, wifCompressedFlag)
+	return base58.Encode(append(payload, wifChecksum(payload)...))
+}
+
+func TestParsePrivateKey_WIFRoundTrip(t *testing.T) {
+	key, err := ToPrivateKey(testKeyBytes)
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+
+	wif := keyToWIF(key)
+	keyBytes, err := ParsePrivateKey(wif)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey(%q) error = %v", wif, err)
+	}
+	if hex.EncodeToString(keyBytes) != hex.EncodeToString(testKeyBytes) {
+		t.Errorf("round trip = %x, want %x", keyBytes, testKeyBytes)
+	}
+	if _, err := ToPrivateKey(keyBytes); err != nil {
+		t.Errorf("ToPrivateKey() on round-tripped key error = %v", err)
+	}
+}
+
+func TestParsePrivateKey_WIFInvalid(t *testing.T) {
+	valid := "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"bad checksum", valid[:len(valid)-1] + "8"},
+		{"truncated", valid[:len(valid)-4]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePrivateKey(tt.input)
+			if err == nil {
+				t.Fatalf("ParsePrivateKey(%q) should fail", tt.input)
+			}
+			if !strings.Contains(err.Error(), "tried CB58, WIF and hex") {
+				t.Errorf("error = %q, want it to list the attempted formats", err)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey_RejectsAmbiguous64Bytes(t *testing.T) {
+	pair := strings.TrimPrefix(testKeyHex, "0x") + strings.Repeat("ab", 32)
+	for _, input := range []string{pair, "0x" + pair} {
+		_, err := ParsePrivateKey(input)
+		if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("ParsePrivateKey(%q) error = %v, want ambiguous 64-byte error", input, err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	ledger "github.com/ava-labs/ledger-avalanche-go"
 	"github.com/ava-labs/libevm/common"
+	ledgergo "github.com/zondax/ledger-go"
 )
 
 const (
@@ -52,9 +53,14 @@ func NewLedgerKeychain(addressIndex uint32) (*LedgerKeychain, error) {
 	}
 
 	fmt.Println("  Ledger connected successfully")
+	if n := ledgerDeviceCount(); n > 1 {
+		// ledger-avalanche-go always opens the first device it enumerates and
+		// offers no way to pick another.
+		fmt.Printf("  WARNING: %d Ledger devices are connected; using the first one found. Disconnect the others to sign with a specific device.\n", n)
+	}
 
 	// Derive the P-Chain/X-Chain address at the specified index (coin type 9000)
-	avaxPath := fmt.Sprintf("%s/0/%d", ledgerRootPath, addressIndex)
+	avaxPath := ledgerPath(ledgerRootPath, addressIndex)
 	fmt.Printf("  Deriving P-Chain address at path: %s\n", avaxPath)
 
 	addrResp, err := getPublicKeyWithRetry(device, avaxPath)
@@ -73,7 +79,7 @@ func NewLedgerKeychain(addressIndex uint32) (*LedgerKeychain, error) {
 	fmt.Printf("  P-Chain address: %s\n", address)
 
 	// Derive the C-Chain/EVM address at the specified index (coin type 60)
-	evmPath := fmt.Sprintf("%s/0/%d", ledgerEVMRootPath, addressIndex)
+	evmPath := ledgerPath(ledgerEVMRootPath, addressIndex)
 	fmt.Printf("  Deriving C-Chain address at path: %s\n", evmPath)
 
 	evmAddrResp, err := getPublicKeyWithRetry(device, evmPath)
@@ -158,6 +164,45 @@ func (kc *LedgerKeychain) GetEVMPublicKey() *secp256k1.PublicKey {
 	return kc.evmPubKey
 }
 
+// PChainPath returns the derivation path of the P-Chain key.
+func (kc *LedgerKeychain) PChainPath() string {
+	return ledgerPath(ledgerRootPath, kc.index)
+}
+
+// EVMPath returns the derivation path of the EVM key.
+func (kc *LedgerKeychain) EVMPath() string {
+	return ledgerPath(ledgerEVMRootPath, kc.index)
+}
+
+// ConfirmOnDevice shows the address at the P-Chain path (or the EVM path if
+// evm is set) on the Ledger screen, encoded with hrp, and blocks until the
+// user approves or rejects it. It returns the address as the device
+// displayed it. The key the device reports must match the one derived when
+// the keychain was opened, so a swapped device or app is caught.
+//
+// The Avalanche app displays every key in bech32 form; for the EVM path this
+// is the bech32 encoding of the key behind the 0x address, not the 0x string.
+func (kc *LedgerKeychain) ConfirmOnDevice(evm bool, hrp string) (string, error) {
+	path, want := kc.PChainPath(), kc.pubKey
+	if evm {
+		path, want = kc.EVMPath(), kc.evmPubKey
+	}
+
+	// No retry: each attempt would prompt on the device again.
+	resp, err := kc.device.GetPubKey(path, true, hrp, "")
+	if err != nil {
+		return "", fmt.Errorf("address at %s was not confirmed on the Ledger: %w", path, err)
+	}
+	got, err := secp256k1.ToPublicKey(resp.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if got.Address() != want.Address() {
+		return "", fmt.Errorf("ledger reported a different key at %s than when the wallet was opened; reconnect the device and retry", path)
+	}
+	return resp.Address, nil
+}
+
 // LedgerSigner implements keychain.Signer using a Ledger device.
 // Signs using the Avalanche path (m/44'/9000'/0') for P-Chain operations.
 type LedgerSigner struct {
@@ -278,6 +323,17 @@ func findLedgerWithRetry() (*ledger.LedgerAvalanche, error) {
 	return nil, err
 }
 
+// ledgerDeviceCount returns the number of connected Ledger devices.
+func ledgerDeviceCount() int {
+	return ledgergo.NewLedgerAdmin().CountDevices()
+}
+
+// ledgerPath returns the BIP44 path of the external address at index under
+// root.
+func ledgerPath(root string, index uint32) string {
+	return fmt.Sprintf("%s/0/%d", root, index)
+}
+
 func getPublicKeyWithRetry(device *ledger.LedgerAvalanche, path string) (*ledger.ResponseAddr, error) {
 	var resp *ledger.ResponseAddr
 	var err error
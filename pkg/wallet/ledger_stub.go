@@ -51,6 +51,21 @@ func (kc *LedgerKeychain) GetEVMPublicKey() *secp256k1.PublicKey {
 	return nil
 }
 
+// PChainPath returns an empty path for stub.
+func (kc *LedgerKeychain) PChainPath() string {
+	return ""
+}
+
+// EVMPath returns an empty path for stub.
+func (kc *LedgerKeychain) EVMPath() string {
+	return ""
+}
+
+// ConfirmOnDevice returns error for stub.
+func (kc *LedgerKeychain) ConfirmOnDevice(evm bool, hrp string) (string, error) {
+	return "", fmt.Errorf("ledger support not compiled")
+}
+
 // EthAddresses returns empty set for stub.
 func (kc *LedgerKeychain) EthAddresses() set.Set[common.Address] {
 	return set.Set[common.Address]{}
@@ -66,6 +81,11 @@ func (kc *LedgerKeychain) SignHash(hash []byte) ([]byte, error) {
 	return nil, fmt.Errorf("ledger support not compiled")
 }
 
+// SignHashEVM returns error for stub.
+func (kc *LedgerKeychain) SignHashEVM(hash []byte) ([]byte, error) {
+	return nil, fmt.Errorf("ledger support not compiled")
+}
+
 // Sign returns error for stub.
 func (kc *LedgerKeychain) Sign(msg []byte) ([]byte, error) {
 	return nil, fmt.Errorf("ledger support not compiled")
@@ -0,0 +1,230 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/libevm/accounts"
+	"github.com/ava-labs/libevm/common"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OwnershipProofVersion is the current ownership proof format version.
+	OwnershipProofVersion = 1
+
+	// OwnershipChainP and OwnershipChainC name the key an ownership proof
+	// was signed with: the P-Chain key or the EVM (C-Chain) key.
+	OwnershipChainP = "P"
+	OwnershipChainC = "C"
+
+	// MaxChallengeLength is the longest challenge, in bytes, that can be
+	// signed. Challenges are nonces or short statements, not documents.
+	MaxChallengeLength = 1024
+
+	// avalancheMessagePrefix is the prefix of an Avalanche signed message,
+	// as used by Core wallet, so P-Chain proofs can be checked there too.
+	avalancheMessagePrefix = "\x1AAvalanche Signed Message:\n"
+
+	// ethRecoveryIDOffset is added to the recovery ID of EVM signatures, as
+	// personal_sign does.
+	ethRecoveryIDOffset = 27
+)
+
+// ErrOwnershipProofInvalid is returned when an ownership proof does not
+// verify.
+var ErrOwnershipProofInvalid = errors.New("ownership proof invalid")
+
+// OwnershipProof is a self-contained proof that the holder of an address's
+// key signed a challenge.
+type OwnershipProof struct {
+	Version   int    `json:"version"`
+	Challenge string `json:"challenge"`
+	// Signatures holds one entry per key that signed the challenge.
+	Signatures []OwnershipSignature `json:"signatures"`
+}
+
+// OwnershipSignature is the signature of one address over the challenge.
+type OwnershipSignature struct {
+	// Chain is OwnershipChainP or OwnershipChainC.
+	Chain string `json:"chain"`
+	// Address is the bech32 P-Chain address (P-avax1...) or the 0x EVM
+	// address.
+	Address string `json:"address"`
+	// Signature is the 65-byte [r || s || v] signature, 0x hex. P-Chain
+	// signatures cover an Avalanche signed message and EVM signatures an
+	// EIP-191 personal message.
+	Signature string `json:"signature"`
+}
+
+// HashSigner signs 32-byte hashes. Private keys and Ledger signers both
+// implement it.
+type HashSigner interface {
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// HashSignerFunc adapts a signing function, such as
+// LedgerKeychain.SignHashEVM, to HashSigner.
+type HashSignerFunc func(hash []byte) ([]byte, error)
+
+// SignHash calls f.
+func (f HashSignerFunc) SignHash(hash []byte) ([]byte, error) {
+	return f(hash)
+}
+
+// ValidateChallenge checks that challenge can be signed.
+func ValidateChallenge(challenge string) error {
+	if challenge == "" {
+		return errors.New("challenge is empty")
+	}
+	if len(challenge) > MaxChallengeLength {
+		return fmt.Errorf("challenge is %d bytes, longer than the %d allowed", len(challenge), MaxChallengeLength)
+	}
+	if !utf8.ValidString(challenge) {
+		return errors.New("challenge is not valid UTF-8")
+	}
+	return nil
+}
+
+// OwnershipMessageHash returns the hash a proof for chain signs: the SHA-256
+// of an Avalanche signed message for the P-Chain, and the EIP-191 personal
+// message hash for the C-Chain.
+func OwnershipMessageHash(chain, challenge string) ([]byte, error) {
+	switch chain {
+	case OwnershipChainP:
+		msg := make([]byte, 0, len(avalancheMessagePrefix)+4+len(challenge))
+		msg = append(msg, avalancheMessagePrefix...)
+		msg = binary.BigEndian.AppendUint32(msg, uint32(len(challenge)))
+		msg = append(msg, challenge...)
+		hash := sha256.Sum256(msg)
+		return hash[:], nil
+	case OwnershipChainC:
+		return accounts.TextHash([]byte(challenge)), nil
+	default:
+		return nil, fmt.Errorf("unknown chain %q: must be %q or %q", chain, OwnershipChainP, OwnershipChainC)
+	}
+}
+
+// SignOwnership signs challenge with signer, the key of addr on chain. The
+// signature is checked against addr before it is returned, so a signer
+// holding a different key is caught here rather than by the verifier.
+func SignOwnership(signer HashSigner, chain, addr, challenge string) (OwnershipSignature, error) {
+	if err := ValidateChallenge(challenge); err != nil {
+		return OwnershipSignature{}, err
+	}
+	hash, err := OwnershipMessageHash(chain, challenge)
+	if err != nil {
+		return OwnershipSignature{}, err
+	}
+	sig, err := signer.SignHash(hash)
+	if err != nil {
+		return OwnershipSignature{}, fmt.Errorf("failed to sign challenge: %w", err)
+	}
+	if len(sig) != secp256k1.SignatureLen {
+		return OwnershipSignature{}, fmt.Errorf("signer returned a %d-byte signature, expected %d", len(sig), secp256k1.SignatureLen)
+	}
+	sig = append([]byte(nil), sig...)
+	if chain == OwnershipChainC {
+		sig[secp256k1.SignatureLen-1] += ethRecoveryIDOffset
+	}
+
+	s := OwnershipSignature{
+		Chain:     chain,
+		Address:   addr,
+		Signature: "0x" + hex.EncodeToString(sig),
+	}
+	if err := s.Verify(challenge); err != nil {
+		return OwnershipSignature{}, fmt.Errorf("signature does not match %s: %w", addr, err)
+	}
+	return s, nil
+}
+
+// Verify checks that s is a signature of challenge by the key of s.Address.
+func (s OwnershipSignature) Verify(challenge string) error {
+	hash, err := OwnershipMessageHash(s.Chain, challenge)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOwnershipProofInvalid, err)
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(s.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature hex: %w", ErrOwnershipProofInvalid, err)
+	}
+	if len(sig) != secp256k1.SignatureLen {
+		return fmt.Errorf("%w: signature is %d bytes, expected %d", ErrOwnershipProofInvalid, len(sig), secp256k1.SignatureLen)
+	}
+	if s.Chain == OwnershipChainC && sig[secp256k1.SignatureLen-1] >= ethRecoveryIDOffset {
+		sig[secp256k1.SignatureLen-1] -= ethRecoveryIDOffset
+	}
+	pub, err := secp256k1.RecoverPublicKeyFromHash(hash, sig)
+	if err != nil {
+		return fmt.Errorf("%w: failed to recover public key: %w", ErrOwnershipProofInvalid, err)
+	}
+
+	switch s.Chain {
+	case OwnershipChainP:
+		chainAlias, _, addrBytes, err := address.Parse(s.Address)
+		if err != nil || chainAlias != OwnershipChainP {
+			return fmt.Errorf("%w: %q is not a P-Chain address", ErrOwnershipProofInvalid, s.Address)
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a P-Chain address", ErrOwnershipProofInvalid, s.Address)
+		}
+		if pub.Address() != addr {
+			return fmt.Errorf("%w: signed by a different key than %s", ErrOwnershipProofInvalid, s.Address)
+		}
+	case OwnershipChainC:
+		if !common.IsHexAddress(s.Address) {
+			return fmt.Errorf("%w: %q is not an EVM address", ErrOwnershipProofInvalid, s.Address)
+		}
+		if pub.EthAddress() != common.HexToAddress(s.Address) {
+			return fmt.Errorf("%w: signed by a different key than %s", ErrOwnershipProofInvalid, s.Address)
+		}
+	}
+	return nil
+}
+
+// Verify checks every signature in p. A proof without signatures does not
+// verify.
+func (p OwnershipProof) Verify() error {
+	if p.Version != OwnershipProofVersion {
+		return fmt.Errorf("%w: unsupported version %d (expected %d)", ErrOwnershipProofInvalid, p.Version, OwnershipProofVersion)
+	}
+	if err := ValidateChallenge(p.Challenge); err != nil {
+		return fmt.Errorf("%w: %w", ErrOwnershipProofInvalid, err)
+	}
+	if len(p.Signatures) == 0 {
+		return fmt.Errorf("%w: no signatures", ErrOwnershipProofInvalid)
+	}
+	for _, s := range p.Signatures {
+		if err := s.Verify(p.Challenge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseOwnershipProof decodes a JSON or YAML ownership proof, as written by
+// prove-ownership with -o json or -o yaml. It does not verify the
+// signatures; call Verify for that.
+func ParseOwnershipProof(data []byte) (OwnershipProof, error) {
+	// JSON is valid YAML, so converting first accepts both.
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return OwnershipProof{}, fmt.Errorf("failed to decode ownership proof: %w", err)
+	}
+	var p OwnershipProof
+	if err := json.Unmarshal(data, &p); err != nil {
+		return OwnershipProof{}, fmt.Errorf("failed to decode ownership proof: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,154 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/libevm/accounts"
+	"github.com/ava-labs/libevm/crypto"
+	"sigs.k8s.io/yaml"
+)
+
+func TestOwnershipProofRoundTrip(t *testing.T) {
+	key, err := ToPrivateKey(testKeyBytes)
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+	const challenge = "exchange-nonce-8f3a"
+	pAddr := FormatPChainAddress(key.Address(), constants.FujiID)
+	evmAddr := key.PublicKey().EthAddress().Hex()
+
+	proof := OwnershipProof{Version: OwnershipProofVersion, Challenge: challenge}
+	for _, s := range []struct{ chain, addr string }{{OwnershipChainP, pAddr}, {OwnershipChainC, evmAddr}} {
+		sig, err := SignOwnership(key, s.chain, s.addr, challenge)
+		if err != nil {
+			t.Fatalf("SignOwnership(%s) error = %v", s.chain, err)
+		}
+		proof.Signatures = append(proof.Signatures, sig)
+	}
+	if err := proof.Verify(); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	// EVM signatures carry the personal_sign recovery ID (27 or 28).
+	if v := proof.Signatures[1].Signature[len(proof.Signatures[1].Signature)-2:]; v != "1b" && v != "1c" {
+		t.Errorf("EVM signature recovery ID = 0x%s, want 0x1b or 0x1c", v)
+	}
+	// and recover with Ethereum tooling.
+	sig, err := hex.DecodeString(strings.TrimPrefix(proof.Signatures[1].Signature, "0x"))
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(challenge)), sig)
+	if err != nil {
+		t.Fatalf("SigToPub() error = %v", err)
+	}
+	if got := crypto.PubkeyToAddress(*pub).Hex(); got != evmAddr {
+		t.Errorf("Ethereum recovery address = %s, want %s", got, evmAddr)
+	}
+
+	tampered := proof
+	tampered.Challenge = challenge + "x"
+	if err := tampered.Verify(); !errors.Is(err, ErrOwnershipProofInvalid) {
+		t.Errorf("Verify() with another challenge error = %v, want %v", err, ErrOwnershipProofInvalid)
+	}
+
+	other, err := ToPrivateKey(append(make([]byte, 31), 1))
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+	forged := OwnershipProof{Version: OwnershipProofVersion, Challenge: challenge, Signatures: []OwnershipSignature{proof.Signatures[0]}}
+	forged.Signatures[0].Address = FormatPChainAddress(other.Address(), constants.FujiID)
+	if err := forged.Verify(); !errors.Is(err, ErrOwnershipProofInvalid) {
+		t.Errorf("Verify() with another address error = %v, want %v", err, ErrOwnershipProofInvalid)
+	}
+
+	if _, err := SignOwnership(key, OwnershipChainC, other.PublicKey().EthAddress().Hex(), challenge); err == nil {
+		t.Error("SignOwnership() expected error when the key does not own the address")
+	}
+}
+
+func TestParseOwnershipProof(t *testing.T) {
+	key, err := ToPrivateKey(testKeyBytes)
+	if err != nil {
+		t.Fatalf("ToPrivateKey() error = %v", err)
+	}
+	const challenge = "exchange-nonce-8f3a"
+	proof := OwnershipProof{Version: OwnershipProofVersion, Challenge: challenge}
+	for _, s := range []struct{ chain, addr string }{
+		{OwnershipChainP, FormatPChainAddress(key.Address(), constants.FujiID)},
+		{OwnershipChainC, key.PublicKey().EthAddress().Hex()},
+	} {
+		sig, err := SignOwnership(key, s.chain, s.addr, challenge)
+		if err != nil {
+			t.Fatalf("SignOwnership(%s) error = %v", s.chain, err)
+		}
+		proof.Signatures = append(proof.Signatures, sig)
+	}
+
+	tests := []struct {
+		name    string
+		marshal func(any) ([]byte, error)
+	}{
+		{name: "json", marshal: func(v any) ([]byte, error) { return json.MarshalIndent(v, "", "  ") }},
+		{name: "yaml", marshal: yaml.Marshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.marshal(proof)
+			if err != nil {
+				t.Fatalf("marshal error = %v", err)
+			}
+			got, err := ParseOwnershipProof(data)
+			if err != nil {
+				t.Fatalf("ParseOwnershipProof() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, proof) {
+				t.Errorf("ParseOwnershipProof() = %+v, want %+v", got, proof)
+			}
+			if err := got.Verify(); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+
+	if _, err := ParseOwnershipProof([]byte("challenge: [\n")); err == nil {
+		t.Error("ParseOwnershipProof() expected error for malformed input")
+	}
+}
+
+func TestOwnershipProofInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		proof OwnershipProof
+	}{
+		{"unknown version", OwnershipProof{Version: 99, Challenge: "c", Signatures: []OwnershipSignature{{}}}},
+		{"no signatures", OwnershipProof{Version: OwnershipProofVersion, Challenge: "c"}},
+		{"empty challenge", OwnershipProof{Version: OwnershipProofVersion, Signatures: []OwnershipSignature{{}}}},
+		{"unknown chain", OwnershipProof{Version: OwnershipProofVersion, Challenge: "c", Signatures: []OwnershipSignature{{Chain: "X"}}}},
+		{"short signature", OwnershipProof{Version: OwnershipProofVersion, Challenge: "c", Signatures: []OwnershipSignature{{Chain: OwnershipChainP, Signature: "0x00"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.proof.Verify(); !errors.Is(err, ErrOwnershipProofInvalid) {
+				t.Fatalf("Verify() error = %v, want %v", err, ErrOwnershipProofInvalid)
+			}
+		})
+	}
+}
+
+func TestValidateChallenge(t *testing.T) {
+	if err := ValidateChallenge("nonce"); err != nil {
+		t.Fatalf("ValidateChallenge() error = %v", err)
+	}
+	for _, bad := range []string{"", strings.Repeat("a", MaxChallengeLength+1), "\xff"} {
+		if err := ValidateChallenge(bad); err == nil {
+			t.Errorf("ValidateChallenge(%q) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/graft/coreth/ethclient"
+	"github.com/ava-labs/avalanchego/graft/coreth/plugin/evm/atomic"
+	cchainclient "github.com/ava-labs/avalanchego/graft/coreth/plugin/evm/client"
+	"github.com/ava-labs/avalanchego/graft/evm/rpc"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	pchainwallet "github.com/ava-labs/avalanchego/wallet/chain/p"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// The functions in this file are primary.FetchPState and primary.MakeWallet
+// with their clients built by the network package, so that requests under a
+// network.WithFailover context fail over. The avalanchego versions take only
+// a URI and always use http.DefaultClient. Keep them in step with the
+// avalanchego version in go.mod, and drop them once primary can be given the
+// clients.
+
+// fetchPState returns a P-Chain client for config's node, the P-Chain
+// builder context and the P-Chain UTXOs of addrs.
+func fetchPState(ctx context.Context, config network.Config, addrs set.Set[ids.ShortID]) (*platformvm.Client, *pbuilder.Context, walletcommon.UTXOs, error) {
+	pClient := network.NewPChainClient(ctx, config)
+	pContext, err := pchainwallet.NewContextFromClients(ctx, network.NewInfoClient(ctx, config), pClient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	utxos := walletcommon.NewUTXOs()
+	err = primary.AddAllUTXOs(ctx, utxos, pClient, txs.Codec, constants.PlatformChainID, constants.PlatformChainID, addrs.List())
+	return pClient, pContext, utxos, err
+}
+
+// makePrimaryWallet returns a wallet for the P-, X- and C-Chains of config's
+// node. The C-Chain atomic API client has no way to set its HTTP client, so
+// its requests alone use http.DefaultClient and do not fail over; the gap is
+// documented with --rpc-url and in docs/networks.md.
+func makePrimaryWallet(ctx context.Context, config network.Config, avaxKC keychain.Keychain, ethKC c.EthKeychain) (*primary.Wallet, error) {
+	infoClient := network.NewInfoClient(ctx, config)
+	pClient := network.NewPChainClient(ctx, config)
+	xClient := network.NewXChainClient(ctx, config)
+	cClient := cchainclient.NewCChainClient(config.RPCURL)
+
+	pContext, err := pchainwallet.NewContextFromClients(ctx, infoClient, pClient)
+	if err != nil {
+		return nil, err
+	}
+	xContext, err := x.NewContextFromClients(ctx, infoClient, xClient)
+	if err != nil {
+		return nil, err
+	}
+	cContext, err := c.NewContextFromClients(ctx, infoClient, xClient)
+	if err != nil {
+		return nil, err
+	}
+
+	avaxAddrs := avaxKC.Addresses()
+	utxos := walletcommon.NewUTXOs()
+	chains := []struct {
+		id     ids.ID
+		client primary.UTXOClient
+		codec  codec.Manager
+	}{
+		{id: constants.PlatformChainID, client: pClient, codec: txs.Codec},
+		{id: xContext.BlockchainID, client: xClient, codec: xbuilder.Parser.Codec()},
+		{id: cContext.BlockchainID, client: cClient, codec: atomic.Codec},
+	}
+	for _, destination := range chains {
+		for _, source := range chains {
+			err := primary.AddAllUTXOs(ctx, utxos, destination.client, destination.codec, source.id, destination.id, avaxAddrs.List())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rpcClient, err := rpc.DialOptions(ctx, config.RPCURL+cChainRPCPath, rpc.WithHTTPClient(network.HTTPClient(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to C-Chain: %w", err)
+	}
+	ethClient := ethclient.NewClient(rpcClient)
+	accounts := make(map[common.Address]*c.Account)
+	for addr := range ethKC.EthAddresses() {
+		balance, err := ethClient.BalanceAt(ctx, addr, nil)
+		if err != nil {
+			return nil, err
+		}
+		nonce, err := ethClient.NonceAt(ctx, addr, nil)
+		if err != nil {
+			return nil, err
+		}
+		accounts[addr] = &c.Account{Balance: balance, Nonce: nonce}
+	}
+
+	owners, err := pClient.GetOwners(ctx, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	pBackend := pwallet.NewBackend(walletcommon.NewChainUTXOs(constants.PlatformChainID, utxos), owners)
+	xBackend := x.NewBackend(xContext, walletcommon.NewChainUTXOs(xContext.BlockchainID, utxos))
+	cBackend := c.NewBackend(walletcommon.NewChainUTXOs(cContext.BlockchainID, utxos), accounts)
+
+	return primary.NewWallet(
+		pwallet.New(
+			pchainwallet.NewClient(pClient, pBackend),
+			pbuilder.New(avaxAddrs, pContext, pBackend),
+			psigner.New(avaxKC, pBackend),
+		),
+		x.NewWallet(
+			xbuilder.New(avaxAddrs, xContext, xBackend),
+			xsigner.New(avaxKC, xBackend),
+			xClient,
+			xBackend,
+		),
+		c.NewWallet(
+			c.NewBuilder(avaxAddrs, ethKC.EthAddresses(), cContext, cBackend),
+			c.NewSigner(avaxKC, ethKC, cBackend),
+			cClient,
+			ethClient,
+			cBackend,
+		),
+	), nil
+}
@@ -4,6 +4,7 @@ package wallet
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -11,12 +12,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/chain/c"
 	pchainwallet "github.com/ava-labs/avalanchego/wallet/chain/p"
 	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
 	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
 	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
 	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 	"github.com/ava-labs/libevm/common"
@@ -27,75 +30,92 @@ import (
 type Wallet struct {
 	key      *secp256k1.PrivateKey // nil for Ledger
 	keychain *secp256k1fx.Keychain // nil for Ledger
-	pWallet  pwallet.Wallet
+	pWallet  pwallet.Wallet        // base with sign-only mode, the signed-transaction handler and options applied
 	config   network.Config
 	address  ids.ShortID // used when key is nil (Ledger mode)
+
+	// What the P-Chain wallet was built from, kept so Refresh can rebuild it.
+	kc        keychain.Keychain
+	subnetIDs []ids.ID
+	owners    map[ids.ID]fx.Owner   // non-nil for wallets from NewWalletFromKeychainWithOwner
+	options   []walletcommon.Option // applied to every transaction, e.g. a change owner
+
+	base     pwallet.Wallet      // the P-Chain wallet as built, issuing to the node
+	backend  pwallet.Backend     // UTXOs and owners the P-Chain wallet builds from
+	signOnly *captureClient      // non-nil when transactions are signed but not issued
+	onSigned func(*txs.Tx) error // called with each signed transaction before it is issued
+
+	refreshBeforeIssue bool // re-sync UTXOs before each transaction is built
 }
 
 // NewWallet creates a new wallet for P-Chain operations.
 func NewWallet(ctx context.Context, key *secp256k1.PrivateKey, config network.Config) (*Wallet, error) {
 	kc := secp256k1fx.NewKeychain(key)
-
-	pWallet, err := primary.MakePWallet(ctx, config.RPCURL, kc, primary.WalletConfig{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
-	}
-
-	return &Wallet{
+	w := &Wallet{
 		key:      key,
 		keychain: kc,
-		pWallet:  pWallet,
 		config:   config,
-	}, nil
+		kc:       kc,
+	}
+	if err := w.load(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
+	}
+	return w, nil
 }
 
 // NewWalletWithSubnet creates a wallet that tracks a specific subnet.
 func NewWalletWithSubnet(ctx context.Context, key *secp256k1.PrivateKey, config network.Config, subnetID ids.ID) (*Wallet, error) {
-	kc := secp256k1fx.NewKeychain(key)
+	return NewWalletWithSubnets(ctx, key, config, []ids.ID{subnetID})
+}
 
-	pWallet, err := primary.MakePWallet(ctx, config.RPCURL, kc, primary.WalletConfig{
-		SubnetIDs: []ids.ID{subnetID},
-	})
-	if err != nil {
+// NewWalletWithSubnets creates a wallet that tracks several subnets, so one
+// wallet can sign for all of them with a single state fetch.
+func NewWalletWithSubnets(ctx context.Context, key *secp256k1.PrivateKey, config network.Config, subnetIDs []ids.ID) (*Wallet, error) {
+	kc := secp256k1fx.NewKeychain(key)
+	w := &Wallet{
+		key:       key,
+		keychain:  kc,
+		config:    config,
+		kc:        kc,
+		subnetIDs: uniqueIDs(subnetIDs),
+	}
+	if err := w.load(ctx); err != nil {
 		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
 	}
-
-	return &Wallet{
-		key:      key,
-		keychain: kc,
-		pWallet:  pWallet,
-		config:   config,
-	}, nil
+	return w, nil
 }
 
 // NewWalletFromKeychain creates a wallet from any keychain implementation (e.g., Ledger).
 func NewWalletFromKeychain(ctx context.Context, kc keychain.Keychain, address ids.ShortID, config network.Config) (*Wallet, error) {
-	pWallet, err := primary.MakePWallet(ctx, config.RPCURL, kc, primary.WalletConfig{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
-	}
-
-	return &Wallet{
-		pWallet: pWallet,
+	w := &Wallet{
 		config:  config,
 		address: address,
-	}, nil
+		kc:      kc,
+	}
+	if err := w.load(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
+	}
+	return w, nil
 }
 
 // NewWalletFromKeychainWithSubnet creates a wallet from any keychain with subnet tracking.
 func NewWalletFromKeychainWithSubnet(ctx context.Context, kc keychain.Keychain, address ids.ShortID, config network.Config, subnetID ids.ID) (*Wallet, error) {
-	pWallet, err := primary.MakePWallet(ctx, config.RPCURL, kc, primary.WalletConfig{
-		SubnetIDs: []ids.ID{subnetID},
-	})
-	if err != nil {
+	return NewWalletFromKeychainWithSubnets(ctx, kc, address, config, []ids.ID{subnetID})
+}
+
+// NewWalletFromKeychainWithSubnets creates a wallet from any keychain that
+// tracks several subnets.
+func NewWalletFromKeychainWithSubnets(ctx context.Context, kc keychain.Keychain, address ids.ShortID, config network.Config, subnetIDs []ids.ID) (*Wallet, error) {
+	w := &Wallet{
+		config:    config,
+		address:   address,
+		kc:        kc,
+		subnetIDs: uniqueIDs(subnetIDs),
+	}
+	if err := w.load(ctx); err != nil {
 		return nil, fmt.Errorf("failed to create P-Chain wallet: %w", err)
 	}
-
-	return &Wallet{
-		pWallet: pWallet,
-		config:  config,
-		address: address,
-	}, nil
+	return w, nil
 }
 
 // NewWalletFromKeychainWithOwner creates a P-Chain wallet whose backend maps
@@ -108,24 +128,142 @@ func NewWalletFromKeychainWithSubnet(ctx context.Context, kc keychain.Keychain,
 // through the backend's owners map. P-Chain state is fetched exactly once here,
 // avoiding a second round-trip on top of loading a standard wallet.
 func NewWalletFromKeychainWithOwner(ctx context.Context, kc keychain.Keychain, address ids.ShortID, config network.Config, ownerID ids.ID, owner fx.Owner) (*Wallet, error) {
-	client, pContext, utxos, err := primary.FetchPState(ctx, config.RPCURL, kc.Addresses())
+	w := &Wallet{
+		config:  config,
+		address: address,
+		kc:      kc,
+		owners:  map[ids.ID]fx.Owner{ownerID: owner},
+	}
+	if err := w.load(ctx); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// load builds the P-Chain wallet from the node's current state, then
+// reapplies sign-only mode and any options. Transactions already captured in
+// sign-only mode are replayed into the new backend, since the node has not
+// seen them spend their inputs.
+func (w *Wallet) load(ctx context.Context) error {
+	client, pContext, utxos, err := fetchPState(ctx, w.config, w.kc.Addresses())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch P-Chain wallet state: %w", err)
+		return fmt.Errorf("failed to fetch P-Chain wallet state: %w", err)
+	}
+	owners := w.owners
+	if owners == nil {
+		owners, err = client.GetOwners(ctx, w.subnetIDs, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch subnet owners: %w", err)
+		}
 	}
 
-	owners := map[ids.ID]fx.Owner{ownerID: owner}
 	backend := pwallet.NewBackend(walletcommon.NewChainUTXOs(constants.PlatformChainID, utxos), owners)
+	if w.signOnly != nil {
+		if err := w.signOnly.replay(ctx, backend); err != nil {
+			return err
+		}
+	}
 	pWallet := pwallet.New(
 		pchainwallet.NewClient(client, backend),
-		pbuilder.New(kc.Addresses(), pContext, backend),
-		psigner.New(kc, backend),
+		pbuilder.New(w.kc.Addresses(), pContext, backend),
+		psigner.New(w.kc, backend),
 	)
 
-	return &Wallet{
-		pWallet: pWallet,
-		config:  config,
-		address: address,
-	}, nil
+	w.backend, w.base = backend, pWallet
+	w.pWallet = w.wrapped()
+	return nil
+}
+
+// wrapped returns the base wallet issuing through the sign-only capture when
+// set, with the signed-transaction handler and options applied. It always
+// starts from the base wallet, so each is applied once.
+func (w *Wallet) wrapped() pwallet.Wallet {
+	pWallet := w.base
+	var client pwallet.Client = pWallet
+	if w.signOnly != nil {
+		client = w.signOnly
+	}
+	if w.onSigned != nil {
+		client = &recordingClient{Client: client, record: w.onSigned}
+	}
+	if client != pwallet.Client(pWallet) {
+		pWallet = pwallet.New(client, pWallet.Builder(), pWallet.Signer())
+	}
+	if len(w.options) > 0 {
+		pWallet = pwallet.WithOptions(pWallet, w.options...)
+	}
+	return pWallet
+}
+
+// Refresh reloads UTXOs and subnet owners from the node, so a wallet can be
+// reused across transactions that depend on each other's results. Sign-only
+// mode, the change owner and tracked subnets are kept.
+func (w *Wallet) Refresh(ctx context.Context) error {
+	if err := w.load(ctx); err != nil {
+		return fmt.Errorf("failed to refresh P-Chain wallet: %w", err)
+	}
+	return nil
+}
+
+// SetRefreshBeforeIssue controls whether RefreshBeforeIssue re-syncs the
+// wallet. The pchain operations call RefreshBeforeIssue before building a
+// transaction, so a long-lived wallet does not build from UTXOs that were
+// spent elsewhere since it was loaded. It is off by default because each
+// refresh fetches the wallet's UTXOs again.
+func (w *Wallet) SetRefreshBeforeIssue(enabled bool) {
+	w.refreshBeforeIssue = enabled
+}
+
+// RefreshBeforeIssue refreshes the wallet if SetRefreshBeforeIssue is on. A
+// sign-only wallet is never refreshed: the transactions it signed were not
+// issued, so the node has nothing newer to report, and the backend already
+// records what they spent.
+func (w *Wallet) RefreshBeforeIssue(ctx context.Context) error {
+	if !w.refreshBeforeIssue || w.signOnly != nil {
+		return nil
+	}
+	return w.Refresh(ctx)
+}
+
+// uniqueIDs returns a copy of subnetIDs without duplicates, in first-seen
+// order.
+func uniqueIDs(subnetIDs []ids.ID) []ids.ID {
+	out := make([]ids.ID, 0, len(subnetIDs))
+	for _, id := range subnetIDs {
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// TrackSubnet adds subnetID to the subnets whose owners the wallet knows,
+// and refreshes the wallet so it can sign for a subnet created after it was
+// loaded.
+func (w *Wallet) TrackSubnet(ctx context.Context, subnetID ids.ID) error {
+	if !slices.Contains(w.subnetIDs, subnetID) {
+		w.subnetIDs = append(w.subnetIDs, subnetID)
+	}
+	return w.Refresh(ctx)
+}
+
+// TracksSubnet reports whether the wallet knows subnetID's owner and so can
+// build subnet auth for it: the subnet was passed to a *WithSubnet(s)
+// constructor or TrackSubnet, or was created by this wallet.
+func (w *Wallet) TracksSubnet(subnetID ids.ID) bool {
+	return slices.Contains(w.subnetIDs, subnetID)
+}
+
+// RecordCreatedSubnet marks subnetID, created by a CreateSubnetTx this wallet
+// issued, as tracked. The backend learned its owner when the transaction was
+// accepted, so no refresh is needed. A sign-only wallet never issued the
+// transaction, so the node does not know the subnet and a later refresh could
+// not fetch its owner; nothing is recorded.
+func (w *Wallet) RecordCreatedSubnet(subnetID ids.ID) {
+	if w.signOnly != nil || slices.Contains(w.subnetIDs, subnetID) {
+		return
+	}
+	w.subnetIDs = append(w.subnetIDs, subnetID)
 }
 
 // PWallet returns the underlying P-Chain wallet.
@@ -133,6 +271,113 @@ func (w *Wallet) PWallet() pwallet.Wallet {
 	return w.pWallet
 }
 
+// captureClient is a pwallet.Client that records signed transactions instead
+// of issuing them to the network. Each one is accepted into the backend as
+// if it had been issued, so later transactions in the same run spend its
+// outputs rather than its inputs again.
+type captureClient struct {
+	backend pwallet.Backend
+	txs     []*txs.Tx
+}
+
+func (c *captureClient) IssueTx(tx *txs.Tx, options ...walletcommon.Option) error {
+	ctx := walletcommon.NewOptions(options).Context()
+	if err := c.backend.AcceptTx(ctx, tx); err != nil {
+		return fmt.Errorf("failed to record signed transaction: %w", err)
+	}
+	c.txs = append(c.txs, tx)
+	return nil
+}
+
+// replay accepts the captured transactions into backend, in signing order,
+// and makes it the backend later transactions are accepted into.
+func (c *captureClient) replay(ctx context.Context, backend pwallet.Backend) error {
+	for _, tx := range c.txs {
+		if err := backend.AcceptTx(ctx, tx); err != nil {
+			return fmt.Errorf("failed to replay signed transaction %s: %w", tx.ID(), err)
+		}
+	}
+	c.backend = backend
+	return nil
+}
+
+// recordingClient is a pwallet.Client that passes each signed transaction to
+// record before issuing it, and does not issue it if record fails.
+type recordingClient struct {
+	pwallet.Client
+	record func(*txs.Tx) error
+}
+
+func (c *recordingClient) IssueTx(tx *txs.Tx, options ...walletcommon.Option) error {
+	if err := c.record(tx); err != nil {
+		return err
+	}
+	return c.Client.IssueTx(tx, options...)
+}
+
+// SetSignOnly switches the wallet to build-and-sign mode. Subsequent Issue*
+// calls build and sign transactions as usual but record them instead of
+// submitting them; retrieve them with SignedTxs.
+func (w *Wallet) SetSignOnly() {
+	if w.signOnly != nil {
+		return
+	}
+	w.signOnly = &captureClient{backend: w.backend}
+	w.pWallet = w.wrapped()
+}
+
+// SetSignedTxHandler calls handler with each transaction the wallet signs,
+// before it is issued or, in sign-only mode, captured. If handler fails the
+// transaction is not issued and the Issue* call returns its error. Call it
+// once, before building transactions.
+func (w *Wallet) SetSignedTxHandler(handler func(*txs.Tx) error) {
+	w.onSigned = handler
+	w.pWallet = w.wrapped()
+}
+
+// SetChangeOwner directs change from subsequently built transactions to addr
+// instead of the signing address.
+func (w *Wallet) SetChangeOwner(addr ids.ShortID) {
+	w.options = append(w.options, walletcommon.WithChangeOwner(changeOwner(addr)))
+	w.pWallet = w.wrapped()
+}
+
+// SetIssueHandlers calls issued with the ID of each transaction the node
+// takes, and confirmed once the wallet has seen it accepted. A caller stopped
+// in between can still report a transaction that may yet be accepted.
+func (w *Wallet) SetIssueHandlers(issued, confirmed func(txID ids.ID)) {
+	w.options = append(w.options, issueHandlerOptions(issued, confirmed)...)
+	w.pWallet = w.wrapped()
+}
+
+func issueHandlerOptions(issued, confirmed func(txID ids.ID)) []walletcommon.Option {
+	return []walletcommon.Option{
+		walletcommon.WithIssuanceHandler(func(r walletcommon.IssuanceReceipt) { issued(r.TxID) }),
+		walletcommon.WithConfirmationHandler(func(r walletcommon.ConfirmationReceipt) { confirmed(r.TxID) }),
+	}
+}
+
+// changeOwner returns the single-signature owner for change sent to addr.
+func changeOwner(addr ids.ShortID) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+}
+
+// IsSignOnly reports whether the wallet is in build-and-sign mode.
+func (w *Wallet) IsSignOnly() bool {
+	return w.signOnly != nil
+}
+
+// SignedTxs returns the transactions signed but not issued in sign-only mode.
+func (w *Wallet) SignedTxs() []*txs.Tx {
+	if w.signOnly == nil {
+		return nil
+	}
+	return w.signOnly.txs
+}
+
 // Key returns the private key.
 func (w *Wallet) Key() *secp256k1.PrivateKey {
 	return w.key
@@ -175,6 +420,18 @@ func (w *Wallet) GetPChainBalance(ctx context.Context) (uint64, error) {
 	return balances[avaxAssetID], nil
 }
 
+// GetAddressBalance returns the unlocked P-Chain balance, in nAVAX, of addr
+// using a read-only RPC query. Unlike constructing a Wallet, it needs no keys
+// and does not fetch UTXOs locally.
+func GetAddressBalance(ctx context.Context, config network.Config, addr ids.ShortID) (uint64, error) {
+	client := network.NewPChainClient(ctx, config)
+	resp, err := client.GetBalance(ctx, []ids.ShortID{addr})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return uint64(resp.Unlocked), nil
+}
+
 // Config returns the network configuration.
 func (w *Wallet) Config() network.Config {
 	return w.config
@@ -188,13 +445,20 @@ type FullWallet struct {
 	config   network.Config
 	address  ids.ShortID    // P-Chain address (used when key is nil)
 	ethAddr  common.Address // C-Chain address (used when key is nil)
+
+	// Kept so Refresh can rebuild the wallet as it was configured.
+	avaxKC  keychain.Keychain
+	ethKC   c.EthKeychain
+	options []walletcommon.Option
+
+	assumeAccepted bool
 }
 
-// NewFullWallet creates a new wallet for multi-chain operations (P-Chain and C-Chain).
+// NewFullWallet creates a new wallet for multi-chain operations (P-Chain, X-Chain and C-Chain).
 func NewFullWallet(ctx context.Context, key *secp256k1.PrivateKey, config network.Config) (*FullWallet, error) {
 	kc := secp256k1fx.NewKeychain(key)
 
-	wallet, err := primary.MakeWallet(ctx, config.RPCURL, kc, kc, primary.WalletConfig{})
+	wallet, err := makePrimaryWallet(ctx, config, kc, kc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create multi-chain wallet: %w", err)
 	}
@@ -204,9 +468,56 @@ func NewFullWallet(ctx context.Context, key *secp256k1.PrivateKey, config networ
 		keychain: kc,
 		wallet:   wallet,
 		config:   config,
+		avaxKC:   kc,
+		ethKC:    kc,
 	}, nil
 }
 
+// SetChangeOwner directs P-Chain and X-Chain change from subsequently built
+// transactions to addr instead of the signing address.
+func (w *FullWallet) SetChangeOwner(addr ids.ShortID) {
+	w.addOption(walletcommon.WithChangeOwner(changeOwner(addr)))
+}
+
+// SetAssumeAccepted makes subsequently issued transactions return as soon as
+// the node takes them, without polling for acceptance. The wallet records
+// their effects locally as if they had been accepted. Only suitable for local
+// networks that accept transactions almost immediately.
+func (w *FullWallet) SetAssumeAccepted() {
+	w.assumeAccepted = true
+	w.addOption(walletcommon.WithAssumeDecided())
+}
+
+// SetIssueHandlers is Wallet.SetIssueHandlers for transactions on every
+// chain.
+func (w *FullWallet) SetIssueHandlers(issued, confirmed func(txID ids.ID)) {
+	for _, option := range issueHandlerOptions(issued, confirmed) {
+		w.addOption(option)
+	}
+}
+
+// AssumesAccepted reports whether SetAssumeAccepted was called.
+func (w *FullWallet) AssumesAccepted() bool {
+	return w.assumeAccepted
+}
+
+func (w *FullWallet) addOption(option walletcommon.Option) {
+	w.options = append(w.options, option)
+	w.wallet = primary.NewWalletWithOptions(w.wallet, option)
+}
+
+// Refresh reloads UTXOs and chain state from the node, replacing whatever the
+// wallet recorded locally while issuing transactions. Options set with
+// SetChangeOwner, SetAssumeAccepted and SetIssueHandlers are kept.
+func (w *FullWallet) Refresh(ctx context.Context) error {
+	wallet, err := makePrimaryWallet(ctx, w.config, w.avaxKC, w.ethKC)
+	if err != nil {
+		return fmt.Errorf("failed to refresh wallet state: %w", err)
+	}
+	w.wallet = primary.NewWalletWithOptions(wallet, w.options...)
+	return nil
+}
+
 // PWallet returns the P-Chain wallet.
 func (w *FullWallet) PWallet() pwallet.Wallet {
 	return w.wallet.P()
@@ -217,6 +528,11 @@ func (w *FullWallet) CWallet() c.Wallet {
 	return w.wallet.C()
 }
 
+// XWallet returns the X-Chain wallet.
+func (w *FullWallet) XWallet() x.Wallet {
+	return w.wallet.X()
+}
+
 // Key returns the private key.
 func (w *FullWallet) Key() *secp256k1.PrivateKey {
 	return w.key
@@ -263,7 +579,7 @@ type FullKeychain interface {
 
 // NewFullWalletFromKeychain creates a multi-chain wallet from any keychain implementation.
 func NewFullWalletFromKeychain(ctx context.Context, kc FullKeychain, address ids.ShortID, ethAddr common.Address, config network.Config) (*FullWallet, error) {
-	wallet, err := primary.MakeWallet(ctx, config.RPCURL, kc, kc, primary.WalletConfig{})
+	wallet, err := makePrimaryWallet(ctx, config, kc, kc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create multi-chain wallet: %w", err)
 	}
@@ -273,6 +589,8 @@ func NewFullWalletFromKeychain(ctx context.Context, kc FullKeychain, address ids
 		config:  config,
 		address: address,
 		ethAddr: ethAddr,
+		avaxKC:  kc,
+		ethKC:   kc,
 	}, nil
 }
 
@@ -0,0 +1,267 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/platform-cli/pkg/network"
+)
+
+// failingClient fails the test if a transaction reaches the network client.
+type failingClient struct {
+	t *testing.T
+}
+
+func (c failingClient) IssueTx(*txs.Tx, ...walletcommon.Option) error {
+	c.t.Fatal("IssueTx reached the network client in sign-only mode")
+	return nil
+}
+
+// newTestBackend returns a P-Chain backend holding utxos, which are all on
+// the P-Chain.
+func newTestBackend(t *testing.T, utxos ...*avax.UTXO) pwallet.Backend {
+	t.Helper()
+	chainUTXOs := walletcommon.NewChainUTXOs(constants.PlatformChainID, walletcommon.NewUTXOs())
+	for _, utxo := range utxos {
+		if err := chainUTXOs.AddUTXO(context.Background(), constants.PlatformChainID, utxo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pwallet.NewBackend(chainUTXOs, nil)
+}
+
+func TestWallet_SetSignOnly(t *testing.T) {
+	w := &Wallet{backend: newTestBackend(t), base: pwallet.New(failingClient{t: t}, nil, nil)}
+	if w.IsSignOnly() {
+		t.Fatal("IsSignOnly() = true before SetSignOnly()")
+	}
+	if got := w.SignedTxs(); got != nil {
+		t.Fatalf("SignedTxs() = %v before SetSignOnly(), want nil", got)
+	}
+
+	w.SetSignOnly()
+	w.SetSignOnly() // idempotent
+	if !w.IsSignOnly() {
+		t.Fatal("IsSignOnly() = false after SetSignOnly()")
+	}
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{}, TxID: ids.GenerateTestID()}
+	if err := w.PWallet().IssueTx(tx); err != nil {
+		t.Fatalf("IssueTx() error = %v", err)
+	}
+
+	signed := w.SignedTxs()
+	if len(signed) != 1 || signed[0] != tx {
+		t.Fatalf("SignedTxs() = %v, want [%v]", signed, tx)
+	}
+}
+
+func TestWallet_SignOnlySpendsOnce(t *testing.T) {
+	key, err := secp256k1.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc := secp256k1fx.NewKeychain(key)
+	avaxAssetID := ids.GenerateTestID()
+	owner := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{key.Address()}}
+	utxo := func(amount uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			Out:    &secp256k1fx.TransferOutput{Amt: amount, OutputOwners: owner},
+		}
+	}
+	backend := newTestBackend(t, utxo(3*units.Avax), utxo(3*units.Avax))
+	w := &Wallet{
+		kc:      kc,
+		backend: backend,
+		base: pwallet.New(
+			failingClient{t: t},
+			pbuilder.New(kc.Addresses(), &pbuilder.Context{AVAXAssetID: avaxAssetID}, backend),
+			psigner.New(kc, backend),
+		),
+	}
+	w.SetSignOnly()
+
+	// Each transfer needs one of the two UTXOs. Without the first
+	// transaction accepted into the backend, the second would spend the
+	// same UTXO and conflict with it.
+	to := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{ids.GenerateTestShortID()}}
+	for i := 0; i < 2; i++ {
+		out := &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: 2 * units.Avax, OutputOwners: to},
+		}
+		if _, err := w.PWallet().IssueBaseTx([]*avax.TransferableOutput{out}); err != nil {
+			t.Fatalf("IssueBaseTx() #%d error = %v", i+1, err)
+		}
+	}
+
+	signed := w.SignedTxs()
+	if len(signed) != 2 {
+		t.Fatalf("SignedTxs() = %d transactions, want 2", len(signed))
+	}
+	first, second := signed[0].Unsigned.InputIDs(), signed[1].Unsigned.InputIDs()
+	if first.Overlaps(second) {
+		t.Fatalf("sign-only transactions spend the same inputs: %v and %v", first.List(), second.List())
+	}
+}
+
+// countingClient counts the transactions that reach the network client.
+type countingClient struct {
+	issued *int
+}
+
+func (c countingClient) IssueTx(*txs.Tx, ...walletcommon.Option) error {
+	*c.issued++
+	return nil
+}
+
+func TestWallet_SetSignedTxHandler(t *testing.T) {
+	var issued int
+	var recorded []*txs.Tx
+	recordErr := errors.New("disk full")
+	var failRecord bool
+	w := &Wallet{backend: newTestBackend(t), base: pwallet.New(countingClient{issued: &issued}, nil, nil)}
+	w.SetSignedTxHandler(func(tx *txs.Tx) error {
+		if failRecord {
+			return recordErr
+		}
+		recorded = append(recorded, tx)
+		return nil
+	})
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{}, TxID: ids.GenerateTestID()}
+	if err := w.PWallet().IssueTx(tx); err != nil {
+		t.Fatalf("IssueTx() error = %v", err)
+	}
+	if len(recorded) != 1 || recorded[0] != tx || issued != 1 {
+		t.Fatalf("recorded %d, issued %d; want the transaction recorded and issued once", len(recorded), issued)
+	}
+
+	// A transaction that cannot be recorded is not issued.
+	failRecord = true
+	if err := w.PWallet().IssueTx(tx); !errors.Is(err, recordErr) {
+		t.Fatalf("IssueTx() error = %v, want %v", err, recordErr)
+	}
+	if issued != 1 {
+		t.Fatalf("issued = %d after a failed record, want 1", issued)
+	}
+
+	// The handler survives the sign-only rewrap and sees captured transactions.
+	failRecord = false
+	w.SetSignOnly()
+	if err := w.PWallet().IssueTx(tx); err != nil {
+		t.Fatalf("IssueTx() in sign-only mode error = %v", err)
+	}
+	if len(recorded) != 2 || len(w.SignedTxs()) != 1 || issued != 1 {
+		t.Fatalf("recorded %d, captured %d, issued %d; want 2, 1, 1", len(recorded), len(w.SignedTxs()), issued)
+	}
+}
+
+// recordingBuilder records the options passed to NewBaseTx. Other Builder
+// methods are unimplemented.
+type recordingBuilder struct {
+	pbuilder.Builder
+	gotOptions []walletcommon.Option
+}
+
+func (b *recordingBuilder) NewBaseTx(_ []*avax.TransferableOutput, options ...walletcommon.Option) (*txs.BaseTx, error) {
+	b.gotOptions = options
+	return &txs.BaseTx{}, nil
+}
+
+func TestWallet_SetChangeOwner(t *testing.T) {
+	builder := &recordingBuilder{}
+	w := &Wallet{base: pwallet.New(failingClient{t: t}, builder, nil)}
+	changeAddr := ids.GenerateTestShortID()
+
+	w.SetChangeOwner(changeAddr)
+	w.SetSignOnly() // change owner must survive the sign-only rewrap
+
+	if _, err := w.PWallet().Builder().NewBaseTx(nil); err != nil {
+		t.Fatalf("NewBaseTx() error = %v", err)
+	}
+	if len(builder.gotOptions) != 1 {
+		t.Fatalf("NewBaseTx() got %d options, want the change owner once", len(builder.gotOptions))
+	}
+	owner := walletcommon.NewOptions(builder.gotOptions).ChangeOwner(nil)
+	if owner == nil || owner.Threshold != 1 || len(owner.Addrs) != 1 || owner.Addrs[0] != changeAddr {
+		t.Fatalf("change owner = %+v, want 1-of-1 %s", owner, changeAddr)
+	}
+}
+
+func TestWallet_RefreshBeforeIssue(t *testing.T) {
+	// A closed server makes any refresh attempt fail.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	w := &Wallet{
+		config: network.Config{RPCURL: srv.URL},
+		kc:     secp256k1fx.NewKeychain(),
+		base:   pwallet.New(failingClient{t: t}, nil, nil),
+	}
+
+	if err := w.RefreshBeforeIssue(context.Background()); err != nil {
+		t.Fatalf("RefreshBeforeIssue() with refresh off error = %v, want no-op", err)
+	}
+
+	w.SetRefreshBeforeIssue(true)
+	if err := w.RefreshBeforeIssue(context.Background()); err == nil {
+		t.Fatal("RefreshBeforeIssue() with refresh on expected an error from the unreachable node")
+	}
+
+	w.SetSignOnly()
+	if err := w.RefreshBeforeIssue(context.Background()); err != nil {
+		t.Fatalf("RefreshBeforeIssue() on a sign-only wallet error = %v, want no-op", err)
+	}
+}
+
+func TestUniqueIDs(t *testing.T) {
+	a, b := ids.GenerateTestID(), ids.GenerateTestID()
+	got := uniqueIDs([]ids.ID{a, b, a, b, a})
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Fatalf("uniqueIDs() = %v, want [%s %s]", got, a, b)
+	}
+}
+
+func TestWallet_TracksSubnet(t *testing.T) {
+	tracked, created, other := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	w := &Wallet{subnetIDs: []ids.ID{tracked}}
+
+	w.RecordCreatedSubnet(created)
+	for _, tc := range []struct {
+		subnetID ids.ID
+		want     bool
+	}{
+		{tracked, true},
+		{created, true},
+		{other, false},
+	} {
+		if got := w.TracksSubnet(tc.subnetID); got != tc.want {
+			t.Errorf("TracksSubnet(%s) = %v, want %v", tc.subnetID, got, tc.want)
+		}
+	}
+
+	// A sign-only wallet never issued the CreateSubnetTx, so the node
+	// cannot report the new subnet's owner on a refresh.
+	signOnly := &Wallet{base: pwallet.New(failingClient{t: t}, nil, nil)}
+	signOnly.SetSignOnly()
+	signOnly.RecordCreatedSubnet(created)
+	if signOnly.TracksSubnet(created) {
+		t.Error("sign-only wallet tracks a subnet it only signed the creation of")
+	}
+}